@@ -75,6 +75,7 @@ type ServerConfig struct {
 	AuthServer    AuthServerConfig `json:"authserver"`     // Config for the gRPC client connection
 	LoginURL      string           `json:"login_path"`     // Path to login form
 	Audiences     []string         `json:"audiences"`      // Accepted audiences from JWT
+	Hardened      bool             `json:"hardened"`       // Exchange authorization codes and keep tokens in secure cookies
 	MultiDB       multidb.Config   `json:"multidb"`        // Imported from multidb
 	PG            *pg.Config       `json:"pg"`             // PG is later embedded in multidb
 	SQLRoutines   int              `json:"sqlroutines"`    // Amount of Go-routines for non-master queries
@@ -81,6 +81,7 @@ const (
 func userActions(id int) []action {
 	return []action{
 		{"reset password", fmt.Sprintf("/users/reset/%d", id), http.MethodPut},
+		{"unlock", fmt.Sprintf("/users/unlock/%d", id), http.MethodPut},
 		{"delete", fmt.Sprintf("/users/delete/%d", id), http.MethodDelete},
 	}
 }
@@ -206,6 +207,44 @@ func deleteHandler(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(fmt.Sprintf("%s %d successfully deleted", strings.TrimSuffix(vars["resource"], "s"), id)))
 }
 
+// unlockHandler removes the login and password reset lockouts of a user.
+// Lockouts of source IP addresses are not affected.
+func unlockHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	entry := r.Context().Value(logEntry).(*logrus.Entry).WithFields(logrus.Fields{"handler": "unlockHandler", "vars": vars})
+	id := aToiMap(entry, vars)["id"]
+
+	tx, err := mdb.MasterTx(r.Context(), nil)
+	if isInternalError(entry, w, err) {
+		return
+	}
+	defer tx.Rollback()
+
+	um, err := models.FindUser(r.Context(), tx, id, models.UserColumns.Email)
+	if err == sql.ErrNoRows {
+		http.NotFound(w, r)
+		return
+	} else if isInternalError(entry, w, err) {
+		return
+	}
+
+	rows, err := models.LoginFailures(
+		models.LoginFailureWhere.Scope.IN([]string{"account", "reset"}),
+		models.LoginFailureWhere.Subject.EQ(um.Email),
+	).DeleteAll(r.Context(), tx)
+	entry = entry.WithField("rows", rows)
+	if isInternalError(entry, w, err) {
+		return
+	}
+	if isInternalError(entry, w, tx.Commit()) {
+		return
+	}
+	entry.Info("Unlocked")
+	if _, err = w.Write([]byte(fmt.Sprintf("user %d successfully unlocked", id))); err != nil {
+		entry.WithError(err).Error("Writing response")
+	}
+}
+
 type breadCrumb struct {
 	Name string
 	URL  string
@@ -661,6 +700,7 @@ func main() {
 		LoginURL:      conf.LoginURL,
 		ServerAddress: conf.ServerAddress,
 		RefreshWithin: 12 * time.Hour,
+		Hardened:      conf.Hardened,
 	}
 
 	r := mux.NewRouter()
@@ -681,6 +721,7 @@ func main() {
 	r.Path("/users/{id}/{relation}/{rid}").Methods(http.MethodPut).HandlerFunc(setUserRelationHandler)
 	r.Path("/users/{id}/remove/{relation}/{rid}").Methods(http.MethodPut).HandlerFunc(removeUserRelationHandler)
 
+	r.Path("/users/unlock/{id}").Methods(http.MethodPut).HandlerFunc(unlockHandler)
 	r.Path("/{resource}/delete/{id}").Methods(http.MethodDelete).HandlerFunc(deleteHandler)
 
 	r.Path("/new/{resource}").Methods(http.MethodGet).HandlerFunc(newEntityFormHandler)
@@ -54,15 +54,18 @@ func (c *AuthServerConfig) dial(ctx context.Context) (cc *grpc.ClientConn, err e
 
 // ServerConfig is a collection on config
 type ServerConfig struct {
-	Address       string                 `json:"address"`        // HTTP listen Address
-	Port          uint16                 `json:"port"`           // HTTP listen Port
-	Timeout       time.Duration          `json:"timeout"`        // HTTP read and write timeouts
-	ServerAddress string                 `json:"server_address"` // Public address of this server
-	Static        string                 `json:"static"`         // Path to static assets
-	TemplateGlob  string                 `json:"template_glob"`  // Globbing pattern for templates
-	Data          map[string]interface{} `json:"data"`           // Static data passed to the templates
-	TLS           *TLSConfig             `json:"tls"`            // TLS will be disabled when nil
-	AuthServer    AuthServerConfig       `json:"authserver"`     // Config for the gRPC client connection
+	Address          string                 `json:"address"`             // HTTP listen Address
+	Port             uint16                 `json:"port"`                // HTTP listen Port
+	Timeout          time.Duration          `json:"timeout"`             // HTTP read and write timeouts
+	ServerAddress    string                 `json:"server_address"`      // Public address of this server
+	Static           string                 `json:"static"`              // Path to static assets
+	TemplateGlob     string                 `json:"template_glob"`       // Globbing pattern for templates
+	Data             map[string]interface{} `json:"data"`                // Static data passed to the templates
+	TLS              *TLSConfig             `json:"tls"`                 // TLS will be disabled when nil
+	AuthServer       AuthServerConfig       `json:"authserver"`          // Config for the gRPC client connection
+	Hardened         bool                   `json:"hardened"`            // Hand out authorization codes and require CSRF tokens
+	AllowedRedirects []string               `json:"allowed_redirects"`   // Allowed redirect origins and path prefixes after login
+	Providers        []string               `json:"federated_providers"` // Upstream identity providers offered for login, as configured on the server
 }
 
 func (c *ServerConfig) writeOut(filename string) error {
@@ -82,10 +85,11 @@ var Default = ServerConfig{
 	Data: map[string]interface{}{
 		"SiteName": "Authenticator",
 	},
-	Static:       "static",
-	TemplateGlob: "templates/*.html",
-	TLS:          nil,
-	AuthServer:   AuthServerConfig{"127.0.0.1", 8765},
+	Static:           "static",
+	TemplateGlob:     "templates/*.html",
+	TLS:              nil,
+	AuthServer:       AuthServerConfig{"127.0.0.1", 8765},
+	AllowedRedirects: []string{"http://localhost:1234"},
 }
 
 func configure(c *ServerConfig, files ...string) (*ServerConfig, error) {
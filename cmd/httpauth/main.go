@@ -112,12 +112,15 @@ func run(dc *ServerConfig) int {
 	defer cc.Close()
 
 	f := &forms.Forms{
-		Tmpl:   tmpl,
-		EP:     ehtml.Pages{Tmpl: tmpl},
-		Data:   conf.Data,
-		Client: auth.NewAuthenticatorClient(cc),
+		Tmpl:      tmpl,
+		EP:        ehtml.Pages{Tmpl: tmpl},
+		Data:      conf.Data,
+		Client:    auth.NewAuthenticatorClient(cc),
+		Hardened:  conf.Hardened,
+		Providers: conf.Providers,
 		Paths: &forms.Paths{
-			ServerAddress: conf.ServerAddress,
+			ServerAddress:    conf.ServerAddress,
+			AllowedRedirects: conf.AllowedRedirects,
 		},
 	}
 
@@ -126,6 +129,14 @@ func run(dc *ServerConfig) int {
 	mux.Handle(forms.DefaultSetPWPath, f.SetPWHandler())
 	mux.Handle(forms.DefaultResetPWPath, f.ResetPWHandler())
 	mux.Handle(forms.DefaultLoginPath, f.LoginHander())
+	mux.Handle(forms.DefaultPasskeyPath, f.PasskeyLoginHandler())
+	mux.Handle(forms.DefaultLoginLinkPath, f.LoginLinkHandler())
+	mux.Handle(forms.DefaultPasskeyRegisterPath, f.PasskeyRegisterHandler())
+	mux.Handle(forms.DefaultAuthorizePath, f.AuthorizeHandler())
+	mux.Handle(forms.DefaultTokenPath, f.TokenHandler())
+	mux.Handle(forms.DefaultUserInfoPath, f.UserInfoHandler())
+	mux.Handle(forms.DefaultFederatedPath, f.FederatedHandler())
+	mux.Handle(forms.DefaultFederatedCallback, f.FederatedCallbackHandler())
 
 	if err = conf.listen(make(chan os.Signal, 1), conf.middleware(mux)); !errors.Is(err, http.ErrServerClosed) {
 		return fatalRun(err)
@@ -0,0 +1,1029 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/lib/pq"
+	auth "github.com/moapis/authenticator"
+	"github.com/moapis/authenticator/models"
+	"github.com/sirupsen/logrus"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AdminConfig enables the AuthenticatorAdmin gRPC service.
+type AdminConfig struct {
+	Groups []string `json:"groups"` // Members of these groups are authorized to use the admin service
+}
+
+var errAdminConfig = errors.New("Admin: at least one group is required")
+
+func (c *AdminConfig) validate() error {
+	if len(c.Groups) == 0 {
+		return errAdminConfig
+	}
+	return nil
+}
+
+const (
+	errAdminGroup  = "Not a member of an admin group"
+	errPageToken   = "Invalid page token"
+	errListFilter  = "Group and audience filters are only supported for users"
+	errMissingID   = "ID missing"
+	errMissingName = "Name missing"
+	errTooLong     = "Value too long"
+
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+// adminServer implements the AuthenticatorAdmin service.
+type adminServer struct {
+	auth.UnimplementedAuthenticatorAdminServer
+	*authServer
+}
+
+// authorizeAdmin checks if the bearer token of the request
+// contains one of the admin groups.
+func (rt *requestTx) authorizeAdmin(now time.Time) error {
+	claims, err := rt.checkBearer(now)
+	if err != nil {
+		return err
+	}
+
+	rt.log = rt.log.WithField("admin", claims.Subject)
+	groups, _ := claims.Set[jwtGroups].([]interface{})
+	for _, g := range groups {
+		for _, ag := range rt.s.conf.Admin.Groups {
+			if name, ok := g.(string); ok && name == ag {
+				rt.log.WithField("group", name).Debug("authorizeAdmin")
+				return nil
+			}
+		}
+	}
+	rt.log.WithField("groups", groups).Warn(errAdminGroup)
+	return status.Error(codes.PermissionDenied, errAdminGroup)
+}
+
+// newAdminTx starts a request transaction for an admin method,
+// after authorization of the caller.
+func (s *adminServer) newAdminTx(ctx context.Context, method string, readOnly bool) (*requestTx, error) {
+	rt, err := s.newTx(ctx, method, readOnly)
+	if err != nil {
+		return nil, err
+	}
+	if err = rt.authorizeAdmin(time.Now()); err != nil {
+		rt.done()
+		return nil, err
+	}
+	return rt, nil
+}
+
+// adminDBError converts a database error to a status error.
+// entry is used for the not found message.
+func (rt *requestTx) adminDBError(action, entry string, err error) error {
+	log := rt.log.WithError(err).WithField("action", action)
+
+	if err == sql.ErrNoRows {
+		log.Warnf("%s not found", entry)
+		return status.Errorf(codes.NotFound, "%s not found", entry)
+	}
+	if pqErr, ok := errors.Cause(err).(*pq.Error); ok {
+		switch pqErr.Code.Name() {
+		case "unique_violation":
+			log.Warnf("%s already exists", entry)
+			return status.Errorf(codes.AlreadyExists, "%s already exists", entry)
+		case "string_data_right_truncation":
+			log.Warn(errTooLong)
+			return status.Error(codes.InvalidArgument, errTooLong)
+		}
+	}
+	log.Error(errDB)
+	return status.Error(codes.Internal, errDB)
+}
+
+// pageMods returns the query mods for the page requested by lr,
+// ordered by the id column.
+// One entry more than the page size is selected, to detect a next page.
+func pageMods(lr *auth.ListRequest) ([]qm.QueryMod, int, error) {
+	size := int(lr.GetPageSize())
+	if size <= 0 {
+		size = defaultPageSize
+	} else if size > maxPageSize {
+		size = maxPageSize
+	}
+
+	mods := []qm.QueryMod{
+		qm.OrderBy("id"),
+		qm.Limit(size + 1),
+	}
+	if t := lr.GetPageToken(); t != "" {
+		after, err := strconv.Atoi(t)
+		if err != nil || after < 1 {
+			return nil, 0, status.Error(codes.InvalidArgument, errPageToken)
+		}
+		mods = append(mods, qm.Where("id > ?", after))
+	}
+	return mods, size, nil
+}
+
+// nextPageToken returns the token for the page after the entry with id,
+// or an empty string if there are no more than size entries.
+func nextPageToken(n, size, id int) string {
+	if n <= size {
+		return ""
+	}
+	return strconv.Itoa(id)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// containsPattern returns a "like" pattern matching lower case values containing s.
+func containsPattern(s string) string {
+	return "%" + likeEscaper.Replace(strings.ToLower(s)) + "%"
+}
+
+func timestamp(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func userMessage(u *models.User) *auth.User {
+	m := &auth.User{
+		Id:                int32(u.ID),
+		Email:             u.Email,
+		Name:              u.Name,
+		CreatedAt:         timestamp(u.CreatedAt),
+		UpdatedAt:         timestamp(u.UpdatedAt),
+		CredentialBackend: u.CredentialBackend.String,
+	}
+	if u.VerifiedAt.Valid {
+		m.VerifiedAt = timestamp(u.VerifiedAt.Time)
+	}
+	if u.R != nil {
+		for _, g := range u.R.Groups {
+			m.Groups = append(m.Groups, g.Name)
+		}
+		for _, a := range u.R.Audiences {
+			m.Audiences = append(m.Audiences, a.Name)
+		}
+		sort.Strings(m.Groups)
+		sort.Strings(m.Audiences)
+	}
+	return m
+}
+
+func groupMessage(g *models.Group) *auth.Group {
+	return &auth.Group{
+		Id:          int32(g.ID),
+		Name:        g.Name,
+		Description: g.Description,
+		CreatedAt:   timestamp(g.CreatedAt),
+		UpdatedAt:   timestamp(g.UpdatedAt),
+	}
+}
+
+func audienceMessage(a *models.Audience) *auth.Audience {
+	return &auth.Audience{
+		Id:          int32(a.ID),
+		Name:        a.Name,
+		Description: a.Description,
+		CreatedAt:   timestamp(a.CreatedAt),
+		UpdatedAt:   timestamp(a.UpdatedAt),
+	}
+}
+
+// findUser returns the user by id, with its groups and audiences loaded.
+func (rt *requestTx) findUser(id int32) (*models.User, error) {
+	rt.log = rt.log.WithField("user_id", id)
+	if id == 0 {
+		rt.log.Warn(errMissingID)
+		return nil, status.Error(codes.InvalidArgument, errMissingID)
+	}
+	u, err := models.Users(
+		models.UserWhere.ID.EQ(int(id)),
+		qm.Load(models.UserRels.Groups),
+		qm.Load(models.UserRels.Audiences),
+	).One(rt.ctx, rt.tx)
+	if err != nil {
+		return nil, rt.adminDBError("findUser", "User", err)
+	}
+	return u, nil
+}
+
+func (s *adminServer) ListUsers(ctx context.Context, lr *auth.ListRequest) (*auth.UserList, error) {
+	rt, err := s.newAdminTx(ctx, "ListUsers", true)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	mods, size, err := pageMods(lr)
+	if err != nil {
+		return nil, err
+	}
+	if f := lr.GetFilter(); f != "" {
+		p := containsPattern(f)
+		mods = append(mods, qm.Where("(lower(email) like ? or lower(name) like ?)", p, p))
+	}
+	if g := lr.GetGroup(); g != "" {
+		mods = append(mods, qm.Where(
+			"id in (select ug.user_id from auth.user_groups ug join auth.groups g on g.id = ug.group_id where g.name = ?)", g,
+		))
+	}
+	if a := lr.GetAudience(); a != "" {
+		mods = append(mods, qm.Where(
+			"id in (select ua.user_id from auth.user_audiences ua join auth.audiences a on a.id = ua.audience_id where a.name = ?)", a,
+		))
+	}
+	mods = append(mods,
+		qm.Load(models.UserRels.Groups),
+		qm.Load(models.UserRels.Audiences),
+	)
+
+	users, err := models.Users(mods...).All(rt.ctx, rt.tx)
+	if err != nil {
+		return nil, rt.adminDBError("ListUsers", "User", err)
+	}
+
+	list := new(auth.UserList)
+	for i, u := range users {
+		if i == size {
+			list.NextPageToken = nextPageToken(len(users), size, users[i-1].ID)
+			break
+		}
+		list.Users = append(list.Users, userMessage(u))
+	}
+	rt.log.WithFields(logrus.Fields{"users": len(list.Users), "next": list.NextPageToken}).Debug("ListUsers")
+	return list, nil
+}
+
+func (s *adminServer) GetUser(ctx context.Context, id *auth.ResourceID) (*auth.User, error) {
+	rt, err := s.newAdminTx(ctx, "GetUser", true)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	u, err := rt.findUser(id.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return userMessage(u), nil
+}
+
+func (s *adminServer) CreateUser(ctx context.Context, um *auth.User) (*auth.User, error) {
+	rt, err := s.newAdminTx(ctx, "CreateUser", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	rt.log = rt.log.WithFields(logrus.Fields{"email": um.GetEmail(), "name": um.GetName()})
+	if um.GetEmail() == "" {
+		rt.log.Warn(errMissingEmail)
+		return nil, status.Error(codes.InvalidArgument, errMissingEmail)
+	}
+
+	u := &models.User{
+		Email: um.GetEmail(),
+		Name:  um.GetName(),
+	}
+	if v := um.GetVerifiedAt(); v != 0 {
+		u.VerifiedAt = null.TimeFrom(time.Unix(v, 0))
+	}
+	if u.CredentialBackend, err = rt.backendColumn(um.GetCredentialBackend()); err != nil {
+		return nil, err
+	}
+	if err = u.Insert(rt.ctx, rt.tx, boil.Infer()); err != nil {
+		return nil, rt.adminDBError("CreateUser", "User", err)
+	}
+	u.R = u.R.NewStruct()
+
+	if err = rt.addUserGroups(u, um.GetGroups()); err != nil {
+		return nil, err
+	}
+	if err = rt.addUserAudiences(u, um.GetAudiences()); err != nil {
+		return nil, err
+	}
+	if err = rt.commit(); err != nil {
+		return nil, err
+	}
+	rt.log.WithField("user_id", u.ID).Info("CreateUser")
+	return userMessage(u), nil
+}
+
+func (s *adminServer) UpdateUser(ctx context.Context, um *auth.User) (*auth.User, error) {
+	rt, err := s.newAdminTx(ctx, "UpdateUser", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	u, err := rt.findUser(um.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if e := um.GetEmail(); e != "" {
+		u.Email = e
+	}
+	if n := um.GetName(); n != "" {
+		u.Name = n
+	}
+	if b := um.GetCredentialBackend(); b != "" {
+		if u.CredentialBackend, err = rt.backendColumn(b); err != nil {
+			return nil, err
+		}
+	}
+	if _, err = u.Update(rt.ctx, rt.tx, boil.Infer()); err != nil {
+		return nil, rt.adminDBError("UpdateUser", "User", err)
+	}
+	if err = rt.commit(); err != nil {
+		return nil, err
+	}
+	rt.log.WithFields(logrus.Fields{"email": u.Email, "name": u.Name, "backend": u.CredentialBackend.String}).Info("UpdateUser")
+	return userMessage(u), nil
+}
+
+func (s *adminServer) DeleteUser(ctx context.Context, id *auth.ResourceID) (*empty.Empty, error) {
+	rt, err := s.newAdminTx(ctx, "DeleteUser", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	u, err := rt.findUser(id.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err = u.SetGroups(rt.ctx, rt.tx, false); err != nil {
+		return nil, rt.adminDBError("DeleteUser", "User", err)
+	}
+	if err = u.SetAudiences(rt.ctx, rt.tx, false); err != nil {
+		return nil, rt.adminDBError("DeleteUser", "User", err)
+	}
+	if _, err = models.Passwords(models.PasswordWhere.UserID.EQ(u.ID)).DeleteAll(rt.ctx, rt.tx); err != nil {
+		return nil, rt.adminDBError("DeleteUser", "User", err)
+	}
+	if _, err = u.Delete(rt.ctx, rt.tx); err != nil {
+		return nil, rt.adminDBError("DeleteUser", "User", err)
+	}
+	if err = rt.commit(); err != nil {
+		return nil, err
+	}
+	rt.log.Info("DeleteUser")
+	return &empty.Empty{}, nil
+}
+
+// uniqueNames returns the non-empty names without duplicates.
+func uniqueNames(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	var unique []string
+	for _, n := range names {
+		if n != "" && !seen[n] {
+			seen[n] = true
+			unique = append(unique, n)
+		}
+	}
+	return unique
+}
+
+// findGroups returns the groups by name.
+// A NotFound error is returned if any of the groups does not exist.
+func (rt *requestTx) findGroups(names []string) (models.GroupSlice, error) {
+	names = uniqueNames(names)
+	if len(names) == 0 {
+		return nil, nil
+	}
+	groups, err := models.Groups(models.GroupWhere.Name.IN(names)).All(rt.ctx, rt.tx)
+	if err != nil {
+		return nil, rt.adminDBError("findGroups", "Group", err)
+	}
+	if len(groups) != len(names) {
+		rt.log.WithField("groups", names).Warn("Group not found")
+		return nil, status.Error(codes.NotFound, "Group not found")
+	}
+	return groups, nil
+}
+
+// findAudiences returns the audiences by name.
+// A NotFound error is returned if any of the audiences does not exist.
+func (rt *requestTx) findAudiences(names []string) (models.AudienceSlice, error) {
+	names = uniqueNames(names)
+	if len(names) == 0 {
+		return nil, nil
+	}
+	audiences, err := models.Audiences(models.AudienceWhere.Name.IN(names)).All(rt.ctx, rt.tx)
+	if err != nil {
+		return nil, rt.adminDBError("findAudiences", "Audience", err)
+	}
+	if len(audiences) != len(names) {
+		rt.log.WithField("audiences", names).Warn("Audience not found")
+		return nil, status.Error(codes.NotFound, "Audience not found")
+	}
+	return audiences, nil
+}
+
+// addUserGroups adds the groups by name to user, skipping the ones user is already member of.
+// user needs to have its groups loaded.
+func (rt *requestTx) addUserGroups(user *models.User, names []string) error {
+	groups, err := rt.findGroups(names)
+	if err != nil {
+		return err
+	}
+	member := make(map[int]bool, len(user.R.Groups))
+	for _, g := range user.R.Groups {
+		member[g.ID] = true
+	}
+	var add models.GroupSlice
+	for _, g := range groups {
+		if !member[g.ID] {
+			add = append(add, g)
+		}
+	}
+	if len(add) == 0 {
+		return nil
+	}
+	if err = user.AddGroups(rt.ctx, rt.tx, false, add...); err != nil {
+		return rt.adminDBError("AddGroups", "Group", err)
+	}
+	return nil
+}
+
+// addUserAudiences adds the audiences by name to user, skipping the ones user already has.
+// user needs to have its audiences loaded.
+func (rt *requestTx) addUserAudiences(user *models.User, names []string) error {
+	audiences, err := rt.findAudiences(names)
+	if err != nil {
+		return err
+	}
+	member := make(map[int]bool, len(user.R.Audiences))
+	for _, a := range user.R.Audiences {
+		member[a.ID] = true
+	}
+	var add models.AudienceSlice
+	for _, a := range audiences {
+		if !member[a.ID] {
+			add = append(add, a)
+		}
+	}
+	if len(add) == 0 {
+		return nil
+	}
+	if err = user.AddAudiences(rt.ctx, rt.tx, false, add...); err != nil {
+		return rt.adminDBError("AddAudiences", "Audience", err)
+	}
+	return nil
+}
+
+// membership runs f on the user from ms and commits.
+// The updated user is returned.
+func (s *adminServer) membership(ctx context.Context, method string, ms *auth.Membership, f func(*requestTx, *models.User, []string) error) (*auth.User, error) {
+	rt, err := s.newAdminTx(ctx, method, false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	u, err := rt.findUser(ms.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	rt.log = rt.log.WithField("names", ms.GetNames())
+	if err = f(rt, u, ms.GetNames()); err != nil {
+		return nil, err
+	}
+	if err = rt.commit(); err != nil {
+		return nil, err
+	}
+	rt.log.Info(method)
+	return userMessage(u), nil
+}
+
+func (s *adminServer) AddUserGroups(ctx context.Context, ms *auth.Membership) (*auth.User, error) {
+	return s.membership(ctx, "AddUserGroups", ms, (*requestTx).addUserGroups)
+}
+
+func (s *adminServer) RemoveUserGroups(ctx context.Context, ms *auth.Membership) (*auth.User, error) {
+	return s.membership(ctx, "RemoveUserGroups", ms, func(rt *requestTx, u *models.User, names []string) error {
+		groups, err := rt.findGroups(names)
+		if err != nil || len(groups) == 0 {
+			return err
+		}
+		if err = u.RemoveGroups(rt.ctx, rt.tx, groups...); err != nil {
+			return rt.adminDBError("RemoveGroups", "Group", err)
+		}
+		return nil
+	})
+}
+
+func (s *adminServer) AddUserAudiences(ctx context.Context, ms *auth.Membership) (*auth.User, error) {
+	return s.membership(ctx, "AddUserAudiences", ms, (*requestTx).addUserAudiences)
+}
+
+func (s *adminServer) RemoveUserAudiences(ctx context.Context, ms *auth.Membership) (*auth.User, error) {
+	return s.membership(ctx, "RemoveUserAudiences", ms, func(rt *requestTx, u *models.User, names []string) error {
+		audiences, err := rt.findAudiences(names)
+		if err != nil || len(audiences) == 0 {
+			return err
+		}
+		if err = u.RemoveAudiences(rt.ctx, rt.tx, audiences...); err != nil {
+			return rt.adminDBError("RemoveAudiences", "Audience", err)
+		}
+		return nil
+	})
+}
+
+// nameFilter returns the query mods for the name filter of lr.
+// Group and audience filters are not supported.
+func nameFilter(lr *auth.ListRequest) ([]qm.QueryMod, error) {
+	if lr.GetGroup() != "" || lr.GetAudience() != "" {
+		return nil, status.Error(codes.InvalidArgument, errListFilter)
+	}
+	if f := lr.GetFilter(); f != "" {
+		return []qm.QueryMod{qm.Where("lower(name) like ?", containsPattern(f))}, nil
+	}
+	return nil, nil
+}
+
+func (s *adminServer) ListGroups(ctx context.Context, lr *auth.ListRequest) (*auth.GroupList, error) {
+	rt, err := s.newAdminTx(ctx, "ListGroups", true)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	mods, size, err := pageMods(lr)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := nameFilter(lr)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := models.Groups(append(mods, filter...)...).All(rt.ctx, rt.tx)
+	if err != nil {
+		return nil, rt.adminDBError("ListGroups", "Group", err)
+	}
+
+	list := new(auth.GroupList)
+	for i, g := range groups {
+		if i == size {
+			list.NextPageToken = nextPageToken(len(groups), size, groups[i-1].ID)
+			break
+		}
+		list.Groups = append(list.Groups, groupMessage(g))
+	}
+	return list, nil
+}
+
+func (rt *requestTx) findGroup(id int32) (*models.Group, error) {
+	rt.log = rt.log.WithField("group_id", id)
+	if id == 0 {
+		rt.log.Warn(errMissingID)
+		return nil, status.Error(codes.InvalidArgument, errMissingID)
+	}
+	g, err := models.FindGroup(rt.ctx, rt.tx, int(id))
+	if err != nil {
+		return nil, rt.adminDBError("findGroup", "Group", err)
+	}
+	return g, nil
+}
+
+func (s *adminServer) GetGroup(ctx context.Context, id *auth.ResourceID) (*auth.Group, error) {
+	rt, err := s.newAdminTx(ctx, "GetGroup", true)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	g, err := rt.findGroup(id.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return groupMessage(g), nil
+}
+
+func (s *adminServer) CreateGroup(ctx context.Context, gm *auth.Group) (*auth.Group, error) {
+	rt, err := s.newAdminTx(ctx, "CreateGroup", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	rt.log = rt.log.WithField("name", gm.GetName())
+	if gm.GetName() == "" {
+		rt.log.Warn(errMissingName)
+		return nil, status.Error(codes.InvalidArgument, errMissingName)
+	}
+	g := &models.Group{
+		Name:        gm.GetName(),
+		Description: gm.GetDescription(),
+	}
+	if err = g.Insert(rt.ctx, rt.tx, boil.Infer()); err != nil {
+		return nil, rt.adminDBError("CreateGroup", "Group", err)
+	}
+	if err = rt.commit(); err != nil {
+		return nil, err
+	}
+	rt.log.WithField("group_id", g.ID).Info("CreateGroup")
+	return groupMessage(g), nil
+}
+
+func (s *adminServer) UpdateGroup(ctx context.Context, gm *auth.Group) (*auth.Group, error) {
+	rt, err := s.newAdminTx(ctx, "UpdateGroup", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	g, err := rt.findGroup(gm.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if n := gm.GetName(); n != "" {
+		g.Name = n
+	}
+	if d := gm.GetDescription(); d != "" {
+		g.Description = d
+	}
+	if _, err = g.Update(rt.ctx, rt.tx, boil.Infer()); err != nil {
+		return nil, rt.adminDBError("UpdateGroup", "Group", err)
+	}
+	if err = rt.commit(); err != nil {
+		return nil, err
+	}
+	rt.log.WithField("name", g.Name).Info("UpdateGroup")
+	return groupMessage(g), nil
+}
+
+func (s *adminServer) DeleteGroup(ctx context.Context, id *auth.ResourceID) (*empty.Empty, error) {
+	rt, err := s.newAdminTx(ctx, "DeleteGroup", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	g, err := rt.findGroup(id.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err = g.SetUsers(rt.ctx, rt.tx, false); err != nil {
+		return nil, rt.adminDBError("DeleteGroup", "Group", err)
+	}
+	if _, err = g.Delete(rt.ctx, rt.tx); err != nil {
+		return nil, rt.adminDBError("DeleteGroup", "Group", err)
+	}
+	if err = rt.commit(); err != nil {
+		return nil, err
+	}
+	rt.log.Info("DeleteGroup")
+	return &empty.Empty{}, nil
+}
+
+func (s *adminServer) ListAudiences(ctx context.Context, lr *auth.ListRequest) (*auth.AudienceList, error) {
+	rt, err := s.newAdminTx(ctx, "ListAudiences", true)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	mods, size, err := pageMods(lr)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := nameFilter(lr)
+	if err != nil {
+		return nil, err
+	}
+
+	audiences, err := models.Audiences(append(mods, filter...)...).All(rt.ctx, rt.tx)
+	if err != nil {
+		return nil, rt.adminDBError("ListAudiences", "Audience", err)
+	}
+
+	list := new(auth.AudienceList)
+	for i, a := range audiences {
+		if i == size {
+			list.NextPageToken = nextPageToken(len(audiences), size, audiences[i-1].ID)
+			break
+		}
+		list.Audiences = append(list.Audiences, audienceMessage(a))
+	}
+	return list, nil
+}
+
+func (rt *requestTx) findAudience(id int32) (*models.Audience, error) {
+	rt.log = rt.log.WithField("audience_id", id)
+	if id == 0 {
+		rt.log.Warn(errMissingID)
+		return nil, status.Error(codes.InvalidArgument, errMissingID)
+	}
+	a, err := models.FindAudience(rt.ctx, rt.tx, int(id))
+	if err != nil {
+		return nil, rt.adminDBError("findAudience", "Audience", err)
+	}
+	return a, nil
+}
+
+func (s *adminServer) GetAudience(ctx context.Context, id *auth.ResourceID) (*auth.Audience, error) {
+	rt, err := s.newAdminTx(ctx, "GetAudience", true)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	a, err := rt.findAudience(id.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return audienceMessage(a), nil
+}
+
+func (s *adminServer) CreateAudience(ctx context.Context, am *auth.Audience) (*auth.Audience, error) {
+	rt, err := s.newAdminTx(ctx, "CreateAudience", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	rt.log = rt.log.WithField("name", am.GetName())
+	if am.GetName() == "" {
+		rt.log.Warn(errMissingName)
+		return nil, status.Error(codes.InvalidArgument, errMissingName)
+	}
+	a := &models.Audience{
+		Name:        am.GetName(),
+		Description: am.GetDescription(),
+	}
+	if err = a.Insert(rt.ctx, rt.tx, boil.Infer()); err != nil {
+		return nil, rt.adminDBError("CreateAudience", "Audience", err)
+	}
+	if err = rt.commit(); err != nil {
+		return nil, err
+	}
+	rt.log.WithField("audience_id", a.ID).Info("CreateAudience")
+	return audienceMessage(a), nil
+}
+
+func (s *adminServer) UpdateAudience(ctx context.Context, am *auth.Audience) (*auth.Audience, error) {
+	rt, err := s.newAdminTx(ctx, "UpdateAudience", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	a, err := rt.findAudience(am.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if n := am.GetName(); n != "" {
+		a.Name = n
+	}
+	if d := am.GetDescription(); d != "" {
+		a.Description = d
+	}
+	if _, err = a.Update(rt.ctx, rt.tx, boil.Infer()); err != nil {
+		return nil, rt.adminDBError("UpdateAudience", "Audience", err)
+	}
+	if err = rt.commit(); err != nil {
+		return nil, err
+	}
+	rt.log.WithField("name", a.Name).Info("UpdateAudience")
+	return audienceMessage(a), nil
+}
+
+func (s *adminServer) DeleteAudience(ctx context.Context, id *auth.ResourceID) (*empty.Empty, error) {
+	rt, err := s.newAdminTx(ctx, "DeleteAudience", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	a, err := rt.findAudience(id.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err = a.SetUsers(rt.ctx, rt.tx, false); err != nil {
+		return nil, rt.adminDBError("DeleteAudience", "Audience", err)
+	}
+	if _, err = a.Delete(rt.ctx, rt.tx); err != nil {
+		return nil, rt.adminDBError("DeleteAudience", "Audience", err)
+	}
+	if err = rt.commit(); err != nil {
+		return nil, err
+	}
+	rt.log.Info("DeleteAudience")
+	return &empty.Empty{}, nil
+}
+
+const (
+	errRedirectURI  = "Redirect URIs need to be absolute, without fragment"
+	clientSecretLen = 32
+)
+
+func clientMessage(c *models.Client) *auth.Client {
+	return &auth.Client{
+		Id:           int32(c.ID),
+		Name:         c.Name,
+		Description:  c.Description,
+		RedirectUris: c.RedirectUris,
+		Scopes:       c.Scopes,
+		Confidential: c.SecretHash.Valid,
+		CreatedAt:    timestamp(c.CreatedAt),
+		UpdatedAt:    timestamp(c.UpdatedAt),
+	}
+}
+
+// checkRedirectURIs checks if all uris are absolute and without fragment,
+// as required for OAuth 2.0 redirection endpoints.
+func (rt *requestTx) checkRedirectURIs(uris []string) error {
+	for _, s := range uris {
+		u, err := url.Parse(s)
+		if err != nil || !u.IsAbs() || u.Host == "" || u.Fragment != "" {
+			rt.log.WithError(err).WithField("redirect_uri", s).Warn(errRedirectURI)
+			return status.Error(codes.InvalidArgument, errRedirectURI)
+		}
+	}
+	return nil
+}
+
+func (s *adminServer) ListClients(ctx context.Context, lr *auth.ListRequest) (*auth.ClientList, error) {
+	rt, err := s.newAdminTx(ctx, "ListClients", true)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	mods, size, err := pageMods(lr)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := nameFilter(lr)
+	if err != nil {
+		return nil, err
+	}
+
+	clients, err := models.Clients(append(mods, filter...)...).All(rt.ctx, rt.tx)
+	if err != nil {
+		return nil, rt.adminDBError("ListClients", "Client", err)
+	}
+
+	list := new(auth.ClientList)
+	for i, c := range clients {
+		if i == size {
+			list.NextPageToken = nextPageToken(len(clients), size, clients[i-1].ID)
+			break
+		}
+		list.Clients = append(list.Clients, clientMessage(c))
+	}
+	return list, nil
+}
+
+func (rt *requestTx) findClientByID(id int32) (*models.Client, error) {
+	rt.log = rt.log.WithField("client_id", id)
+	if id == 0 {
+		rt.log.Warn(errMissingID)
+		return nil, status.Error(codes.InvalidArgument, errMissingID)
+	}
+	c, err := models.FindClient(rt.ctx, rt.tx, int(id))
+	if err != nil {
+		return nil, rt.adminDBError("findClientByID", "Client", err)
+	}
+	return c, nil
+}
+
+func (s *adminServer) GetClient(ctx context.Context, id *auth.ResourceID) (*auth.Client, error) {
+	rt, err := s.newAdminTx(ctx, "GetClient", true)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	c, err := rt.findClientByID(id.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return clientMessage(c), nil
+}
+
+func (s *adminServer) CreateClient(ctx context.Context, cm *auth.Client) (*auth.Client, error) {
+	rt, err := s.newAdminTx(ctx, "CreateClient", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	rt.log = rt.log.WithField("name", cm.GetName())
+	if cm.GetName() == "" {
+		rt.log.Warn(errMissingName)
+		return nil, status.Error(codes.InvalidArgument, errMissingName)
+	}
+	if err = rt.checkRedirectURIs(cm.GetRedirectUris()); err != nil {
+		return nil, err
+	}
+	c := &models.Client{
+		Name:         cm.GetName(),
+		Description:  cm.GetDescription(),
+		RedirectUris: uniqueNames(cm.GetRedirectUris()),
+		Scopes:       uniqueNames(cm.GetScopes()),
+	}
+
+	var secret string
+	if cm.GetConfidential() {
+		if secret, err = randomString(rand.Read, clientSecretLen); err != nil {
+			rt.log.WithError(err).Error("CreateClient")
+			return nil, status.Error(codes.Internal, errFatal)
+		}
+		c.SecretHash = null.BytesFrom(hashRefreshToken(secret))
+	}
+
+	if err = c.Insert(rt.ctx, rt.tx, boil.Infer()); err != nil {
+		return nil, rt.adminDBError("CreateClient", "Client", err)
+	}
+	if err = rt.commit(); err != nil {
+		return nil, err
+	}
+	rt.log.WithField("client_id", c.ID).Info("CreateClient")
+
+	m := clientMessage(c)
+	m.Secret = secret
+	return m, nil
+}
+
+func (s *adminServer) UpdateClient(ctx context.Context, cm *auth.Client) (*auth.Client, error) {
+	rt, err := s.newAdminTx(ctx, "UpdateClient", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	c, err := rt.findClientByID(cm.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if n := cm.GetName(); n != "" {
+		c.Name = n
+	}
+	if d := cm.GetDescription(); d != "" {
+		c.Description = d
+	}
+	if uris := cm.GetRedirectUris(); len(uris) > 0 {
+		if err = rt.checkRedirectURIs(uris); err != nil {
+			return nil, err
+		}
+		c.RedirectUris = uniqueNames(uris)
+	}
+	if scopes := cm.GetScopes(); len(scopes) > 0 {
+		c.Scopes = uniqueNames(scopes)
+	}
+	if _, err = c.Update(rt.ctx, rt.tx, boil.Infer()); err != nil {
+		return nil, rt.adminDBError("UpdateClient", "Client", err)
+	}
+	if err = rt.commit(); err != nil {
+		return nil, err
+	}
+	rt.log.WithField("name", c.Name).Info("UpdateClient")
+	return clientMessage(c), nil
+}
+
+func (s *adminServer) DeleteClient(ctx context.Context, id *auth.ResourceID) (*empty.Empty, error) {
+	rt, err := s.newAdminTx(ctx, "DeleteClient", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	c, err := rt.findClientByID(id.GetId())
+	if err != nil {
+		return nil, err
+	}
+	// Authorization codes and grants are deleted by cascade.
+	if _, err = c.Delete(rt.ctx, rt.tx); err != nil {
+		return nil, rt.adminDBError("DeleteClient", "Client", err)
+	}
+	if err = rt.commit(); err != nil {
+		return nil, err
+	}
+	rt.log.Info("DeleteClient")
+	return &empty.Empty{}, nil
+}
@@ -0,0 +1,315 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	auth "github.com/moapis/authenticator"
+	"github.com/moapis/authenticator/models"
+	"github.com/pascaldekloe/jwt"
+	"github.com/volatiletech/null/v8"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAdminConfig_validate(t *testing.T) {
+	if err := (&AdminConfig{Groups: []string{"admin"}}).validate(); err != nil {
+		t.Errorf("AdminConfig.validate() error = %v", err)
+	}
+	if err := (&AdminConfig{}).validate(); err != errAdminConfig {
+		t.Errorf("AdminConfig.validate() error = %v, want %v", err, errAdminConfig)
+	}
+}
+
+func Test_pageMods(t *testing.T) {
+	tests := []struct {
+		name     string
+		lr       *auth.ListRequest
+		wantMods int
+		wantSize int
+		wantErr  bool
+	}{
+		{"Default", &auth.ListRequest{}, 2, defaultPageSize, false},
+		{"Page size", &auth.ListRequest{PageSize: 10}, 2, 10, false},
+		{"Maximum", &auth.ListRequest{PageSize: 5000}, 2, maxPageSize, false},
+		{"Page token", &auth.ListRequest{PageToken: "12"}, 3, defaultPageSize, false},
+		{"Invalid token", &auth.ListRequest{PageToken: "foo"}, 0, 0, true},
+		{"Negative token", &auth.ListRequest{PageToken: "-1"}, 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mods, size, err := pageMods(tt.lr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("pageMods() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(mods) != tt.wantMods || size != tt.wantSize {
+				t.Errorf("pageMods() = %v, %v, want %v, %v", len(mods), size, tt.wantMods, tt.wantSize)
+			}
+		})
+	}
+}
+
+func Test_nextPageToken(t *testing.T) {
+	if got := nextPageToken(10, 10, 99); got != "" {
+		t.Errorf("nextPageToken() = %v, want %v", got, "")
+	}
+	if got := nextPageToken(11, 10, 99); got != "99" {
+		t.Errorf("nextPageToken() = %v, want %v", got, "99")
+	}
+}
+
+func Test_containsPattern(t *testing.T) {
+	if got, want := containsPattern(`Foo_%\`), `%foo\_\%\\%`; got != want {
+		t.Errorf("containsPattern() = %v, want %v", got, want)
+	}
+}
+
+func Test_userMessage(t *testing.T) {
+	u := &models.User{
+		ID:         1,
+		Email:      "foo@bar.com",
+		Name:       "foo",
+		CreatedAt:  time.Unix(12, 0),
+		UpdatedAt:  time.Unix(34, 0),
+		VerifiedAt: null.TimeFrom(time.Unix(56, 0)),
+	}
+	u.R = u.R.NewStruct()
+	u.R.Groups = models.GroupSlice{{Name: "b"}, {Name: "a"}}
+	u.R.Audiences = models.AudienceSlice{{Name: "c"}}
+
+	want := &auth.User{
+		Id:         1,
+		Email:      "foo@bar.com",
+		Name:       "foo",
+		Groups:     []string{"a", "b"},
+		Audiences:  []string{"c"},
+		VerifiedAt: 56,
+		CreatedAt:  12,
+		UpdatedAt:  34,
+	}
+	if got := userMessage(u); !reflect.DeepEqual(got, want) {
+		t.Errorf("userMessage() = %v, want %v", got, want)
+	}
+}
+
+// adminTestCtx returns a context with a bearer token for subject,
+// member of groups.
+func adminTestCtx(t *testing.T, subject string, groups ...interface{}) context.Context {
+	claims := &jwt.Claims{
+		KeyID: "10",
+		Registered: jwt.Registered{
+			Issuer:  "localhost",
+			Subject: subject,
+			Expires: jwt.NewNumericTime(time.Now().Add(time.Hour)),
+			Issued:  jwt.NewNumericTime(time.Now()),
+		},
+		Set: map[string]interface{}{jwtGroups: groups},
+	}
+	tkn, err := claims.EdDSASign([]byte(testPrivKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return metadata.NewIncomingContext(testCtx, metadata.Pairs("authorization", "Bearer "+string(tkn)))
+}
+
+func Test_requestTx_authorizeAdmin(t *testing.T) {
+	rt, err := tas.newTx(testCtx, "testing", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pwTkn, err := rt.authReply(testUsers["allGroups"].Email, time.Now(), map[string]interface{}{jwtGroups: []string{"admin"}}, tas.passwordAudience())
+	if err != nil {
+		rt.done()
+		t.Fatal(err)
+	}
+	clientTkn, err := rt.authReply(testUsers["allGroups"].Email, time.Now(), map[string]interface{}{jwtGroups: []string{"admin"}, jwtClientID: "spa"})
+	rt.done()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want codes.Code
+	}{
+		{"Admin", adminTestCtx(t, "all@groups.com", "public", "admin"), codes.OK},
+		{"Not admin", adminTestCtx(t, "one@group.com", "public"), codes.PermissionDenied},
+		{"No groups", adminTestCtx(t, "no@group.com"), codes.PermissionDenied},
+		{"Missing token", testCtx, codes.InvalidArgument},
+		{"Password token", metadata.NewIncomingContext(testCtx, metadata.Pairs("authorization", "Bearer "+pwTkn.GetJwt())), codes.Unauthenticated},
+		{"Client token", metadata.NewIncomingContext(testCtx, metadata.Pairs("authorization", "Bearer "+clientTkn.GetJwt())), codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt, err := tas.newTx(tt.ctx, "testing", true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer rt.done()
+
+			if got := status.Code(rt.authorizeAdmin(time.Now())); got != tt.want {
+				t.Errorf("requestTx.authorizeAdmin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_adminServer(t *testing.T) {
+	s := &adminServer{authServer: tas}
+	ctx := adminTestCtx(t, "all@groups.com", "admin")
+
+	if _, err := s.ListUsers(adminTestCtx(t, "one@group.com", "public"), &auth.ListRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("adminServer.ListUsers() error = %v, want %v", err, codes.PermissionDenied)
+	}
+
+	group, err := s.CreateGroup(ctx, &auth.Group{Name: "admin-test", Description: "Admin test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.CreateGroup(ctx, &auth.Group{Name: "admin-test"}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("adminServer.CreateGroup() error = %v, want %v", err, codes.AlreadyExists)
+	}
+	if _, err = s.CreateGroup(ctx, &auth.Group{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("adminServer.CreateGroup() error = %v, want %v", err, codes.InvalidArgument)
+	}
+	audience, err := s.CreateAudience(ctx, &auth.Audience{Name: "admin-test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = s.CreateUser(ctx, &auth.User{Email: "admin@test.com", Groups: []string{"foo"}}); status.Code(err) != codes.NotFound {
+		t.Errorf("adminServer.CreateUser() error = %v, want %v", err, codes.NotFound)
+	}
+	user, err := s.CreateUser(ctx, &auth.User{
+		Email:      "admin@test.com",
+		Name:       "adminTest",
+		Groups:     []string{"admin-test", "public", "admin-test"},
+		Audiences:  []string{"admin-test"},
+		VerifiedAt: 123,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"admin-test", "public"}; !reflect.DeepEqual(user.GetGroups(), want) || user.GetVerifiedAt() != 123 {
+		t.Errorf("adminServer.CreateUser() = %v, want groups %v", user, want)
+	}
+
+	got, err := s.GetUser(ctx, &auth.ResourceID{Id: user.GetId()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.GetEmail() != "admin@test.com" || !reflect.DeepEqual(got.GetAudiences(), []string{"admin-test"}) {
+		t.Errorf("adminServer.GetUser() = %v", got)
+	}
+
+	list, err := s.ListUsers(ctx, &auth.ListRequest{Group: "admin-test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.GetUsers()) != 1 || list.GetUsers()[0].GetId() != user.GetId() {
+		t.Errorf("adminServer.ListUsers() = %v, want %v", list, user)
+	}
+	list, err = s.ListUsers(ctx, &auth.ListRequest{Filter: "ADMIN@TEST", Audience: "admin-test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.GetUsers()) != 1 {
+		t.Errorf("adminServer.ListUsers() = %v, want %v", list, user)
+	}
+
+	var pages []*auth.User
+	lr := &auth.ListRequest{PageSize: 2}
+	for {
+		list, err = s.ListUsers(ctx, lr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(list.GetUsers()) > 2 {
+			t.Fatalf("adminServer.ListUsers() = %d users, want at most %d", len(list.GetUsers()), 2)
+		}
+		pages = append(pages, list.GetUsers()...)
+		if lr.PageToken = list.GetNextPageToken(); lr.PageToken == "" {
+			break
+		}
+	}
+	rt, err := tas.newTx(testCtx, "testing", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	all, err := models.Users().Count(testCtx, rt.tx)
+	rt.done()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if int64(len(pages)) != all {
+		t.Errorf("adminServer.ListUsers() pages = %d users, want %d", len(pages), all)
+	}
+
+	if got, err = s.UpdateUser(ctx, &auth.User{Id: user.GetId(), Name: "renamed"}); err != nil || got.GetName() != "renamed" || got.GetEmail() != "admin@test.com" {
+		t.Errorf("adminServer.UpdateUser() = %v, %v", got, err)
+	}
+	if _, err = s.UpdateUser(ctx, &auth.User{Id: user.GetId(), Email: "all@groups.com"}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("adminServer.UpdateUser() error = %v, want %v", err, codes.AlreadyExists)
+	}
+
+	if got, err = s.RemoveUserGroups(ctx, &auth.Membership{UserId: user.GetId(), Names: []string{"public"}}); err != nil || !reflect.DeepEqual(got.GetGroups(), []string{"admin-test"}) {
+		t.Errorf("adminServer.RemoveUserGroups() = %v, %v", got, err)
+	}
+	if got, err = s.AddUserGroups(ctx, &auth.Membership{UserId: user.GetId(), Names: []string{"admin-test", "user"}}); err != nil || !reflect.DeepEqual(got.GetGroups(), []string{"admin-test", "user"}) {
+		t.Errorf("adminServer.AddUserGroups() = %v, %v", got, err)
+	}
+	if got, err = s.RemoveUserAudiences(ctx, &auth.Membership{UserId: user.GetId(), Names: []string{"admin-test"}}); err != nil || len(got.GetAudiences()) != 0 {
+		t.Errorf("adminServer.RemoveUserAudiences() = %v, %v", got, err)
+	}
+	if got, err = s.AddUserAudiences(ctx, &auth.Membership{UserId: user.GetId(), Names: []string{"admin-test"}}); err != nil || len(got.GetAudiences()) != 1 {
+		t.Errorf("adminServer.AddUserAudiences() = %v, %v", got, err)
+	}
+
+	groups, err := s.ListGroups(ctx, &auth.ListRequest{Filter: "admin-"})
+	if err != nil || len(groups.GetGroups()) != 1 || groups.GetGroups()[0].GetDescription() != "Admin test" {
+		t.Errorf("adminServer.ListGroups() = %v, %v", groups, err)
+	}
+	if _, err = s.ListGroups(ctx, &auth.ListRequest{Group: "admin"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("adminServer.ListGroups() error = %v, want %v", err, codes.InvalidArgument)
+	}
+	if g, err := s.UpdateGroup(ctx, &auth.Group{Id: group.GetId(), Description: "Updated"}); err != nil || g.GetName() != "admin-test" || g.GetDescription() != "Updated" {
+		t.Errorf("adminServer.UpdateGroup() = %v, %v", g, err)
+	}
+	if a, err := s.UpdateAudience(ctx, &auth.Audience{Id: audience.GetId(), Name: "admin-test2"}); err != nil || a.GetName() != "admin-test2" {
+		t.Errorf("adminServer.UpdateAudience() = %v, %v", a, err)
+	}
+	if audiences, err := s.ListAudiences(ctx, &auth.ListRequest{Filter: "admin-test2"}); err != nil || len(audiences.GetAudiences()) != 1 {
+		t.Errorf("adminServer.ListAudiences() = %v, %v", audiences, err)
+	}
+
+	if _, err = s.DeleteGroup(ctx, &auth.ResourceID{Id: group.GetId()}); err != nil {
+		t.Error(err)
+	}
+	if _, err = s.GetGroup(ctx, &auth.ResourceID{Id: group.GetId()}); status.Code(err) != codes.NotFound {
+		t.Errorf("adminServer.GetGroup() error = %v, want %v", err, codes.NotFound)
+	}
+	if _, err = s.DeleteAudience(ctx, &auth.ResourceID{Id: audience.GetId()}); err != nil {
+		t.Error(err)
+	}
+	if _, err = s.GetAudience(ctx, &auth.ResourceID{Id: audience.GetId()}); status.Code(err) != codes.NotFound {
+		t.Errorf("adminServer.GetAudience() error = %v, want %v", err, codes.NotFound)
+	}
+	if _, err = s.DeleteUser(ctx, &auth.ResourceID{Id: user.GetId()}); err != nil {
+		t.Error(err)
+	}
+	if _, err = s.GetUser(ctx, &auth.ResourceID{Id: user.GetId()}); status.Code(err) != codes.NotFound {
+		t.Errorf("adminServer.GetUser() error = %v, want %v", err, codes.NotFound)
+	}
+	if _, err = s.GetUser(ctx, &auth.ResourceID{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("adminServer.GetUser() error = %v, want %v", err, codes.InvalidArgument)
+	}
+}
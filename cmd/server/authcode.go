@@ -0,0 +1,149 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"time"
+
+	auth "github.com/moapis/authenticator"
+	"github.com/moapis/authenticator/models"
+	"github.com/sirupsen/logrus"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// authCodeLen is the amount of random bytes in an authorization code.
+const authCodeLen = 32
+
+// issueAuthCode inserts m as a new authorization code.
+// UserID and Family need to be set, Hash and ExpiresAt are set by this method.
+// Codes are hashed like refresh tokens and always read from crypto/rand.
+func (rt *requestTx) issueAuthCode(m *models.AuthCode, issued time.Time) (*auth.AuthCode, error) {
+	log := rt.log.WithFields(logrus.Fields{"user_id": m.UserID, "family": m.Family})
+
+	code, err := randomString(rand.Read, authCodeLen)
+	if err != nil {
+		log.WithError(err).Error("issueAuthCode")
+		return nil, status.Error(codes.Internal, errFatal)
+	}
+
+	m.Hash = hashRefreshToken(code)
+	m.ExpiresAt = issued.Add(rt.s.conf.JWT.AuthCodeExpiry)
+	if err = m.Insert(rt.ctx, rt.tx, boil.Infer()); err != nil {
+		log.WithError(err).Error("issueAuthCode")
+		return nil, status.Error(codes.Internal, errDB)
+	}
+	log.WithField("id", m.ID).Debug("issueAuthCode")
+	return &auth.AuthCode{Code: code, Expires: m.ExpiresAt.Unix()}, nil
+}
+
+// useAuthCode marks code as used and returns its database entry.
+// Reuse of a code indicates it was intercepted,
+// so all refresh tokens in its family are revoked and committed, before returning an error.
+func (rt *requestTx) useAuthCode(code string, now time.Time) (*models.AuthCode, error) {
+	if code == "" {
+		rt.log.Warn(errMissingAuthCode)
+		return nil, status.Error(codes.InvalidArgument, errMissingAuthCode)
+	}
+	m, err := models.AuthCodes(
+		models.AuthCodeWhere.Hash.EQ(hashRefreshToken(code)),
+		qm.For("update"),
+	).One(rt.ctx, rt.tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			rt.log.WithError(err).Warn("useAuthCode")
+			return nil, status.Error(codes.Unauthenticated, errAuthCode)
+		}
+		rt.log.WithError(err).Error("useAuthCode")
+		return nil, status.Error(codes.Internal, errDB)
+	}
+	rt.log = rt.log.WithFields(logrus.Fields{"auth_code_id": m.ID, "family": m.Family})
+
+	if m.UsedAt.Valid {
+		rt.log.Warn(errAuthCodeReused)
+		if err = rt.revokeRefreshFamily(m.Family, now); err != nil {
+			return nil, err
+		}
+		if err = rt.commit(); err != nil {
+			return nil, err
+		}
+		return nil, status.Error(codes.Unauthenticated, errAuthCodeReused)
+	}
+	if m.ExpiresAt.Before(now) {
+		rt.log.WithField("expires_at", m.ExpiresAt).Warn(errAuthCodeExpired)
+		return nil, status.Error(codes.Unauthenticated, errAuthCodeExpired)
+	}
+
+	m.UsedAt = null.TimeFrom(now)
+	if _, err = m.Update(rt.ctx, rt.tx, boil.Whitelist(models.AuthCodeColumns.UsedAt)); err != nil {
+		rt.log.WithError(err).Error("useAuthCode")
+		return nil, status.Error(codes.Internal, errDB)
+	}
+	return m, nil
+}
+
+func (s *authServer) CreateAuthCode(ctx context.Context, ar *auth.AuthReply) (*auth.AuthCode, error) {
+	rt, err := s.newTx(ctx, "CreateAuthCode", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	now := time.Now()
+	m, err := rt.useRefreshToken(ar.GetRefreshToken(), now)
+	if err != nil {
+		return nil, err
+	}
+	if err = rt.userFamily(m.Family); err != nil {
+		return nil, err
+	}
+	code, err := rt.issueAuthCode(&models.AuthCode{UserID: m.UserID, Family: m.Family}, now)
+	if err != nil {
+		return nil, err
+	}
+	if err = rt.commit(); err != nil {
+		return nil, err
+	}
+	return code, nil
+}
+
+func (s *authServer) ExchangeAuthCode(ctx context.Context, ac *auth.AuthCode) (*auth.AuthReply, error) {
+	rt, err := s.newTx(ctx, "ExchangeAuthCode", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	now := time.Now()
+	m, err := rt.useAuthCode(ac.GetCode(), now)
+	if err != nil {
+		return nil, err
+	}
+	if m.ClientID.Valid {
+		rt.log.Warn(errClientAuthCode)
+		return nil, status.Error(codes.Unauthenticated, errAuthCode)
+	}
+	user, err := models.FindUser(ctx, rt.tx, m.UserID)
+	if err != nil {
+		return nil, rt.dbAuthError("FindUser", "user", err)
+	}
+	return rt.refreshAuthReply(user, m.Family, now)
+}
+
+// pruneAuthCodes deletes authorization codes which expired before now.
+func (s *authServer) pruneAuthCodes(ctx context.Context, now time.Time) (int64, error) {
+	db, err := s.mdb.Master(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return models.AuthCodes(models.AuthCodeWhere.ExpiresAt.LT(now)).DeleteAll(ctx, db)
+}
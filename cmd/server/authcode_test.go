@@ -0,0 +1,161 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	auth "github.com/moapis/authenticator"
+	"github.com/moapis/authenticator/models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_authServer_CreateAuthCode(t *testing.T) {
+	exCtx, cancel := context.WithTimeout(testCtx, -1)
+	defer cancel()
+
+	login := loginTestUser(t)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		ar   *auth.AuthReply
+		want codes.Code
+	}{
+		{"Expired context", exCtx, nil, codes.Unknown},
+		{"Empty token", testCtx, &auth.AuthReply{}, codes.InvalidArgument},
+		{"Unknown refresh token", testCtx, &auth.AuthReply{RefreshToken: "foo"}, codes.Unauthenticated},
+		{"Success", testCtx, login, codes.OK},
+		{"Used refresh token", testCtx, login, codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tas.CreateAuthCode(tt.ctx, tt.ar)
+			if c := status.Code(err); c != tt.want {
+				t.Fatalf("authServer.CreateAuthCode() error = %v, want %v", err, tt.want)
+			}
+			if err != nil {
+				return
+			}
+			if got.GetCode() == "" {
+				t.Errorf("authServer.CreateAuthCode() = %v, want code", got)
+			}
+			if want := time.Now().Add(tas.conf.JWT.AuthCodeExpiry).Unix(); got.GetExpires() > want {
+				t.Errorf("authServer.CreateAuthCode() expires = %v, want <= %v", got.GetExpires(), want)
+			}
+		})
+	}
+}
+
+func Test_authServer_ExchangeAuthCode(t *testing.T) {
+	exCtx, cancel := context.WithTimeout(testCtx, -1)
+	defer cancel()
+
+	code, err := tas.CreateAuthCode(testCtx, loginTestUser(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired, err := tas.CreateAuthCode(testCtx, loginTestUser(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := mdb.Master(testCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = models.AuthCodes(
+		models.AuthCodeWhere.Hash.EQ(hashRefreshToken(expired.GetCode())),
+	).UpdateAll(testCtx, db, models.M{
+		models.AuthCodeColumns.ExpiresAt: time.Unix(1000, 0),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var reply *auth.AuthReply
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		ac   *auth.AuthCode
+		want codes.Code
+	}{
+		{"Expired context", exCtx, nil, codes.Unknown},
+		{"Empty code", testCtx, &auth.AuthCode{}, codes.InvalidArgument},
+		{"Unknown code", testCtx, &auth.AuthCode{Code: "foo"}, codes.Unauthenticated},
+		{"Expired code", testCtx, expired, codes.Unauthenticated},
+		{"Success", testCtx, code, codes.OK},
+		{"Reused code", testCtx, code, codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tas.ExchangeAuthCode(tt.ctx, tt.ac)
+			if c := status.Code(err); c != tt.want {
+				t.Fatalf("authServer.ExchangeAuthCode() error = %v, want %v", err, tt.want)
+			}
+			if err != nil {
+				return
+			}
+			if got.GetJwt() == "" || got.GetRefreshToken() == "" {
+				t.Errorf("authServer.ExchangeAuthCode() = %v, want JWT and refresh token", got)
+			}
+			reply = got
+		})
+	}
+
+	// Reuse of the code revoked the refresh token from the exchange.
+	if _, err = tas.RefreshToken(testCtx, reply); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("authServer.RefreshToken() after code reuse error = %v, want %v", err, codes.Unauthenticated)
+	}
+}
+
+func Test_authServer_pruneAuthCodes(t *testing.T) {
+	db, err := mdb.Master(testCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &models.AuthCode{
+		UserID:    testUsers["allGroups"].ID,
+		Family:    "prune",
+		Hash:      hashRefreshToken("prune-code"),
+		ExpiresAt: time.Unix(500, 0),
+	}
+	if err = m.Insert(testCtx, db, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(501, 0)
+
+	tests := []struct {
+		name    string
+		want    int64
+		wantErr bool
+	}{
+		{
+			"Success",
+			1,
+			false,
+		},
+		{
+			"Nothing to prune",
+			0,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tas.pruneAuthCodes(testCtx, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("authServer.pruneAuthCodes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("authServer.pruneAuthCodes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
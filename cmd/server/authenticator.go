@@ -22,6 +22,7 @@ import (
 	"github.com/moapis/mailer"
 	"github.com/moapis/multidb"
 	"github.com/sirupsen/logrus"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -38,11 +39,28 @@ type authServer struct {
 	mdb     *multidb.MultiDB
 	privKey privateKey
 	keyMtx  sync.RWMutex //Protects privKey during updates
+	kek     []byte       // Key-encryption key for shared keys, nil when keys are not shared
+	mfaKEK  []byte       // Key-encryption key for TOTP secrets, nil when MFA is disabled
 	log     *logrus.Entry
 	conf    *ServerConfig
 	mail    *mailer.Mailer
+
+	// breached is the breached password corpus, nil when not configured.
+	breached *breachedCorpus
+
+	// providers for federated login by name, nil when federation is disabled.
+	providers map[string]*provider
+
+	// backends verifying the passwords of users by name, nil when only local passwords are used.
+	backends map[string]credentialBackend
+
+	// readRand is used for generating token IDs.
+	// crypto/rand.Read is used when nil.
+	readRand func([]byte) (int, error)
 }
 
+// updateKeyPair generates a new signing key and stores its public key.
+// The previous signing key, if any, is retired.
 func (s *authServer) updateKeyPair(ctx context.Context, r io.Reader) error {
 	pub, priv, err := ed25519.GenerateKey(r)
 	if err != nil {
@@ -50,9 +68,14 @@ func (s *authServer) updateKeyPair(ctx context.Context, r io.Reader) error {
 	}
 	log := s.log.WithField("pub", string(pub))
 	log.Debug("Generated key")
+
+	now := time.Now()
 	m := &models.JWTKey{
 		PublicKey: pub,
 	}
+	if every := s.conf.JWT.RotateEvery; every > 0 {
+		m.ExpiresAt = null.TimeFrom(now.Add(every))
+	}
 	db, err := s.mdb.Master(ctx)
 	if err != nil {
 		return err
@@ -69,15 +92,29 @@ func (s *authServer) updateKeyPair(ctx context.Context, r io.Reader) error {
 		key: priv,
 	}
 
-	s.keyMtx.Lock()
-	s.privKey = pk
-	s.keyMtx.Unlock()
+	old := s.setPrivateKey(pk)
 
 	log.Info("JWT keypair update complete")
 
+	if old.id != "" {
+		if err = retireKey(ctx, db, old.id, now); err != nil {
+			// The old key will expire regardless, at its original expiry time.
+			log.WithError(err).WithField("old", old.id).Error("retireKey")
+		}
+	}
+
 	return nil
 }
 
+// setPrivateKey replaces the signing key and returns the previous one.
+func (s *authServer) setPrivateKey(pk privateKey) privateKey {
+	s.keyMtx.Lock()
+	old := s.privKey
+	s.privKey = pk
+	s.keyMtx.Unlock()
+	return old
+}
+
 func (s *authServer) privateKey() privateKey {
 	s.keyMtx.RLock()
 	p := s.privKey
@@ -86,16 +123,41 @@ func (s *authServer) privateKey() privateKey {
 }
 
 const (
-	errMissingEmail = "Missing email"
-	errMissingPW    = "Missing password"
-	errMissingToken = "JWT token missing"
-	errExpiredToken = "JWT expired"
-	errMissingUUID  = "UUID missing"
-	errMissingKeyID = "Public key ID missing"
-	errKeyNotFound  = "Key ID not found"
-	errFatal        = "Fatal I/O error"
-	errDB           = "Database error"
-	errMailer       = "Failed to send verification mail"
+	errMissingEmail       = "Missing email"
+	errMissingPW          = "Missing password"
+	errMissingToken       = "JWT token missing"
+	errExpiredToken       = "JWT expired"
+	errMissingUUID        = "UUID missing"
+	errMissingKeyID       = "Public key ID missing"
+	errKeyNotFound        = "Key ID not found"
+	errKeyExpired         = "Key ID expired"
+	errUserNotFound       = "User not found"
+	errMissingJTI         = "JWT ID missing"
+	errRevokedToken       = "JWT revoked"
+	errMissingRefresh     = "Refresh token missing"
+	errRefreshToken       = "Invalid refresh token"
+	errRefreshReused      = "Refresh token reused"
+	errRefreshExpired     = "Refresh token expired"
+	errMissingAuthCode    = "Authorization code missing"
+	errAuthCode           = "Invalid authorization code"
+	errAuthCodeReused     = "Authorization code reused"
+	errAuthCodeExpired    = "Authorization code expired"
+	errUserToken          = "Not a user token"
+	errMFAToken           = "Not a two-factor authentication token"
+	errMFADisabled        = "Two-factor authentication disabled"
+	errMissingMFACode     = "Two-factor authentication code missing"
+	errMFACode            = "Invalid two-factor authentication code"
+	errTOTPEnabled        = "TOTP already enabled"
+	errTOTPNotEnrolled    = "TOTP not enrolled"
+	errWebAuthnDisabled   = "WebAuthn disabled"
+	errWebAuthnChallenge  = "Invalid WebAuthn challenge"
+	errWebAuthnResponse   = "Invalid WebAuthn response"
+	errWebAuthnCredential = "Unknown WebAuthn credential"
+	errWebAuthnCounter    = "WebAuthn sign counter did not increase"
+	errWebAuthnExists     = "WebAuthn credential already registered"
+	errFatal              = "Fatal I/O error"
+	errDB                 = "Database error"
+	errMailer             = "Failed to send verification mail"
 )
 
 func callBackURL(cb *auth.CallBackUrl, token string) template.URL {
@@ -140,7 +202,7 @@ func (s *authServer) RegisterPwUser(ctx context.Context, rd *auth.RegistrationDa
 	if err != nil {
 		return nil, err
 	}
-	reply, err := rt.authReply(user.Name, time.Now(), nil, s.passwordAudience())
+	reply, err := rt.mailToken(user, purposeVerify, time.Now())
 	if err != nil {
 		return nil, err
 	}
@@ -160,18 +222,200 @@ func (s *authServer) RegisterPwUser(ctx context.Context, rd *auth.RegistrationDa
 }
 
 func (s *authServer) AuthenticatePwUser(ctx context.Context, up *auth.UserPassword) (*auth.AuthReply, error) {
-	rt, err := s.newTx(ctx, "AuthenticatePwUser", true)
+	rt, err := s.newTx(ctx, "AuthenticatePwUser", false)
 	if err != nil {
 		return nil, err
 	}
 	defer rt.done()
 
-	user, err := rt.authenticatePwUser(up.GetEmail(), up.GetPassword())
+	now := time.Now()
+	user, err := rt.checkPwUser(up.GetEmail(), up.GetPassword(), now)
 	if err != nil {
 		return nil, err
 	}
 
-	return rt.userAuthReply(user, time.Now())
+	required, err := rt.mfaRequired(user)
+	if err != nil {
+		return nil, err
+	}
+	if required {
+		return rt.mfaAuthReply(user, now)
+	}
+
+	if err = rt.clearFailures(lockoutAccount, user.Email); err != nil {
+		return nil, err
+	}
+	return rt.refreshAuthReply(user, "", now)
+}
+
+func (s *authServer) VerifyMFA(ctx context.Context, mc *auth.MFACode) (*auth.AuthReply, error) {
+	rt, err := s.newTx(ctx, "VerifyMFA", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	now := time.Now()
+	claims, err := rt.checkJWT(mc.GetJwt(), now)
+	if err != nil {
+		return nil, err
+	}
+	if !containsString(claims.Audiences, s.mfaAudience()) {
+		rt.log.WithField("audiences", claims.Audiences).Warn(errMFAToken)
+		return nil, status.Error(codes.Unauthenticated, errMFAToken)
+	}
+	subjects := rt.lockSubjects(lockoutAccount, claims.Subject)
+	if err = rt.checkLocked(now, subjects...); err != nil {
+		return nil, err
+	}
+
+	user, err := rt.findUserByEmail(claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+	err = rt.verifyMFACode(user, mc.GetCode(), now)
+	if status.Code(err) == codes.Unauthenticated {
+		return nil, rt.loginFailed(now, err, subjects...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = rt.clearFailures(lockoutAccount, user.Email); err != nil {
+		return nil, err
+	}
+	return rt.refreshAuthReply(user, "", now)
+}
+
+func (s *authServer) EnrollTOTP(ctx context.Context, tkn *auth.AuthReply) (*auth.TOTPEnrollment, error) {
+	rt, err := s.newTx(ctx, "EnrollTOTP", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	user, err := rt.userFromToken(tkn.GetJwt(), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	enrollment, err := rt.enrollTOTP(user, rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	if err = rt.commit(); err != nil {
+		return nil, err
+	}
+	return enrollment, nil
+}
+
+func (s *authServer) ConfirmTOTP(ctx context.Context, mc *auth.MFACode) (*auth.RecoveryCodes, error) {
+	rt, err := s.newTx(ctx, "ConfirmTOTP", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	now := time.Now()
+	user, err := rt.userFromToken(mc.GetJwt(), now)
+	if err != nil {
+		return nil, err
+	}
+	codeList, err := rt.confirmTOTP(user, mc.GetCode(), now, rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	if err = rt.commit(); err != nil {
+		return nil, err
+	}
+	return &auth.RecoveryCodes{Codes: codeList}, nil
+}
+
+func (s *authServer) BeginWebAuthnRegistration(ctx context.Context, tkn *auth.AuthReply) (*auth.WebAuthnChallenge, error) {
+	rt, err := s.newTx(ctx, "BeginWebAuthnRegistration", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	now := time.Now()
+	user, err := rt.userFromToken(tkn.GetJwt(), now)
+	if err != nil {
+		return nil, err
+	}
+	challenge, err := rt.beginWebAuthnRegistration(user, now, rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	if err = rt.commit(); err != nil {
+		return nil, err
+	}
+	return challenge, nil
+}
+
+func (s *authServer) FinishWebAuthnRegistration(ctx context.Context, att *auth.WebAuthnAttestation) (*auth.WebAuthnCredential, error) {
+	rt, err := s.newTx(ctx, "FinishWebAuthnRegistration", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	now := time.Now()
+	user, err := rt.userFromToken(att.GetJwt(), now)
+	if err != nil {
+		return nil, err
+	}
+	cred, err := rt.finishWebAuthnRegistration(user, att, now)
+	if err != nil {
+		return nil, err
+	}
+	if err = rt.commit(); err != nil {
+		return nil, err
+	}
+	return &auth.WebAuthnCredential{CredentialId: cred.CredentialID}, nil
+}
+
+func (s *authServer) BeginWebAuthnLogin(ctx context.Context, ud *auth.UserData) (*auth.WebAuthnChallenge, error) {
+	rt, err := s.newTx(ctx, "BeginWebAuthnLogin", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	challenge, err := rt.beginWebAuthnLogin(ud.GetEmail(), time.Now(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	if err = rt.commit(); err != nil {
+		return nil, err
+	}
+	return challenge, nil
+}
+
+// FinishWebAuthnLogin requires the TOTP code of users with two-factor authentication,
+// unless the authenticator verified the user.
+func (s *authServer) FinishWebAuthnLogin(ctx context.Context, as *auth.WebAuthnAssertion) (*auth.AuthReply, error) {
+	rt, err := s.newTx(ctx, "FinishWebAuthnLogin", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	now := time.Now()
+	user, verified, err := rt.finishWebAuthnLogin(as, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if !verified {
+		required, err := rt.mfaRequired(user)
+		if err != nil {
+			return nil, err
+		}
+		if required {
+			return rt.mfaAuthReply(user, now)
+		}
+	}
+
+	return rt.refreshAuthReply(user, "", now)
 }
 
 func (s *authServer) hasPasswordAudience(audiences []string) error {
@@ -193,20 +437,18 @@ func (s *authServer) ChangeUserPw(ctx context.Context, up *auth.NewUserPassword)
 
 	var user *models.User
 	if old := up.GetOldPassword(); old != "" {
-		if user, err = rt.authenticatePwUser(up.GetEmail(), old); err != nil {
+		if user, err = rt.checkPwUser(up.GetEmail(), old, time.Now()); err != nil {
 			return nil, err
 		}
 	} else {
-		claims, err := rt.checkJWT(up.GetResetToken(), time.Now())
+		// The registration mail asks to create a password with its verification token.
+		// As tokens are bound to the password, it can't be used once a password is set.
+		user, err = rt.useMailToken(up.GetResetToken(), time.Now(), purposeReset, purposeVerify)
 		if err != nil {
 			return nil, err
 		}
-		if err = s.hasPasswordAudience(claims.Audiences); err != nil {
-			return nil, err
-		}
-
-		user, err = rt.findUserByEmail(claims.Subject)
-		if err != nil {
+		// Receiving the reset token proves ownership of the e-mail address.
+		if err = rt.markVerified(user, time.Now()); err != nil {
 			return nil, err
 		}
 	}
@@ -229,23 +471,45 @@ func (s *authServer) CheckUserExists(ctx context.Context, ud *auth.UserData) (*a
 	return rt.checkUserExists(ud.GetEmail())
 }
 
-func (s *authServer) RefreshToken(ctx context.Context, old *auth.AuthReply) (*auth.AuthReply, error) {
-	rt, err := s.newTx(ctx, "RefreshToken", true)
+func (s *authServer) VerifyUser(ctx context.Context, tkn *auth.AuthReply) (*auth.AuthReply, error) {
+	rt, err := s.newTx(ctx, "VerifyUser", false)
 	if err != nil {
 		return nil, err
 	}
 	defer rt.done()
-	claims, err := rt.checkJWT(old.GetJwt(), time.Now())
+
+	user, err := rt.useMailToken(tkn.GetJwt(), time.Now(), purposeVerify)
 	if err != nil {
 		return nil, err
 	}
-	user, err := rt.findUserByEmail(claims.Subject)
-	if err != nil {
+	if err = rt.markVerified(user, time.Now()); err != nil {
 		return nil, err
 	}
 	return rt.userAuthReply(user, time.Now())
 }
 
+func (s *authServer) RefreshToken(ctx context.Context, old *auth.AuthReply) (*auth.AuthReply, error) {
+	rt, err := s.newTx(ctx, "RefreshToken", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	now := time.Now()
+	m, err := rt.useRefreshToken(old.GetRefreshToken(), now)
+	if err != nil {
+		return nil, err
+	}
+	if err = rt.userFamily(m.Family); err != nil {
+		return nil, err
+	}
+	user, err := models.FindUser(ctx, rt.tx, m.UserID)
+	if err != nil {
+		return nil, rt.dbAuthError("FindUser", "user", err)
+	}
+	return rt.refreshAuthReply(user, m.Family, now)
+}
+
 func (s *authServer) PublicUserToken(ctx context.Context, pu *auth.PublicUser) (*auth.AuthReply, error) {
 	rt, err := s.newTx(ctx, "PublicUserToken", true)
 	if err != nil {
@@ -281,11 +545,25 @@ func (s *authServer) ResetUserPW(ctx context.Context, ue *auth.UserEmail) (*empt
 		return nil, status.Error(codes.InvalidArgument, errMissingEmail)
 	}
 
-	user, err := rt.findUserByEmail(ue.GetEmail())
+	now := time.Now()
+	subjects := rt.lockSubjects(lockoutReset, email)
+	if err = rt.checkLocked(now, subjects...); err != nil {
+		return nil, err
+	}
+
+	user, err := rt.findUserByEmail(email)
 	if err != nil {
-		return nil, status.Error(codes.NotFound, "User not found")
+		// Unknown addresses only count as failure for the source IP.
+		return nil, rt.loginFailed(now, status.Error(codes.NotFound, errUserNotFound), inScope(lockoutIP, subjects)...)
 	}
-	reply, err := rt.authReply(user.Email, time.Now(), nil, s.passwordAudience())
+	if err = rt.localPassword(user); err != nil {
+		return nil, err
+	}
+	// Every sent mail counts against the account.
+	if err = rt.countAttempt(now, inScope(lockoutReset, subjects)...); err != nil {
+		return nil, err
+	}
+	reply, err := rt.mailToken(user, purposeReset, now)
 	if err != nil {
 		return nil, err
 	}
@@ -303,3 +581,81 @@ func (s *authServer) ResetUserPW(ctx context.Context, ue *auth.UserEmail) (*empt
 
 	return &empty.Empty{}, nil
 }
+
+func (s *authServer) Logout(ctx context.Context, tkn *auth.AuthReply) (*empty.Empty, error) {
+	rt, err := s.newTx(ctx, "Logout", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	claims, err := rt.checkJWT(tkn.GetJwt(), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	expires := time.Now().Add(s.conf.JWT.Expiry)
+	if claims.Expires != nil {
+		expires = claims.Expires.Time()
+	}
+	if err = rt.revokeToken(claims.ID, expires); err != nil {
+		return nil, err
+	}
+	if refresh := tkn.GetRefreshToken(); refresh != "" {
+		m, err := rt.findRefreshToken(refresh)
+		if err != nil {
+			return nil, err
+		}
+		if err = rt.revokeRefreshFamily(m.Family, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+	if err = rt.commit(); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func (s *authServer) RevokeToken(ctx context.Context, id *auth.TokenID) (*empty.Empty, error) {
+	rt, err := s.newTx(ctx, "RevokeToken", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	// The token is not available, so its expiry is not known.
+	// It is kept in the list for the longest lifetime of any token.
+	if err = rt.revokeToken(id.GetJti(), time.Now().Add(s.maxTokenExpiry())); err != nil {
+		return nil, err
+	}
+	if err = rt.commit(); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func (s *authServer) ListRevoked(ctx context.Context, _ *empty.Empty) (*auth.RevokedTokens, error) {
+	rt, err := s.newTx(ctx, "ListRevoked", true)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+	return rt.revokedTokens(time.Now())
+}
+
+func (s *authServer) ListPubKeys(ctx context.Context, _ *empty.Empty) (*auth.PublicKeys, error) {
+	rt, err := s.newTx(ctx, "ListPubKeys", true)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	keys, err := rt.validKeys(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	pks := &auth.PublicKeys{Keys: make([]*auth.PublicKey, len(keys))}
+	for i, k := range keys {
+		pks.Keys[i] = &auth.PublicKey{Key: k.PublicKey, Kid: int32(k.ID)}
+	}
+	return pks, nil
+}
@@ -5,11 +5,17 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"crypto/ed25519"
+	"fmt"
 	"html/template"
 	"io"
+	"net"
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -17,8 +23,11 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 	auth "github.com/moapis/authenticator"
 	"github.com/moapis/authenticator/models"
+	"github.com/moapis/mailer"
 	"github.com/moapis/multidb"
 	"github.com/pascaldekloe/jwt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -91,8 +100,9 @@ func Test_authServer_updateKeyPair(t *testing.T) {
 	for i, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &authServer{
-				mdb: tt.fields.mdb,
-				log: tas.log,
+				mdb:  tt.fields.mdb,
+				log:  tas.log,
+				conf: tas.conf,
 			}
 			err := s.updateKeyPair(tt.args.ctx, tt.args.r)
 			if (err != nil) != tt.wantErr {
@@ -217,6 +227,106 @@ func Test_authServer_RegisterPwUser(t *testing.T) {
 	}
 }
 
+// smtpRecorder serves a minimal SMTP dialog on a local port,
+// sending the DATA of each received message on the returned channel.
+func smtpRecorder(t *testing.T) (string, <-chan string) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	msgs := make(chan string, 1)
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				fmt.Fprint(conn, "220 localhost\r\n")
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+					case cmd == "DATA":
+						fmt.Fprint(conn, "354 Go ahead\r\n")
+						var b strings.Builder
+						for {
+							line, err = r.ReadString('\n')
+							if err != nil || line == ".\r\n" {
+								break
+							}
+							b.WriteString(line)
+						}
+						msgs <- b.String()
+						fmt.Fprint(conn, "250 OK\r\n")
+					case cmd == "QUIT":
+						fmt.Fprint(conn, "221 Bye\r\n")
+						return
+					default:
+						fmt.Fprint(conn, "250 OK\r\n")
+					}
+				}
+			}(conn)
+		}
+	}()
+	return l.Addr().String(), msgs
+}
+
+func Test_authServer_RegisterPwUser_token(t *testing.T) {
+	addr, msgs := smtpRecorder(t)
+	s := &authServer{
+		mdb:     mdb,
+		log:     tas.log,
+		conf:    tas.conf,
+		privKey: tas.privateKey(),
+		mail: mailer.New(
+			template.Must(template.ParseGlob(tas.conf.Mail.TemplateGlob)),
+			addr, tas.conf.Mail.From, nil,
+		),
+	}
+
+	const email = "registered@test.mailu.io"
+	if _, err := s.RegisterPwUser(testCtx, &auth.RegistrationData{
+		Email: email,
+		Name:  "Registered",
+		Url:   &auth.CallBackUrl{BaseUrl: "https://example.com/verify"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var msg string
+	select {
+	case msg = <-msgs:
+	case <-time.After(time.Second):
+		t.Fatal("authServer.RegisterPwUser() did not send mail")
+	}
+	m := regexp.MustCompile(`token=([\w.-]+)`).FindStringSubmatch(msg)
+	if m == nil {
+		t.Fatalf("authServer.RegisterPwUser() mail without token: %s", msg)
+	}
+
+	claims, err := jwt.EdDSACheck([]byte(m[1]), []byte(testPubKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// VerifyUser looks up the user by the subject's email
+	if claims.Subject != email {
+		t.Errorf("authServer.RegisterPwUser() token subject = %v, want %v", claims.Subject, email)
+	}
+	if !claims.AcceptAudience(tas.passwordAudience()) {
+		t.Errorf("authServer.RegisterPwUser() token audiences = %v, want %v", claims.Audiences, tas.passwordAudience())
+	}
+	if got := claims.Set[purposeClaim]; got != purposeVerify {
+		t.Errorf("authServer.RegisterPwUser() token purpose = %v, want %v", got, purposeVerify)
+	}
+}
+
 func Test_authServer_AuthenticatePwUser(t *testing.T) {
 	exCtx, cancel := context.WithTimeout(testCtx, -1)
 	defer cancel()
@@ -265,8 +375,11 @@ func Test_authServer_AuthenticatePwUser(t *testing.T) {
 				t.Errorf("authServer.AuthenticatePwUser() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !tt.wantErr && got == nil {
-				t.Errorf("authServer.AuthenticatePwUser() = %v, wantErr %v", got, tt.wantErr)
+			if !tt.wantErr && (got.GetJwt() == "" || got.GetRefreshToken() == "") {
+				t.Errorf("authServer.AuthenticatePwUser() = %v, want JWT and refresh token", got)
+			}
+			if !tt.wantErr && got.GetRefreshExpires() <= got.GetExpires() {
+				t.Errorf("authServer.AuthenticatePwUser() refresh expires = %v, want after %v", got.GetRefreshExpires(), got.GetExpires())
 			}
 		})
 	}
@@ -277,25 +390,6 @@ func Test_authServer_ChangeUserPw(t *testing.T) {
 	defer cancel()
 
 	claims := &jwt.Claims{
-		KeyID: "10",
-		Registered: jwt.Registered{
-			Issuer:    "localhost",
-			Subject:   testUsers["oneGroup"].Email,
-			Audiences: []string{"passwords@localhost"},
-			Expires:   jwt.NewNumericTime(time.Now().Add(24 * time.Hour)),
-			Issued:    jwt.NewNumericTime(time.Now()),
-		},
-		Set: map[string]interface{}{
-			"user_id":   103,
-			"group_ids": []int{1, 2, 3},
-		},
-	}
-	jwtKnown, err := claims.EdDSASign([]byte(testPrivKey))
-	if err != nil {
-		t.Fatal(err)
-	}
-
-	claims = &jwt.Claims{
 		KeyID: "10",
 		Registered: jwt.Registered{
 			Issuer:    "localhost",
@@ -400,19 +494,6 @@ func Test_authServer_ChangeUserPw(t *testing.T) {
 			nil,
 			true,
 		},
-		{
-			"Valid user and token",
-			args{
-				testCtx,
-				&auth.NewUserPassword{
-					Email:       "one@group.com",
-					Credential:  &auth.NewUserPassword_ResetToken{ResetToken: string(jwtKnown)},
-					NewPassword: "oneGroup",
-				},
-			},
-			&auth.ChangePwReply{Success: true},
-			false,
-		},
 		{
 			"Valid token and unknown user",
 			args{
@@ -510,47 +591,154 @@ func Test_authServer_CheckUserExists(t *testing.T) {
 	}
 }
 
-func Test_authServer_RefreshToken(t *testing.T) {
+func Test_authServer_VerifyUser(t *testing.T) {
 	exCtx, cancel := context.WithTimeout(testCtx, -1)
 	defer cancel()
 
-	claims := &jwt.Claims{
-		KeyID: "10",
-		Registered: jwt.Registered{
-			Issuer:    "localhost",
-			Subject:   testUsers["allGroups"].Email,
-			Expires:   jwt.NewNumericTime(time.Now().Add(24 * time.Hour)),
-			Audiences: []string{"me", "and", "you"},
-			Issued:    jwt.NewNumericTime(time.Now()),
+	newToken := func(subject string, audiences ...string) *auth.AuthReply {
+		claims := &jwt.Claims{
+			KeyID: "10",
+			Registered: jwt.Registered{
+				Issuer:    "localhost",
+				Subject:   subject,
+				Expires:   jwt.NewNumericTime(time.Now().Add(time.Hour)),
+				Audiences: audiences,
+				Issued:    jwt.NewNumericTime(time.Now()),
+			},
+		}
+		tkn, err := claims.EdDSASign([]byte(testPrivKey))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &auth.AuthReply{Jwt: string(tkn)}
+	}
+	verifyTkn := purposeToken(t, testUsers["toVerify"].Email, purposeVerify)
+
+	type args struct {
+		ctx context.Context
+		tkn *auth.AuthReply
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			"Expired context",
+			args{
+				exCtx,
+				nil,
+			},
+			true,
 		},
-		Set: map[string]interface{}{
-			"user_id":   103,
-			"group_ids": []int{1, 2, 3},
+		{
+			"Empty token",
+			args{
+				testCtx,
+				&auth.AuthReply{},
+			},
+			true,
 		},
+		{
+			"Wrong audience",
+			args{
+				testCtx,
+				newToken(testUsers["toVerify"].Email, "foo"),
+			},
+			true,
+		},
+		{
+			"Unknown user",
+			args{
+				testCtx,
+				newToken("nobody@nowhere.com", tas.passwordAudience()),
+			},
+			true,
+		},
+		{
+			"Without purpose",
+			args{
+				testCtx,
+				newToken(testUsers["toVerify"].Email, tas.passwordAudience()),
+			},
+			true,
+		},
+		{
+			"Reset purpose",
+			args{
+				testCtx,
+				purposeToken(t, testUsers["toVerify"].Email, purposeReset),
+			},
+			true,
+		},
+		{
+			"Success",
+			args{
+				testCtx,
+				verifyTkn,
+			},
+			false,
+		},
+		{
+			"Reused",
+			args{
+				testCtx,
+				verifyTkn,
+			},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tas.VerifyUser(tt.args.ctx, tt.args.tkn)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("authServer.VerifyUser() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			claims, err := jwt.EdDSACheck([]byte(got.GetJwt()), []byte(testPubKey))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if groups, _ := claims.Set["groups"].([]interface{}); len(groups) == 0 {
+				t.Errorf("authServer.VerifyUser() groups = %v, want groups of verified user", claims.Set["groups"])
+			}
+		})
 	}
-	jwtKnown, err := claims.EdDSASign([]byte(testPrivKey))
+}
+
+// loginTestUser authenticates the allGroups user, returning a JWT and refresh token.
+func loginTestUser(t *testing.T) *auth.AuthReply {
+	reply, err := tas.AuthenticatePwUser(testCtx, &auth.UserPassword{
+		Email:    testUsers["allGroups"].Email,
+		Password: testUsers["allGroups"].Name,
+	})
 	if err != nil {
 		t.Fatal(err)
 	}
+	return reply
+}
 
-	claims = &jwt.Claims{
-		KeyID: "10",
-		Registered: jwt.Registered{
-			Issuer:    "localhost",
-			Subject:   "Nobody",
-			Expires:   jwt.NewNumericTime(time.Now().Add(24 * time.Hour)),
-			Audiences: []string{"me", "and", "you"},
-			Issued:    jwt.NewNumericTime(time.Now()),
-		},
-		Set: map[string]interface{}{
-			"user_id":   667,
-			"group_ids": []int{1, 2, 3},
-		},
-	}
-	jwtUnKnown, err := claims.EdDSASign([]byte(testPrivKey))
+func Test_authServer_RefreshToken(t *testing.T) {
+	exCtx, cancel := context.WithTimeout(testCtx, -1)
+	defer cancel()
+
+	login := loginTestUser(t)
+
+	expired := loginTestUser(t)
+	db, err := mdb.Master(testCtx)
 	if err != nil {
 		t.Fatal(err)
 	}
+	if _, err = models.RefreshTokens(
+		models.RefreshTokenWhere.Hash.EQ(hashRefreshToken(expired.GetRefreshToken())),
+	).UpdateAll(testCtx, db, models.M{
+		models.RefreshTokenColumns.ExpiresAt: time.Unix(1000, 0),
+	}); err != nil {
+		t.Fatal(err)
+	}
 
 	type args struct {
 		ctx context.Context
@@ -559,6 +747,7 @@ func Test_authServer_RefreshToken(t *testing.T) {
 	tests := []struct {
 		name    string
 		args    args
+		want    codes.Code
 		wantErr bool
 	}{
 		{
@@ -567,6 +756,7 @@ func Test_authServer_RefreshToken(t *testing.T) {
 				exCtx,
 				nil,
 			},
+			codes.Unknown,
 			true,
 		},
 		{
@@ -575,22 +765,52 @@ func Test_authServer_RefreshToken(t *testing.T) {
 				testCtx,
 				&auth.AuthReply{},
 			},
+			codes.InvalidArgument,
+			true,
+		},
+		{
+			"Access token only",
+			args{
+				testCtx,
+				&auth.AuthReply{Jwt: login.GetJwt()},
+			},
+			codes.InvalidArgument,
 			true,
 		},
 		{
-			"Known User",
+			"Unknown refresh token",
 			args{
 				testCtx,
-				&auth.AuthReply{Jwt: string(jwtKnown)},
+				&auth.AuthReply{RefreshToken: "foo"},
 			},
+			codes.Unauthenticated,
+			true,
+		},
+		{
+			"Expired refresh token",
+			args{
+				testCtx,
+				expired,
+			},
+			codes.Unauthenticated,
+			true,
+		},
+		{
+			"Success",
+			args{
+				testCtx,
+				login,
+			},
+			codes.OK,
 			false,
 		},
 		{
-			"Unknown User",
+			"Reused refresh token",
 			args{
 				testCtx,
-				&auth.AuthReply{Jwt: string(jwtUnKnown)},
+				login,
 			},
+			codes.Unauthenticated,
 			true,
 		},
 	}
@@ -601,11 +821,33 @@ func Test_authServer_RefreshToken(t *testing.T) {
 				t.Errorf("authServer.RefreshToken() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !tt.wantErr && got == nil {
-				t.Errorf("authServer.RefreshToken() = %v, wantErr %v", got, tt.wantErr)
+			if tt.wantErr {
+				if status.Code(err) != tt.want {
+					t.Errorf("authServer.RefreshToken() error = %v, want %v", err, tt.want)
+				}
+				return
+			}
+			if got.GetJwt() == "" || got.GetRefreshToken() == "" {
+				t.Errorf("authServer.RefreshToken() = %v, want JWT and refresh token", got)
+			}
+			if got.GetRefreshToken() == tt.args.old.GetRefreshToken() {
+				t.Errorf("authServer.RefreshToken() = %v, want new refresh token", got.GetRefreshToken())
 			}
 		})
 	}
+
+	// Reuse of a rotated token revokes its descendants.
+	first := loginTestUser(t)
+	second, err := tas.RefreshToken(testCtx, first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = tas.RefreshToken(testCtx, first); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("authServer.RefreshToken() error = %v, want %v", err, codes.Unauthenticated)
+	}
+	if _, err = tas.RefreshToken(testCtx, second); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("authServer.RefreshToken() after reuse error = %v, want %v", err, codes.Unauthenticated)
+	}
 }
 
 func Test_authServer_PublicUserToken(t *testing.T) {
@@ -698,7 +940,7 @@ func Test_authServer_GetPubKey(t *testing.T) {
 				testCtx,
 				&auth.KeyID{Kid: 10},
 			},
-			&auth.PublicKey{Key: []byte(testPubKey)},
+			&auth.PublicKey{Key: []byte(testPubKey), Kid: 10},
 			false,
 		},
 	}
@@ -861,3 +1103,491 @@ func Test_authServer_ResetUserPW(t *testing.T) {
 		})
 	}
 }
+
+// checkTestToken runs checkJWT on tkn.
+func checkTestToken(t *testing.T, tkn *auth.AuthReply) error {
+	rt, err := tas.newTx(testCtx, "testing", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rt.done()
+	_, err = rt.checkJWT(tkn.GetJwt(), time.Now())
+	return err
+}
+
+// signTestToken returns a token for subject, signed with the test key.
+func signTestToken(t *testing.T, subject, jti string) *auth.AuthReply {
+	claims := &jwt.Claims{
+		KeyID: "10",
+		Registered: jwt.Registered{
+			Issuer:  "localhost",
+			Subject: subject,
+			Expires: jwt.NewNumericTime(time.Now().Add(time.Hour)),
+			Issued:  jwt.NewNumericTime(time.Now()),
+			ID:      jti,
+		},
+	}
+	tkn, err := claims.EdDSASign([]byte(testPrivKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &auth.AuthReply{Jwt: string(tkn)}
+}
+
+func Test_authServer_Logout(t *testing.T) {
+	exCtx, cancel := context.WithTimeout(testCtx, -1)
+	defer cancel()
+
+	tkn := signTestToken(t, testUsers["allGroups"].Email, "logout")
+	login := loginTestUser(t)
+	withRefresh := signTestToken(t, testUsers["allGroups"].Email, "logout-refresh")
+	withRefresh.RefreshToken = login.GetRefreshToken()
+	unknownRefresh := signTestToken(t, testUsers["allGroups"].Email, "logout-unknown")
+	unknownRefresh.RefreshToken = "foo"
+
+	type args struct {
+		ctx context.Context
+		tkn *auth.AuthReply
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			"Expired context",
+			args{
+				exCtx,
+				nil,
+			},
+			true,
+		},
+		{
+			"Empty token",
+			args{
+				testCtx,
+				&auth.AuthReply{},
+			},
+			true,
+		},
+		{
+			"Missing token ID",
+			args{
+				testCtx,
+				signTestToken(t, testUsers["allGroups"].Email, ""),
+			},
+			true,
+		},
+		{
+			"Success",
+			args{
+				testCtx,
+				tkn,
+			},
+			false,
+		},
+		{
+			"Already revoked",
+			args{
+				testCtx,
+				tkn,
+			},
+			true,
+		},
+		{
+			"Unknown refresh token",
+			args{
+				testCtx,
+				unknownRefresh,
+			},
+			true,
+		},
+		{
+			"With refresh token",
+			args{
+				testCtx,
+				withRefresh,
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tas.Logout(tt.args.ctx, tt.args.tkn)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("authServer.Logout() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := checkTestToken(t, tkn); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("requestTx.checkJWT() after Logout() error = %v, want %v", err, codes.Unauthenticated)
+	}
+	if _, err := tas.RefreshToken(testCtx, login); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("authServer.RefreshToken() after Logout() error = %v, want %v", err, codes.Unauthenticated)
+	}
+}
+
+func Test_authServer_RevokeToken(t *testing.T) {
+	exCtx, cancel := context.WithTimeout(testCtx, -1)
+	defer cancel()
+
+	type args struct {
+		ctx context.Context
+		id  *auth.TokenID
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			"Expired context",
+			args{
+				exCtx,
+				nil,
+			},
+			true,
+		},
+		{
+			"Missing token ID",
+			args{
+				testCtx,
+				&auth.TokenID{},
+			},
+			true,
+		},
+		{
+			"Success",
+			args{
+				testCtx,
+				&auth.TokenID{Jti: "revoke-by-id"},
+			},
+			false,
+		},
+		{
+			"Already revoked",
+			args{
+				testCtx,
+				&auth.TokenID{Jti: "revoke-by-id"},
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tas.RevokeToken(tt.args.ctx, tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("authServer.RevokeToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	tkn := signTestToken(t, testUsers["allGroups"].Email, "revoke-by-id")
+	if err := checkTestToken(t, tkn); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("requestTx.checkJWT() after RevokeToken() error = %v, want %v", err, codes.Unauthenticated)
+	}
+}
+
+func Test_authServer_ListRevoked(t *testing.T) {
+	exCtx, cancel := context.WithTimeout(testCtx, -1)
+	defer cancel()
+
+	insertRevoked(t, "list-revoked", time.Now().Add(time.Hour))
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantErr bool
+	}{
+		{
+			"Expired context",
+			exCtx,
+			true,
+		},
+		{
+			"Success",
+			testCtx,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tas.ListRevoked(tt.ctx, &empty.Empty{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("authServer.ListRevoked() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			var found bool
+			for _, tkn := range got.GetTokens() {
+				if tkn.GetJti() == "list-revoked" {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("authServer.ListRevoked() = %v, want %v", got, "list-revoked")
+			}
+		})
+	}
+}
+
+func Test_authServer_ListPubKeys(t *testing.T) {
+	exCtx, cancel := context.WithTimeout(testCtx, -1)
+	defer cancel()
+
+	if _, err := tas.ListPubKeys(exCtx, &empty.Empty{}); err == nil {
+		t.Errorf("authServer.ListPubKeys() error = %v, want error", err)
+	}
+
+	got, err := tas.ListPubKeys(testCtx, &empty.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prKey := tas.privateKey()
+	var found bool
+	for _, k := range got.GetKeys() {
+		if strconv.Itoa(int(k.GetKid())) == prKey.id {
+			found = bytes.Equal(k.GetKey(), prKey.key.Public().(ed25519.PublicKey))
+		}
+	}
+	if !found {
+		t.Errorf("authServer.ListPubKeys() = %v, want key %v", got, prKey.id)
+	}
+}
+
+func Test_authServer_TOTP(t *testing.T) {
+	exCtx, cancel := context.WithTimeout(testCtx, -1)
+	defer cancel()
+
+	user := testUsers["mfaUser"]
+	userTkn := signTestToken(t, user.Email, "totp")
+	login := &auth.UserPassword{
+		Email:    user.Email,
+		Password: user.Name,
+	}
+
+	t.Run("EnrollTOTP", func(t *testing.T) {
+		if _, err := tas.EnrollTOTP(exCtx, userTkn); err == nil {
+			t.Errorf("authServer.EnrollTOTP() error = %v, wantErr %v", err, true)
+		}
+		if _, err := tas.EnrollTOTP(testCtx, &auth.AuthReply{}); err == nil {
+			t.Errorf("authServer.EnrollTOTP() error = %v, wantErr %v", err, true)
+		}
+	})
+	enrollment, err := tas.EnrollTOTP(testCtx, userTkn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := decodeSecret(t, enrollment.GetSecret())
+	counter := totpCounter(time.Now())
+
+	t.Run("ConfirmTOTP", func(t *testing.T) {
+		if _, err := tas.ConfirmTOTP(exCtx, &auth.MFACode{Jwt: userTkn.GetJwt()}); err == nil {
+			t.Errorf("authServer.ConfirmTOTP() error = %v, wantErr %v", err, true)
+		}
+		if _, err := tas.ConfirmTOTP(testCtx, &auth.MFACode{Jwt: userTkn.GetJwt(), Code: "000000"}); status.Code(err) != codes.Unauthenticated {
+			t.Errorf("authServer.ConfirmTOTP() error = %v, want %v", err, codes.Unauthenticated)
+		}
+	})
+	recovery, err := tas.ConfirmTOTP(testCtx, &auth.MFACode{
+		Jwt:  userTkn.GetJwt(),
+		Code: hotp(secret, uint64(counter)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mfaTkn, err := tas.AuthenticatePwUser(testCtx, login)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !mfaTkn.GetMfaRequired() || mfaTkn.GetRefreshToken() != "" {
+		t.Fatalf("authServer.AuthenticatePwUser() = %v, want MFA required without refresh token", mfaTkn)
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		mc      *auth.MFACode
+		want    codes.Code
+		wantErr bool
+	}{
+		{
+			"Expired context",
+			exCtx,
+			&auth.MFACode{Jwt: mfaTkn.GetJwt()},
+			codes.Unknown,
+			true,
+		},
+		{
+			"Not an MFA token",
+			testCtx,
+			&auth.MFACode{Jwt: userTkn.GetJwt(), Code: hotp(secret, uint64(counter+1))},
+			codes.Unauthenticated,
+			true,
+		},
+		{
+			"Without audience",
+			testCtx,
+			&auth.MFACode{Jwt: signTestToken(t, user.Email, "mfa").GetJwt(), Code: hotp(secret, uint64(counter+1))},
+			codes.Unauthenticated,
+			true,
+		},
+		{
+			"Missing code",
+			testCtx,
+			&auth.MFACode{Jwt: mfaTkn.GetJwt()},
+			codes.InvalidArgument,
+			true,
+		},
+		{
+			"Reused code",
+			testCtx,
+			&auth.MFACode{Jwt: mfaTkn.GetJwt(), Code: hotp(secret, uint64(counter))},
+			codes.Unauthenticated,
+			true,
+		},
+		{
+			"TOTP code",
+			testCtx,
+			&auth.MFACode{Jwt: mfaTkn.GetJwt(), Code: hotp(secret, uint64(counter+1))},
+			codes.OK,
+			false,
+		},
+		{
+			"Recovery code",
+			testCtx,
+			&auth.MFACode{Jwt: mfaTkn.GetJwt(), Code: recovery.GetCodes()[0]},
+			codes.OK,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tas.VerifyMFA(tt.ctx, tt.mc)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("authServer.VerifyMFA() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if status.Code(err) != tt.want {
+					t.Errorf("authServer.VerifyMFA() error = %v, want %v", err, tt.want)
+				}
+				return
+			}
+			if got.GetMfaRequired() || got.GetRefreshToken() == "" {
+				t.Errorf("authServer.VerifyMFA() = %v, want JWT and refresh token", got)
+			}
+		})
+	}
+}
+
+func Test_authServer_WebAuthn(t *testing.T) {
+	exCtx, cancel := context.WithTimeout(testCtx, -1)
+	defer cancel()
+
+	// mfaUser has TOTP enabled by Test_authServer_TOTP
+	user := testUsers["mfaUser"]
+	userTkn := signTestToken(t, user.Email, "webauthn")
+	a := newTestAuthenticator(t)
+
+	t.Run("BeginWebAuthnRegistration", func(t *testing.T) {
+		if _, err := tas.BeginWebAuthnRegistration(exCtx, userTkn); err == nil {
+			t.Errorf("authServer.BeginWebAuthnRegistration() error = %v, wantErr %v", err, true)
+		}
+		if _, err := tas.BeginWebAuthnRegistration(testCtx, &auth.AuthReply{}); err == nil {
+			t.Errorf("authServer.BeginWebAuthnRegistration() error = %v, wantErr %v", err, true)
+		}
+	})
+	reg, err := tas.BeginWebAuthnRegistration(testCtx, userTkn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("FinishWebAuthnRegistration", func(t *testing.T) {
+		if _, err := tas.FinishWebAuthnRegistration(exCtx, a.attestation(t, userTkn.GetJwt(), reg.GetChallenge())); err == nil {
+			t.Errorf("authServer.FinishWebAuthnRegistration() error = %v, wantErr %v", err, true)
+		}
+		if _, err := tas.FinishWebAuthnRegistration(testCtx, a.attestation(t, "", reg.GetChallenge())); err == nil {
+			t.Errorf("authServer.FinishWebAuthnRegistration() error = %v, wantErr %v", err, true)
+		}
+	})
+	cred, err := tas.FinishWebAuthnRegistration(testCtx, a.attestation(t, userTkn.GetJwt(), reg.GetChallenge()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cred.GetCredentialId(), a.id) {
+		t.Errorf("authServer.FinishWebAuthnRegistration() = %v, want %v", cred.GetCredentialId(), a.id)
+	}
+
+	t.Run("BeginWebAuthnLogin", func(t *testing.T) {
+		if _, err := tas.BeginWebAuthnLogin(exCtx, &auth.UserData{}); err == nil {
+			t.Errorf("authServer.BeginWebAuthnLogin() error = %v, wantErr %v", err, true)
+		}
+	})
+	challenge := func() []byte {
+		c, err := tas.BeginWebAuthnLogin(testCtx, &auth.UserData{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c.GetChallenge()
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		as      *auth.WebAuthnAssertion
+		wantMFA bool
+		wantErr bool
+	}{
+		{
+			"Expired context",
+			exCtx,
+			a.assertion(t, challenge(), flagUP),
+			false,
+			true,
+		},
+		{
+			"Unknown challenge",
+			testCtx,
+			a.assertion(t, reg.GetChallenge(), flagUP),
+			false,
+			true,
+		},
+		{
+			"User present",
+			testCtx,
+			a.assertion(t, challenge(), flagUP),
+			true,
+			false,
+		},
+		{
+			"User verified",
+			testCtx,
+			a.assertion(t, challenge(), flagUP|flagUV),
+			false,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tas.FinishWebAuthnLogin(tt.ctx, tt.as)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("authServer.FinishWebAuthnLogin() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.GetMfaRequired() != tt.wantMFA || (got.GetRefreshToken() == "") != tt.wantMFA {
+				t.Errorf("authServer.FinishWebAuthnLogin() = %v, want MFA required %v", got, tt.wantMFA)
+			}
+			if err = checkTestToken(t, got); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
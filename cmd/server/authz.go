@@ -0,0 +1,290 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/moapis/authenticator/verify"
+	"github.com/pascaldekloe/jwt"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// AccessLevel of a gRPC method, as documented in the proto file.
+type AccessLevel string
+
+const (
+	// PublicAccess allows anyone to call a method
+	PublicAccess AccessLevel = "public"
+	// BasicAccess requires a bearer token issued by this server, or a verified client certificate
+	BasicAccess AccessLevel = "basic"
+	// InternalAccess requires a bearer token with one of the internal groups,
+	// or a verified client certificate with one of the internal peer names
+	InternalAccess AccessLevel = "internal"
+)
+
+// defaultAccess is the policy table of all gRPC methods.
+// Methods which are not listed require InternalAccess.
+var defaultAccess = map[string]AccessLevel{
+	"/authenticator.Authenticator/RegisterPwUser":             PublicAccess,
+	"/authenticator.Authenticator/AuthenticatePwUser":         PublicAccess,
+	"/authenticator.Authenticator/VerifyMFA":                  PublicAccess,
+	"/authenticator.Authenticator/EnrollTOTP":                 PublicAccess,
+	"/authenticator.Authenticator/ConfirmTOTP":                PublicAccess,
+	"/authenticator.Authenticator/BeginWebAuthnRegistration":  PublicAccess,
+	"/authenticator.Authenticator/FinishWebAuthnRegistration": PublicAccess,
+	"/authenticator.Authenticator/BeginWebAuthnLogin":         PublicAccess,
+	"/authenticator.Authenticator/FinishWebAuthnLogin":        PublicAccess,
+	"/authenticator.Authenticator/ChangeUserPw":               PublicAccess,
+	"/authenticator.Authenticator/CheckUserExists":            BasicAccess,
+	"/authenticator.Authenticator/VerifyUser":                 PublicAccess,
+	"/authenticator.Authenticator/RefreshToken":               PublicAccess,
+	"/authenticator.Authenticator/PublicUserToken":            InternalAccess,
+	"/authenticator.Authenticator/GetPubKey":                  InternalAccess,
+	"/authenticator.Authenticator/ResetUserPW":                PublicAccess,
+	"/authenticator.Authenticator/SendLoginLink":              PublicAccess,
+	"/authenticator.Authenticator/AuthenticateLoginLink":      PublicAccess,
+	"/authenticator.Authenticator/Logout":                     PublicAccess,
+	"/authenticator.Authenticator/RevokeToken":                InternalAccess,
+	"/authenticator.Authenticator/ListRevoked":                InternalAccess,
+	"/authenticator.Authenticator/ListPubKeys":                InternalAccess,
+	"/authenticator.Authenticator/CreateAuthCode":             PublicAccess,
+	"/authenticator.Authenticator/ExchangeAuthCode":           PublicAccess,
+	"/authenticator.Authenticator/CheckClient":                PublicAccess,
+	"/authenticator.Authenticator/AuthorizeClient":            PublicAccess,
+	"/authenticator.Authenticator/Token":                      PublicAccess,
+	"/authenticator.Authenticator/UserInfo":                   PublicAccess,
+	"/authenticator.Authenticator/BeginFederatedLogin":        PublicAccess,
+	"/authenticator.Authenticator/FinishFederatedLogin":       PublicAccess,
+
+	// The admin service checks the admin groups itself.
+	"/authenticator.AuthenticatorAdmin/ListUsers":           BasicAccess,
+	"/authenticator.AuthenticatorAdmin/GetUser":             BasicAccess,
+	"/authenticator.AuthenticatorAdmin/CreateUser":          BasicAccess,
+	"/authenticator.AuthenticatorAdmin/UpdateUser":          BasicAccess,
+	"/authenticator.AuthenticatorAdmin/DeleteUser":          BasicAccess,
+	"/authenticator.AuthenticatorAdmin/ListGroups":          BasicAccess,
+	"/authenticator.AuthenticatorAdmin/GetGroup":            BasicAccess,
+	"/authenticator.AuthenticatorAdmin/CreateGroup":         BasicAccess,
+	"/authenticator.AuthenticatorAdmin/UpdateGroup":         BasicAccess,
+	"/authenticator.AuthenticatorAdmin/DeleteGroup":         BasicAccess,
+	"/authenticator.AuthenticatorAdmin/ListAudiences":       BasicAccess,
+	"/authenticator.AuthenticatorAdmin/GetAudience":         BasicAccess,
+	"/authenticator.AuthenticatorAdmin/CreateAudience":      BasicAccess,
+	"/authenticator.AuthenticatorAdmin/UpdateAudience":      BasicAccess,
+	"/authenticator.AuthenticatorAdmin/DeleteAudience":      BasicAccess,
+	"/authenticator.AuthenticatorAdmin/AddUserGroups":       BasicAccess,
+	"/authenticator.AuthenticatorAdmin/RemoveUserGroups":    BasicAccess,
+	"/authenticator.AuthenticatorAdmin/AddUserAudiences":    BasicAccess,
+	"/authenticator.AuthenticatorAdmin/RemoveUserAudiences": BasicAccess,
+	"/authenticator.AuthenticatorAdmin/ListClients":         BasicAccess,
+	"/authenticator.AuthenticatorAdmin/GetClient":           BasicAccess,
+	"/authenticator.AuthenticatorAdmin/CreateClient":        BasicAccess,
+	"/authenticator.AuthenticatorAdmin/UpdateClient":        BasicAccess,
+	"/authenticator.AuthenticatorAdmin/DeleteClient":        BasicAccess,
+}
+
+// AuthzConfig enables enforcement of the access levels of gRPC methods.
+type AuthzConfig struct {
+	Groups  []string               `json:"groups,omitempty"`  // Bearer token groups granted internal access
+	Peers   []string               `json:"peers,omitempty"`   // Client certificate common or DNS names granted internal access
+	Methods map[string]AccessLevel `json:"methods,omitempty"` // Access level overrides, by full method name such as "/authenticator.Authenticator/CheckUserExists"
+}
+
+var errAuthzConfig = errors.New("Authz: methods need to be known and have a public, basic or internal access level")
+
+func (c *AuthzConfig) validate() error {
+	for m, l := range c.Methods {
+		if _, ok := defaultAccess[m]; !ok {
+			return errAuthzConfig
+		}
+		switch l {
+		case PublicAccess, BasicAccess, InternalAccess:
+		default:
+			return errAuthzConfig
+		}
+	}
+	return nil
+}
+
+// level returns the access level of the full method name.
+func (c *AuthzConfig) level(method string) AccessLevel {
+	if l, ok := c.Methods[method]; ok {
+		return l
+	}
+	if l, ok := defaultAccess[method]; ok {
+		return l
+	}
+	return InternalAccess
+}
+
+const (
+	errMissingCredentials = "Missing bearer token or client certificate"
+	errInternalAccess     = "Not authorized for internal methods"
+)
+
+// caller is the authenticated identity of a gRPC request.
+type caller struct {
+	subject string   // Token subject or certificate common name
+	groups  []string // Groups from the bearer token
+	peers   []string // Common and DNS names of the verified client certificate
+}
+
+type callerKey struct{}
+
+func callerFromContext(ctx context.Context) (*caller, bool) {
+	c, ok := ctx.Value(callerKey{}).(*caller)
+	return c, ok
+}
+
+// peerCaller returns the caller identified by a verified client certificate.
+// False is returned when there is no such certificate.
+func peerCaller(ctx context.Context) (*caller, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return nil, false
+	}
+	cert := info.State.VerifiedChains[0][0]
+
+	c := &caller{subject: cert.Subject.CommonName}
+	if cert.Subject.CommonName != "" {
+		c.peers = append(c.peers, cert.Subject.CommonName)
+	}
+	c.peers = append(c.peers, cert.DNSNames...)
+	return c, true
+}
+
+// checkBearer verifies the bearer token of the request, which must be a user token.
+func (rt *requestTx) checkBearer(now time.Time) (*jwt.Claims, error) {
+	claims, err := rt.checkJWT(verify.BearerToken(rt.ctx), now)
+	if err != nil {
+		return nil, err
+	}
+	if err = rt.checkUserToken(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// checkUserToken returns an error if claims are not of a regular user token.
+// Tokens for a password reset or second factor are rejected,
+// as well as tokens issued to OAuth clients, which are only accepted by UserInfo.
+func (rt *requestTx) checkUserToken(claims *jwt.Claims) error {
+	if rt.s.restrictedToken(claims) {
+		rt.log.WithField("audiences", claims.Audiences).Warn(errUserToken)
+		return status.Error(codes.Unauthenticated, errUserToken)
+	}
+	if client, ok := claims.Set[jwtClientID]; ok {
+		rt.log.WithField("client", client).Warn(errClientToken)
+		return status.Error(codes.PermissionDenied, errClientToken)
+	}
+	return nil
+}
+
+// bearerCaller returns the caller identified by the bearer token.
+func (s *authServer) bearerCaller(ctx context.Context, method string) (*caller, error) {
+	rt, err := s.newTx(ctx, method, true)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	claims, err := rt.checkBearer(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	c := &caller{subject: claims.Subject}
+	groups, _ := claims.Set[jwtGroups].([]interface{})
+	for _, g := range groups {
+		if name, ok := g.(string); ok {
+			c.groups = append(c.groups, name)
+		}
+	}
+	return c, nil
+}
+
+// authenticate is a gRPC interceptor which identifies the caller of non-public methods,
+// by client certificate or bearer token.
+// The caller is passed to the next handler in the context.
+func (s *authServer) authenticate(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if s.conf.Authz.level(info.FullMethod) == PublicAccess {
+		return handler(ctx, req)
+	}
+
+	c, ok := peerCaller(ctx)
+	if !ok {
+		if verify.BearerToken(ctx) == "" {
+			s.log.WithField("method", info.FullMethod).Warn(errMissingCredentials)
+			return nil, status.Error(codes.Unauthenticated, errMissingCredentials)
+		}
+		var err error
+		if c, err = s.bearerCaller(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+	}
+	return handler(context.WithValue(ctx, callerKey{}, c), req)
+}
+
+// allowed reports if c is granted level.
+func (c *AuthzConfig) allowed(level AccessLevel, cl *caller) bool {
+	switch level {
+	case PublicAccess:
+		return true
+	case BasicAccess:
+		return cl != nil
+	}
+	if cl == nil {
+		return false
+	}
+	for _, g := range cl.groups {
+		for _, ag := range c.Groups {
+			if g == ag {
+				return true
+			}
+		}
+	}
+	for _, p := range cl.peers {
+		for _, ap := range c.Peers {
+			if p == ap {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// authorize is a gRPC interceptor which enforces the access level of the method,
+// for the caller set by authenticate.
+func (s *authServer) authorize(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	level := s.conf.Authz.level(info.FullMethod)
+	c, _ := callerFromContext(ctx)
+
+	if !s.conf.Authz.allowed(level, c) {
+		log := s.log.WithFields(logrus.Fields{"method": info.FullMethod, "level": level})
+		if c == nil {
+			log.Warn(errMissingCredentials)
+			return nil, status.Error(codes.Unauthenticated, errMissingCredentials)
+		}
+		log.WithField("caller", c.subject).Warn(errInternalAccess)
+		return nil, status.Error(codes.PermissionDenied, errInternalAccess)
+	}
+	return handler(ctx, req)
+}
+
+// interceptors returns the gRPC interceptor chain enforcing the access levels.
+func (s *authServer) interceptors() []grpc.UnaryServerInterceptor {
+	return []grpc.UnaryServerInterceptor{s.authenticate, s.authorize}
+}
@@ -0,0 +1,269 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	auth "github.com/moapis/authenticator"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// testTLSFiles writes a self-signed certificate and its key to temporary files.
+func testTLSFiles(t *testing.T) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		DNSNames:              []string{"localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	certFile, keyFile = filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	if err = ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+func Test_defaultAccess(t *testing.T) {
+	gs := grpc.NewServer()
+	auth.RegisterAuthenticatorServer(gs, &authServer{})
+	auth.RegisterAuthenticatorAdminServer(gs, &adminServer{})
+
+	for name, info := range gs.GetServiceInfo() {
+		for _, m := range info.Methods {
+			if _, ok := defaultAccess["/"+name+"/"+m.Name]; !ok {
+				t.Errorf("defaultAccess missing %s/%s", name, m.Name)
+			}
+		}
+	}
+}
+
+func TestAuthzConfig_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       AuthzConfig
+		wantErr bool
+	}{
+		{"Empty", AuthzConfig{}, false},
+		{"Override", AuthzConfig{Methods: map[string]AccessLevel{"/authenticator.Authenticator/CheckUserExists": PublicAccess}}, false},
+		{"Unknown method", AuthzConfig{Methods: map[string]AccessLevel{"/authenticator.Authenticator/Foo": PublicAccess}}, true},
+		{"Unknown level", AuthzConfig{Methods: map[string]AccessLevel{"/authenticator.Authenticator/CheckUserExists": "foo"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.c.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("AuthzConfig.validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthzConfig_level(t *testing.T) {
+	c := &AuthzConfig{Methods: map[string]AccessLevel{"/authenticator.Authenticator/GetPubKey": BasicAccess}}
+
+	tests := []struct {
+		method string
+		want   AccessLevel
+	}{
+		{"/authenticator.Authenticator/AuthenticatePwUser", PublicAccess},
+		{"/authenticator.Authenticator/CheckUserExists", BasicAccess},
+		{"/authenticator.Authenticator/PublicUserToken", InternalAccess},
+		{"/authenticator.Authenticator/GetPubKey", BasicAccess},
+		{"/foo.Bar/Baz", InternalAccess},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			if got := c.level(tt.method); got != tt.want {
+				t.Errorf("AuthzConfig.level() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthzConfig_allowed(t *testing.T) {
+	c := &AuthzConfig{
+		Groups: []string{"internal"},
+		Peers:  []string{"service.example.com"},
+	}
+	user := &caller{subject: "user", groups: []string{"public"}}
+	internal := &caller{subject: "admin", groups: []string{"public", "internal"}}
+	service := &caller{subject: "service", peers: []string{"service", "service.example.com"}}
+
+	tests := []struct {
+		name   string
+		level  AccessLevel
+		caller *caller
+		want   bool
+	}{
+		{"Public anonymous", PublicAccess, nil, true},
+		{"Basic anonymous", BasicAccess, nil, false},
+		{"Basic user", BasicAccess, user, true},
+		{"Internal anonymous", InternalAccess, nil, false},
+		{"Internal user", InternalAccess, user, false},
+		{"Internal group", InternalAccess, internal, true},
+		{"Internal peer", InternalAccess, service, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.allowed(tt.level, tt.caller); got != tt.want {
+				t.Errorf("AuthzConfig.allowed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// tlsPeerCtx returns a context with a TLS peer, verified by cert when not nil.
+func tlsPeerCtx(ctx context.Context, cert *x509.Certificate) context.Context {
+	var state tls.ConnectionState
+	if cert != nil {
+		state.VerifiedChains = [][]*x509.Certificate{{cert}}
+	}
+	return peer.NewContext(ctx, &peer.Peer{
+		Addr:     &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 1234},
+		AuthInfo: credentials.TLSInfo{State: state},
+	})
+}
+
+func Test_peerCaller(t *testing.T) {
+	cert := &x509.Certificate{
+		Subject:  pkix.Name{CommonName: "service"},
+		DNSNames: []string{"service.example.com"},
+	}
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		want   *caller
+		wantOK bool
+	}{
+		{"No peer", context.Background(), nil, false},
+		{"Insecure peer", peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 1234}}), nil, false},
+		{"Unverified", tlsPeerCtx(context.Background(), nil), nil, false},
+		{
+			"Verified",
+			tlsPeerCtx(context.Background(), cert),
+			&caller{subject: "service", peers: []string{"service", "service.example.com"}},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := peerCaller(tt.ctx)
+			if ok != tt.wantOK || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("peerCaller() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func authzServer(c *AuthzConfig) *authServer {
+	conf := *tas.conf
+	conf.Authz = c
+	return &authServer{
+		mdb:     mdb,
+		log:     tas.log,
+		conf:    &conf,
+		privKey: tas.privateKey(),
+	}
+}
+
+func Test_authServer_interceptors(t *testing.T) {
+	s := authzServer(&AuthzConfig{
+		Groups:  []string{"admin"},
+		Peers:   []string{"service.example.com"},
+		Methods: map[string]AccessLevel{"/authenticator.Authenticator/RevokeToken": BasicAccess},
+	})
+	cert := &x509.Certificate{
+		Subject:  pkix.Name{CommonName: "service"},
+		DNSNames: []string{"service.example.com"},
+	}
+	invalid := metadata.NewIncomingContext(testCtx, metadata.Pairs("authorization", "Bearer foo"))
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		method      string
+		want        codes.Code
+		wantSubject string
+	}{
+		{"Public anonymous", testCtx, "/authenticator.Authenticator/AuthenticatePwUser", codes.OK, ""},
+		{"Public invalid token", invalid, "/authenticator.Authenticator/AuthenticatePwUser", codes.OK, ""},
+		{"Basic anonymous", testCtx, "/authenticator.Authenticator/CheckUserExists", codes.Unauthenticated, ""},
+		{"Basic invalid token", invalid, "/authenticator.Authenticator/CheckUserExists", codes.Unauthenticated, ""},
+		{"Basic user", adminTestCtx(t, "one@group.com", "public"), "/authenticator.Authenticator/CheckUserExists", codes.OK, "one@group.com"},
+		{"Basic override", adminTestCtx(t, "one@group.com", "public"), "/authenticator.Authenticator/RevokeToken", codes.OK, "one@group.com"},
+		{"Internal user", adminTestCtx(t, "one@group.com", "public"), "/authenticator.Authenticator/GetPubKey", codes.PermissionDenied, ""},
+		{"Internal group", adminTestCtx(t, "all@groups.com", "public", "admin"), "/authenticator.Authenticator/GetPubKey", codes.OK, "all@groups.com"},
+		{"Internal peer", tlsPeerCtx(testCtx, cert), "/authenticator.Authenticator/PublicUserToken", codes.OK, "service"},
+		{"Unverified peer", tlsPeerCtx(testCtx, nil), "/authenticator.Authenticator/PublicUserToken", codes.Unauthenticated, ""},
+		{"Unknown method", adminTestCtx(t, "one@group.com", "public"), "/foo.Bar/Baz", codes.PermissionDenied, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *caller
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				got, _ = callerFromContext(ctx)
+				return req, nil
+			}
+			info := &grpc.UnaryServerInfo{FullMethod: tt.method}
+
+			interceptors := s.interceptors()
+			_, err := interceptors[0](tt.ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptors[1](ctx, req, info, handler)
+			})
+			if c := status.Code(err); c != tt.want {
+				t.Fatalf("authServer.interceptors() = %v, want %v", c, tt.want)
+			}
+			if tt.wantSubject != "" && (got == nil || got.subject != tt.wantSubject) {
+				t.Errorf("authServer.interceptors() caller = %v, want %v", got, tt.wantSubject)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// cborMaxDepth limits nesting of arrays and maps,
+// WebAuthn structures never nest deeper than a few levels.
+const cborMaxDepth = 16
+
+var (
+	errCBORShort       = errors.New("cbor: unexpected end of data")
+	errCBORUnsupported = errors.New("cbor: unsupported data item")
+	errCBORDepth       = errors.New("cbor: maximum nesting depth exceeded")
+	errCBORKey         = errors.New("cbor: unsupported map key")
+)
+
+// cborDecode decodes the first data item in b, as used by WebAuthn (RFC 8949).
+// Only definite length items are supported, as CTAP2 requires.
+// Integers decode to int64, byte strings to []byte, text strings to string,
+// arrays to []interface{} and maps to map[interface{}]interface{},
+// with int64 or string keys.
+// The bytes following the data item are returned as rest.
+func cborDecode(b []byte) (v interface{}, rest []byte, err error) {
+	return cborDecodeDepth(b, 0)
+}
+
+// cborHead decodes the major type and argument of the data item in b.
+func cborHead(b []byte) (major byte, arg uint64, rest []byte, err error) {
+	if len(b) < 1 {
+		return 0, 0, nil, errCBORShort
+	}
+	major, info := b[0]>>5, b[0]&0x1f
+	b = b[1:]
+
+	switch {
+	case info < 24:
+		return major, uint64(info), b, nil
+	case info == 24 && len(b) >= 1:
+		return major, uint64(b[0]), b[1:], nil
+	case info == 25 && len(b) >= 2:
+		return major, uint64(binary.BigEndian.Uint16(b)), b[2:], nil
+	case info == 26 && len(b) >= 4:
+		return major, uint64(binary.BigEndian.Uint32(b)), b[4:], nil
+	case info == 27 && len(b) >= 8:
+		return major, binary.BigEndian.Uint64(b), b[8:], nil
+	case info > 27:
+		return 0, 0, nil, errCBORUnsupported
+	default:
+		return 0, 0, nil, errCBORShort
+	}
+}
+
+func cborDecodeDepth(b []byte, depth int) (interface{}, []byte, error) {
+	if depth > cborMaxDepth {
+		return nil, nil, errCBORDepth
+	}
+
+	major, arg, b, err := cborHead(b)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch major {
+	case 0: // unsigned integer
+		if arg > math.MaxInt64 {
+			return nil, nil, errCBORUnsupported
+		}
+		return int64(arg), b, nil
+
+	case 1: // negative integer
+		if arg > math.MaxInt64 {
+			return nil, nil, errCBORUnsupported
+		}
+		return -1 - int64(arg), b, nil
+
+	case 2, 3: // byte and text string
+		if arg > uint64(len(b)) {
+			return nil, nil, errCBORShort
+		}
+		if major == 2 {
+			return append([]byte(nil), b[:arg]...), b[arg:], nil
+		}
+		return string(b[:arg]), b[arg:], nil
+
+	case 4: // array
+		// Every item takes at least one byte
+		if arg > uint64(len(b)) {
+			return nil, nil, errCBORShort
+		}
+		a := make([]interface{}, arg)
+		for i := range a {
+			if a[i], b, err = cborDecodeDepth(b, depth+1); err != nil {
+				return nil, nil, err
+			}
+		}
+		return a, b, nil
+
+	case 5: // map
+		// Every key and value takes at least one byte
+		if arg > uint64(len(b))/2 {
+			return nil, nil, errCBORShort
+		}
+		m := make(map[interface{}]interface{}, arg)
+		for i := uint64(0); i < arg; i++ {
+			var k, v interface{}
+			if k, b, err = cborDecodeDepth(b, depth+1); err != nil {
+				return nil, nil, err
+			}
+			switch k.(type) {
+			case int64, string:
+			default:
+				return nil, nil, errCBORKey
+			}
+			if v, b, err = cborDecodeDepth(b, depth+1); err != nil {
+				return nil, nil, err
+			}
+			m[k] = v
+		}
+		return m, b, nil
+
+	case 7: // simple values
+		switch arg {
+		case 20:
+			return false, b, nil
+		case 21:
+			return true, b, nil
+		case 22:
+			return nil, b, nil
+		}
+	}
+
+	// Tags, floats and undefined are not used by WebAuthn
+	return nil, nil, errCBORUnsupported
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// cborHeadBytes encodes a data item head with the shortest argument.
+func cborHeadBytes(major byte, arg uint64) []byte {
+	major <<= 5
+	switch {
+	case arg < 24:
+		return []byte{major | byte(arg)}
+	case arg <= 0xff:
+		return []byte{major | 24, byte(arg)}
+	case arg <= 0xffff:
+		b := []byte{major | 25, 0, 0}
+		binary.BigEndian.PutUint16(b[1:], uint16(arg))
+		return b
+	case arg <= 0xffffffff:
+		b := []byte{major | 26, 0, 0, 0, 0}
+		binary.BigEndian.PutUint32(b[1:], uint32(arg))
+		return b
+	default:
+		b := []byte{major | 27, 0, 0, 0, 0, 0, 0, 0, 0}
+		binary.BigEndian.PutUint64(b[1:], arg)
+		return b
+	}
+}
+
+// cborEncode is the counterpart of cborDecode, for building test data.
+// Map keys are sorted in CTAP2 canonical order: shorter first, then bytewise.
+func cborEncode(v interface{}) []byte {
+	switch v := v.(type) {
+	case int:
+		return cborEncode(int64(v))
+	case int64:
+		if v < 0 {
+			return cborHeadBytes(1, uint64(-1-v))
+		}
+		return cborHeadBytes(0, uint64(v))
+	case []byte:
+		return append(cborHeadBytes(2, uint64(len(v))), v...)
+	case string:
+		return append(cborHeadBytes(3, uint64(len(v))), v...)
+	case []interface{}:
+		b := cborHeadBytes(4, uint64(len(v)))
+		for _, e := range v {
+			b = append(b, cborEncode(e)...)
+		}
+		return b
+	case map[interface{}]interface{}:
+		keys := make([][]byte, 0, len(v))
+		items := make(map[string][]byte, len(v))
+		for k, e := range v {
+			kb := cborEncode(k)
+			keys = append(keys, kb)
+			items[string(kb)] = cborEncode(e)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if len(keys[i]) != len(keys[j]) {
+				return len(keys[i]) < len(keys[j])
+			}
+			return bytes.Compare(keys[i], keys[j]) < 0
+		})
+
+		b := cborHeadBytes(5, uint64(len(v)))
+		for _, kb := range keys {
+			b = append(b, kb...)
+			b = append(b, items[string(kb)]...)
+		}
+		return b
+	case bool:
+		if v {
+			return []byte{0xf5}
+		}
+		return []byte{0xf4}
+	case nil:
+		return []byte{0xf6}
+	}
+	panic("cborEncode: unsupported type")
+}
+
+func mustHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func Test_cborDecode(t *testing.T) {
+	// RFC 8949, Appendix A
+	tests := []struct {
+		name     string
+		in       string
+		want     interface{}
+		wantRest []byte
+		wantErr  bool
+	}{
+		{"Zero", "00", int64(0), []byte{}, false},
+		{"Small", "17", int64(23), []byte{}, false},
+		{"One byte", "1818", int64(24), []byte{}, false},
+		{"Two bytes", "1903e8", int64(1000), []byte{}, false},
+		{"Four bytes", "1a000f4240", int64(1000000), []byte{}, false},
+		{"Eight bytes", "1b000000e8d4a51000", int64(1000000000000), []byte{}, false},
+		{"Too large", "1bffffffffffffffff", nil, nil, true},
+		{"Negative", "20", int64(-1), []byte{}, false},
+		{"Negative two bytes", "3903e7", int64(-1000), []byte{}, false},
+		{"Bytes", "4401020304", []byte{1, 2, 3, 4}, []byte{}, false},
+		{"Text", "6449455446", "IETF", []byte{}, false},
+		{"Array", "83010203", []interface{}{int64(1), int64(2), int64(3)}, []byte{}, false},
+		{
+			"Map",
+			"a201020304",
+			map[interface{}]interface{}{int64(1): int64(2), int64(3): int64(4)},
+			[]byte{},
+			false,
+		},
+		{
+			"Nested",
+			"a26161016162820203",
+			map[interface{}]interface{}{"a": int64(1), "b": []interface{}{int64(2), int64(3)}},
+			[]byte{},
+			false,
+		},
+		{"False", "f4", false, []byte{}, false},
+		{"True", "f5", true, []byte{}, false},
+		{"Null", "f6", nil, []byte{}, false},
+		{"Rest", "0102", int64(1), []byte{2}, false},
+		{"Empty", "", nil, nil, true},
+		{"Short argument", "19ff", nil, nil, true},
+		{"Short bytes", "4401", nil, nil, true},
+		{"Short array", "8301", nil, nil, true},
+		{"Short map", "a201", nil, nil, true},
+		{"Indefinite", "5f42010243030405ff", nil, nil, true},
+		{"Tag", "c11a514b67b0", nil, nil, true},
+		{"Float", "f93c00", nil, nil, true},
+		{"Map key", "a1800102", nil, nil, true},
+		{"Depth", "8181818181818181818181818181818181818100", nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, rest, err := cborDecode(mustHex(t, tt.in))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("cborDecode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("cborDecode() got = %#v, want %#v", got, tt.want)
+			}
+			if !bytes.Equal(rest, tt.wantRest) {
+				t.Errorf("cborDecode() rest = %v, want %v", rest, tt.wantRest)
+			}
+		})
+	}
+}
+
+func Test_cborEncode(t *testing.T) {
+	canonical := map[interface{}]interface{}{int64(-1): int64(1), int64(3): int64(-7), int64(1): int64(2)}
+	if got, want := cborEncode(canonical), mustHex(t, "a3010203262001"); !bytes.Equal(got, want) {
+		t.Errorf("cborEncode() = %x, want %x", got, want)
+	}
+
+	in := map[interface{}]interface{}{
+		"fmt":         "none",
+		"attStmt":     map[interface{}]interface{}{},
+		"authData":    bytes.Repeat([]byte{1}, 300),
+		int64(-70000): []interface{}{true, false, nil, 1 << 40},
+	}
+	want := map[interface{}]interface{}{
+		"fmt":         "none",
+		"attStmt":     map[interface{}]interface{}{},
+		"authData":    bytes.Repeat([]byte{1}, 300),
+		int64(-70000): []interface{}{true, false, nil, int64(1 << 40)},
+	}
+	got, rest, err := cborDecode(cborEncode(in))
+	if err != nil || len(rest) != 0 {
+		t.Fatalf("cborDecode() rest = %v, err = %v", rest, err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("cborDecode(cborEncode()) = %#v, want %#v", got, want)
+	}
+}
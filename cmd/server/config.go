@@ -8,14 +8,18 @@ import (
 	"context"
 	"crypto/rand"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"html/template"
 	"io"
 	"io/ioutil"
 	"net"
+	"net/http"
 	"net/smtp"
+	"net/url"
 	"strings"
 	"time"
 
@@ -25,8 +29,8 @@ import (
 	"github.com/moapis/multidb"
 	pg "github.com/moapis/multidb/drivers/postgresql"
 	"github.com/sirupsen/logrus"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
-	"golang.org/x/crypto/argon2"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
@@ -37,12 +41,322 @@ import (
 type TLSConfig struct {
 	CertFile string `json:"certfile,omitempty"`
 	KeyFile  string `json:"keyfile,omitempty"`
+	// ClientCAFile contains the PEM encoded CA certificates for verifying client certificates.
+	// Verified clients are identified by their certificate for authorization.
+	// Only used by the gRPC server.
+	ClientCAFile string `json:"client_ca_file,omitempty"`
 }
 
+var errClientCA = errors.New("TLS: no certificates found in client_ca_file")
+
 // JWTConfig sets static properties of every token produced by this server
 type JWTConfig struct {
 	Issuer string        `json:"issuer,omitempty"`
 	Expiry time.Duration `json:"expiry,omitempty"`
+	// RefreshExpiry sets the lifetime of refresh tokens.
+	RefreshExpiry time.Duration `json:"refresh_expiry,omitempty"`
+	// AuthCodeExpiry sets the lifetime of one-time authorization codes.
+	AuthCodeExpiry time.Duration `json:"auth_code_expiry,omitempty"`
+	// LoginLinkExpiry sets the lifetime of the tokens in login links.
+	LoginLinkExpiry time.Duration `json:"login_link_expiry,omitempty"`
+	// ResetExpiry sets the lifetime of the tokens in password reset mails.
+	ResetExpiry time.Duration `json:"reset_expiry,omitempty"`
+	// VerifyExpiry sets the lifetime of the tokens in registration mails,
+	// for e-mail verification.
+	VerifyExpiry time.Duration `json:"verify_expiry,omitempty"`
+	// RotateEvery sets the interval for signing key rotation.
+	// Rotation is disabled when 0, keys will never expire.
+	RotateEvery time.Duration `json:"rotate_every,omitempty"`
+	// PruneEvery sets the interval for removing expired keys from the database.
+	// Pruning is disabled when 0.
+	PruneEvery time.Duration `json:"prune_every,omitempty"`
+	// Shared enables storage of the signing key in the database,
+	// so that multiple server replicas sign with the same key.
+	// Each replica generates its own key when nil.
+	Shared *SharedKeyConfig `json:"shared,omitempty"`
+}
+
+// SharedKeyConfig for signing keys shared between server replicas.
+// Private keys are stored encrypted with the key-encryption key (KEK).
+// All replicas need to be configured with the same KEK.
+type SharedKeyConfig struct {
+	KEK     string        `json:"kek,omitempty"`      // Base64 encoded 32 byte key-encryption key
+	KEKFile string        `json:"kek_file,omitempty"` // File containing the base64 encoded KEK, used when KEK is empty
+	Poll    time.Duration `json:"poll,omitempty"`     // Interval for checking the database for a new key. Required.
+}
+
+// MFAConfig for two-factor authentication.
+// TOTP secrets are stored encrypted with the key-encryption key (KEK).
+type MFAConfig struct {
+	KEK     string `json:"kek,omitempty"`      // Base64 encoded 32 byte key-encryption key
+	KEKFile string `json:"kek_file,omitempty"` // File containing the base64 encoded KEK, used when KEK is empty
+	Issuer  string `json:"issuer,omitempty"`   // Issuer shown in authenticator apps, defaults to the JWT issuer
+}
+
+// loadKEK decodes the key-encryption key from KEK or KEKFile.
+func (c *MFAConfig) loadKEK() ([]byte, error) {
+	return loadKEK(c.KEK, c.KEKFile)
+}
+
+// WebAuthnConfig for passkey registration and login.
+type WebAuthnConfig struct {
+	RPID    string        `json:"rp_id,omitempty"`   // Relying party ID: the domain of the login forms, or a parent domain
+	RPName  string        `json:"rp_name,omitempty"` // Relying party name shown by browsers, defaults to RPID
+	Origins []string      `json:"origins,omitempty"` // Accepted origins of the login forms, such as "https://login.example.com"
+	Timeout time.Duration `json:"timeout,omitempty"` // Lifetime of challenges, defaults to 5 minutes
+}
+
+var errWebAuthnConfig = errors.New("WebAuthn: rp_id and origins are required")
+
+func (c *WebAuthnConfig) rpName() string {
+	if c.RPName == "" {
+		return c.RPID
+	}
+	return c.RPName
+}
+
+func (c *WebAuthnConfig) timeout() time.Duration {
+	if c.Timeout <= 0 {
+		return 5 * time.Minute
+	}
+	return c.Timeout
+}
+
+// FederationConfig for login through upstream OpenID Connect providers.
+type FederationConfig struct {
+	Providers map[string]*ProviderConfig `json:"providers,omitempty"` // Providers by name, as used in FederatedRequest
+	Expiry    time.Duration              `json:"expiry,omitempty"`    // Lifetime of pending logins, defaults to 10 minutes
+	Timeout   time.Duration              `json:"timeout,omitempty"`   // Timeout of requests to providers, defaults to 10 seconds
+}
+
+var errFederationConfig = errors.New("Federation: providers need an issuer and client_id")
+
+func (c *FederationConfig) validate() error {
+	for _, p := range c.Providers {
+		if p == nil || p.Issuer == "" || p.ClientID == "" {
+			return errFederationConfig
+		}
+	}
+	return nil
+}
+
+func (c *FederationConfig) expiry() time.Duration {
+	if c.Expiry <= 0 {
+		return 10 * time.Minute
+	}
+	return c.Expiry
+}
+
+func (c *FederationConfig) timeout() time.Duration {
+	if c.Timeout <= 0 {
+		return 10 * time.Second
+	}
+	return c.Timeout
+}
+
+// ProviderConfig for an upstream OpenID Connect provider.
+// The provider metadata is obtained through OpenID Connect discovery on Issuer.
+type ProviderConfig struct {
+	Issuer       string           `json:"issuer,omitempty"`        // Issuer URL, such as "https://accounts.example.com"
+	ClientID     string           `json:"client_id,omitempty"`     // Client ID registered at the provider
+	ClientSecret string           `json:"client_secret,omitempty"` // Client secret, empty for public clients
+	Scopes       []string         `json:"scopes,omitempty"`        // Requested scopes, defaults to "openid", "email" and "profile"
+	Provision    *ProvisionConfig `json:"provision,omitempty"`     // Unknown identities are rejected when nil
+}
+
+func (c *ProviderConfig) scopes() []string {
+	if len(c.Scopes) == 0 {
+		return []string{scopeOpenID, scopeEmail, scopeProfile}
+	}
+	return c.Scopes
+}
+
+// ProvisionConfig for just-in-time provisioning of users from upstream identities.
+// Identities need a verified e-mail address to be provisioned.
+type ProvisionConfig struct {
+	// LinkByEmail links an identity to an existing user with the same e-mail address.
+	// Such identities are rejected when false.
+	LinkByEmail bool `json:"link_by_email,omitempty"`
+	// Rules are applied on every login.
+	// Groups and audiences are only added, never removed.
+	Rules []ProvisionRule `json:"rules,omitempty"`
+}
+
+// ProvisionRule grants Groups and Audiences to users with a matching claim in the ID token.
+// A claim holding an array matches when any of its elements matches.
+type ProvisionRule struct {
+	Claim     string   `json:"claim"`
+	Value     string   `json:"value,omitempty"` // Any value matches when empty
+	Groups    []string `json:"groups,omitempty"`
+	Audiences []string `json:"audiences,omitempty"`
+}
+
+// LDAPConfig for a directory verifying the passwords of its users.
+// Users are bound as the DN from UserDN, or as the DN of the single entry
+// found by Filter under BaseDN, after binding as BindDN.
+// In both templates, "%s" is replaced by the escaped e-mail address of the user.
+type LDAPConfig struct {
+	URL          string `json:"url,omitempty"`           // Directory URL, such as "ldaps://ldap.example.com"
+	StartTLS     bool   `json:"start_tls,omitempty"`     // Upgrade "ldap://" connections with StartTLS
+	CAFile       string `json:"ca_file,omitempty"`       // PEM encoded CA certificates for verifying the directory, defaults to the system pool
+	UserDN       string `json:"user_dn,omitempty"`       // DN template for binding as the user, such as "uid=%s,ou=people,dc=example,dc=com"
+	BindDN       string `json:"bind_dn,omitempty"`       // DN for searching users, anonymous when empty
+	BindPassword string `json:"bind_password,omitempty"` // Password of BindDN
+	BaseDN       string `json:"base_dn,omitempty"`       // Base of the user search, required without UserDN
+	Filter       string `json:"filter,omitempty"`        // Filter template for the user search, defaults to "(mail=%s)"
+	// GroupAttribute holds the DNs of the groups of a user, defaults to "memberOf".
+	GroupAttribute string `json:"group_attribute,omitempty"`
+	// Groups maps group DNs to names of local groups.
+	// On every login, the user is added to the groups it is member of in the directory
+	// and removed from the other mapped groups.
+	// Groups which are not mapped are left alone.
+	Groups  map[string]string `json:"groups,omitempty"`
+	Timeout time.Duration     `json:"timeout,omitempty"` // Timeout of requests to the directory, defaults to 10 seconds
+}
+
+var (
+	errLDAPConfig   = errors.New("LDAP: backends need a url and a user_dn or base_dn")
+	errLDAPStartTLS = errors.New("LDAP: start_tls needs an ldap:// url")
+	errLDAPLocal    = errors.New("LDAP: \"" + localBackendName + "\" is reserved for local passwords")
+	errLDAPCAFile   = errors.New("LDAP: no certificates found in ca_file")
+	errLDAPTemplate = errors.New("LDAP: user_dn and filter need a %s placeholder")
+)
+
+func (c *LDAPConfig) validate() error {
+	switch {
+	case c.URL == "" || (c.UserDN == "" && c.BaseDN == ""):
+		return errLDAPConfig
+	case c.StartTLS && !strings.HasPrefix(c.URL, "ldap://"):
+		return errLDAPStartTLS
+	case c.UserDN != "" && !strings.Contains(c.UserDN, "%s"),
+		c.Filter != "" && !strings.Contains(c.Filter, "%s"):
+		return errLDAPTemplate
+	}
+	return nil
+}
+
+func (c *LDAPConfig) filter() string {
+	if c.Filter == "" {
+		return "(mail=%s)"
+	}
+	return c.Filter
+}
+
+func (c *LDAPConfig) groupAttribute() string {
+	if c.GroupAttribute == "" {
+		return "memberOf"
+	}
+	return c.GroupAttribute
+}
+
+func (c *LDAPConfig) timeout() time.Duration {
+	if c.Timeout <= 0 {
+		return 10 * time.Second
+	}
+	return c.Timeout
+}
+
+// tlsConfig returns the TLS configuration for ldaps:// and StartTLS connections.
+func (c *LDAPConfig) tlsConfig() (*tls.Config, error) {
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return nil, err
+	}
+	tc := &tls.Config{ServerName: u.Hostname()}
+	if c.CAFile != "" {
+		pem, err := ioutil.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tc.RootCAs = x509.NewCertPool()
+		if !tc.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, errLDAPCAFile
+		}
+	}
+	return tc, nil
+}
+
+// LockoutConfig for brute-force protection.
+// Failed logins are counted per account and per source IP,
+// password reset mails per account.
+// Once a counter reaches its threshold, the account or IP is locked for Backoff,
+// doubled on every further failure, up to MaxBackoff.
+// A threshold of 0 disables the respective counter.
+type LockoutConfig struct {
+	AccountThreshold int           `json:"account_threshold,omitempty"` // Failed logins per account before lockout
+	IPThreshold      int           `json:"ip_threshold,omitempty"`      // Failed logins per source IP before lockout
+	ResetThreshold   int           `json:"reset_threshold,omitempty"`   // Password reset mails per account before lockout
+	Backoff          time.Duration `json:"backoff,omitempty"`           // Duration of the first lockout
+	MaxBackoff       time.Duration `json:"max_backoff,omitempty"`       // Maximum duration of a lockout
+	Window           time.Duration `json:"window,omitempty"`            // Counters are reset after this period without failures
+	// IP addresses or CIDR ranges of peers which may pass the client's IP
+	// in "x-forwarded-for" metadata, such as the login forms server.
+	TrustedProxies []string `json:"trusted_proxies,omitempty"`
+}
+
+var errLockoutConfig = errors.New("Lockout: backoff, max_backoff and window must be positive and trusted_proxies valid IP addresses or CIDR ranges")
+
+func (c *LockoutConfig) validate() error {
+	if c.Backoff <= 0 || c.MaxBackoff <= 0 || c.Window <= 0 {
+		return errLockoutConfig
+	}
+	for _, p := range c.TrustedProxies {
+		if _, _, err := net.ParseCIDR(p); err != nil && net.ParseIP(p) == nil {
+			return errLockoutConfig
+		}
+	}
+	return nil
+}
+
+// trusted reports if ip matches any of TrustedProxies.
+func (c *LockoutConfig) trusted(ip net.IP) bool {
+	for _, p := range c.TrustedProxies {
+		if _, n, err := net.ParseCIDR(p); err == nil {
+			if n.Contains(ip) {
+				return true
+			}
+		} else if net.ParseIP(p).Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// lockDuration returns the lockout duration after failures,
+// or 0 if threshold is not reached or disabled.
+func (c *LockoutConfig) lockDuration(failures, threshold int) time.Duration {
+	if threshold <= 0 || failures < threshold {
+		return 0
+	}
+	d := c.Backoff
+	for i := threshold; i < failures && d < c.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > c.MaxBackoff {
+		return c.MaxBackoff
+	}
+	return d
+}
+
+// HTTPConfig for the HTTP server, publishing the JWKS and OpenID discovery document.
+type HTTPConfig struct {
+	Address   string        `json:"address"`    // HTTP listen Address
+	Port      uint16        `json:"port"`       // HTTP listen Port
+	PublicURL string        `json:"public_url"` // Public base URL of the HTTP server
+	FormsURL  string        `json:"forms_url"`  // Public base URL of the HTTP forms, serving the OAuth 2.0 endpoints
+	Timeout   time.Duration `json:"timeout"`    // HTTP read and write timeouts
+	MaxAge    time.Duration `json:"max_age"`    // Cache-Control max-age of served documents
+	TLS       *TLSConfig    `json:"tls"`        // TLS will be disabled when nil
+}
+
+// UnmarshalJSON fills an unset HTTPConfig with DefaultHTTP,
+// before applying the values from b.
+func (h *HTTPConfig) UnmarshalJSON(b []byte) error {
+	type plain HTTPConfig
+	if *h == (HTTPConfig{}) {
+		*h = DefaultHTTP
+	}
+	return json.Unmarshal(b, (*plain)(h))
 }
 
 // BootstrapUser defines a primary user
@@ -67,16 +381,26 @@ type MailConfig struct {
 
 // ServerConfig is a collection on config
 type ServerConfig struct {
-	Addres      string          `json:"address"`     // gRPC listen Address
-	Port        uint16          `json:"port"`        // gRPC listen Port
-	LogLevel    LogLevel        `json:"loglevel"`    // LogLevel used for logrus
-	TLS         *TLSConfig      `json:"tls"`         // TLS will be disabled when nil
-	MultiDB     multidb.Config  `json:"multidb"`     // Imported from multidb
-	PG          *pg.Config      `json:"pg"`          // PG is later embedded in multidb
-	SQLRoutines int             `json:"sqlroutines"` // Amount of Go-routines for non-master queries
-	Users       []BootstrapUser `json:"bootsrap"`    // Users which will be upserted at start
-	JWT         JWTConfig       `json:"jwt"`
-	Mail        MailConfig      `json:"smtp"`
+	Addres      string                 `json:"address"`     // gRPC listen Address
+	Port        uint16                 `json:"port"`        // gRPC listen Port
+	LogLevel    LogLevel               `json:"loglevel"`    // LogLevel used for logrus
+	TLS         *TLSConfig             `json:"tls"`         // TLS will be disabled when nil
+	MultiDB     multidb.Config         `json:"multidb"`     // Imported from multidb
+	PG          *pg.Config             `json:"pg"`          // PG is later embedded in multidb
+	SQLRoutines int                    `json:"sqlroutines"` // Amount of Go-routines for non-master queries
+	Users       []BootstrapUser        `json:"bootsrap"`    // Users which will be upserted at start
+	JWT         JWTConfig              `json:"jwt"`
+	Password    PasswordConfig         `json:"password"`        // Hashing parameters for new passwords
+	Policy      PolicyConfig           `json:"password_policy"` // Requirements for new passwords
+	Mail        MailConfig             `json:"smtp"`
+	HTTP        *HTTPConfig            `json:"http"`       // HTTP server will be disabled when nil
+	MFA         *MFAConfig             `json:"mfa"`        // Two-factor authentication will be disabled when nil
+	WebAuthn    *WebAuthnConfig        `json:"webauthn"`   // Passkeys will be disabled when nil
+	Lockout     *LockoutConfig         `json:"lockout"`    // Brute-force protection will be disabled when nil
+	Admin       *AdminConfig           `json:"admin"`      // Admin gRPC service will be disabled when nil
+	Authz       *AuthzConfig           `json:"authz"`      // Access levels of gRPC methods are not enforced when nil
+	Federation  *FederationConfig      `json:"federation"` // Federated login will be disabled when nil
+	LDAP        map[string]*LDAPConfig `json:"ldap"`       // Credential backends by name, as set per user
 }
 
 func (c *ServerConfig) writeOut(filename string) error {
@@ -124,8 +448,25 @@ var Default = ServerConfig{
 		},
 	},
 	JWT: JWTConfig{
-		Issuer: "localhost",
-		Expiry: 24 * time.Hour,
+		Issuer:          "localhost",
+		Expiry:          24 * time.Hour,
+		RefreshExpiry:   30 * 24 * time.Hour,
+		AuthCodeExpiry:  time.Minute,
+		LoginLinkExpiry: 15 * time.Minute,
+		ResetExpiry:     time.Hour,
+		VerifyExpiry:    24 * time.Hour,
+		RotateEvery:     24 * time.Hour,
+		PruneEvery:      time.Hour,
+	},
+	Password: PasswordConfig{
+		Time:    1,
+		Memory:  64 * 1024,
+		Threads: 2,
+		KeyLen:  32,
+		SaltLen: 16,
+	},
+	Policy: PolicyConfig{
+		MinLength: 8,
 	},
 	Mail: MailConfig{
 		Host:         "test.mailu.io",
@@ -136,6 +477,28 @@ var Default = ServerConfig{
 		From:         "admin@test.mailu.io",
 		TemplateGlob: "templates/*.mail.html",
 	},
+	HTTP:     nil,
+	MFA:      nil,
+	WebAuthn: nil,
+	Lockout: &LockoutConfig{
+		AccountThreshold: 5,
+		IPThreshold:      50,
+		ResetThreshold:   3,
+		Backoff:          time.Minute,
+		MaxBackoff:       time.Hour,
+		Window:           24 * time.Hour,
+		TrustedProxies:   []string{"127.0.0.1", "::1"},
+	},
+}
+
+// DefaultHTTP is applied to the HTTP server config, when it is enabled through a config file.
+var DefaultHTTP = HTTPConfig{
+	Address:   "127.0.0.1",
+	Port:      8766,
+	PublicURL: "http://localhost:8766",
+	FormsURL:  "http://localhost:1235",
+	Timeout:   10 * time.Second,
+	MaxAge:    time.Hour,
 }
 
 var configFiles = flag.String("config", "", "Comma separated list of JSON config files")
@@ -145,6 +508,15 @@ func configure(c ServerConfig) (*ServerConfig, error) {
 
 	files := strings.Split(*configFiles, ",")
 	s := &c
+	if s.HTTP != nil {
+		// Prevent config files from altering the defaults
+		h := *s.HTTP
+		s.HTTP = &h
+	}
+	if s.Lockout != nil {
+		l := *s.Lockout
+		s.Lockout = &l
+	}
 	for _, f := range files {
 		if f == "" {
 			continue
@@ -179,9 +551,7 @@ func configure(c ServerConfig) (*ServerConfig, error) {
 }
 
 func (c ServerConfig) grpcOpts() ([]grpc.ServerOption, error) {
-	opts := []grpc.ServerOption{
-		grpc.UnaryInterceptor(middlewareInterceptor),
-	}
+	opts := []grpc.ServerOption{}
 	if c.TLS != nil {
 		log := log.WithFields(logrus.Fields{"certFile": c.TLS.CertFile, "keyFile": c.TLS.KeyFile})
 		cert, err := tls.LoadX509KeyPair(c.TLS.CertFile, c.TLS.KeyFile)
@@ -189,7 +559,26 @@ func (c ServerConfig) grpcOpts() ([]grpc.ServerOption, error) {
 			log.WithError(err).Error("Failed to set TLS opts")
 			return nil, err
 		}
-		opts = append(opts, grpc.Creds(credentials.NewServerTLSFromCert(&cert)))
+		if c.TLS.ClientCAFile == "" {
+			opts = append(opts, grpc.Creds(credentials.NewServerTLSFromCert(&cert)))
+			return opts, nil
+		}
+
+		pem, err := ioutil.ReadFile(c.TLS.ClientCAFile)
+		if err != nil {
+			log.WithError(err).Error("Failed to set TLS opts")
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			log.WithField("clientCAFile", c.TLS.ClientCAFile).WithError(errClientCA).Error("Failed to set TLS opts")
+			return nil, errClientCA
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(&tls.Config{
+			Certificates: []tls.Certificate{cert},
+			ClientCAs:    pool,
+			ClientAuth:   tls.VerifyClientCertIfGiven,
+		})))
 	}
 	return opts, nil
 }
@@ -205,8 +594,9 @@ func (c ServerConfig) bootStrapUsers(ctx context.Context, s *authServer) error {
 
 		log := s.log.WithField("user", u)
 		um := &models.User{
-			Name:  u.Name,
-			Email: u.Email,
+			Name:       u.Name,
+			Email:      u.Email,
+			VerifiedAt: null.TimeFrom(time.Now()),
 		}
 		if err = um.Insert(ctx, tx, boil.Infer()); err != nil {
 			if strings.Contains(err.Error(), "pq: duplicate key value violates unique constraint") {
@@ -218,16 +608,15 @@ func (c ServerConfig) bootStrapUsers(ctx context.Context, s *authServer) error {
 		}
 		log.Debug("bootstrap User insert")
 
-		pwm := &models.Password{
-			UserID: um.ID,
-			Salt:   make([]byte, PasswordSaltLen),
-		}
-		if _, err := rand.Read(pwm.Salt); err != nil {
+		hash, err := c.Password.hash(u.Password, rand.Read)
+		if err != nil {
 			log.WithError(err).Error("Salt generation")
 			return status.Error(codes.Internal, errFatal)
 		}
-		pwm.Hash = argon2.IDKey([]byte(u.Password), pwm.Salt, Argon2Time, Argon2Memory, Argon2Threads, Argon2KeyLen)
-		log = log.WithField("password_model", pwm)
+		pwm := &models.Password{
+			UserID: um.ID,
+			Hash:   hash,
+		}
 
 		if err := um.SetPassword(ctx, tx, true, pwm); err != nil {
 			log.WithError(err).Error("password.SetPassword()")
@@ -274,14 +663,80 @@ func (c ServerConfig) newAuthServer(ctx context.Context, r io.Reader) (*authServ
 		return nil, err
 	}
 
+	if err = c.Password.validate(); err != nil {
+		return nil, err
+	}
+	if err = c.Policy.validate(); err != nil {
+		return nil, err
+	}
+	if c.Policy.BreachedCorpus != "" {
+		if s.breached, err = openBreachedCorpus(c.Policy.BreachedCorpus); err != nil {
+			return nil, err
+		}
+	}
+
 	if err = c.bootStrapUsers(ctx, s); err != nil {
 		return nil, err
 	}
 
-	if err = s.updateKeyPair(ctx, r); err != nil {
+	if c.JWT.Shared != nil {
+		// Without polling the shared key would never rotate,
+		// and keep signing after its expiry.
+		if c.JWT.Shared.Poll <= 0 {
+			return nil, errSharedPoll
+		}
+		if s.kek, err = c.JWT.Shared.loadKEK(); err != nil {
+			return nil, err
+		}
+		err = s.syncSharedKey(ctx, r)
+	} else {
+		err = s.updateKeyPair(ctx, r)
+	}
+	if err != nil {
 		return nil, err
 	}
 
+	if c.MFA != nil {
+		if s.mfaKEK, err = c.MFA.loadKEK(); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.WebAuthn != nil && (c.WebAuthn.RPID == "" || len(c.WebAuthn.Origins) == 0) {
+		return nil, errWebAuthnConfig
+	}
+
+	if c.Lockout != nil {
+		if err = c.Lockout.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.Admin != nil {
+		if err = c.Admin.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.Authz != nil {
+		if err = c.Authz.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.Federation != nil {
+		if err = c.Federation.validate(); err != nil {
+			return nil, err
+		}
+		s.providers = newProviders(c.Federation)
+	}
+
+	if len(c.LDAP) > 0 {
+		if s.backends, err = newLDAPBackends(c.LDAP); err != nil {
+			return nil, err
+		}
+	}
+
 	tmpl, err := template.ParseGlob(c.Mail.TemplateGlob)
 	if err != nil {
 		return nil, err
@@ -296,9 +751,17 @@ func (c ServerConfig) newAuthServer(ctx context.Context, r io.Reader) (*authServ
 }
 
 func (c ServerConfig) listenAndServe(s *authServer, opts ...grpc.ServerOption) (*grpc.Server, <-chan error) {
+	if c.Authz != nil {
+		opts = append(opts, grpc.ChainUnaryInterceptor(s.interceptors()...))
+	} else {
+		log.Warn("Access levels of gRPC methods are not enforced")
+	}
 	gs := grpc.NewServer(opts...)
 	ec := make(chan error)
 	auth.RegisterAuthenticatorServer(gs, s)
+	if c.Admin != nil {
+		auth.RegisterAuthenticatorAdminServer(gs, &adminServer{authServer: s})
+	}
 
 	log := log.WithFields(logrus.Fields{"address": c.Addres, "port": c.Port})
 	log.WithField("grpc", gs.GetServiceInfo()).Debug("Registered services")
@@ -320,3 +783,32 @@ func (c ServerConfig) listenAndServe(s *authServer, opts ...grpc.ServerOption) (
 	}(ec)
 	return gs, ec
 }
+
+func (c ServerConfig) listenAndServeHTTP(s *authServer) (*http.Server, <-chan error) {
+	hs := &http.Server{
+		Addr:         fmt.Sprintf("%s:%d", c.HTTP.Address, c.HTTP.Port),
+		Handler:      s.httpHandler(),
+		ReadTimeout:  c.HTTP.Timeout,
+		WriteTimeout: c.HTTP.Timeout,
+	}
+	ec := make(chan error, 1)
+
+	log := log.WithFields(logrus.Fields{"address": c.HTTP.Address, "port": c.HTTP.Port})
+	log.Info("Starting HTTP server")
+
+	go func(ec chan<- error) {
+		var err error
+		if c.HTTP.TLS == nil {
+			err = hs.ListenAndServe()
+		} else {
+			err = hs.ListenAndServeTLS(c.HTTP.TLS.CertFile, c.HTTP.TLS.KeyFile)
+		}
+		if err != nil && err != http.ErrServerClosed {
+			log.WithError(err).Error("Failed to serve HTTP")
+			ec <- err
+			return
+		}
+		ec <- nil
+	}(ec)
+	return hs, ec
+}
@@ -6,6 +6,7 @@ package main
 
 import (
 	"context"
+	"encoding/base64"
 	"io"
 	"io/ioutil"
 	"os"
@@ -47,6 +48,7 @@ func Test_configure(t *testing.T) {
 		"corrupt.json": []byte("???"),
 		"one.json":     []byte("{\"port\": 1}"),
 		"two.json":     []byte("{\"port\": 2}"),
+		"http.json":    []byte("{\"http\": {\"port\": 1}}"),
 	}
 
 	for k, v := range files {
@@ -90,6 +92,25 @@ func Test_configure(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"HTTP defaults",
+			[]string{"http.json"},
+			ServerConfig{
+				LogLevel: DebugLevel,
+			},
+			&ServerConfig{
+				LogLevel: DebugLevel,
+				HTTP: &HTTPConfig{
+					Address:   DefaultHTTP.Address,
+					Port:      1,
+					PublicURL: DefaultHTTP.PublicURL,
+					FormsURL:  DefaultHTTP.FormsURL,
+					Timeout:   DefaultHTTP.Timeout,
+					MaxAge:    DefaultHTTP.MaxAge,
+				},
+			},
+			false,
+		},
 		{
 			"LogLevel error",
 			nil,
@@ -114,6 +135,8 @@ func Test_configure(t *testing.T) {
 }
 
 func TestServerConfig_grpcOpts(t *testing.T) {
+	certFile, keyFile := testTLSFiles(t)
+
 	tests := []struct {
 		name    string
 		tls     *TLSConfig
@@ -127,8 +150,43 @@ func TestServerConfig_grpcOpts(t *testing.T) {
 		{
 			"TLS file errors",
 			&TLSConfig{
-				"Foo",
-				"Bar",
+				CertFile: "Foo",
+				KeyFile:  "Bar",
+			},
+			true,
+		},
+		{
+			"TLS",
+			&TLSConfig{
+				CertFile: certFile,
+				KeyFile:  keyFile,
+			},
+			false,
+		},
+		{
+			"Client CA",
+			&TLSConfig{
+				CertFile:     certFile,
+				KeyFile:      keyFile,
+				ClientCAFile: certFile,
+			},
+			false,
+		},
+		{
+			"Client CA file error",
+			&TLSConfig{
+				CertFile:     certFile,
+				KeyFile:      keyFile,
+				ClientCAFile: "Foo",
+			},
+			true,
+		},
+		{
+			"Client CA without certificates",
+			&TLSConfig{
+				CertFile:     certFile,
+				KeyFile:      keyFile,
+				ClientCAFile: keyFile,
 			},
 			true,
 		},
@@ -155,6 +213,36 @@ func TestServerConfig_newAuthServer(t *testing.T) {
 	cc := *testConfig
 	cc.Mail.TemplateGlob = "foo"
 
+	kc := *testConfig
+	kc.JWT.Shared = &SharedKeyConfig{Poll: time.Minute}
+
+	pc := *testConfig
+	pc.JWT.Shared = &SharedKeyConfig{KEK: base64.StdEncoding.EncodeToString(testKEK)}
+
+	mc := *testConfig
+	mc.MFA = &MFAConfig{}
+
+	wc := *testConfig
+	wc.WebAuthn = &WebAuthnConfig{RPID: "localhost"}
+
+	lc := *testConfig
+	lc.Lockout = &LockoutConfig{}
+
+	hc := *testConfig
+	hc.Password = PasswordConfig{}
+
+	poc := *testConfig
+	poc.Policy = PolicyConfig{MinLength: -1}
+
+	ac := *testConfig
+	ac.Admin = &AdminConfig{}
+
+	zc := *testConfig
+	zc.Authz = &AuthzConfig{Methods: map[string]AccessLevel{"/foo/Bar": PublicAccess}}
+
+	bc := *testConfig
+	bc.Policy = PolicyConfig{BreachedCorpus: "foo/bar"}
+
 	type args struct {
 		ctx context.Context
 		r   io.Reader
@@ -195,6 +283,66 @@ func TestServerConfig_newAuthServer(t *testing.T) {
 			args{testCtx, strings.NewReader(testKeyInput)},
 			true,
 		},
+		{
+			"KEK error",
+			&kc,
+			args{testCtx, strings.NewReader(testKeyInput)},
+			true,
+		},
+		{
+			"Shared poll error",
+			&pc,
+			args{testCtx, strings.NewReader(testKeyInput)},
+			true,
+		},
+		{
+			"MFA KEK error",
+			&mc,
+			args{testCtx, strings.NewReader(testKeyInput)},
+			true,
+		},
+		{
+			"WebAuthn config error",
+			&wc,
+			args{testCtx, strings.NewReader(testKeyInput)},
+			true,
+		},
+		{
+			"Lockout config error",
+			&lc,
+			args{testCtx, strings.NewReader(testKeyInput)},
+			true,
+		},
+		{
+			"Password config error",
+			&hc,
+			args{testCtx, strings.NewReader(testKeyInput)},
+			true,
+		},
+		{
+			"Password policy error",
+			&poc,
+			args{testCtx, strings.NewReader(testKeyInput)},
+			true,
+		},
+		{
+			"Breached corpus error",
+			&bc,
+			args{testCtx, strings.NewReader(testKeyInput)},
+			true,
+		},
+		{
+			"Admin config error",
+			&ac,
+			args{testCtx, strings.NewReader(testKeyInput)},
+			true,
+		},
+		{
+			"Authz config error",
+			&zc,
+			args{testCtx, strings.NewReader(testKeyInput)},
+			true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -260,3 +408,47 @@ func TestServerConfig_listenAndServe(t *testing.T) {
 		})
 	}
 }
+
+func TestServerConfig_listenAndServeHTTP(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    HTTPConfig
+		wantErr bool
+	}{
+		{
+			"Healthy start",
+			HTTPConfig{
+				Address: "127.0.0.1",
+				Port:    9876,
+				Timeout: time.Second,
+			},
+			false,
+		},
+		{
+			"Fail to listen",
+			HTTPConfig{
+				Address: "127.0.0.1",
+				Port:    12,
+				Timeout: time.Second,
+			},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := ServerConfig{
+				HTTP: &tt.conf,
+			}
+			got, ec := c.listenAndServeHTTP(tas)
+			time.Sleep(time.Millisecond)
+			if got == nil {
+				t.Fatalf("ServerConfig.listenAndServeHTTP() got = %v, want %v", got, "not nil")
+			}
+			got.Close()
+			err := <-ec
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ServerConfig.listenAndServeHTTP() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"crypto/rand"
+	"errors"
+
+	"github.com/moapis/authenticator/models"
+	"github.com/volatiletech/null/v8"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	errBackend            = "Unknown credential backend"
+	errBackendUnavailable = "Credential backend unavailable"
+	errBackendPassword    = "Password is managed by a credential backend"
+	errGroupMapping       = "Group mapping with unknown group"
+
+	// localBackendName selects local passwords in UpdateUser.
+	localBackendName = "local"
+)
+
+// credentialBackend verifies the passwords of users.
+// The backend of a user is selected by its CredentialBackend column,
+// local passwords are used when it is null.
+type credentialBackend interface {
+	// verify returns an error with codes.Unauthenticated when password does not match.
+	// The transaction of rt may be used to update user.
+	verify(rt *requestTx, user *models.User, password string) error
+}
+
+// localBackend verifies password hashes stored in the database.
+type localBackend struct{}
+
+func (localBackend) verify(rt *requestTx, user *models.User, password string) error {
+	pwm, err := user.Password().One(rt.ctx, rt.tx)
+	if err != nil {
+		return rt.dbAuthError("Get user password", "password", err)
+	}
+
+	ok, err := verifyPassword(pwm.Hash, password)
+	if err != nil {
+		rt.log.WithError(err).Error("verifyPassword")
+		return status.Error(codes.Internal, errFatal)
+	}
+	if !ok {
+		rt.log.WithError(errors.New(errCredentials)).Warn("Password missmatch")
+		return status.Error(codes.Unauthenticated, errCredentials)
+	}
+
+	if rt.s.conf.Password.needsRehash(pwm.Hash) {
+		if err = rt.storePassword(user, password, rand.Read); err != nil {
+			return err
+		}
+		rt.log.Info("Password rehashed")
+	}
+	return nil
+}
+
+// credentialBackend returns the backend verifying the password of user.
+func (rt *requestTx) credentialBackend(user *models.User) (credentialBackend, error) {
+	if !user.CredentialBackend.Valid {
+		return localBackend{}, nil
+	}
+	b, ok := rt.s.backends[user.CredentialBackend.String]
+	if !ok {
+		rt.log.WithField("backend", user.CredentialBackend.String).Error(errBackend)
+		return nil, status.Error(codes.Internal, errBackend)
+	}
+	return b, nil
+}
+
+// localPassword returns an error with codes.FailedPrecondition
+// when the password of user is not stored locally,
+// as it can't be changed or reset here.
+func (rt *requestTx) localPassword(user *models.User) error {
+	if user.CredentialBackend.Valid {
+		rt.log.WithField("backend", user.CredentialBackend.String).Warn(errBackendPassword)
+		return status.Error(codes.FailedPrecondition, errBackendPassword)
+	}
+	return nil
+}
+
+// backendColumn returns the CredentialBackend column for name, as set through the admin service.
+// An empty name or localBackendName selects local passwords.
+func (rt *requestTx) backendColumn(name string) (null.String, error) {
+	if name == "" || name == localBackendName {
+		return null.String{}, nil
+	}
+	if _, ok := rt.s.backends[name]; !ok {
+		rt.log.WithField("backend", name).Warn(errBackend)
+		return null.String{}, status.Error(codes.InvalidArgument, errBackend)
+	}
+	return null.StringFrom(name), nil
+}
+
+// syncGroups adds user to the local groups named in member,
+// and removes it from the other groups named in mapped.
+// Groups not named in mapped are left alone.
+// An error with codes.Internal is returned when any group in member does not exist.
+func (rt *requestTx) syncGroups(user *models.User, mapped, member []string) error {
+	log := rt.log.WithField("method", "syncGroups()")
+	if len(mapped) == 0 {
+		return nil
+	}
+	current, err := user.Groups(models.GroupWhere.Name.IN(mapped)).All(rt.ctx, rt.tx)
+	if err != nil {
+		log.WithError(err).Error("user.Groups()")
+		return status.Error(codes.Internal, errDB)
+	}
+	groups, err := rt.findGroups(member)
+	if status.Code(err) == codes.NotFound {
+		log.WithError(err).Error(errGroupMapping)
+		return status.Error(codes.Internal, errGroupMapping)
+	}
+	if err != nil {
+		return err
+	}
+
+	has := make(map[int]bool, len(current))
+	for _, g := range current {
+		has[g.ID] = true
+	}
+	keep := make(map[int]bool, len(groups))
+	var add models.GroupSlice
+	for _, g := range groups {
+		keep[g.ID] = true
+		if !has[g.ID] {
+			add = append(add, g)
+		}
+	}
+	var remove models.GroupSlice
+	for _, g := range current {
+		if !keep[g.ID] {
+			remove = append(remove, g)
+		}
+	}
+
+	if len(add) > 0 {
+		if err = user.AddGroups(rt.ctx, rt.tx, false, add...); err != nil {
+			log.WithError(err).Error("user.AddGroups()")
+			return status.Error(codes.Internal, errDB)
+		}
+	}
+	if len(remove) > 0 {
+		if err = user.RemoveGroups(rt.ctx, rt.tx, remove...); err != nil {
+			log.WithError(err).Error("user.RemoveGroups()")
+			return status.Error(codes.Internal, errDB)
+		}
+	}
+	log.WithField("added", len(add)).WithField("removed", len(remove)).Debug("syncGroups")
+	return nil
+}
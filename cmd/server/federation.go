@@ -0,0 +1,588 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	auth "github.com/moapis/authenticator"
+	"github.com/moapis/authenticator/models"
+	"github.com/pascaldekloe/jwt"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	errProvider            = "Unknown identity provider"
+	errProviderUnavailable = "Identity provider unavailable"
+	errMissingRedirectURI  = "Redirect URI missing"
+	errFederatedState      = "Invalid federated login state"
+	errProviderCode        = "Authorization code rejected by identity provider"
+	errIDToken             = "Invalid ID token"
+	errIdentity            = "Identity not linked to a user"
+	errIdentityEmail       = "Identity without verified e-mail address"
+	errIdentityConflict    = "E-mail address belongs to an existing user"
+	errProvisionRule       = "Provisioning rule with unknown group or audience"
+
+	// Amount of random bytes in the parameters of a federated login.
+	federatedStateLen    = 32
+	federatedNonceLen    = 16
+	federatedVerifierLen = 32
+
+	// maxProviderResponse limits the size of responses from providers.
+	maxProviderResponse = 1 << 20
+	// keysRefreshMin is the minimal interval for retrieving the keys of a provider,
+	// when an ID token is signed by an unknown key.
+	keysRefreshMin = time.Minute
+)
+
+// unavailableError is returned when a provider could not be reached,
+// or responded unexpectedly.
+type unavailableError struct {
+	err error
+}
+
+func (e *unavailableError) Error() string { return e.err.Error() }
+func (e *unavailableError) Unwrap() error { return e.err }
+
+// providerMetadata is the part of the OpenID Connect discovery document in use.
+type providerMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// provider is an upstream OpenID Connect provider.
+// Its metadata and keys are retrieved on first use and cached.
+type provider struct {
+	name   string
+	conf   *ProviderConfig
+	client *http.Client
+
+	mtx    sync.Mutex // Protects the fields below
+	meta   *providerMetadata
+	keys   *jwt.KeyRegister
+	keysAt time.Time
+}
+
+func newProviders(c *FederationConfig) map[string]*provider {
+	client := &http.Client{Timeout: c.timeout()}
+	ps := make(map[string]*provider, len(c.Providers))
+	for name, pc := range c.Providers {
+		ps[name] = &provider{name: name, conf: pc, client: client}
+	}
+	return ps
+}
+
+// getJSON decodes the response from a GET request to url into v.
+func (p *provider) getJSON(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return &unavailableError{err}
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return &unavailableError{err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return &unavailableError{fmt.Errorf("%s: %s", url, resp.Status)}
+	}
+	if err = json.NewDecoder(io.LimitReader(resp.Body, maxProviderResponse)).Decode(v); err != nil {
+		return &unavailableError{err}
+	}
+	return nil
+}
+
+// metadata returns the discovery document of the provider.
+func (p *provider) metadata(ctx context.Context) (*providerMetadata, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.meta != nil {
+		return p.meta, nil
+	}
+
+	m := new(providerMetadata)
+	if err := p.getJSON(ctx, strings.TrimSuffix(p.conf.Issuer, "/")+"/.well-known/openid-configuration", m); err != nil {
+		return nil, err
+	}
+	switch {
+	case m.Issuer != p.conf.Issuer:
+		return nil, &unavailableError{fmt.Errorf("discovery: issuer %q does not match %q", m.Issuer, p.conf.Issuer)}
+	case m.AuthorizationEndpoint == "" || m.TokenEndpoint == "" || m.JWKSURI == "":
+		return nil, &unavailableError{errors.New("discovery: missing endpoints")}
+	}
+	p.meta = m
+	return m, nil
+}
+
+// keyRegister returns the signing keys of the provider.
+// When refresh is set, the keys are retrieved again,
+// unless that was already done less than keysRefreshMin ago.
+func (p *provider) keyRegister(ctx context.Context, refresh bool, now time.Time) (*jwt.KeyRegister, error) {
+	meta, err := p.metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.keys != nil && (!refresh || now.Sub(p.keysAt) < keysRefreshMin) {
+		return p.keys, nil
+	}
+
+	var set struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+	if err = p.getJSON(ctx, meta.JWKSURI, &set); err != nil {
+		return nil, err
+	}
+	keys := new(jwt.KeyRegister)
+	for _, k := range set.Keys {
+		var h struct {
+			Kty string `json:"kty"`
+			Use string `json:"use"`
+		}
+		// Symmetric keys would be accepted as HMAC secrets.
+		if json.Unmarshal(k, &h) != nil || h.Kty == "oct" || h.Use == "enc" {
+			continue
+		}
+		// Keys of unsupported types are skipped.
+		keys.LoadJWK(k)
+	}
+	p.keys, p.keysAt = keys, now
+	return keys, nil
+}
+
+// authURL returns the authorization URL at the provider, using PKCE.
+func (p *provider) authURL(meta *providerMetadata, redirectURI, state, nonce, verifier string) (string, error) {
+	u, err := url.Parse(meta.AuthorizationEndpoint)
+	if err != nil {
+		return "", &unavailableError{err}
+	}
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", p.conf.ClientID)
+	q.Set("redirect_uri", redirectURI)
+	q.Set("scope", strings.Join(p.conf.scopes(), " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", pkceChallenge(verifier))
+	q.Set("code_challenge_method", pkceS256)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// exchange the authorization code at the token endpoint of the provider.
+// It returns the ID token from the response.
+func (p *provider) exchange(ctx context.Context, code, redirectURI, verifier string) (string, error) {
+	meta, err := p.metadata(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{
+		"grant_type":    {grantAuthorizationCode},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"code_verifier": {verifier},
+	}
+	if p.conf.ClientSecret == "" {
+		form.Set("client_id", p.conf.ClientID)
+	}
+	req, err := http.NewRequest(http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", &unavailableError{err}
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.conf.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.conf.ClientID), url.QueryEscape(p.conf.ClientSecret))
+	}
+
+	resp, err := p.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return "", &unavailableError{err}
+	}
+	defer resp.Body.Close()
+
+	var tr struct {
+		IDToken     string `json:"id_token"`
+		Error       string `json:"error"`
+		Description string `json:"error_description"`
+	}
+	err = json.NewDecoder(io.LimitReader(resp.Body, maxProviderResponse)).Decode(&tr)
+	switch {
+	case resp.StatusCode >= 400 && resp.StatusCode < 500 && tr.Error != "":
+		return "", fmt.Errorf("token endpoint: %s: %s", tr.Error, tr.Description)
+	case resp.StatusCode != http.StatusOK:
+		return "", &unavailableError{fmt.Errorf("%s: %s", meta.TokenEndpoint, resp.Status)}
+	case err != nil:
+		return "", &unavailableError{err}
+	case tr.IDToken == "":
+		return "", errors.New("token endpoint: id_token missing")
+	}
+	return tr.IDToken, nil
+}
+
+// verifyIDToken checks the signature and claims of an ID token from the provider.
+func (p *provider) verifyIDToken(ctx context.Context, token, nonce string, now time.Time) (*jwt.Claims, error) {
+	keys, err := p.keyRegister(ctx, false, now)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := keys.Check([]byte(token))
+	if errors.Is(err, jwt.ErrSigMiss) {
+		// The provider might have rotated its keys.
+		if keys, err = p.keyRegister(ctx, true, now); err != nil {
+			return nil, err
+		}
+		claims, err = keys.Check([]byte(token))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case claims.Issuer != p.conf.Issuer:
+		return nil, fmt.Errorf("ID token: issuer %q", claims.Issuer)
+	case !containsString(claims.Audiences, p.conf.ClientID):
+		return nil, fmt.Errorf("ID token: audiences %v", claims.Audiences)
+	case claims.Expires == nil || !claims.Valid(now):
+		return nil, errors.New("ID token: expired")
+	case claims.Set[oidcNonce] != nonce:
+		return nil, errors.New("ID token: nonce mismatch")
+	case claims.Subject == "":
+		return nil, errors.New("ID token: subject missing")
+	}
+	return claims, nil
+}
+
+// providerError logs err from a provider and converts it to a status error.
+// msg is used when the provider is available, but rejected the request.
+func (rt *requestTx) providerError(err error, msg string) error {
+	var ue *unavailableError
+	if errors.As(err, &ue) {
+		rt.log.WithError(err).Error(errProviderUnavailable)
+		return status.Error(codes.Unavailable, errProviderUnavailable)
+	}
+	rt.log.WithError(err).Warn(msg)
+	return status.Error(codes.Unauthenticated, msg)
+}
+
+// useFederatedLogin deletes the pending login by its state, so that it can't be used again.
+// Unknown or expired states are rejected.
+func (rt *requestTx) useFederatedLogin(state string, now time.Time) (*models.FederatedLogin, error) {
+	if state == "" {
+		rt.log.Warn(errFederatedState)
+		return nil, status.Error(codes.Unauthenticated, errFederatedState)
+	}
+	m, err := models.FederatedLogins(
+		models.FederatedLoginWhere.StateHash.EQ(hashRefreshToken(state)),
+		qm.For("update"),
+	).One(rt.ctx, rt.tx)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		rt.log.Warn(errFederatedState)
+		return nil, status.Error(codes.Unauthenticated, errFederatedState)
+	case err != nil:
+		rt.log.WithError(err).Error("useFederatedLogin")
+		return nil, status.Error(codes.Internal, errDB)
+	}
+
+	if _, err = m.Delete(rt.ctx, rt.tx); err != nil {
+		rt.log.WithError(err).Error("useFederatedLogin")
+		return nil, status.Error(codes.Internal, errDB)
+	}
+	if !now.Before(m.ExpiresAt) {
+		rt.log.WithField("expires_at", m.ExpiresAt).Warn(errFederatedState)
+		return nil, status.Error(codes.Unauthenticated, errFederatedState)
+	}
+	return m, nil
+}
+
+// emailVerified reports if the e-mail address in claims is verified by the provider.
+// Some providers send the claim as a string.
+func emailVerified(claims *jwt.Claims) bool {
+	switch v := claims.Set[oidcEmailVerified].(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	}
+	return false
+}
+
+// federatedUser returns the user linked to the identity in claims.
+// Unknown identities are provisioned, if configured for the provider.
+func (rt *requestTx) federatedUser(p *provider, claims *jwt.Claims, now time.Time) (*models.User, error) {
+	rt.log = rt.log.WithField("subject", claims.Subject)
+
+	identity, err := models.UserIdentities(
+		models.UserIdentityWhere.Issuer.EQ(claims.Issuer),
+		models.UserIdentityWhere.Subject.EQ(claims.Subject),
+		qm.Load(models.UserIdentityRels.User),
+	).One(rt.ctx, rt.tx)
+	switch {
+	case err == nil:
+		identity.UpdatedAt = now
+		if _, err = identity.Update(rt.ctx, rt.tx, boil.Whitelist(models.UserIdentityColumns.UpdatedAt)); err != nil {
+			rt.log.WithError(err).Error("federatedUser")
+			return nil, status.Error(codes.Internal, errDB)
+		}
+		return identity.R.User, nil
+	case !errors.Is(err, sql.ErrNoRows):
+		rt.log.WithError(err).Error("federatedUser")
+		return nil, status.Error(codes.Internal, errDB)
+	}
+
+	return rt.provisionUser(p.conf.Provision, claims, now)
+}
+
+// provisionUser links the identity in claims to a new user,
+// or to an existing user with the same e-mail address when conf allows it.
+func (rt *requestTx) provisionUser(conf *ProvisionConfig, claims *jwt.Claims, now time.Time) (*models.User, error) {
+	if conf == nil {
+		rt.log.Warn(errIdentity)
+		return nil, status.Error(codes.PermissionDenied, errIdentity)
+	}
+	email, _ := claims.String(oidcEmail)
+	if email == "" || !emailVerified(claims) {
+		rt.log.WithField("email", email).Warn(errIdentityEmail)
+		return nil, status.Error(codes.PermissionDenied, errIdentityEmail)
+	}
+	rt.log = rt.log.WithField("email", email)
+
+	user, err := models.Users(models.UserWhere.Email.EQ(email)).One(rt.ctx, rt.tx)
+	switch {
+	case err == nil:
+		if !conf.LinkByEmail {
+			rt.log.Warn(errIdentityConflict)
+			return nil, status.Error(codes.PermissionDenied, errIdentityConflict)
+		}
+		if !user.VerifiedAt.Valid {
+			user.VerifiedAt = null.TimeFrom(now)
+			if _, err = user.Update(rt.ctx, rt.tx, boil.Whitelist(models.UserColumns.VerifiedAt, models.UserColumns.UpdatedAt)); err != nil {
+				rt.log.WithError(err).Error("provisionUser")
+				return nil, status.Error(codes.Internal, errDB)
+			}
+		}
+	case errors.Is(err, sql.ErrNoRows):
+		user = &models.User{
+			Email:      email,
+			VerifiedAt: null.TimeFrom(now),
+		}
+		user.Name, _ = claims.String(oidcName)
+		if err = user.Insert(rt.ctx, rt.tx, boil.Infer()); err != nil {
+			rt.log.WithError(err).Error("provisionUser")
+			return nil, status.Error(codes.Internal, errDB)
+		}
+	default:
+		rt.log.WithError(err).Error("provisionUser")
+		return nil, status.Error(codes.Internal, errDB)
+	}
+
+	identity := &models.UserIdentity{
+		Issuer:  claims.Issuer,
+		Subject: claims.Subject,
+	}
+	if err = user.AddUserIdentities(rt.ctx, rt.tx, true, identity); err != nil {
+		rt.log.WithError(err).Error("provisionUser")
+		return nil, status.Error(codes.Internal, errDB)
+	}
+	rt.log.WithField("user_id", user.ID).Info("provisionUser")
+	return user, nil
+}
+
+// matchValue reports if the claim value v equals want.
+// Arrays match when any of their elements matches.
+func matchValue(v interface{}, want string) bool {
+	switch v := v.(type) {
+	case string:
+		return v == want
+	case bool:
+		return strconv.FormatBool(v) == want
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64) == want
+	case []interface{}:
+		for _, e := range v {
+			if matchValue(e, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchRule reports if the claims set satisfies rule.
+func matchRule(rule ProvisionRule, set map[string]interface{}) bool {
+	v, ok := set[rule.Claim]
+	if !ok {
+		return false
+	}
+	return rule.Value == "" || matchValue(v, rule.Value)
+}
+
+// applyProvisionRules adds the groups and audiences of all matching rules to user.
+func (rt *requestTx) applyProvisionRules(user *models.User, conf *ProvisionConfig, claims *jwt.Claims) error {
+	if conf == nil {
+		return nil
+	}
+	var groups, audiences []string
+	for _, r := range conf.Rules {
+		if matchRule(r, claims.Set) {
+			groups = append(groups, r.Groups...)
+			audiences = append(audiences, r.Audiences...)
+		}
+	}
+	if len(groups) == 0 && len(audiences) == 0 {
+		return nil
+	}
+
+	u, err := models.Users(
+		models.UserWhere.ID.EQ(user.ID),
+		qm.Load(models.UserRels.Groups),
+		qm.Load(models.UserRels.Audiences),
+	).One(rt.ctx, rt.tx)
+	if err != nil {
+		rt.log.WithError(err).Error("applyProvisionRules")
+		return status.Error(codes.Internal, errDB)
+	}
+	if err = rt.addUserGroups(u, groups); err == nil {
+		err = rt.addUserAudiences(u, audiences)
+	}
+	if status.Code(err) == codes.NotFound {
+		rt.log.WithError(err).Error(errProvisionRule)
+		return status.Error(codes.Internal, errProvisionRule)
+	}
+	return err
+}
+
+func (s *authServer) BeginFederatedLogin(ctx context.Context, fr *auth.FederatedRequest) (*auth.FederatedRedirect, error) {
+	rt, err := s.newTx(ctx, "BeginFederatedLogin", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	rt.log = rt.log.WithField("provider", fr.GetProvider())
+	p, ok := s.providers[fr.GetProvider()]
+	if !ok {
+		rt.log.Warn(errProvider)
+		return nil, status.Error(codes.NotFound, errProvider)
+	}
+	if fr.GetRedirectUri() == "" {
+		rt.log.Warn(errMissingRedirectURI)
+		return nil, status.Error(codes.InvalidArgument, errMissingRedirectURI)
+	}
+
+	meta, err := p.metadata(rt.ctx)
+	if err != nil {
+		return nil, rt.providerError(err, errProviderUnavailable)
+	}
+
+	var params [3]string
+	for i, n := range []int{federatedStateLen, federatedNonceLen, federatedVerifierLen} {
+		if params[i], err = randomString(s.readRand, n); err != nil {
+			rt.log.WithError(err).Error("randomString")
+			return nil, status.Error(codes.Internal, errFatal)
+		}
+	}
+	state, nonce, verifier := params[0], params[1], params[2]
+
+	u, err := p.authURL(meta, fr.GetRedirectUri(), state, nonce, verifier)
+	if err != nil {
+		return nil, rt.providerError(err, errProviderUnavailable)
+	}
+
+	m := &models.FederatedLogin{
+		Provider:     p.name,
+		StateHash:    hashRefreshToken(state),
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		RedirectURI:  fr.GetRedirectUri(),
+		ExpiresAt:    time.Now().Add(s.conf.Federation.expiry()),
+	}
+	if err = m.Insert(rt.ctx, rt.tx, boil.Infer()); err != nil {
+		rt.log.WithError(err).Error("BeginFederatedLogin")
+		return nil, status.Error(codes.Internal, errDB)
+	}
+	if err = rt.commit(); err != nil {
+		return nil, err
+	}
+	return &auth.FederatedRedirect{Url: u, State: state}, nil
+}
+
+func (s *authServer) FinishFederatedLogin(ctx context.Context, cb *auth.FederatedCallback) (*auth.AuthReply, error) {
+	rt, err := s.newTx(ctx, "FinishFederatedLogin", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	fl, err := rt.useFederatedLogin(cb.GetState(), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	rt.log = rt.log.WithField("provider", fl.Provider)
+	p, ok := s.providers[fl.Provider]
+	if !ok {
+		rt.log.Warn(errProvider)
+		return nil, status.Error(codes.Unauthenticated, errFederatedState)
+	}
+
+	token, err := p.exchange(rt.ctx, cb.GetCode(), fl.RedirectURI, fl.CodeVerifier)
+	if err != nil {
+		return nil, rt.providerError(err, errProviderCode)
+	}
+	now := time.Now()
+	claims, err := p.verifyIDToken(rt.ctx, token, fl.Nonce, now)
+	if err != nil {
+		return nil, rt.providerError(err, errIDToken)
+	}
+
+	user, err := rt.federatedUser(p, claims, now)
+	if err != nil {
+		return nil, err
+	}
+	if err = rt.applyProvisionRules(user, p.conf.Provision, claims); err != nil {
+		return nil, err
+	}
+
+	required, err := rt.mfaRequired(user)
+	if err != nil {
+		return nil, err
+	}
+	if required {
+		return rt.mfaAuthReply(user, now)
+	}
+	return rt.refreshAuthReply(user, "", now)
+}
+
+// pruneFederatedLogins deletes pending logins which expired before now.
+func (s *authServer) pruneFederatedLogins(ctx context.Context, now time.Time) (int64, error) {
+	db, err := s.mdb.Master(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return models.FederatedLogins(models.FederatedLoginWhere.ExpiresAt.LT(now)).DeleteAll(ctx, db)
+}
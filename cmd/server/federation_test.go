@@ -0,0 +1,389 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	auth "github.com/moapis/authenticator"
+	"github.com/moapis/authenticator/models"
+	"github.com/pascaldekloe/jwt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	stubCode     = "stub-code"
+	stubClientID = "authenticator"
+	stubSecret   = "stub-secret"
+)
+
+// stubIdP is a minimal OpenID Connect provider.
+type stubIdP struct {
+	*httptest.Server
+	key       *rsa.PrivateKey
+	challenge string     // PKCE code challenge from the last authorization URL
+	claims    jwt.Claims // Claims of the next ID token
+}
+
+func newStubIdP(t *testing.T) *stubIdP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idp := &stubIdP{key: key}
+	b64 := base64.RawURLEncoding.EncodeToString
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&providerMetadata{
+			Issuer:                idp.URL,
+			AuthorizationEndpoint: idp.URL + "/authorize",
+			TokenEndpoint:         idp.URL + "/token",
+			JWKSURI:               idp.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string][]map[string]string{"keys": {
+			{"kty": "oct", "k": b64([]byte(stubSecret))},
+			{"kty": "RSA", "use": "sig", "kid": "stub", "n": b64(key.N.Bytes()), "e": b64(big.NewInt(int64(key.E)).Bytes())},
+		}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.PostFormValue("code") != stubCode || !verifyPKCE(idp.challenge, r.PostFormValue("code_verifier")) {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "stub",
+			"token_type":   "Bearer",
+			"id_token":     idp.sign(t, idp.claims),
+		})
+	})
+	idp.Server = httptest.NewServer(mux)
+	return idp
+}
+
+func (idp *stubIdP) sign(t *testing.T, c jwt.Claims) string {
+	c.KeyID = "stub"
+	tkn, err := c.RSASign(jwt.RS256, idp.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(tkn)
+}
+
+// idClaims returns valid ID token claims, with the claims from set added.
+func (idp *stubIdP) idClaims(subject, nonce string, set map[string]interface{}) jwt.Claims {
+	now := time.Now().Round(time.Second)
+	c := jwt.Claims{Set: map[string]interface{}{oidcNonce: nonce}}
+	for k, v := range set {
+		c.Set[k] = v
+	}
+	c.Issuer = idp.URL
+	c.Subject = subject
+	c.Audiences = []string{stubClientID}
+	c.Issued = jwt.NewNumericTime(now)
+	c.Expires = jwt.NewNumericTime(now.Add(time.Minute))
+	return c
+}
+
+func Test_matchRule(t *testing.T) {
+	set := map[string]interface{}{
+		"hd":     "example.com",
+		"admin":  true,
+		"level":  float64(3),
+		"groups": []interface{}{"staff", "dev"},
+	}
+	tests := []struct {
+		name string
+		rule ProvisionRule
+		want bool
+	}{
+		{"Missing claim", ProvisionRule{Claim: "foo"}, false},
+		{"Any value", ProvisionRule{Claim: "hd"}, true},
+		{"String", ProvisionRule{Claim: "hd", Value: "example.com"}, true},
+		{"String mismatch", ProvisionRule{Claim: "hd", Value: "example.org"}, false},
+		{"Bool", ProvisionRule{Claim: "admin", Value: "true"}, true},
+		{"Number", ProvisionRule{Claim: "level", Value: "3"}, true},
+		{"Array element", ProvisionRule{Claim: "groups", Value: "dev"}, true},
+		{"Array mismatch", ProvisionRule{Claim: "groups", Value: "ops"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchRule(tt.rule, set); got != tt.want {
+				t.Errorf("matchRule() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_provider(t *testing.T) {
+	ctx := context.Background()
+	idp := newStubIdP(t)
+	defer idp.Close()
+
+	p := newProviders(&FederationConfig{Providers: map[string]*ProviderConfig{
+		"stub": {Issuer: idp.URL, ClientID: stubClientID},
+	}})["stub"]
+
+	meta, err := p.metadata(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := p.authURL(meta, "https://login.example.com/cb", "xyz", "n-0S6", testVerifier)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "response_type=code&client_id=authenticator&redirect_uri=https%3A%2F%2Flogin.example.com%2Fcb&scope=openid+email+profile&state=xyz&nonce=n-0S6&code_challenge=" + testChallenge(testVerifier) + "&code_challenge_method=S256"
+	wantQ, _ := url.ParseQuery(want)
+	gotU, _ := url.Parse(u)
+	if gotU.Path != "/authorize" || gotU.Query().Encode() != wantQ.Encode() {
+		t.Errorf("provider.authURL() = %v, want query %v", u, want)
+	}
+
+	idp.challenge = testChallenge(testVerifier)
+	idp.claims = idp.idClaims("alice", "n-0S6", nil)
+	exchangeTests := []struct {
+		name     string
+		code     string
+		verifier string
+		wantErr  bool
+	}{
+		{"Valid", stubCode, testVerifier, false},
+		{"Invalid code", "foo", testVerifier, true},
+		{"Invalid verifier", stubCode, "foo", true},
+	}
+	for _, tt := range exchangeTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := p.exchange(ctx, tt.code, "https://login.example.com/cb", tt.verifier)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("provider.exchange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var ue *unavailableError
+			if errors.As(err, &ue) {
+				t.Errorf("provider.exchange() error = %v, provider is available", err)
+			}
+			if !tt.wantErr && got == "" {
+				t.Errorf("provider.exchange() returned empty ID token")
+			}
+		})
+	}
+
+	expired := idp.idClaims("alice", "n-0S6", nil)
+	expired.Expires = jwt.NewNumericTime(time.Now().Add(-time.Minute))
+	noExpiry := idp.idClaims("alice", "n-0S6", nil)
+	noExpiry.Expires = nil
+	otherIssuer := idp.idClaims("alice", "n-0S6", nil)
+	otherIssuer.Issuer = "https://evil.com"
+	otherAudience := idp.idClaims("alice", "n-0S6", nil)
+	otherAudience.Audiences = []string{"other"}
+	noAudience := idp.idClaims("alice", "n-0S6", nil)
+	noAudience.Audiences = nil
+
+	hmacClaims := idp.idClaims("alice", "n-0S6", nil)
+	hmacToken, err := hmacClaims.HMACSign(jwt.HS256, []byte(stubSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKeyClaims := idp.idClaims("alice", "n-0S6", nil)
+	otherKeyToken, err := otherKeyClaims.RSASign(jwt.RS256, otherKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verifyTests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{"Valid", idp.sign(t, idp.idClaims("alice", "n-0S6", nil)), false},
+		{"Nonce", idp.sign(t, idp.idClaims("alice", "other", nil)), true},
+		{"Subject", idp.sign(t, idp.idClaims("", "n-0S6", nil)), true},
+		{"Expired", idp.sign(t, expired), true},
+		{"No expiry", idp.sign(t, noExpiry), true},
+		{"Issuer", idp.sign(t, otherIssuer), true},
+		{"Audience", idp.sign(t, otherAudience), true},
+		{"No audience", idp.sign(t, noAudience), true},
+		{"Symmetric key", string(hmacToken), true},
+		{"Unknown key", string(otherKeyToken), true},
+	}
+	for _, tt := range verifyTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := p.verifyIDToken(ctx, tt.token, "n-0S6", time.Now())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("provider.verifyIDToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.Subject != "alice" {
+				t.Errorf("provider.verifyIDToken() subject = %v, want alice", got.Subject)
+			}
+		})
+	}
+
+	down := newProviders(&FederationConfig{Providers: map[string]*ProviderConfig{
+		"down": {Issuer: "http://127.0.0.1:1", ClientID: stubClientID},
+	}})["down"]
+	var ue *unavailableError
+	if _, err = down.metadata(ctx); !errors.As(err, &ue) {
+		t.Errorf("provider.metadata() error = %v, want unavailableError", err)
+	}
+}
+
+func Test_authServer_federatedLogin(t *testing.T) {
+	idp := newStubIdP(t)
+	defer idp.Close()
+
+	conf := &FederationConfig{Providers: map[string]*ProviderConfig{
+		"strict": {Issuer: idp.URL, ClientID: stubClientID},
+		"stub": {Issuer: idp.URL, ClientID: stubClientID, ClientSecret: "secret", Provision: &ProvisionConfig{
+			Rules: []ProvisionRule{{Claim: "groups", Value: "staff", Groups: []string{"user"}, Audiences: []string{"aud1"}}},
+		}},
+		"linking": {Issuer: idp.URL, ClientID: stubClientID, Provision: &ProvisionConfig{LinkByEmail: true}},
+	}}
+	tas.conf.Federation, tas.providers = conf, newProviders(conf)
+	defer func() {
+		tas.conf.Federation, tas.providers = nil, nil
+		db, err := tas.mdb.Master(testCtx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = models.UserIdentities().DeleteAll(testCtx, db); err != nil {
+			t.Error(err)
+		}
+		if _, err = models.Users(models.UserWhere.Email.EQ("alice@example.com")).DeleteAll(testCtx, db); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	beginTests := []struct {
+		name     string
+		provider string
+		redirect string
+		code     codes.Code
+	}{
+		{"Unknown provider", "foo", "https://login.example.com/cb", codes.NotFound},
+		{"Missing redirect", "stub", "", codes.InvalidArgument},
+		{"Valid", "stub", "https://login.example.com/cb", codes.OK},
+	}
+	for _, tt := range beginTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tas.BeginFederatedLogin(testCtx, &auth.FederatedRequest{Provider: tt.provider, RedirectUri: tt.redirect})
+			if status.Code(err) != tt.code {
+				t.Errorf("authServer.BeginFederatedLogin() error = %v, want %v", err, tt.code)
+			}
+		})
+	}
+
+	// begin a login at provider and return the state and nonce.
+	begin := func(t *testing.T, provider string) (string, string) {
+		reply, err := tas.BeginFederatedLogin(testCtx, &auth.FederatedRequest{Provider: provider, RedirectUri: "https://login.example.com/cb"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		u, err := url.Parse(reply.GetUrl())
+		if err != nil {
+			t.Fatal(err)
+		}
+		q := u.Query()
+		if q.Get("state") != reply.GetState() {
+			t.Fatalf("authServer.BeginFederatedLogin() state = %v, URL %v", reply.GetState(), u)
+		}
+		idp.challenge = q.Get("code_challenge")
+		return reply.GetState(), q.Get("nonce")
+	}
+
+	var reused string
+	tests := []struct {
+		name     string
+		provider string
+		state    func(string) string
+		code     string
+		nonce    string
+		subject  string
+		set      map[string]interface{}
+		want     codes.Code
+		wantSub  string
+	}{
+		{"Invalid state", "stub", func(string) string { return "foo" }, stubCode, "", "alice", nil, codes.Unauthenticated, ""},
+		{"Invalid code", "stub", nil, "foo", "", "alice", nil, codes.Unauthenticated, ""},
+		{"Invalid nonce", "stub", nil, stubCode, "foo", "alice", nil, codes.Unauthenticated, ""},
+		{
+			"Not provisioned", "strict", nil, stubCode, "", "alice",
+			map[string]interface{}{oidcEmail: "alice@example.com", oidcEmailVerified: true},
+			codes.PermissionDenied, "",
+		},
+		{
+			"Unverified email", "stub", nil, stubCode, "", "alice",
+			map[string]interface{}{oidcEmail: "alice@example.com", oidcEmailVerified: false},
+			codes.PermissionDenied, "",
+		},
+		{
+			"Existing email", "stub", nil, stubCode, "", "alice",
+			map[string]interface{}{oidcEmail: "one@group.com", oidcEmailVerified: true},
+			codes.PermissionDenied, "",
+		},
+		{
+			"Provisioned", "stub", func(s string) string { reused = s; return s }, stubCode, "", "alice",
+			map[string]interface{}{oidcEmail: "alice@example.com", oidcEmailVerified: "true", oidcName: "Alice", "groups": []string{"staff"}},
+			codes.OK, "alice@example.com",
+		},
+		{"Reused state", "stub", func(string) string { return reused }, stubCode, "", "alice", nil, codes.Unauthenticated, ""},
+		{"Linked identity", "strict", nil, stubCode, "", "alice", nil, codes.OK, "alice@example.com"},
+		{
+			"Linked by email", "linking", nil, stubCode, "", "bob",
+			map[string]interface{}{oidcEmail: "one@group.com", oidcEmailVerified: true},
+			codes.OK, "one@group.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, nonce := begin(t, tt.provider)
+			if tt.state != nil {
+				state = tt.state(state)
+			}
+			if tt.nonce != "" {
+				nonce = tt.nonce
+			}
+			idp.claims = idp.idClaims(tt.subject, nonce, tt.set)
+
+			got, err := tas.FinishFederatedLogin(testCtx, &auth.FederatedCallback{State: state, Code: tt.code})
+			if status.Code(err) != tt.want {
+				t.Fatalf("authServer.FinishFederatedLogin() error = %v, want %v", err, tt.want)
+			}
+			if err != nil {
+				return
+			}
+			claims, err := jwt.EdDSACheck([]byte(got.GetJwt()), []byte(testPubKey))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if claims.Subject != tt.wantSub || got.GetRefreshToken() == "" {
+				t.Errorf("authServer.FinishFederatedLogin() = %v, want subject %v", claims, tt.wantSub)
+			}
+			if tt.wantSub == "alice@example.com" {
+				groups, _ := claims.Set[jwtGroups].([]interface{})
+				if len(groups) != 1 || groups[0] != "user" || !claims.AcceptAudience("aud1") {
+					t.Errorf("authServer.FinishFederatedLogin() groups = %v, audiences = %v", groups, claims.Audiences)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moapis/authenticator/models"
+	"github.com/pascaldekloe/jwt"
+	"github.com/sirupsen/logrus"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Well-known paths served by the HTTP server
+const (
+	JWKSPath      = "/.well-known/jwks.json"
+	DiscoveryPath = "/.well-known/openid-configuration"
+)
+
+// OAuth 2.0 endpoint paths of the HTTP forms, as served by httpauth.
+const (
+	formsAuthorizePath = "/authorize"
+	formsTokenPath     = "/token"
+	formsUserInfoPath  = "/userinfo"
+)
+
+// JWK is a JSON Web Key, as defined in RFC 7517 and RFC 8037.
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+}
+
+// JWKS is a JSON Web Key Set.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+func newJWK(key *models.JWTKey) JWK {
+	return JWK{
+		Kty: "OKP",
+		Crv: "Ed25519",
+		X:   base64.RawURLEncoding.EncodeToString(key.PublicKey),
+		Kid: strconv.Itoa(key.ID),
+		Use: "sig",
+		Alg: jwt.EdDSA,
+	}
+}
+
+// validKeysQuery selects all keys that expired less than a token lifetime ago.
+// Keys without expiry are selected if they have no successor older than the token lifetime.
+// Such keys might still have issued tokens which did not expire yet.
+const validKeysQuery = `expires_at >= ? or (expires_at is null and not exists (
+	select 1 from auth.jwt_keys n
+	where n.id > jwt_keys.id and n.created_at < ?
+))`
+
+// validKeys returns the public keys which can still verify unexpired tokens,
+// ordered by ID.
+func (rt *requestTx) validKeys(now time.Time) (models.JWTKeySlice, error) {
+	cutoff := now.Add(-rt.s.conf.JWT.Expiry)
+	keys, err := models.JWTKeys(
+		qm.Where(validKeysQuery, cutoff, cutoff),
+		qm.OrderBy(models.JWTKeyColumns.ID),
+	).All(rt.ctx, rt.tx)
+	if err != nil {
+		rt.log.WithError(err).Error("validKeys")
+		return nil, status.Error(codes.Internal, errDB)
+	}
+	rt.log.WithField("keys", len(keys)).Debug("validKeys")
+	return keys, nil
+}
+
+// DiscoveryDocument is a subset of the OpenID provider metadata.
+// The OAuth 2.0 endpoints are served by the HTTP forms, at FormsURL.
+type DiscoveryDocument struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	UserInfoEndpoint      string   `json:"userinfo_endpoint"`
+	JWKSURI               string   `json:"jwks_uri"`
+	Scopes                []string `json:"scopes_supported"`
+	ResponseTypes         []string `json:"response_types_supported"`
+	GrantTypes            []string `json:"grant_types_supported"`
+	SubjectTypes          []string `json:"subject_types_supported"`
+	SigningAlgorithms     []string `json:"id_token_signing_alg_values_supported"`
+	TokenAuthMethods      []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethods  []string `json:"code_challenge_methods_supported"`
+	Claims                []string `json:"claims_supported"`
+}
+
+func (s *authServer) discovery() *DiscoveryDocument {
+	forms := strings.TrimSuffix(s.conf.HTTP.FormsURL, "/")
+	return &DiscoveryDocument{
+		Issuer:                s.conf.JWT.Issuer,
+		AuthorizationEndpoint: fmt.Sprint(forms, formsAuthorizePath),
+		TokenEndpoint:         fmt.Sprint(forms, formsTokenPath),
+		UserInfoEndpoint:      fmt.Sprint(forms, formsUserInfoPath),
+		JWKSURI:               fmt.Sprint(s.conf.HTTP.PublicURL, JWKSPath),
+		Scopes:                []string{scopeOpenID, scopeProfile, scopeEmail},
+		ResponseTypes:         []string{"code"},
+		GrantTypes:            []string{grantAuthorizationCode, grantRefreshToken, grantClientCredentials},
+		SubjectTypes:          []string{"public"},
+		SigningAlgorithms:     []string{jwt.EdDSA},
+		TokenAuthMethods:      []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethods:  []string{pkceS256},
+		Claims: []string{
+			"iss", "sub", "aud", "exp", "iat",
+			oidcAuthTime, oidcNonce, oidcName, oidcEmail, oidcEmailVerified,
+		},
+	}
+}
+
+func writeJSON(log *logrus.Entry, w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.WithError(err).Warn("Write to client")
+	}
+}
+
+func (s *authServer) jwksHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Add("Allow", "GET, HEAD")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.conf.HTTP.Timeout)
+	defer cancel()
+
+	rt, err := s.newTx(ctx, "JWKS", true)
+	if err != nil {
+		http.Error(w, errDB, http.StatusInternalServerError)
+		return
+	}
+	defer rt.done()
+
+	keys, err := rt.validKeys(time.Now())
+	if err != nil {
+		http.Error(w, errDB, http.StatusInternalServerError)
+		return
+	}
+
+	set := JWKS{Keys: make([]JWK, len(keys))}
+	for i, k := range keys {
+		set.Keys[i] = newJWK(k)
+	}
+
+	// The set only changes when a new key is added,
+	// so the newest key identifies the version of the set.
+	var modified time.Time
+	maxAge := s.conf.HTTP.MaxAge
+	if n := len(keys); n > 0 {
+		newest := keys[n-1]
+		modified = newest.CreatedAt
+		w.Header().Set("ETag", fmt.Sprintf(`"%d"`, newest.ID))
+
+		// Don't let clients cache beyond the next rotation.
+		if newest.ExpiresAt.Valid {
+			if next := time.Until(newest.ExpiresAt.Time); next < maxAge {
+				maxAge = next
+			}
+		}
+	}
+	if maxAge < 0 {
+		maxAge = 0
+	}
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds())))
+
+	b, err := json.Marshal(set)
+	if err != nil {
+		rt.log.WithError(err).Error("Marshal JWKS")
+		http.Error(w, errFatal, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+
+	// ServeContent handles conditional requests against the ETag and modification time.
+	http.ServeContent(w, r, "", modified, bytes.NewReader(b))
+}
+
+func (s *authServer) discoveryHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Add("Allow", "GET, HEAD")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(s.conf.HTTP.MaxAge.Seconds())))
+	writeJSON(s.log.WithField("method", "Discovery"), w, s.discovery())
+}
+
+func (s *authServer) httpHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(JWKSPath, s.jwksHandler)
+	mux.HandleFunc(DiscoveryPath, s.discoveryHandler)
+	return mux
+}
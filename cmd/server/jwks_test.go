@@ -0,0 +1,188 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/moapis/authenticator/models"
+)
+
+func Test_newJWK(t *testing.T) {
+	want := JWK{
+		Kty: "OKP",
+		Crv: "Ed25519",
+		X:   "2vlXFPxj4uUbK2mjCr5rKCQeGMZqLyqIr6dY3Yl81rM",
+		Kid: "10",
+		Use: "sig",
+		Alg: "EdDSA",
+	}
+	got := newJWK(&models.JWTKey{ID: 10, PublicKey: []byte(testPubKey)})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("newJWK() = %v, want %v", got, want)
+	}
+}
+
+func Test_requestTx_validKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			"Success",
+			false,
+		},
+		{
+			"DB error",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt, err := tas.newTx(testCtx, "testing", true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer rt.done()
+			if tt.wantErr {
+				rt.done()
+			}
+
+			got, err := rt.validKeys(time.Now())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("requestTx.validKeys() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			var found bool
+			for _, k := range got {
+				if k.ID == 10 {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("requestTx.validKeys() = %v, want key ID 10", got)
+			}
+		})
+	}
+}
+
+func Test_authServer_jwksHandler(t *testing.T) {
+	w := httptest.NewRecorder()
+	tas.httpHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, JWKSPath, nil))
+	etag := w.Result().Header.Get("ETag")
+
+	tests := []struct {
+		name     string
+		method   string
+		header   http.Header
+		wantCode int
+	}{
+		{
+			"Method not allowed",
+			http.MethodPost,
+			nil,
+			http.StatusMethodNotAllowed,
+		},
+		{
+			"Success",
+			http.MethodGet,
+			nil,
+			http.StatusOK,
+		},
+		{
+			"ETag changed",
+			http.MethodGet,
+			http.Header{"If-None-Match": {`"1"`}},
+			http.StatusOK,
+		},
+		{
+			"ETag not modified",
+			http.MethodGet,
+			http.Header{"If-None-Match": {etag}},
+			http.StatusNotModified,
+		},
+		{
+			"Not modified since",
+			http.MethodGet,
+			http.Header{"If-Modified-Since": {time.Now().UTC().Format(http.TimeFormat)}},
+			http.StatusNotModified,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, JWKSPath, nil)
+			for k, v := range tt.header {
+				req.Header[k] = v
+			}
+			w := httptest.NewRecorder()
+			tas.httpHandler().ServeHTTP(w, req)
+
+			resp := w.Result()
+			if resp.StatusCode != tt.wantCode {
+				t.Fatalf("authServer.jwksHandler() status = %v, want %v", resp.StatusCode, tt.wantCode)
+			}
+			if tt.wantCode == http.StatusMethodNotAllowed {
+				if got := resp.Header.Get("Allow"); got != "GET, HEAD" {
+					t.Errorf("authServer.jwksHandler() Allow = %v, want %v", got, "GET, HEAD")
+				}
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			if resp.Header.Get("ETag") == "" || resp.Header.Get("Last-Modified") == "" || resp.Header.Get("Cache-Control") == "" {
+				t.Errorf("authServer.jwksHandler() headers = %v, want ETag, Last-Modified and Cache-Control", resp.Header)
+			}
+
+			var set JWKS
+			if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+				t.Fatal(err)
+			}
+			if len(set.Keys) == 0 {
+				t.Errorf("authServer.jwksHandler() = %v, want keys", set)
+			}
+		})
+	}
+}
+
+func Test_authServer_discoveryHandler(t *testing.T) {
+	w := httptest.NewRecorder()
+	tas.httpHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, DiscoveryPath, nil))
+
+	resp := w.Result()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("authServer.discoveryHandler() status = %v, want %v", resp.StatusCode, http.StatusOK)
+	}
+
+	var got DiscoveryDocument
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	want := DiscoveryDocument{
+		Issuer:                "localhost",
+		AuthorizationEndpoint: "http://localhost:1235/authorize",
+		TokenEndpoint:         "http://localhost:1235/token",
+		UserInfoEndpoint:      "http://localhost:1235/userinfo",
+		JWKSURI:               "http://localhost:8766/.well-known/jwks.json",
+		Scopes:                []string{"openid", "profile", "email"},
+		ResponseTypes:         []string{"code"},
+		GrantTypes:            []string{"authorization_code", "refresh_token", "client_credentials"},
+		SubjectTypes:          []string{"public"},
+		SigningAlgorithms:     []string{"EdDSA"},
+		TokenAuthMethods:      []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethods:  []string{"S256"},
+		Claims:                []string{"iss", "sub", "aud", "exp", "iat", "auth_time", "nonce", "name", "email", "email_verified"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("authServer.discoveryHandler() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/moapis/authenticator/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errLDAPCredentials is returned when the directory rejects the password,
+// or does not know the user.
+var errLDAPCredentials = errors.New("LDAP: invalid credentials")
+
+// ldapGroup is a group DN mapped to a local group.
+type ldapGroup struct {
+	dn   *ldap.DN
+	name string
+}
+
+// ldapBackend verifies passwords by binding to an LDAP directory,
+// such as Active Directory.
+type ldapBackend struct {
+	conf   *LDAPConfig
+	tls    *tls.Config
+	groups []ldapGroup
+}
+
+func newLDAPBackend(c *LDAPConfig) (*ldapBackend, error) {
+	if c == nil {
+		return nil, errLDAPConfig
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	tc, err := c.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	b := &ldapBackend{conf: c, tls: tc}
+	for dn, name := range c.Groups {
+		parsed, err := ldap.ParseDN(dn)
+		if err != nil {
+			return nil, fmt.Errorf("LDAP: group %q: %w", dn, err)
+		}
+		b.groups = append(b.groups, ldapGroup{parsed, name})
+	}
+	return b, nil
+}
+
+// newLDAPBackends returns the credential backends for conf, by name.
+func newLDAPBackends(conf map[string]*LDAPConfig) (map[string]credentialBackend, error) {
+	backends := make(map[string]credentialBackend, len(conf))
+	for name, c := range conf {
+		if name == localBackendName {
+			return nil, errLDAPLocal
+		}
+		b, err := newLDAPBackend(c)
+		if err != nil {
+			return nil, err
+		}
+		backends[name] = b
+	}
+	return backends, nil
+}
+
+// escapeDN escapes s for use as an attribute value in a DN, as in RFC 4514.
+func escapeDN(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		switch {
+		case r == 0:
+			b.WriteString(`\00`)
+			continue
+		case strings.ContainsRune(`"+,;<=>\`, r),
+			r == '#' && i == 0,
+			r == ' ' && (i == 0 || i == len(s)-1):
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// dial connects to the directory, upgrading the connection when StartTLS is set.
+// Errors are of type unavailableError.
+func (b *ldapBackend) dial() (*ldap.Conn, error) {
+	conn, err := ldap.DialURL(b.conf.URL,
+		ldap.DialWithDialer(&net.Dialer{Timeout: b.conf.timeout()}),
+		ldap.DialWithTLSConfig(b.tls),
+	)
+	if err != nil {
+		return nil, &unavailableError{err}
+	}
+	conn.SetTimeout(b.conf.timeout())
+
+	if b.conf.StartTLS {
+		if err = conn.StartTLS(b.tls); err != nil {
+			conn.Close()
+			return nil, &unavailableError{err}
+		}
+	}
+	return conn, nil
+}
+
+// findUser returns the single entry of the user with email under BaseDN,
+// with its group attribute.
+func (b *ldapBackend) findUser(conn *ldap.Conn, email string) (*ldap.Entry, error) {
+	res, err := conn.Search(ldap.NewSearchRequest(
+		b.conf.BaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, 0, false,
+		strings.ReplaceAll(b.conf.filter(), "%s", ldap.EscapeFilter(email)),
+		[]string{b.conf.groupAttribute()}, nil,
+	))
+	switch {
+	case ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded):
+		return nil, fmt.Errorf("%w: more than one entry for %s", errLDAPCredentials, email)
+	case err != nil:
+		return nil, &unavailableError{err}
+	case len(res.Entries) != 1:
+		return nil, fmt.Errorf("%w: %d entries for %s", errLDAPCredentials, len(res.Entries), email)
+	}
+	return res.Entries[0], nil
+}
+
+// readEntry returns the entry of dn, with its group attribute.
+func (b *ldapBackend) readEntry(conn *ldap.Conn, dn string) (*ldap.Entry, error) {
+	res, err := conn.Search(ldap.NewSearchRequest(
+		dn, ldap.ScopeBaseObject, ldap.NeverDerefAliases, 1, 0, false,
+		"(objectClass=*)", []string{b.conf.groupAttribute()}, nil,
+	))
+	if err != nil {
+		return nil, &unavailableError{err}
+	}
+	if len(res.Entries) != 1 {
+		return nil, &unavailableError{fmt.Errorf("LDAP: no entry for %s", dn)}
+	}
+	return res.Entries[0], nil
+}
+
+// authenticate binds to the directory as the user with email and password.
+// It returns the group DNs of the user, when any groups are mapped.
+// errLDAPCredentials is returned when the directory rejects the credentials,
+// an unavailableError when it could not be used.
+func (b *ldapBackend) authenticate(email, password string) ([]string, error) {
+	conn, err := b.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var entry *ldap.Entry
+	dn := strings.ReplaceAll(b.conf.UserDN, "%s", escapeDN(email))
+	if b.conf.UserDN == "" {
+		if b.conf.BindDN != "" {
+			if err = conn.Bind(b.conf.BindDN, b.conf.BindPassword); err != nil {
+				return nil, &unavailableError{err}
+			}
+		}
+		if entry, err = b.findUser(conn, email); err != nil {
+			return nil, err
+		}
+		dn = entry.DN
+	}
+
+	if err = conn.Bind(dn, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, fmt.Errorf("%w: %v", errLDAPCredentials, err)
+		}
+		return nil, &unavailableError{err}
+	}
+	if len(b.groups) == 0 {
+		return nil, nil
+	}
+
+	// After binding as the user, the entry is read with its own permissions.
+	// Directories accepting other names than DNs for binding,
+	// such as Active Directory with user principal names, need a BaseDN.
+	if entry == nil {
+		if b.conf.BaseDN != "" {
+			entry, err = b.findUser(conn, email)
+		} else {
+			entry, err = b.readEntry(conn, dn)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return entry.GetAttributeValues(b.conf.groupAttribute()), nil
+}
+
+// mappedGroups returns the names of all mapped local groups.
+func (b *ldapBackend) mappedGroups() []string {
+	names := make([]string, len(b.groups))
+	for i, g := range b.groups {
+		names[i] = g.name
+	}
+	return uniqueNames(names)
+}
+
+// memberGroups returns the names of the local groups mapped from dns.
+// Unparsable and unmapped DNs are ignored.
+func (b *ldapBackend) memberGroups(dns []string) []string {
+	var names []string
+	for _, dn := range dns {
+		parsed, err := ldap.ParseDN(dn)
+		if err != nil {
+			continue
+		}
+		for _, g := range b.groups {
+			if g.dn.Equal(parsed) {
+				names = append(names, g.name)
+			}
+		}
+	}
+	return uniqueNames(names)
+}
+
+func (b *ldapBackend) verify(rt *requestTx, user *models.User, password string) error {
+	dns, err := b.authenticate(user.Email, password)
+	var ue *unavailableError
+	switch {
+	case errors.As(err, &ue):
+		rt.log.WithError(err).Error(errBackendUnavailable)
+		return status.Error(codes.Unavailable, errBackendUnavailable)
+	case err != nil:
+		rt.log.WithError(err).Warn("Password missmatch")
+		return status.Error(codes.Unauthenticated, errCredentials)
+	}
+	return rt.syncGroups(user, b.mappedGroups(), b.memberGroups(dns))
+}
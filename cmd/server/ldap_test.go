@@ -0,0 +1,481 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/moapis/authenticator/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	startTLSOID = "1.3.6.1.4.1.1466.20037"
+
+	stubGroupStaff = "cn=staff,ou=groups,dc=example,dc=com"
+	stubGroupAdmin = "cn=admins,ou=groups,dc=example,dc=com"
+)
+
+type stubEntry struct {
+	password string
+	attrs    map[string][]string
+}
+
+// stubDirectory is a minimal LDAP server.
+// It serves simple binds, StartTLS and searches with equality or presence filters.
+// Searching requires a successful bind.
+type stubDirectory struct {
+	plain   net.Listener // Accepts StartTLS
+	tls     net.Listener
+	tlsConf *tls.Config
+	caFile  string
+	entries map[string]stubEntry // by DN
+}
+
+func newStubDirectory(t *testing.T) *stubDirectory {
+	// Borrow the certificate for 127.0.0.1 from httptest.
+	hs := httptest.NewTLSServer(nil)
+	certs := hs.TLS.Certificates
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: hs.Certificate().Raw})
+	hs.Close()
+
+	f, err := ioutil.TempFile("", "ldap_ca_*.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err = f.Write(ca); err != nil {
+		t.Fatal(err)
+	}
+
+	d := &stubDirectory{
+		tlsConf: &tls.Config{Certificates: certs},
+		caFile:  f.Name(),
+		entries: map[string]stubEntry{
+			"cn=service,dc=example,dc=com": {password: "service"},
+			"uid=alice@example.com,ou=people,dc=example,dc=com": {password: "alice", attrs: map[string][]string{
+				"mail":     {"alice@example.com"},
+				"memberOf": {stubGroupStaff, "cn=other,ou=groups,dc=example,dc=com"},
+			}},
+			"uid=bob,ou=people,dc=example,dc=com": {password: "bob", attrs: map[string][]string{
+				"mail": {"bob@example.com"},
+			}},
+			"uid=bobby,ou=people,dc=example,dc=com": {password: "bob", attrs: map[string][]string{
+				"mail": {"bob@example.com"},
+			}},
+		},
+	}
+	if d.plain, err = net.Listen("tcp", "127.0.0.1:0"); err != nil {
+		t.Fatal(err)
+	}
+	if d.tls, err = tls.Listen("tcp", "127.0.0.1:0", d.tlsConf); err != nil {
+		t.Fatal(err)
+	}
+	go d.serve(d.plain)
+	go d.serve(d.tls)
+	return d
+}
+
+func (d *stubDirectory) Close() {
+	d.plain.Close()
+	d.tls.Close()
+	os.Remove(d.caFile)
+}
+
+func (d *stubDirectory) serve(ln net.Listener) {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		go d.handle(conn)
+	}
+}
+
+func stubResult(id int64, tag ber.Tag, code uint16) *ber.Packet {
+	p := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, "MessageID"))
+	r := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Response")
+	r.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, uint64(code), "resultCode"))
+	r.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "matchedDN"))
+	r.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "diagnosticMessage"))
+	p.AppendChild(r)
+	return p
+}
+
+func stubSearchEntry(id int64, dn string, attrs map[string][]string) *ber.Packet {
+	p := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, "MessageID"))
+	e := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "Entry")
+	e.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, dn, "objectName"))
+	list := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "attributes")
+	for name, values := range attrs {
+		a := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "attribute")
+		a.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "type"))
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "vals")
+		for _, v := range values {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, "value"))
+		}
+		a.AppendChild(set)
+		list.AppendChild(a)
+	}
+	e.AppendChild(list)
+	p.AppendChild(e)
+	return p
+}
+
+// match entry e with dn against the search request.
+func (e stubEntry) match(dn, base string, scope int64, filter *ber.Packet) bool {
+	dn, base = strings.ToLower(dn), strings.ToLower(base)
+	if (scope == ldap.ScopeBaseObject && dn != base) || !strings.HasSuffix(dn, base) {
+		return false
+	}
+	switch filter.Tag {
+	case ldap.FilterPresent:
+		return strings.EqualFold(filter.Data.String(), "objectClass")
+	case ldap.FilterEqualityMatch:
+		for name, values := range e.attrs {
+			if !strings.EqualFold(name, filter.Children[0].Data.String()) {
+				continue
+			}
+			for _, v := range values {
+				if strings.EqualFold(v, filter.Children[1].Data.String()) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (d *stubDirectory) search(conn net.Conn, id int64, req *ber.Packet) {
+	base := req.Children[0].Data.String()
+	scope, _ := req.Children[1].Value.(int64)
+	limit, _ := req.Children[3].Value.(int64)
+
+	var found int64
+	for dn, e := range d.entries {
+		if !e.match(dn, base, scope, req.Children[6]) {
+			continue
+		}
+		if found++; limit > 0 && found > limit {
+			conn.Write(stubResult(id, ldap.ApplicationSearchResultDone, ldap.LDAPResultSizeLimitExceeded).Bytes())
+			return
+		}
+		attrs := make(map[string][]string)
+		for _, a := range req.Children[7].Children {
+			if v, ok := e.attrs[a.Data.String()]; ok {
+				attrs[a.Data.String()] = v
+			}
+		}
+		conn.Write(stubSearchEntry(id, dn, attrs).Bytes())
+	}
+	conn.Write(stubResult(id, ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess).Bytes())
+}
+
+func (d *stubDirectory) handle(conn net.Conn) {
+	defer func() { conn.Close() }()
+
+	var bound bool
+	for {
+		p, err := ber.ReadPacket(conn)
+		if err != nil || len(p.Children) < 2 {
+			return
+		}
+		id, _ := p.Children[0].Value.(int64)
+		req := p.Children[1]
+
+		switch req.Tag {
+		case ldap.ApplicationBindRequest:
+			e, ok := d.entries[req.Children[1].Data.String()]
+			bound = ok && e.password != "" && e.password == req.Children[2].Data.String()
+			code := uint16(ldap.LDAPResultInvalidCredentials)
+			if bound {
+				code = ldap.LDAPResultSuccess
+			}
+			conn.Write(stubResult(id, ldap.ApplicationBindResponse, code).Bytes())
+
+		case ldap.ApplicationSearchRequest:
+			if !bound {
+				conn.Write(stubResult(id, ldap.ApplicationSearchResultDone, ldap.LDAPResultInsufficientAccessRights).Bytes())
+				continue
+			}
+			d.search(conn, id, req)
+
+		case ldap.ApplicationExtendedRequest:
+			if req.Children[0].Data.String() != startTLSOID {
+				conn.Write(stubResult(id, ldap.ApplicationExtendedResponse, ldap.LDAPResultProtocolError).Bytes())
+				continue
+			}
+			conn.Write(stubResult(id, ldap.ApplicationExtendedResponse, ldap.LDAPResultSuccess).Bytes())
+			conn = tls.Server(conn, d.tlsConf)
+
+		default:
+			return
+		}
+	}
+}
+
+func Test_escapeDN(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"alice@example.com", "alice@example.com"},
+		{"a,b+c=d", `a\,b\+c\=d`},
+		{"#a b ", `\#a b\ `},
+		{" a\x00", `\ a\00`},
+	}
+	for _, tt := range tests {
+		if got := escapeDN(tt.s); got != tt.want {
+			t.Errorf("escapeDN(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestLDAPConfig_validate(t *testing.T) {
+	tests := []struct {
+		name string
+		c    LDAPConfig
+		want error
+	}{
+		{"Missing URL", LDAPConfig{UserDN: "uid=%s"}, errLDAPConfig},
+		{"Missing DN", LDAPConfig{URL: "ldap://localhost"}, errLDAPConfig},
+		{"StartTLS on ldaps", LDAPConfig{URL: "ldaps://localhost", UserDN: "uid=%s", StartTLS: true}, errLDAPStartTLS},
+		{"User DN template", LDAPConfig{URL: "ldap://localhost", UserDN: "uid=alice"}, errLDAPTemplate},
+		{"Filter template", LDAPConfig{URL: "ldap://localhost", BaseDN: "dc=example", Filter: "(mail=alice)"}, errLDAPTemplate},
+		{"Bind", LDAPConfig{URL: "ldap://localhost", UserDN: "uid=%s", StartTLS: true}, nil},
+		{"Search", LDAPConfig{URL: "ldaps://localhost", BaseDN: "dc=example"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.c.validate(); err != tt.want {
+				t.Errorf("LDAPConfig.validate() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newLDAPBackends(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    map[string]*LDAPConfig
+		wantErr bool
+	}{
+		{"Nil config", map[string]*LDAPConfig{"ldap": nil}, true},
+		{"Local", map[string]*LDAPConfig{localBackendName: {URL: "ldap://localhost", UserDN: "uid=%s"}}, true},
+		{"Group DN", map[string]*LDAPConfig{"ldap": {URL: "ldap://localhost", UserDN: "uid=%s", Groups: map[string]string{"foo": "user"}}}, true},
+		{"CA file", map[string]*LDAPConfig{"ldap": {URL: "ldaps://localhost", UserDN: "uid=%s", CAFile: "/does/not/exist"}}, true},
+		{"Valid", map[string]*LDAPConfig{"ldap": {URL: "ldap://localhost", UserDN: "uid=%s", Groups: map[string]string{stubGroupStaff: "user"}}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newLDAPBackends(tt.conf)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newLDAPBackends() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && len(got) != len(tt.conf) {
+				t.Errorf("newLDAPBackends() = %v, want %d backends", got, len(tt.conf))
+			}
+		})
+	}
+}
+
+func Test_ldapBackend_memberGroups(t *testing.T) {
+	b, err := newLDAPBackend(&LDAPConfig{URL: "ldap://localhost", UserDN: "uid=%s", Groups: map[string]string{
+		stubGroupStaff:                        "user",
+		stubGroupAdmin:                        "admin",
+		"cn=root,ou=groups,dc=example,dc=com": "admin",
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mapped := b.mappedGroups()
+	sort.Strings(mapped)
+	if want := []string{"admin", "user"}; !reflect.DeepEqual(mapped, want) {
+		t.Errorf("ldapBackend.mappedGroups() = %v, want %v", mapped, want)
+	}
+
+	got := b.memberGroups([]string{
+		"CN=staff, OU=groups, DC=example, DC=com",
+		"cn=admins,ou=groups,dc=example,dc=com",
+		"cn=root,ou=groups,dc=example,dc=com",
+		"cn=other,ou=groups,dc=example,dc=com",
+		"not a DN",
+	})
+	sort.Strings(got)
+	if want := []string{"admin", "user"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ldapBackend.memberGroups() = %v, want %v", got, want)
+	}
+}
+
+func Test_ldapBackend_authenticate(t *testing.T) {
+	d := newStubDirectory(t)
+	defer d.Close()
+
+	plain, secure := "ldap://"+d.plain.Addr().String(), "ldaps://"+d.tls.Addr().String()
+	groups := map[string]string{stubGroupStaff: "user"}
+	confs := map[string]*LDAPConfig{
+		"bind":      {URL: plain, UserDN: "uid=%s,ou=people,dc=example,dc=com", Groups: groups},
+		"nogroups":  {URL: plain, UserDN: "uid=%s,ou=people,dc=example,dc=com"},
+		"starttls":  {URL: plain, StartTLS: true, CAFile: d.caFile, UserDN: "uid=%s,ou=people,dc=example,dc=com", BaseDN: "dc=example,dc=com", Groups: groups},
+		"search":    {URL: secure, CAFile: d.caFile, BindDN: "cn=service,dc=example,dc=com", BindPassword: "service", BaseDN: "dc=example,dc=com", Groups: groups},
+		"untrusted": {URL: secure, UserDN: "uid=%s,ou=people,dc=example,dc=com"},
+		"service":   {URL: secure, CAFile: d.caFile, BindDN: "cn=service,dc=example,dc=com", BindPassword: "wrong", BaseDN: "dc=example,dc=com"},
+		"anonymous": {URL: plain, BaseDN: "dc=example,dc=com"},
+		"closed":    {URL: "ldap://127.0.0.1:1", UserDN: "uid=%s"},
+	}
+	backends, err := newLDAPBackends(confs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unavailable := new(unavailableError)
+	tests := []struct {
+		backend  string
+		email    string
+		password string
+		want     []string
+		wantErr  interface{} // Target for errors.As or errors.Is
+	}{
+		{"bind", "alice@example.com", "alice", []string{stubGroupStaff, "cn=other,ou=groups,dc=example,dc=com"}, nil},
+		{"bind", "alice@example.com", "wrong", nil, errLDAPCredentials},
+		{"bind", "eve@example.com", "alice", nil, errLDAPCredentials},
+		{"nogroups", "alice@example.com", "alice", nil, nil},
+		{"starttls", "alice@example.com", "alice", []string{stubGroupStaff, "cn=other,ou=groups,dc=example,dc=com"}, nil},
+		{"search", "alice@example.com", "alice", []string{stubGroupStaff, "cn=other,ou=groups,dc=example,dc=com"}, nil},
+		{"search", "alice@example.com", "wrong", nil, errLDAPCredentials},
+		{"search", "eve@example.com", "alice", nil, errLDAPCredentials},
+		{"search", "bob@example.com", "bob", nil, errLDAPCredentials},
+		{"untrusted", "alice@example.com", "alice", nil, &unavailable},
+		{"service", "alice@example.com", "alice", nil, &unavailable},
+		{"anonymous", "alice@example.com", "alice", nil, &unavailable},
+		{"closed", "alice@example.com", "alice", nil, &unavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.backend+" "+tt.email+" "+tt.password, func(t *testing.T) {
+			got, err := backends[tt.backend].(*ldapBackend).authenticate(tt.email, tt.password)
+			switch target := tt.wantErr.(type) {
+			case nil:
+				if err != nil {
+					t.Fatalf("ldapBackend.authenticate() error = %v", err)
+				}
+			case error:
+				if !errors.Is(err, target) {
+					t.Fatalf("ldapBackend.authenticate() error = %v, want %v", err, target)
+				}
+			default:
+				if !errors.As(err, target) {
+					t.Fatalf("ldapBackend.authenticate() error = %v, want %T", err, target)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ldapBackend.authenticate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_requestTx_authenticatePwUser_ldap(t *testing.T) {
+	d := newStubDirectory(t)
+	defer d.Close()
+
+	backends, err := newLDAPBackends(map[string]*LDAPConfig{"directory": {
+		URL:    "ldap://" + d.plain.Addr().String(),
+		UserDN: "uid=%s,ou=people,dc=example,dc=com",
+		Groups: map[string]string{stubGroupStaff: "user", stubGroupAdmin: "admin"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tas.backends = backends
+	defer func() { tas.backends = nil }()
+
+	m, err := mdb.Master(testCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := &models.User{Email: "alice@example.com", Name: "alice", CredentialBackend: null.StringFrom("directory")}
+	if err = u.Insert(testCtx, m, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := u.SetGroups(testCtx, m, false); err != nil {
+			t.Error(err)
+		}
+		if _, err := u.Delete(testCtx, m); err != nil {
+			t.Error(err)
+		}
+	}()
+	admin, err := models.Groups(models.GroupWhere.Name.EQ("admin")).One(testCtx, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = u.AddGroups(testCtx, m, false, admin); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		email    string
+		password string
+		code     codes.Code
+	}{
+		{"Local password", "one@group.com", "oneGroup", codes.OK},
+		{"Wrong password", "alice@example.com", "wrong", codes.Unauthenticated},
+		{"Directory password", "alice@example.com", "alice", codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt, err := tas.newTx(testCtx, "testing", false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer rt.done()
+
+			_, err = rt.authenticatePwUser(tt.email, tt.password)
+			if status.Code(err) != tt.code {
+				t.Fatalf("requestTx.authenticatePwUser() error = %v, want %v", err, tt.code)
+			}
+			if err = rt.commit(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+
+	// The mapped "admin" group is removed, "user" added.
+	groups, err := u.Groups().All(testCtx, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 1 || groups[0].Name != "user" {
+		t.Errorf("requestTx.authenticatePwUser() groups = %v, want [user]", groups)
+	}
+
+	rt, err := tas.newTx(testCtx, "testing", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rt.done()
+	if err = rt.setUserPassword(u, "someLongPassword", nil); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("requestTx.setUserPassword() error = %v, want %v", err, codes.FailedPrecondition)
+	}
+}
@@ -0,0 +1,260 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/moapis/authenticator/models"
+	"github.com/sirupsen/logrus"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Lockout scopes
+const (
+	lockoutAccount = "account"
+	lockoutReset   = "reset"
+	lockoutIP      = "ip"
+)
+
+const (
+	errLocked = "Too many attempts, try again later"
+
+	// forwardedKey is the metadata key under which trusted proxies pass the client's IP.
+	forwardedKey = "x-forwarded-for"
+	// subjectLen is the maximum length of a lockout subject in the database.
+	subjectLen = 128
+)
+
+// lockSubject identifies a failure counter and its threshold.
+type lockSubject struct {
+	scope     string
+	subject   string
+	threshold int
+}
+
+// sourceIP returns the IP address of the client.
+// When the peer is a trusted proxy, the last address in the "x-forwarded-for" metadata is used.
+// An empty string is returned if the address can't be determined.
+func (c *LockoutConfig) sourceIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	ip := p.Addr.String()
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+
+	if c.trusted(net.ParseIP(ip)) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if fwd := md.Get(forwardedKey); len(fwd) > 0 {
+			list := strings.Split(fwd[len(fwd)-1], ",")
+			if fip := net.ParseIP(strings.TrimSpace(list[len(list)-1])); fip != nil {
+				return fip.String()
+			}
+		}
+	}
+	return ip
+}
+
+// lockSubjects returns the failure counters for a request on the account of email, in scope.
+// Counters with a disabled threshold are omitted.
+// Nil is returned when brute-force protection is disabled.
+func (rt *requestTx) lockSubjects(scope, email string) []lockSubject {
+	c := rt.s.conf.Lockout
+	if c == nil {
+		return nil
+	}
+
+	threshold := c.AccountThreshold
+	if scope == lockoutReset {
+		threshold = c.ResetThreshold
+	}
+	if len(email) > subjectLen {
+		email = email[:subjectLen]
+	}
+
+	var subjects []lockSubject
+	if threshold > 0 && email != "" {
+		subjects = append(subjects, lockSubject{scope, email, threshold})
+	}
+	if ip := c.sourceIP(rt.ctx); c.IPThreshold > 0 && ip != "" {
+		subjects = append(subjects, lockSubject{lockoutIP, ip, c.IPThreshold})
+	}
+
+	rt.log = rt.log.WithField("lockSubjects", subjects)
+	return subjects
+}
+
+// inScope returns the subjects in scope.
+func inScope(scope string, subjects []lockSubject) []lockSubject {
+	var in []lockSubject
+	for _, ls := range subjects {
+		if ls.scope == scope {
+			in = append(in, ls)
+		}
+	}
+	return in
+}
+
+// lockedError returns a ResourceExhausted error,
+// with the time until the lock expires as RetryInfo.
+func lockedError(retry time.Duration) error {
+	st, err := status.New(codes.ResourceExhausted, errLocked).WithDetails(
+		&errdetails.RetryInfo{RetryDelay: ptypes.DurationProto(retry)},
+	)
+	if err != nil {
+		return status.Error(codes.ResourceExhausted, errLocked)
+	}
+	return st.Err()
+}
+
+// checkLocked returns a ResourceExhausted error if any of subjects is locked at now.
+func (rt *requestTx) checkLocked(now time.Time, subjects ...lockSubject) error {
+	var retry time.Duration
+
+	for _, ls := range subjects {
+		lf, err := models.LoginFailures(
+			models.LoginFailureWhere.Scope.EQ(ls.scope),
+			models.LoginFailureWhere.Subject.EQ(ls.subject),
+			models.LoginFailureWhere.LockedUntil.GT(null.TimeFrom(now)),
+		).One(rt.ctx, rt.tx)
+
+		switch err {
+		case nil:
+			if d := lf.LockedUntil.Time.Sub(now); d > retry {
+				retry = d
+			}
+		case sql.ErrNoRows:
+		default:
+			rt.log.WithError(err).Error("checkLocked")
+			return status.Error(codes.Internal, errDB)
+		}
+	}
+
+	if retry > 0 {
+		rt.log.WithField("retry", retry).Warn(errLocked)
+		return lockedError(retry)
+	}
+	return nil
+}
+
+const countAttemptQuery = `insert into auth.login_failures
+	(scope, subject, failures, last_failure_at, created_at)
+	values ($1, $2, 1, $3, $3)
+on conflict (scope, subject) do update set
+	failures = case when auth.login_failures.last_failure_at < $4 then 1 else auth.login_failures.failures + 1 end,
+	last_failure_at = $3
+returning failures;`
+
+// countAttempt counts a failed login or sent reset mail for subjects,
+// and locks the ones that reach their threshold.
+// Counters without failures during the lockout window restart at 1.
+func (rt *requestTx) countAttempt(now time.Time, subjects ...lockSubject) error {
+	c := rt.s.conf.Lockout
+
+	for _, ls := range subjects {
+		var failures int
+		if err := queries.Raw(countAttemptQuery, ls.scope, ls.subject, now, now.Add(-c.Window)).
+			QueryRowContext(rt.ctx, rt.tx).Scan(&failures); err != nil {
+			rt.log.WithError(err).Error("countAttempt")
+			return status.Error(codes.Internal, errDB)
+		}
+
+		d := c.lockDuration(failures, ls.threshold)
+		if d == 0 {
+			continue
+		}
+		if _, err := models.LoginFailures(
+			models.LoginFailureWhere.Scope.EQ(ls.scope),
+			models.LoginFailureWhere.Subject.EQ(ls.subject),
+		).UpdateAll(rt.ctx, rt.tx, models.M{
+			models.LoginFailureColumns.LockedUntil: null.TimeFrom(now.Add(d)),
+		}); err != nil {
+			rt.log.WithError(err).Error("countAttempt")
+			return status.Error(codes.Internal, errDB)
+		}
+		rt.log.WithFields(logrus.Fields{"scope": ls.scope, "subject": ls.subject, "failures": failures, "duration": d}).Warn("Locked")
+	}
+	return nil
+}
+
+// loginFailed counts the failure for subjects and commits,
+// before returning the original authentication error.
+func (rt *requestTx) loginFailed(now time.Time, authErr error, subjects ...lockSubject) error {
+	if len(subjects) == 0 {
+		return authErr
+	}
+	if err := rt.countAttempt(now, subjects...); err != nil {
+		return err
+	}
+	if err := rt.commit(); err != nil {
+		return err
+	}
+	return authErr
+}
+
+// checkPwUser authenticates the user by email and password,
+// with the failure counters of the account and source IP.
+// Failures are not cleared, as the login might still require a second factor.
+func (rt *requestTx) checkPwUser(email, password string, now time.Time) (*models.User, error) {
+	subjects := rt.lockSubjects(lockoutAccount, email)
+	if err := rt.checkLocked(now, subjects...); err != nil {
+		return nil, err
+	}
+	user, err := rt.authenticatePwUser(email, password)
+	if status.Code(err) == codes.Unauthenticated {
+		return nil, rt.loginFailed(now, err, subjects...)
+	}
+	return user, err
+}
+
+// clearFailures removes the failure counters in scope for subject, after a successful login.
+// Source IP counters are not cleared, as anyone can login to their own account.
+func (rt *requestTx) clearFailures(scope, subject string) error {
+	if rt.s.conf.Lockout == nil {
+		return nil
+	}
+	if _, err := models.LoginFailures(
+		models.LoginFailureWhere.Scope.EQ(scope),
+		models.LoginFailureWhere.Subject.EQ(subject),
+	).DeleteAll(rt.ctx, rt.tx); err != nil {
+		rt.log.WithError(err).Error("clearFailures")
+		return status.Error(codes.Internal, errDB)
+	}
+	return nil
+}
+
+// pruneLoginFailures deletes counters without failures during the lockout window,
+// which are no longer locked.
+func (s *authServer) pruneLoginFailures(ctx context.Context, now time.Time) (int64, error) {
+	if s.conf.Lockout == nil {
+		return 0, nil
+	}
+	db, err := s.mdb.Master(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return models.LoginFailures(
+		models.LoginFailureWhere.LastFailureAt.LT(now.Add(-s.conf.Lockout.Window)),
+		qm.Expr(
+			models.LoginFailureWhere.LockedUntil.IsNull(),
+			qm.Or2(models.LoginFailureWhere.LockedUntil.LT(null.TimeFrom(now))),
+		),
+	).DeleteAll(ctx, db)
+}
@@ -0,0 +1,447 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	auth "github.com/moapis/authenticator"
+	"github.com/moapis/authenticator/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+var testLockout = LockoutConfig{
+	AccountThreshold: 3,
+	IPThreshold:      10,
+	ResetThreshold:   2,
+	Backoff:          time.Minute,
+	MaxBackoff:       5 * time.Minute,
+	Window:           time.Hour,
+	TrustedProxies:   []string{"127.0.0.1", "::1", "172.16.0.0/12"},
+}
+
+func TestLockoutConfig_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       LockoutConfig
+		wantErr bool
+	}{
+		{
+			"Valid",
+			testLockout,
+			false,
+		},
+		{
+			"Zero backoff",
+			LockoutConfig{MaxBackoff: time.Hour, Window: time.Hour},
+			true,
+		},
+		{
+			"CIDR proxy",
+			LockoutConfig{Backoff: time.Minute, MaxBackoff: time.Hour, Window: time.Hour, TrustedProxies: []string{"172.16.0.0/12"}},
+			false,
+		},
+		{
+			"Invalid proxy",
+			LockoutConfig{Backoff: time.Minute, MaxBackoff: time.Hour, Window: time.Hour, TrustedProxies: []string{"localhost"}},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.c.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("LockoutConfig.validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLockoutConfig_lockDuration(t *testing.T) {
+	tests := []struct {
+		name      string
+		failures  int
+		threshold int
+		want      time.Duration
+	}{
+		{"Disabled", 100, 0, 0},
+		{"Below threshold", 2, 3, 0},
+		{"Threshold", 3, 3, time.Minute},
+		{"Doubled", 4, 3, 2 * time.Minute},
+		{"Doubled twice", 5, 3, 4 * time.Minute},
+		{"Max", 6, 3, 5 * time.Minute},
+		{"Far beyond max", 1000, 3, 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := testLockout.lockDuration(tt.failures, tt.threshold); got != tt.want {
+				t.Errorf("LockoutConfig.lockDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLockoutConfig_sourceIP(t *testing.T) {
+	peerCtx := func(ip string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 1234},
+		})
+	}
+	forwarded := func(ctx context.Context, values ...string) context.Context {
+		return metadata.NewIncomingContext(ctx, metadata.Pairs(append([]string{forwardedKey}, values...)...))
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{
+			"No peer",
+			context.Background(),
+			"",
+		},
+		{
+			"Peer",
+			peerCtx("192.0.2.1"),
+			"192.0.2.1",
+		},
+		{
+			"Untrusted peer",
+			forwarded(peerCtx("192.0.2.1"), "198.51.100.1"),
+			"192.0.2.1",
+		},
+		{
+			"Trusted peer",
+			forwarded(peerCtx("127.0.0.1"), "198.51.100.1"),
+			"198.51.100.1",
+		},
+		{
+			"Trusted IPv6 peer",
+			forwarded(peerCtx("::1"), "2001:db8::1"),
+			"2001:db8::1",
+		},
+		{
+			"Trusted network",
+			forwarded(peerCtx("172.18.0.3"), "198.51.100.1"),
+			"198.51.100.1",
+		},
+		{
+			"Last forwarded",
+			forwarded(peerCtx("127.0.0.1"), "198.51.100.1, 198.51.100.2"),
+			"198.51.100.2",
+		},
+		{
+			"Invalid forwarded",
+			forwarded(peerCtx("127.0.0.1"), "foo"),
+			"127.0.0.1",
+		},
+		{
+			"Trusted peer without metadata",
+			peerCtx("127.0.0.1"),
+			"127.0.0.1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := testLockout.sourceIP(tt.ctx); got != tt.want {
+				t.Errorf("LockoutConfig.sourceIP() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_lockedError(t *testing.T) {
+	s := status.Convert(lockedError(90 * time.Second))
+	if s.Code() != codes.ResourceExhausted {
+		t.Errorf("lockedError() code = %v, want %v", s.Code(), codes.ResourceExhausted)
+	}
+
+	details := s.Details()
+	if len(details) != 1 {
+		t.Fatalf("lockedError() details = %v, want RetryInfo", details)
+	}
+	ri, ok := details[0].(*errdetails.RetryInfo)
+	if !ok {
+		t.Fatalf("lockedError() details = %T, want %T", details[0], ri)
+	}
+	if got, _ := ptypes.Duration(ri.GetRetryDelay()); got != 90*time.Second {
+		t.Errorf("lockedError() retry = %v, want %v", got, 90*time.Second)
+	}
+}
+
+// lockoutServer returns a copy of tas with lockout config c.
+func lockoutServer(c *LockoutConfig) *authServer {
+	conf := *tas.conf
+	conf.Lockout = c
+	return &authServer{
+		mdb:     mdb,
+		log:     tas.log,
+		conf:    &conf,
+		privKey: tas.privateKey(),
+		mfaKEK:  tas.mfaKEK,
+		mail:    tas.mail,
+	}
+}
+
+func Test_requestTx_lockout(t *testing.T) {
+	s := lockoutServer(&testLockout)
+
+	rt, err := s.newTx(peer.NewContext(testCtx, &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.2"), Port: 1234},
+	}), "testing", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rt.done()
+
+	subjects := rt.lockSubjects(lockoutReset, "reset@lockout.com")
+	want := []lockSubject{
+		{lockoutReset, "reset@lockout.com", testLockout.ResetThreshold},
+		{lockoutIP, "192.0.2.2", testLockout.IPThreshold},
+	}
+	if !reflect.DeepEqual(subjects, want) {
+		t.Fatalf("requestTx.lockSubjects() = %v, want %v", subjects, want)
+	}
+
+	now := time.Now()
+	for i := 0; i < testLockout.ResetThreshold; i++ {
+		if err = rt.checkLocked(now, subjects...); err != nil {
+			t.Fatalf("requestTx.checkLocked() attempt %d error = %v", i, err)
+		}
+		if err = rt.countAttempt(now, subjects...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = rt.checkLocked(now.Add(time.Second), subjects...)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("requestTx.checkLocked() error = %v, want %v", err, codes.ResourceExhausted)
+	}
+	if err = rt.checkLocked(now, inScope(lockoutIP, subjects)...); err != nil {
+		t.Errorf("requestTx.checkLocked() IP error = %v, want %v", err, nil)
+	}
+	if err = rt.checkLocked(now.Add(testLockout.Backoff), subjects...); err != nil {
+		t.Errorf("requestTx.checkLocked() after backoff error = %v, want %v", err, nil)
+	}
+
+	// A failure after the window restarts the counter
+	if err = rt.countAttempt(now.Add(2*testLockout.Window), subjects...); err != nil {
+		t.Fatal(err)
+	}
+	if err = rt.checkLocked(now.Add(2*testLockout.Window), subjects...); err != nil {
+		t.Errorf("requestTx.checkLocked() after window error = %v, want %v", err, nil)
+	}
+
+	if err = rt.clearFailures(lockoutReset, "reset@lockout.com"); err != nil {
+		t.Fatal(err)
+	}
+	n, err := models.LoginFailures(models.LoginFailureWhere.Scope.EQ(lockoutReset)).Count(rt.ctx, rt.tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 0 {
+		t.Errorf("requestTx.clearFailures() left %d counters", n)
+	}
+}
+
+func Test_requestTx_lockout_disabled(t *testing.T) {
+	s := lockoutServer(nil)
+
+	rt, err := s.newTx(testCtx, "testing", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rt.done()
+
+	if subjects := rt.lockSubjects(lockoutAccount, "no@group.com"); subjects != nil {
+		t.Errorf("requestTx.lockSubjects() = %v, want %v", subjects, nil)
+	}
+	if err = rt.clearFailures(lockoutAccount, "no@group.com"); err != nil {
+		t.Errorf("requestTx.clearFailures() error = %v", err)
+	}
+}
+
+func Test_authServer_AuthenticatePwUser_lockout(t *testing.T) {
+	s := lockoutServer(&testLockout)
+	ctx := peer.NewContext(testCtx, &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.3"), Port: 1234},
+	})
+	wrong := &auth.UserPassword{Email: "no@group.com", Password: "wrong"}
+	right := &auth.UserPassword{Email: "no@group.com", Password: "noGroup"}
+
+	// Successful login clears earlier failures
+	for i := 0; i < testLockout.AccountThreshold-1; i++ {
+		if _, err := s.AuthenticatePwUser(ctx, wrong); status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("authServer.AuthenticatePwUser() error = %v, want %v", err, codes.Unauthenticated)
+		}
+	}
+	if _, err := s.AuthenticatePwUser(ctx, right); err != nil {
+		t.Fatalf("authServer.AuthenticatePwUser() error = %v", err)
+	}
+
+	for i := 0; i < testLockout.AccountThreshold; i++ {
+		if _, err := s.AuthenticatePwUser(ctx, wrong); status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("authServer.AuthenticatePwUser() error = %v, want %v", err, codes.Unauthenticated)
+		}
+	}
+	if _, err := s.AuthenticatePwUser(ctx, right); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("authServer.AuthenticatePwUser() error = %v, want %v", err, codes.ResourceExhausted)
+	}
+
+	db, err := mdb.Master(testCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, err := models.LoginFailures(
+		models.LoginFailureWhere.Scope.EQ(lockoutIP),
+		models.LoginFailureWhere.Subject.EQ("192.0.2.3"),
+		models.LoginFailureWhere.Failures.EQ(2*testLockout.AccountThreshold-1),
+	).Count(testCtx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Errorf("authServer.AuthenticatePwUser() IP counters = %d, want %d", n, 1)
+	}
+
+	if _, err = models.LoginFailures().DeleteAll(testCtx, db); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_authServer_lockout_secondFactor(t *testing.T) {
+	s := lockoutServer(&testLockout)
+	ctx := peer.NewContext(testCtx, &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.4"), Port: 1234},
+	})
+	user := testUsers["noGroup"]
+
+	rt, err := s.newTx(testCtx, "testing", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mfaTkn, err := rt.mfaAuthReply(user, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rt.done()
+
+	// Failed codes count towards the account lock
+	wrong := &auth.MFACode{Jwt: mfaTkn.GetJwt(), Code: "000000"}
+	for i := 0; i < testLockout.AccountThreshold; i++ {
+		if _, err := s.VerifyMFA(ctx, wrong); status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("authServer.VerifyMFA() error = %v, want %v", err, codes.Unauthenticated)
+		}
+	}
+	if _, err := s.VerifyMFA(ctx, wrong); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("authServer.VerifyMFA() error = %v, want %v", err, codes.ResourceExhausted)
+	}
+	// The locked account can't be used to guess passwords elsewhere
+	if _, err := s.ChangeUserPw(ctx, &auth.NewUserPassword{
+		Email:       user.Email,
+		Credential:  &auth.NewUserPassword_OldPassword{OldPassword: user.Name},
+		NewPassword: user.Name,
+	}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("authServer.ChangeUserPw() error = %v, want %v", err, codes.ResourceExhausted)
+	}
+
+	db, err := mdb.Master(testCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = models.LoginFailures().DeleteAll(testCtx, db); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < testLockout.AccountThreshold; i++ {
+		if _, err := s.ChangeUserPw(ctx, &auth.NewUserPassword{
+			Email:       user.Email,
+			Credential:  &auth.NewUserPassword_OldPassword{OldPassword: "wrong"},
+			NewPassword: user.Name,
+		}); status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("authServer.ChangeUserPw() error = %v, want %v", err, codes.Unauthenticated)
+		}
+	}
+	if _, err := s.AuthenticatePwUser(ctx, &auth.UserPassword{
+		Email:    user.Email,
+		Password: user.Name,
+	}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("authServer.AuthenticatePwUser() error = %v, want %v", err, codes.ResourceExhausted)
+	}
+
+	if _, err = models.LoginFailures().DeleteAll(testCtx, db); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_authServer_pruneLoginFailures(t *testing.T) {
+	db, err := mdb.Master(testCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []*models.LoginFailure{
+		{Scope: lockoutAccount, Subject: "old@lockout.com", Failures: 1, LastFailureAt: time.Unix(500, 0)},
+		{Scope: lockoutAccount, Subject: "locked@lockout.com", Failures: 9, LastFailureAt: time.Unix(500, 0), LockedUntil: null.TimeFrom(time.Unix(1e6, 0))},
+		{Scope: lockoutAccount, Subject: "recent@lockout.com", Failures: 1, LastFailureAt: time.Unix(9000, 0)},
+	} {
+		if err = m.Insert(testCtx, db, boil.Infer()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := time.Unix(10000, 0)
+	tests := []struct {
+		name    string
+		s       *authServer
+		want    int64
+		wantErr bool
+	}{
+		{
+			"Disabled",
+			lockoutServer(nil),
+			0,
+			false,
+		},
+		{
+			"Old counter",
+			lockoutServer(&testLockout),
+			1,
+			false,
+		},
+		{
+			"Nothing to prune",
+			lockoutServer(&testLockout),
+			0,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.pruneLoginFailures(testCtx, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("authServer.pruneLoginFailures() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("authServer.pruneLoginFailures() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err = models.LoginFailures().DeleteAll(testCtx, db); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/lib/pq"
+	auth "github.com/moapis/authenticator"
+	"github.com/moapis/authenticator/models"
+	"github.com/pascaldekloe/jwt"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	loginLinkSubject  = "Login link"
+	errLoginLinkToken = "Not a login link token"
+
+	// purposeLogin is the purpose of login link tokens.
+	purposeLogin = "login"
+)
+
+func (s *authServer) loginAudience() string {
+	return fmt.Sprintf("login@%s", s.conf.JWT.Issuer)
+}
+
+// restrictedToken returns true if c is a token for a single purpose,
+// such as password reset, two-factor authentication or a login link.
+// Such tokens are not accepted as user tokens.
+func (s *authServer) restrictedToken(c *jwt.Claims) bool {
+	if _, ok := c.Set[purposeClaim]; ok {
+		return true
+	}
+	return containsString(c.Audiences, s.passwordAudience()) ||
+		containsString(c.Audiences, s.mfaAudience()) ||
+		containsString(c.Audiences, s.loginAudience())
+}
+
+// useToken revokes the token of claims, so that it can't be used again.
+// Unlike revokeToken, a token which is already revoked is rejected,
+// also when it is used concurrently.
+func (rt *requestTx) useToken(claims *jwt.Claims) error {
+	log := rt.log.WithField("jti", claims.ID)
+	if claims.ID == "" || claims.Expires == nil {
+		log.Warn(errMissingJTI)
+		return status.Error(codes.Unauthenticated, errMissingJTI)
+	}
+
+	m := &models.RevokedToken{
+		Jti:       claims.ID,
+		ExpiresAt: claims.Expires.Time(),
+	}
+	if err := m.Insert(rt.ctx, rt.tx, boil.Infer()); err != nil {
+		if pqErr, ok := errors.Cause(err).(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			log.WithError(err).Warn(errRevokedToken)
+			return status.Error(codes.Unauthenticated, errRevokedToken)
+		}
+		log.WithError(err).Error("useToken")
+		return status.Error(codes.Internal, errDB)
+	}
+	log.Debug("useToken")
+	return nil
+}
+
+func (s *authServer) SendLoginLink(ctx context.Context, ue *auth.UserEmail) (*empty.Empty, error) {
+	rt, err := s.newTx(ctx, "SendLoginLink", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	email := ue.GetEmail()
+	if email == "" {
+		rt.log.Warn(errMissingEmail)
+		return nil, status.Error(codes.InvalidArgument, errMissingEmail)
+	}
+
+	now := time.Now()
+	// Login links are mail bombs like password resets, and share their counters.
+	subjects := rt.lockSubjects(lockoutReset, email)
+	if err = rt.checkLocked(now, subjects...); err != nil {
+		return nil, err
+	}
+
+	user, err := rt.findUserByEmail(email)
+	if err != nil {
+		// Unknown addresses only count as failure for the source IP.
+		return nil, rt.loginFailed(now, status.Error(codes.NotFound, errUserNotFound), inScope(lockoutIP, subjects)...)
+	}
+	// Users of a credential backend sign in through the backend,
+	// which may have disabled the account.
+	if err = rt.localPassword(user); err != nil {
+		return nil, err
+	}
+	if err = rt.countAttempt(now, inScope(lockoutReset, subjects)...); err != nil {
+		return nil, err
+	}
+	set := map[string]interface{}{purposeClaim: purposeLogin}
+	reply, err := rt.signedReply(user.Email, now, now.Add(s.conf.JWT.LoginLinkExpiry), set, s.loginAudience())
+	if err != nil {
+		return nil, err
+	}
+	if err := rt.sendMail(
+		"login_link", mailData{
+			user, loginLinkSubject,
+			callBackURL(
+				ue.GetUrl(),
+				reply.GetJwt(),
+			),
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *authServer) AuthenticateLoginLink(ctx context.Context, tkn *auth.AuthReply) (*auth.AuthReply, error) {
+	rt, err := s.newTx(ctx, "AuthenticateLoginLink", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	now := time.Now()
+	claims, err := rt.checkJWT(tkn.GetJwt(), now)
+	if err != nil {
+		return nil, err
+	}
+	if !containsString(claims.Audiences, s.loginAudience()) {
+		rt.log.WithField("audiences", claims.Audiences).Warn(errLoginLinkToken)
+		return nil, status.Error(codes.Unauthenticated, errLoginLinkToken)
+	}
+	if err = rt.useToken(claims); err != nil {
+		return nil, err
+	}
+
+	user, err := rt.findUserByEmail(claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if err = rt.localPassword(user); err != nil {
+		return nil, err
+	}
+	// Receiving the login link proves ownership of the e-mail address.
+	if err = rt.markVerified(user, now); err != nil {
+		return nil, err
+	}
+
+	required, err := rt.mfaRequired(user)
+	if err != nil {
+		return nil, err
+	}
+	if required {
+		return rt.mfaAuthReply(user, now)
+	}
+	return rt.refreshAuthReply(user, "", now)
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	auth "github.com/moapis/authenticator"
+	"github.com/pascaldekloe/jwt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_authServer_restrictedToken(t *testing.T) {
+	s := &authServer{conf: &ServerConfig{JWT: JWTConfig{Issuer: "localhost"}}}
+	tests := []struct {
+		audiences []string
+		want      bool
+	}{
+		{nil, false},
+		{[]string{"authenticator"}, false},
+		{[]string{"passwords@localhost"}, true},
+		{[]string{"mfa@localhost"}, true},
+		{[]string{"authenticator", "login@localhost"}, true},
+	}
+	for _, tt := range tests {
+		c := &jwt.Claims{Registered: jwt.Registered{Audiences: tt.audiences}}
+		if got := s.restrictedToken(c); got != tt.want {
+			t.Errorf("authServer.restrictedToken(%v) = %v, want %v", tt.audiences, got, tt.want)
+		}
+	}
+}
+
+// signLinkToken signs a login link token for subject, with audiences.
+func signLinkToken(t *testing.T, subject, jti string, expires time.Time, audiences ...string) *auth.AuthReply {
+	claims := &jwt.Claims{
+		KeyID: "10",
+		Registered: jwt.Registered{
+			Issuer:    "localhost",
+			Subject:   subject,
+			Expires:   jwt.NewNumericTime(expires),
+			Issued:    jwt.NewNumericTime(time.Now()),
+			Audiences: audiences,
+			ID:        jti,
+		},
+	}
+	tkn, err := claims.EdDSASign([]byte(testPrivKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &auth.AuthReply{Jwt: string(tkn)}
+}
+
+func Test_authServer_SendLoginLink(t *testing.T) {
+	cb := &auth.CallBackUrl{BaseUrl: "http://localhost:1234/login-link", TokenKey: "jwt"}
+	tests := []struct {
+		name string
+		ue   *auth.UserEmail
+		code codes.Code
+	}{
+		{"Missing e-mail", &auth.UserEmail{Url: cb}, codes.InvalidArgument},
+		{"Non-existent e-mail", &auth.UserEmail{Email: "does-not@exist.com", Url: cb}, codes.NotFound},
+		{"Success", &auth.UserEmail{Email: "all@audiences.com", Url: cb}, codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tas.SendLoginLink(testCtx, tt.ue)
+			if status.Code(err) != tt.code {
+				t.Errorf("authServer.SendLoginLink() error = %v, want %v", err, tt.code)
+			}
+		})
+	}
+}
+
+func Test_authServer_AuthenticateLoginLink(t *testing.T) {
+	email := testUsers["allGroups"].Email
+	valid := signLinkToken(t, email, "login-link", time.Now().Add(time.Minute), tas.loginAudience())
+
+	tests := []struct {
+		name string
+		tkn  *auth.AuthReply
+		code codes.Code
+	}{
+		{"Empty token", &auth.AuthReply{}, codes.InvalidArgument},
+		{"Password token", signLinkToken(t, email, "login-link-pw", time.Now().Add(time.Minute), tas.passwordAudience()), codes.Unauthenticated},
+		{"Without audience", signLinkToken(t, email, "login-link-no-aud", time.Now().Add(time.Minute)), codes.Unauthenticated},
+		{"Expired", signLinkToken(t, email, "login-link-exp", time.Now().Add(-time.Minute), tas.loginAudience()), codes.Unauthenticated},
+		{"Unknown user", signLinkToken(t, "nobody@nowhere.com", "login-link-unknown", time.Now().Add(time.Minute), tas.loginAudience()), codes.Unauthenticated},
+		{"Valid", valid, codes.OK},
+		{"Replay", valid, codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tas.AuthenticateLoginLink(testCtx, tt.tkn)
+			if status.Code(err) != tt.code {
+				t.Fatalf("authServer.AuthenticateLoginLink() error = %v, want %v", err, tt.code)
+			}
+			if err != nil {
+				return
+			}
+			if got.GetRefreshToken() == "" {
+				t.Errorf("authServer.AuthenticateLoginLink() = %v, want refresh token", got)
+			}
+			if err = checkTestToken(t, got); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
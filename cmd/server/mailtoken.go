@@ -0,0 +1,114 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"time"
+
+	auth "github.com/moapis/authenticator"
+	"github.com/moapis/authenticator/models"
+	"github.com/moapis/authenticator/verify"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Claims of the tokens sent by mail for password reset and e-mail verification.
+// The purpose claim is also set on other single-purpose tokens.
+const (
+	purposeClaim  = verify.PurposeClaim
+	passwordClaim = "password_version"
+)
+
+// Token purposes
+const (
+	purposeReset  = "reset"
+	purposeVerify = "verify"
+)
+
+const (
+	errTokenPurpose  = "Token not valid for this purpose"
+	errTokenPassword = "Token invalidated by a password change"
+)
+
+// passwordVersion returns a digest of the current password hash of user,
+// or an empty string if the user has no password.
+// It changes each time the password is set.
+func (rt *requestTx) passwordVersion(user *models.User) (string, error) {
+	pwm, err := user.Password().One(rt.ctx, rt.tx)
+	switch err {
+	case nil:
+	case sql.ErrNoRows:
+		return "", nil
+	default:
+		rt.log.WithError(err).Error("passwordVersion")
+		return "", status.Error(codes.Internal, errDB)
+	}
+	sum := sha256.Sum256([]byte(pwm.Hash))
+	return base64.RawURLEncoding.EncodeToString(sum[:16]), nil
+}
+
+// purposeExpiry returns the lifetime of tokens for purpose.
+func (s *authServer) purposeExpiry(purpose string) time.Duration {
+	if purpose == purposeVerify {
+		return s.conf.JWT.VerifyExpiry
+	}
+	return s.conf.JWT.ResetExpiry
+}
+
+// mailToken signs a token for purpose, to be sent by mail to user.
+// The token is bound to the current password of user.
+// The transaction is committed.
+func (rt *requestTx) mailToken(user *models.User, purpose string, now time.Time) (*auth.AuthReply, error) {
+	version, err := rt.passwordVersion(user)
+	if err != nil {
+		return nil, err
+	}
+	set := map[string]interface{}{
+		purposeClaim:  purpose,
+		passwordClaim: version,
+	}
+	return rt.signedReply(user.Email, now, now.Add(rt.s.purposeExpiry(purpose)), set, rt.s.passwordAudience())
+}
+
+// useMailToken checks a token from mailToken and returns its user.
+// The token is rejected if its purpose is not one of purposes,
+// or the password of the user changed since it was issued.
+// Accepted tokens are revoked, so that they can be used only once.
+func (rt *requestTx) useMailToken(token string, now time.Time, purposes ...string) (*models.User, error) {
+	claims, err := rt.checkJWT(token, now)
+	if err != nil {
+		return nil, err
+	}
+	if err = rt.s.hasPasswordAudience(claims.Audiences); err != nil {
+		return nil, err
+	}
+
+	purpose, _ := claims.Set[purposeClaim].(string)
+	if !containsString(purposes, purpose) {
+		rt.log.WithField(purposeClaim, purpose).Warn(errTokenPurpose)
+		return nil, status.Error(codes.Unauthenticated, errTokenPurpose)
+	}
+
+	user, err := rt.findUserByEmail(claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+	version, err := rt.passwordVersion(user)
+	if err != nil {
+		return nil, err
+	}
+	if v, ok := claims.Set[passwordClaim].(string); !ok || v != version {
+		rt.log.WithField("user", user.ID).Warn(errTokenPassword)
+		return nil, status.Error(codes.Unauthenticated, errTokenPassword)
+	}
+
+	if err = rt.useToken(claims); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	auth "github.com/moapis/authenticator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_authServer_purposeExpiry(t *testing.T) {
+	s := &authServer{conf: &ServerConfig{JWT: JWTConfig{ResetExpiry: time.Hour, VerifyExpiry: 24 * time.Hour}}}
+	tests := []struct {
+		purpose string
+		want    time.Duration
+	}{
+		{purposeReset, time.Hour},
+		{purposeVerify, 24 * time.Hour},
+		{"foo", time.Hour},
+	}
+	for _, tt := range tests {
+		if got := s.purposeExpiry(tt.purpose); got != tt.want {
+			t.Errorf("authServer.purposeExpiry(%q) = %v, want %v", tt.purpose, got, tt.want)
+		}
+	}
+}
+
+// purposeToken signs a mail token for purpose, bound to the current password of the user with email.
+func purposeToken(t *testing.T, email, purpose string) *auth.AuthReply {
+	rt, err := tas.newTx(testCtx, "purposeToken", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rt.done()
+
+	user, err := rt.findUserByEmail(email)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply, err := rt.mailToken(user, purpose, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return reply
+}
+
+func Test_authServer_ChangeUserPw_token(t *testing.T) {
+	email := testUsers["oneGroup"].Email
+	reset := purposeToken(t, email, purposeReset).GetJwt()
+	previous := purposeToken(t, email, purposeReset).GetJwt()
+	wrong := purposeToken(t, email, "login").GetJwt()
+
+	tests := []struct {
+		name  string
+		token string
+		code  codes.Code
+	}{
+		{"Wrong purpose", wrong, codes.Unauthenticated},
+		{"Success", reset, codes.OK},
+		{"Reused", reset, codes.Unauthenticated},
+		{"Password changed", previous, codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tas.ChangeUserPw(testCtx, &auth.NewUserPassword{
+				Credential:  &auth.NewUserPassword_ResetToken{ResetToken: tt.token},
+				NewPassword: testUsers["oneGroup"].Name,
+			})
+			if status.Code(err) != tt.code {
+				t.Errorf("authServer.ChangeUserPw() error = %v, want %v", err, tt.code)
+			}
+		})
+	}
+}
@@ -7,12 +7,12 @@ package main
 import (
 	"context"
 	"crypto/rand"
+	"net/http"
 	"os"
 	"os/signal"
 	"time"
 
 	"github.com/sirupsen/logrus"
-	"google.golang.org/grpc"
 )
 
 var (
@@ -62,21 +62,36 @@ func main() {
 		log.WithError(err).Fatal("newAuthServer")
 	}
 
+	mctx, mcancel := context.WithCancel(context.Background())
+	defer mcancel()
+	go s.maintainKeys(mctx, rand.Reader)
+
 	sc := make(chan os.Signal, 1)
 	signal.Notify(sc, os.Interrupt)
 
+	var (
+		hs  *http.Server
+		hec <-chan error
+	)
+	if c.HTTP != nil {
+		hs, hec = c.listenAndServeHTTP(s)
+	}
+
 	gs, ec := c.listenAndServe(s, opts...)
 	select {
 	case sig := <-sc:
 		log.WithField("signal", sig).Info("Shutdown")
+		if hs != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), c.HTTP.Timeout)
+			defer cancel()
+			if err = hs.Shutdown(ctx); err != nil {
+				log.WithError(err).Error("HTTP shutdown")
+			}
+		}
 		gs.GracefulStop()
 	case err = <-ec:
 		log.WithError(err).Fatal("Shutdown")
+	case err = <-hec:
+		log.WithError(err).Fatal("HTTP shutdown")
 	}
 }
-
-var middleware []grpc.UnaryServerInterceptor
-
-func middlewareInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-	return handler(ctx, req)
-}
@@ -6,6 +6,7 @@ package main
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"html/template"
 	"net/smtp"
@@ -18,6 +19,7 @@ import (
 	"github.com/moapis/multidb"
 	migrate "github.com/rubenv/sql-migrate"
 	"github.com/sirupsen/logrus"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"golang.org/x/crypto/argon2"
 )
@@ -34,24 +36,46 @@ var (
 	tas       *authServer
 	testUsers = map[string]*models.User{
 		"noGroup": {
-			ID:    101,
-			Email: "no@group.com",
-			Name:  "noGroup",
+			ID:         101,
+			Email:      "no@group.com",
+			Name:       "noGroup",
+			VerifiedAt: null.TimeFrom(time.Unix(123, 456)),
 		},
 		"oneGroup": {
-			ID:    102,
-			Email: "one@group.com",
-			Name:  "oneGroup",
+			ID:         102,
+			Email:      "one@group.com",
+			Name:       "oneGroup",
+			VerifiedAt: null.TimeFrom(time.Unix(123, 456)),
 		},
 		"allGroups": {
-			ID:    103,
-			Email: "all@groups.com",
-			Name:  "allGroups",
+			ID:         103,
+			Email:      "all@groups.com",
+			Name:       "allGroups",
+			VerifiedAt: null.TimeFrom(time.Unix(123, 456)),
 		},
 		"allAudiences": {
-			ID:    104,
-			Email: "all@audiences.com",
-			Name:  "allAudiences",
+			ID:         104,
+			Email:      "all@audiences.com",
+			Name:       "allAudiences",
+			VerifiedAt: null.TimeFrom(time.Unix(123, 456)),
+		},
+		"unverified": {
+			ID:    105,
+			Email: "un@verified.com",
+			Name:  "unverified",
+		},
+		// toVerify is verified by Test_authServer_VerifyUser
+		"toVerify": {
+			ID:    106,
+			Email: "to@verify.com",
+			Name:  "toVerify",
+		},
+		// mfaUser enables TOTP in Test_authServer_TOTP
+		"mfaUser": {
+			ID:         107,
+			Email:      "mfa@user.com",
+			Name:       "mfaUser",
+			VerifiedAt: null.TimeFrom(time.Unix(123, 456)),
 		},
 	}
 	testGroups = []*models.Group{
@@ -123,10 +147,13 @@ func userTestData() error {
 		}
 		ul.Debug("Insert user")
 
+		// Hashes as converted by the PHC migration, with the former parameters.
 		pw := &models.Password{
 			UserID: u.ID,
-			Salt:   []byte(testSalt),
-			Hash:   argon2.IDKey([]byte(u.Name), []byte(testSalt), Argon2Time, Argon2Memory, Argon2Threads, Argon2KeyLen),
+			Hash: fmt.Sprintf("$argon2id$v=19$m=65536,t=1,p=2$%s$%s",
+				b64.EncodeToString([]byte(testSalt)),
+				b64.EncodeToString(argon2.IDKey([]byte(u.Name), []byte(testSalt), 1, 64*1024, 2, 32)),
+			),
 		}
 		ul = ul.WithField("password", pw)
 
@@ -145,6 +172,12 @@ func userTestData() error {
 	if err = testUsers["allAudiences"].AddAudiences(testCtx, tx, true, testAudiences...); err != nil {
 		log.WithError(err).Error("AddAudiences all")
 	}
+	if err = testUsers["unverified"].AddGroups(testCtx, tx, false, testGroups[0]); err != nil {
+		log.WithError(err).Error("AddGroups unverified")
+	}
+	if err = testUsers["toVerify"].AddGroups(testCtx, tx, false, testGroups[0]); err != nil {
+		log.WithError(err).Error("AddGroups toVerify")
+	}
 
 	if err = tx.Commit(); err != nil {
 		log.WithError(err).Error("tx.Commit()")
@@ -161,17 +194,26 @@ func jwtTestData() error {
 	}
 	defer tx.Rollback()
 
-	token := &models.JWTKey{
-		ID:        10,
-		PublicKey: []byte(testPubKey),
+	tokens := []*models.JWTKey{
+		{
+			ID:        10,
+			PublicKey: []byte(testPubKey),
+		},
+		{
+			ID:        11,
+			PublicKey: []byte(testPubKey),
+			ExpiresAt: null.TimeFrom(time.Now().Add(-25 * time.Hour)),
+		},
 	}
-	log := log.WithField("token", token)
+	for _, token := range tokens {
+		log := log.WithField("token", token)
 
-	if err = token.Insert(testCtx, tx, boil.Infer()); err != nil {
-		log.WithError(err).Error("token.Insert()")
-		return err
+		if err = token.Insert(testCtx, tx, boil.Infer()); err != nil {
+			log.WithError(err).Error("token.Insert()")
+			return err
+		}
+		log.Debug("token.Insert()")
 	}
-	log.Debug("token.Insert()")
 	if err = tx.Commit(); err != nil {
 		log.WithError(err).Error("tx.Commit()")
 		return err
@@ -190,6 +232,11 @@ func TestMain(m *testing.M) {
 	}
 
 	testConfig.Port = 9999 // Avoid conflict with running instance
+	hc := DefaultHTTP
+	testConfig.HTTP = &hc
+	testConfig.MFA = &MFAConfig{KEK: base64.StdEncoding.EncodeToString(testKEK)}
+	testConfig.WebAuthn = &WebAuthnConfig{RPID: "localhost", Origins: []string{testOrigin}}
+	testConfig.Admin = &AdminConfig{Groups: []string{"admin"}}
 
 	var cancel context.CancelFunc
 	testCtx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
@@ -214,6 +261,9 @@ func TestMain(m *testing.M) {
 		conf:    testConfig,
 		mdb:     mdb,
 		privKey: privateKey{"10", []byte(testPrivKey)},
+		mfaKEK:  testKEK,
+		// Predictable token IDs for comparing tokens
+		readRand: zeroRand,
 		mail: mailer.New(
 			template.Must(template.ParseGlob(testConfig.Mail.TemplateGlob)),
 			fmt.Sprintf("%s:%d", testConfig.Mail.Host, testConfig.Mail.Port),
@@ -0,0 +1,376 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	auth "github.com/moapis/authenticator"
+	"github.com/moapis/authenticator/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	totpSecretLen = 20 // 160 bits, as recommended by RFC 4226
+	totpDigits    = 6
+	totpModulo    = 1000000 // 10^totpDigits
+	totpPeriod    = 30      // Seconds per time step
+	totpSkew      = 1       // Accepted time steps before and after the current, for clock drift
+
+	recoveryCount = 10 // Amount of recovery codes per user
+	recoveryLen   = 10 // Random bytes per recovery code
+
+	// mfaExpiry is the lifetime of the token returned by AuthenticatePwUser,
+	// when two-factor authentication is required.
+	mfaExpiry = 5 * time.Minute
+	// purposeMFA is the purpose of that token.
+	purposeMFA = "mfa"
+)
+
+var base32NoPad = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// hotp returns the RFC 4226 one-time password for counter.
+func hotp(secret []byte, counter uint64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, code%totpModulo)
+}
+
+// totpCounter returns the RFC 6238 time step at t.
+func totpCounter(t time.Time) int64 {
+	return t.Unix() / totpPeriod
+}
+
+// validTOTP checks code against the time steps around now.
+// Only time steps after last are accepted, so that a code can't be used twice.
+// The matching time step is returned.
+func validTOTP(secret []byte, code string, now time.Time, last int64) (int64, bool) {
+	c := totpCounter(now)
+	for i := c - totpSkew; i <= c+totpSkew; i++ {
+		if i > last && hmac.Equal([]byte(hotp(secret, uint64(i))), []byte(code)) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// totpURI returns the otpauth URI for secret, as understood by authenticator apps.
+func totpURI(issuer, account string, secret []byte) string {
+	u := url.URL{
+		Scheme: "otpauth",
+		Host:   "totp",
+		Path:   fmt.Sprintf("/%s:%s", issuer, account),
+		RawQuery: url.Values{
+			"secret":    {base32NoPad.EncodeToString(secret)},
+			"issuer":    {issuer},
+			"algorithm": {"SHA1"},
+			"digits":    {strconv.Itoa(totpDigits)},
+			"period":    {strconv.Itoa(totpPeriod)},
+		}.Encode(),
+	}
+	return u.String()
+}
+
+// secretAD is authenticated as additional data of an encrypted TOTP secret,
+// binding it to its user.
+func secretAD(userID int) []byte {
+	return []byte(strconv.Itoa(userID))
+}
+
+// normalizeRecoveryCode removes separators and case from code.
+func normalizeRecoveryCode(code string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
+
+// hashRecoveryCode returns the SHA-256 hash of the normalized code, as stored in the database.
+func hashRecoveryCode(code string) []byte {
+	h := sha256.Sum256([]byte(normalizeRecoveryCode(code)))
+	return h[:]
+}
+
+// newRecoveryCode returns a random recovery code, in groups of 4 characters.
+func newRecoveryCode(r io.Reader) (string, error) {
+	b := make([]byte, recoveryLen)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return "", err
+	}
+	enc := base32NoPad.EncodeToString(b)
+
+	groups := make([]string, 0, len(enc)/4)
+	for i := 0; i < len(enc); i += 4 {
+		groups = append(groups, enc[i:i+4])
+	}
+	return strings.Join(groups, "-"), nil
+}
+
+func (s *authServer) mfaAudience() string {
+	return fmt.Sprintf("mfa@%s", s.conf.JWT.Issuer)
+}
+
+func (s *authServer) totpIssuer() string {
+	if s.conf.MFA != nil && s.conf.MFA.Issuer != "" {
+		return s.conf.MFA.Issuer
+	}
+	return s.conf.JWT.Issuer
+}
+
+// userFromToken returns the user identified by a regular user token.
+// Restricted tokens, such as for password reset or two-factor authentication,
+// and tokens issued to OAuth clients are rejected.
+func (rt *requestTx) userFromToken(token string, now time.Time) (*models.User, error) {
+	claims, err := rt.checkJWT(token, now)
+	if err != nil {
+		return nil, err
+	}
+	if err = rt.checkUserToken(claims); err != nil {
+		return nil, err
+	}
+	return rt.findUserByEmail(claims.Subject)
+}
+
+// totpSecret returns the TOTP secret entry of the user, locked for update.
+// Nil is returned if the user did not enroll.
+func (rt *requestTx) totpSecret(userID int) (*models.TotpSecret, error) {
+	m, err := models.TotpSecrets(
+		models.TotpSecretWhere.UserID.EQ(userID),
+		qm.For("update"),
+	).One(rt.ctx, rt.tx)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		rt.log.WithError(err).Error("totpSecret")
+		return nil, status.Error(codes.Internal, errDB)
+	}
+	return m, nil
+}
+
+// mfaRequired returns true if user has confirmed TOTP.
+func (rt *requestTx) mfaRequired(user *models.User) (bool, error) {
+	ok, err := models.TotpSecrets(
+		models.TotpSecretWhere.UserID.EQ(user.ID),
+		models.TotpSecretWhere.ConfirmedAt.IsNotNull(),
+	).Exists(rt.ctx, rt.tx)
+	if err != nil {
+		rt.log.WithError(err).Error("mfaRequired")
+		return false, status.Error(codes.Internal, errDB)
+	}
+	return ok, nil
+}
+
+// mfaAuthReply builds a short lived token, which can only be used for VerifyMFA.
+// Its purpose claim makes verify.Verificator reject it as user token.
+func (rt *requestTx) mfaAuthReply(user *models.User, issued time.Time) (*auth.AuthReply, error) {
+	rt.log = rt.log.WithField("user", user)
+
+	set := map[string]interface{}{purposeClaim: purposeMFA}
+	reply, err := rt.signedReply(user.Email, issued, issued.Add(mfaExpiry), set, rt.s.mfaAudience())
+	if err != nil {
+		return nil, err
+	}
+	reply.MfaRequired = true
+	return reply, nil
+}
+
+// enrollTOTP stores a new secret for user, replacing any pending secret.
+func (rt *requestTx) enrollTOTP(user *models.User, r io.Reader) (*auth.TOTPEnrollment, error) {
+	if rt.s.mfaKEK == nil {
+		rt.log.Warn(errMFADisabled)
+		return nil, status.Error(codes.FailedPrecondition, errMFADisabled)
+	}
+
+	m, err := rt.totpSecret(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		m = &models.TotpSecret{UserID: user.ID}
+	}
+	if m.ConfirmedAt.Valid {
+		rt.log.Warn(errTOTPEnabled)
+		return nil, status.Error(codes.FailedPrecondition, errTOTPEnabled)
+	}
+
+	secret := make([]byte, totpSecretLen)
+	if _, err = io.ReadFull(r, secret); err != nil {
+		rt.log.WithError(err).Error("enrollTOTP")
+		return nil, status.Error(codes.Internal, errFatal)
+	}
+	if m.EncryptedSecret, err = seal(rt.s.mfaKEK, secret, secretAD(user.ID), r); err != nil {
+		rt.log.WithError(err).Error("enrollTOTP")
+		return nil, status.Error(codes.Internal, errFatal)
+	}
+	m.LastCounter = 0
+
+	if m.ID == 0 {
+		err = m.Insert(rt.ctx, rt.tx, boil.Infer())
+	} else {
+		_, err = m.Update(rt.ctx, rt.tx, boil.Infer())
+	}
+	if err != nil {
+		rt.log.WithError(err).Error("enrollTOTP")
+		return nil, status.Error(codes.Internal, errDB)
+	}
+	rt.log.WithField("user", user).Debug("enrollTOTP")
+
+	return &auth.TOTPEnrollment{
+		Secret: base32NoPad.EncodeToString(secret),
+		Uri:    totpURI(rt.s.totpIssuer(), user.Email, secret),
+	}, nil
+}
+
+// checkTOTP verifies code against the secret in m and records its time step.
+func (rt *requestTx) checkTOTP(m *models.TotpSecret, code string, now time.Time) error {
+	if rt.s.mfaKEK == nil {
+		rt.log.Warn(errMFADisabled)
+		return status.Error(codes.FailedPrecondition, errMFADisabled)
+	}
+
+	secret, err := unseal(rt.s.mfaKEK, m.EncryptedSecret, secretAD(m.UserID))
+	if err != nil {
+		rt.log.WithError(err).Error("checkTOTP")
+		return status.Error(codes.Internal, errFatal)
+	}
+
+	counter, ok := validTOTP(secret, code, now, m.LastCounter)
+	if !ok {
+		rt.log.Warn(errMFACode)
+		return status.Error(codes.Unauthenticated, errMFACode)
+	}
+
+	m.LastCounter = counter
+	if _, err = m.Update(rt.ctx, rt.tx, boil.Whitelist(models.TotpSecretColumns.LastCounter, models.TotpSecretColumns.UpdatedAt)); err != nil {
+		rt.log.WithError(err).Error("checkTOTP")
+		return status.Error(codes.Internal, errDB)
+	}
+	return nil
+}
+
+// confirmTOTP enables the pending secret of user, after verifying code.
+// New recovery codes are returned.
+func (rt *requestTx) confirmTOTP(user *models.User, code string, now time.Time, r io.Reader) ([]string, error) {
+	if code == "" {
+		rt.log.Warn(errMissingMFACode)
+		return nil, status.Error(codes.InvalidArgument, errMissingMFACode)
+	}
+
+	m, err := rt.totpSecret(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case m == nil:
+		rt.log.Warn(errTOTPNotEnrolled)
+		return nil, status.Error(codes.FailedPrecondition, errTOTPNotEnrolled)
+	case m.ConfirmedAt.Valid:
+		rt.log.Warn(errTOTPEnabled)
+		return nil, status.Error(codes.FailedPrecondition, errTOTPEnabled)
+	}
+
+	if err = rt.checkTOTP(m, code, now); err != nil {
+		return nil, err
+	}
+	m.ConfirmedAt = null.TimeFrom(now)
+	if _, err = m.Update(rt.ctx, rt.tx, boil.Whitelist(models.TotpSecretColumns.ConfirmedAt, models.TotpSecretColumns.UpdatedAt)); err != nil {
+		rt.log.WithError(err).Error("confirmTOTP")
+		return nil, status.Error(codes.Internal, errDB)
+	}
+
+	return rt.newRecoveryCodes(user.ID, r)
+}
+
+// newRecoveryCodes replaces the recovery codes of the user.
+func (rt *requestTx) newRecoveryCodes(userID int, r io.Reader) ([]string, error) {
+	if _, err := models.RecoveryCodes(models.RecoveryCodeWhere.UserID.EQ(userID)).DeleteAll(rt.ctx, rt.tx); err != nil {
+		rt.log.WithError(err).Error("newRecoveryCodes")
+		return nil, status.Error(codes.Internal, errDB)
+	}
+
+	codeList := make([]string, recoveryCount)
+	for i := range codeList {
+		code, err := newRecoveryCode(r)
+		if err != nil {
+			rt.log.WithError(err).Error("newRecoveryCodes")
+			return nil, status.Error(codes.Internal, errFatal)
+		}
+
+		m := &models.RecoveryCode{
+			UserID: userID,
+			Hash:   hashRecoveryCode(code),
+		}
+		if err = m.Insert(rt.ctx, rt.tx, boil.Infer()); err != nil {
+			rt.log.WithError(err).Error("newRecoveryCodes")
+			return nil, status.Error(codes.Internal, errDB)
+		}
+		codeList[i] = code
+	}
+	return codeList, nil
+}
+
+// useRecoveryCode marks an unused recovery code of the user as used.
+func (rt *requestTx) useRecoveryCode(userID int, code string, now time.Time) error {
+	n, err := models.RecoveryCodes(
+		models.RecoveryCodeWhere.UserID.EQ(userID),
+		models.RecoveryCodeWhere.Hash.EQ(hashRecoveryCode(code)),
+		models.RecoveryCodeWhere.UsedAt.IsNull(),
+	).UpdateAll(rt.ctx, rt.tx, models.M{
+		models.RecoveryCodeColumns.UsedAt: null.TimeFrom(now),
+	})
+	if err != nil {
+		rt.log.WithError(err).Error("useRecoveryCode")
+		return status.Error(codes.Internal, errDB)
+	}
+	if n == 0 {
+		rt.log.Warn(errMFACode)
+		return status.Error(codes.Unauthenticated, errMFACode)
+	}
+	rt.log.Info("Recovery code used")
+	return nil
+}
+
+// verifyMFACode checks a TOTP code or recovery code for user.
+// Codes of totpDigits length are treated as TOTP code.
+func (rt *requestTx) verifyMFACode(user *models.User, code string, now time.Time) error {
+	if code == "" {
+		rt.log.Warn(errMissingMFACode)
+		return status.Error(codes.InvalidArgument, errMissingMFACode)
+	}
+	if len(code) != totpDigits {
+		return rt.useRecoveryCode(user.ID, code, now)
+	}
+
+	m, err := rt.totpSecret(user.ID)
+	if err != nil {
+		return err
+	}
+	if m == nil || !m.ConfirmedAt.Valid {
+		rt.log.Warn(errTOTPNotEnrolled)
+		return status.Error(codes.Unauthenticated, errMFACode)
+	}
+	return rt.checkTOTP(m, code, now)
+}
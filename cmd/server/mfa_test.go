@@ -0,0 +1,393 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moapis/authenticator/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// rfcSecret is the secret used for the test vectors in RFC 4226 and RFC 6238.
+var rfcSecret = []byte("12345678901234567890")
+
+func Test_hotp(t *testing.T) {
+	// RFC 4226, Appendix D
+	want := []string{
+		"755224",
+		"287082",
+		"359152",
+		"969429",
+		"338314",
+		"254676",
+		"287922",
+		"162583",
+		"399871",
+		"520489",
+	}
+	for i, w := range want {
+		if got := hotp(rfcSecret, uint64(i)); got != w {
+			t.Errorf("hotp(%d) = %v, want %v", i, got, w)
+		}
+	}
+}
+
+func Test_validTOTP(t *testing.T) {
+	// RFC 6238, Appendix B, truncated to 6 digits
+	now := time.Unix(1111111109, 0)
+	const current = 37037036
+
+	tests := []struct {
+		name   string
+		code   string
+		last   int64
+		want   int64
+		wantOK bool
+	}{
+		{
+			"Current",
+			"081804",
+			0,
+			current,
+			true,
+		},
+		{
+			"Previous step",
+			hotp(rfcSecret, current-1),
+			0,
+			current - 1,
+			true,
+		},
+		{
+			"Next step",
+			hotp(rfcSecret, current+1),
+			0,
+			current + 1,
+			true,
+		},
+		{
+			"Too old",
+			hotp(rfcSecret, current-2),
+			0,
+			0,
+			false,
+		},
+		{
+			"Reused",
+			"081804",
+			current,
+			0,
+			false,
+		},
+		{
+			"Wrong code",
+			"123456",
+			0,
+			0,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := validTOTP(rfcSecret, tt.code, now, tt.last)
+			if ok != tt.wantOK {
+				t.Errorf("validTOTP() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if got != tt.want {
+				t.Errorf("validTOTP() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_totpURI(t *testing.T) {
+	want := "otpauth://totp/Example:alice@example.com?algorithm=SHA1&digits=6&issuer=Example&period=30&secret=GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	if got := totpURI("Example", "alice@example.com", rfcSecret); got != want {
+		t.Errorf("totpURI() = %v, want %v", got, want)
+	}
+}
+
+func Test_newRecoveryCode(t *testing.T) {
+	code, err := newRecoveryCode(strings.NewReader("0123456789"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "GAYT-EMZU-GU3D-OOBZ"; code != want {
+		t.Errorf("newRecoveryCode() = %v, want %v", code, want)
+	}
+	if !bytes.Equal(hashRecoveryCode(code), hashRecoveryCode("gayt emzu gu3d oobz")) {
+		t.Errorf("hashRecoveryCode() not normalized")
+	}
+
+	if _, err = newRecoveryCode(strings.NewReader("")); err == nil {
+		t.Errorf("newRecoveryCode() error = %v, wantErr %v", err, true)
+	}
+}
+
+func Test_requestTx_userFromToken(t *testing.T) {
+	rt, err := tas.newTx(testCtx, "testing", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rt.done()
+
+	now := time.Now()
+	pwTkn, err := rt.authReply(testUsers["allGroups"].Email, now, nil, tas.passwordAudience())
+	if err != nil {
+		t.Fatal(err)
+	}
+	mfaTkn, err := rt.mfaAuthReply(testUsers["allGroups"], now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientTkn, err := rt.authReply(testUsers["allGroups"].Email, now, map[string]interface{}{jwtClientID: "spa"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		want    int
+		wantErr bool
+	}{
+		{
+			"User token",
+			signTestToken(t, testUsers["allGroups"].Email, "user-from-token").GetJwt(),
+			testUsers["allGroups"].ID,
+			false,
+		},
+		{
+			"Invalid token",
+			"foo",
+			0,
+			true,
+		},
+		{
+			"Password token",
+			pwTkn.GetJwt(),
+			0,
+			true,
+		},
+		{
+			"MFA token",
+			mfaTkn.GetJwt(),
+			0,
+			true,
+		},
+		{
+			"Client token",
+			clientTkn.GetJwt(),
+			0,
+			true,
+		},
+		{
+			"Unknown user",
+			signTestToken(t, "nobody@example.com", "user-from-token").GetJwt(),
+			0,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rt.userFromToken(tt.token, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("requestTx.userFromToken() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.ID != tt.want {
+				t.Errorf("requestTx.userFromToken() = %v, want %v", got.ID, tt.want)
+			}
+		})
+	}
+}
+
+func Test_requestTx_mfaAuthReply(t *testing.T) {
+	rt, err := tas.newTx(testCtx, "testing", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rt.done()
+
+	now := time.Now()
+	got, err := rt.mfaAuthReply(testUsers["allGroups"], now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.GetMfaRequired() || got.GetRefreshToken() != "" {
+		t.Errorf("requestTx.mfaAuthReply() = %v, want MFA required without refresh token", got)
+	}
+	if want := now.Add(mfaExpiry).Unix(); got.GetExpires() != want {
+		t.Errorf("requestTx.mfaAuthReply() expires = %v, want %v", got.GetExpires(), want)
+	}
+
+	claims, err := rt.checkJWT(got.GetJwt(), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !claims.AcceptAudience(tas.mfaAudience()) || len(claims.Audiences) != 1 {
+		t.Errorf("requestTx.mfaAuthReply() audiences = %v, want %v", claims.Audiences, tas.mfaAudience())
+	}
+	if purpose := claims.Set[purposeClaim]; purpose != purposeMFA {
+		t.Errorf("requestTx.mfaAuthReply() %s = %v, want %v", purposeClaim, purpose, purposeMFA)
+	}
+}
+
+// decodeSecret returns the TOTP secret from an enrollment.
+func decodeSecret(t *testing.T, secret string) []byte {
+	b, err := base32NoPad.DecodeString(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func Test_requestTx_TOTP(t *testing.T) {
+	rt, err := tas.newTx(testCtx, "testing", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Nothing gets committed
+	defer rt.done()
+
+	user := testUsers["noGroup"]
+	now := time.Now()
+
+	required, err := rt.mfaRequired(user)
+	if err != nil || required {
+		t.Fatalf("requestTx.mfaRequired() = %v, %v, want %v", required, err, false)
+	}
+	if err = rt.verifyMFACode(user, "123456", now); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("requestTx.verifyMFACode() before enrollment error = %v, want %v", err, codes.Unauthenticated)
+	}
+	if _, err = rt.confirmTOTP(user, "123456", now, rand.Reader); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("requestTx.confirmTOTP() before enrollment error = %v, want %v", err, codes.FailedPrecondition)
+	}
+
+	if _, err = rt.enrollTOTP(user, rand.Reader); err != nil {
+		t.Fatal(err)
+	}
+	// Enrolling again replaces the pending secret
+	enrollment, err := rt.enrollTOTP(user, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(enrollment.GetUri(), "otpauth://totp/") {
+		t.Errorf("requestTx.enrollTOTP() uri = %v", enrollment.GetUri())
+	}
+	secret := decodeSecret(t, enrollment.GetSecret())
+	counter := totpCounter(now)
+
+	m, err := models.TotpSecrets(models.TotpSecretWhere.UserID.EQ(user.ID)).One(testCtx, rt.tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(m.EncryptedSecret, secret) {
+		t.Error("requestTx.enrollTOTP() stored the secret unencrypted")
+	}
+
+	// A pending secret does not require MFA
+	if required, err = rt.mfaRequired(user); err != nil || required {
+		t.Errorf("requestTx.mfaRequired() = %v, %v, want %v", required, err, false)
+	}
+
+	if _, err = rt.confirmTOTP(user, "", now, rand.Reader); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("requestTx.confirmTOTP() error = %v, want %v", err, codes.InvalidArgument)
+	}
+	if _, err = rt.confirmTOTP(user, "000000", now, rand.Reader); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("requestTx.confirmTOTP() error = %v, want %v", err, codes.Unauthenticated)
+	}
+
+	codeList, err := rt.confirmTOTP(user, hotp(secret, uint64(counter)), now, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(codeList) != recoveryCount {
+		t.Errorf("requestTx.confirmTOTP() = %v, want %d recovery codes", codeList, recoveryCount)
+	}
+	if required, err = rt.mfaRequired(user); err != nil || !required {
+		t.Errorf("requestTx.mfaRequired() = %v, %v, want %v", required, err, true)
+	}
+	if _, err = rt.enrollTOTP(user, rand.Reader); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("requestTx.enrollTOTP() after confirmation error = %v, want %v", err, codes.FailedPrecondition)
+	}
+
+	tests := []struct {
+		name string
+		code string
+		want codes.Code
+	}{
+		{
+			"Missing code",
+			"",
+			codes.InvalidArgument,
+		},
+		{
+			"Reused TOTP code",
+			hotp(secret, uint64(counter)),
+			codes.Unauthenticated,
+		},
+		{
+			"Next TOTP code",
+			hotp(secret, uint64(counter+1)),
+			codes.OK,
+		},
+		{
+			"Recovery code",
+			strings.ToLower(codeList[0]),
+			codes.OK,
+		},
+		{
+			"Used recovery code",
+			codeList[0],
+			codes.Unauthenticated,
+		},
+		{
+			"Wrong recovery code",
+			"AAAA-AAAA-AAAA-AAAA",
+			codes.Unauthenticated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := rt.verifyMFACode(user, tt.code, now); status.Code(err) != tt.want {
+				t.Errorf("requestTx.verifyMFACode() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	// Confirmation replaced the recovery codes
+	n, err := models.RecoveryCodes(models.RecoveryCodeWhere.UserID.EQ(user.ID)).Count(testCtx, rt.tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != recoveryCount {
+		t.Errorf("recovery codes = %d, want %d", n, recoveryCount)
+	}
+}
+
+func Test_requestTx_enrollTOTP_disabled(t *testing.T) {
+	s := &authServer{
+		mdb:  mdb,
+		log:  tas.log,
+		conf: tas.conf,
+	}
+
+	rt, err := s.newTx(testCtx, "testing", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rt.done()
+
+	if _, err = rt.enrollTOTP(testUsers["noGroup"], rand.Reader); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("requestTx.enrollTOTP() error = %v, want %v", err, codes.FailedPrecondition)
+	}
+}
@@ -0,0 +1,440 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	auth "github.com/moapis/authenticator"
+	"github.com/moapis/authenticator/models"
+	"github.com/sirupsen/logrus"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	errMissingClient  = "Client ID missing"
+	errClient         = "Unknown client or redirect URI"
+	errClientAuth     = "Client authentication failed"
+	errClientFamily   = "Refresh token issued to a client"
+	errClientAuthCode = "Authorization code issued to a client"
+	errClientGrant    = "Grant issued to another client or redirect URI"
+	errScope          = "Scope not allowed for client"
+	errPKCE           = "Missing or unsupported PKCE code challenge"
+	errCodeVerifier   = "Invalid PKCE code verifier"
+	errGrantType      = "Unsupported grant type"
+	errNonce          = "Nonce too long"
+	errClientToken    = "Token issued to a client"
+
+	jwtClientID = "client_id"
+	jwtScope    = "scope"
+
+	// Supported OAuth 2.0 grant types
+	grantAuthorizationCode = "authorization_code"
+	grantRefreshToken      = "refresh_token"
+	grantClientCredentials = "client_credentials"
+
+	// pkceS256 is the only supported PKCE code challenge method.
+	pkceS256 = "S256"
+	// pkceChallengeLen is the length of a base64url encoded SHA-256 hash.
+	pkceChallengeLen = 43
+	// nonceMaxLen is the maximum length of an OpenID Connect nonce.
+	nonceMaxLen = 255
+)
+
+// findClient returns the client by name.
+// notFound is the status code returned for unknown clients.
+func (rt *requestTx) findClient(name string, notFound codes.Code) (*models.Client, error) {
+	rt.log = rt.log.WithField("client", name)
+	if name == "" {
+		rt.log.Warn(errMissingClient)
+		return nil, status.Error(codes.InvalidArgument, errMissingClient)
+	}
+	client, err := models.Clients(models.ClientWhere.Name.EQ(name)).One(rt.ctx, rt.tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			rt.log.WithError(err).Warn("findClient")
+			return nil, status.Error(notFound, errClient)
+		}
+		rt.log.WithError(err).Error("findClient")
+		return nil, status.Error(codes.Internal, errDB)
+	}
+	return client, nil
+}
+
+// grantScopes returns the requested scopes,
+// or all scopes of the client when none are requested.
+func (rt *requestTx) grantScopes(client *models.Client, requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		return client.Scopes, nil
+	}
+	for _, r := range requested {
+		if !containsString(client.Scopes, r) {
+			rt.log.WithField("scope", r).Warn(errScope)
+			return nil, status.Error(codes.PermissionDenied, errScope)
+		}
+	}
+	return requested, nil
+}
+
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// checkAuthorization validates an authorization request
+// and returns the client and granted scopes.
+func (rt *requestTx) checkAuthorization(ca *auth.ClientAuthorization) (*models.Client, []string, error) {
+	client, err := rt.findClient(ca.GetClientId(), codes.NotFound)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !containsString(client.RedirectUris, ca.GetRedirectUri()) {
+		rt.log.WithField("redirect_uri", ca.GetRedirectUri()).Warn(errClient)
+		return nil, nil, status.Error(codes.NotFound, errClient)
+	}
+	scopes, err := rt.grantScopes(client, ca.GetScopes())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	challenge := ca.GetCodeChallenge()
+	if (challenge == "" && !client.SecretHash.Valid) ||
+		(challenge != "" && (ca.GetCodeChallengeMethod() != pkceS256 || len(challenge) != pkceChallengeLen)) {
+		rt.log.WithField("method", ca.GetCodeChallengeMethod()).Warn(errPKCE)
+		return nil, nil, status.Error(codes.InvalidArgument, errPKCE)
+	}
+	if len(ca.GetNonce()) > nonceMaxLen {
+		rt.log.Warn(errNonce)
+		return nil, nil, status.Error(codes.InvalidArgument, errNonce)
+	}
+	return client, scopes, nil
+}
+
+// authenticateClient returns the client by name, after checking the secret of confidential clients.
+func (rt *requestTx) authenticateClient(name, secret string) (*models.Client, error) {
+	client, err := rt.findClient(name, codes.Unauthenticated)
+	if err != nil {
+		return nil, err
+	}
+	if client.SecretHash.Valid &&
+		subtle.ConstantTimeCompare(hashRefreshToken(secret), client.SecretHash.Bytes) != 1 {
+		rt.log.Warn(errClientAuth)
+		return nil, status.Error(codes.Unauthenticated, errClientAuth)
+	}
+	return client, nil
+}
+
+// pkceChallenge returns the S256 code challenge for verifier.
+func pkceChallenge(verifier string) string {
+	h := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(h[:])
+}
+
+// verifyPKCE reports if the S256 hash of verifier matches challenge.
+func verifyPKCE(challenge, verifier string) bool {
+	return subtle.ConstantTimeCompare([]byte(pkceChallenge(verifier)), []byte(challenge)) == 1
+}
+
+// userFamily returns an error if family was issued to a client.
+// Such refresh tokens may only be used through the Token endpoint,
+// which authenticates the client.
+func (rt *requestTx) userFamily(family string) error {
+	exists, err := models.ClientGrants(models.ClientGrantWhere.Family.EQ(family)).Exists(rt.ctx, rt.tx)
+	if err != nil {
+		rt.log.WithError(err).Error("userFamily")
+		return status.Error(codes.Internal, errDB)
+	}
+	if exists {
+		rt.log.Warn(errClientFamily)
+		return status.Error(codes.Unauthenticated, errRefreshToken)
+	}
+	return nil
+}
+
+// grantError converts Unauthenticated errors about codes and refresh tokens
+// to FailedPrecondition, which maps to the OAuth "invalid_grant" error.
+// Unauthenticated is reserved for "invalid_client".
+func grantError(err error) error {
+	if s, ok := status.FromError(err); ok && s.Code() == codes.Unauthenticated {
+		return status.Error(codes.FailedPrecondition, s.Message())
+	}
+	return err
+}
+
+// clientClaims returns the claims identifying the client and granted scopes.
+func clientClaims(set map[string]interface{}, client *models.Client, scopes []string) map[string]interface{} {
+	if set == nil {
+		set = make(map[string]interface{})
+	}
+	set[jwtClientID] = client.Name
+	set[jwtScope] = strings.Join(scopes, " ")
+	return set
+}
+
+// scopedClaims limits the groups and audiences of a user token to scopes.
+// A client only gets the groups and audiences named by its granted scopes.
+func scopedClaims(set map[string]interface{}, audiences, scopes []string) (map[string]interface{}, []string) {
+	var groups []string
+	if gns, ok := set[jwtGroups].([]string); ok {
+		groups = make([]string, 0, len(gns))
+		for _, g := range gns {
+			if containsString(scopes, g) {
+				groups = append(groups, g)
+			}
+		}
+		set[jwtGroups] = groups
+	}
+
+	var ans []string
+	for _, a := range audiences {
+		if containsString(scopes, a) {
+			ans = append(ans, a)
+		}
+	}
+	return set, ans
+}
+
+// clientAuthReply builds a token for user, issued to client with the scopes of grant,
+// accompanied by a new refresh token in the family of grant.
+// The groups and audiences of the user are limited to the granted scopes.
+// An ID token is included when the "openid" scope is granted.
+func (rt *requestTx) clientAuthReply(user *models.User, client *models.Client, grant *models.ClientGrant, nonce string, issued time.Time) (*auth.AuthReply, error) {
+	tkn, expires, err := rt.issueRefreshToken(user.ID, grant.Family, issued)
+	if err != nil {
+		return nil, err
+	}
+	var idToken string
+	if containsString(grant.Scopes, scopeOpenID) {
+		if idToken, err = rt.idToken(user, client, grant.Scopes, grant.AuthTime, nonce, issued); err != nil {
+			return nil, err
+		}
+	}
+	set, ans, err := rt.userClaims(user)
+	if err != nil {
+		return nil, err
+	}
+	set, ans = scopedClaims(set, ans, grant.Scopes)
+	reply, err := rt.authReply(user.Email, issued, clientClaims(set, client, grant.Scopes), ans...)
+	if err != nil {
+		return nil, err
+	}
+	reply.RefreshToken = tkn
+	reply.RefreshExpires = expires.Unix()
+	reply.Scopes = grant.Scopes
+	reply.IdToken = idToken
+	return reply, nil
+}
+
+func (s *authServer) CheckClient(ctx context.Context, ca *auth.ClientAuthorization) (*empty.Empty, error) {
+	rt, err := s.newTx(ctx, "CheckClient", true)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	if _, _, err = rt.checkAuthorization(ca); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func (s *authServer) AuthorizeClient(ctx context.Context, ca *auth.ClientAuthorization) (*auth.AuthCode, error) {
+	rt, err := s.newTx(ctx, "AuthorizeClient", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	client, scopes, err := rt.checkAuthorization(ca)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	m, err := rt.useRefreshToken(ca.GetRefreshToken(), now)
+	if err != nil {
+		return nil, err
+	}
+	if err = rt.userFamily(m.Family); err != nil {
+		return nil, err
+	}
+	authTime, err := rt.familyAuthTime(m.Family)
+	if err != nil {
+		return nil, err
+	}
+
+	// The client gets its own refresh token family, apart from the user's session.
+	family, err := randomString(rand.Read, familyLen)
+	if err != nil {
+		rt.log.WithError(err).Error("AuthorizeClient")
+		return nil, status.Error(codes.Internal, errFatal)
+	}
+	code, err := rt.issueAuthCode(&models.AuthCode{
+		UserID:        m.UserID,
+		Family:        family,
+		ClientID:      null.IntFrom(client.ID),
+		RedirectURI:   null.StringFrom(ca.GetRedirectUri()),
+		CodeChallenge: null.NewString(ca.GetCodeChallenge(), ca.GetCodeChallenge() != ""),
+		Scopes:        scopes,
+		Nonce:         null.NewString(ca.GetNonce(), ca.GetNonce() != ""),
+		AuthTime:      null.TimeFrom(authTime),
+	}, now)
+	if err != nil {
+		return nil, err
+	}
+	if err = rt.commit(); err != nil {
+		return nil, err
+	}
+	return code, nil
+}
+
+func (s *authServer) Token(ctx context.Context, tr *auth.TokenRequest) (*auth.AuthReply, error) {
+	switch tr.GetGrantType() {
+	case grantAuthorizationCode:
+		return s.codeGrant(ctx, tr)
+	case grantRefreshToken:
+		return s.refreshGrant(ctx, tr)
+	case grantClientCredentials:
+		return s.clientCredentialsGrant(ctx, tr)
+	default:
+		s.log.WithFields(logrus.Fields{"method": "Token", "grant_type": tr.GetGrantType()}).Warn(errGrantType)
+		return nil, status.Error(codes.Unimplemented, errGrantType)
+	}
+}
+
+// codeGrant exchanges an authorization code issued by AuthorizeClient.
+func (s *authServer) codeGrant(ctx context.Context, tr *auth.TokenRequest) (*auth.AuthReply, error) {
+	rt, err := s.newTx(ctx, "Token", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	client, err := rt.authenticateClient(tr.GetClientId(), tr.GetClientSecret())
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	m, err := rt.useAuthCode(tr.GetCode(), now)
+	if err != nil {
+		return nil, grantError(err)
+	}
+	if m.ClientID.Int != client.ID || m.RedirectURI.String != tr.GetRedirectUri() {
+		rt.log.WithField("redirect_uri", tr.GetRedirectUri()).Warn(errClientGrant)
+		return nil, status.Error(codes.FailedPrecondition, errClientGrant)
+	}
+	if m.CodeChallenge.Valid && !verifyPKCE(m.CodeChallenge.String, tr.GetCodeVerifier()) {
+		rt.log.Warn(errCodeVerifier)
+		return nil, status.Error(codes.FailedPrecondition, errCodeVerifier)
+	}
+
+	user, err := models.FindUser(ctx, rt.tx, m.UserID)
+	if err != nil {
+		return nil, grantError(rt.dbAuthError("FindUser", "user", err))
+	}
+	grant := &models.ClientGrant{
+		ClientID: client.ID,
+		Family:   m.Family,
+		Scopes:   m.Scopes,
+		AuthTime: m.AuthTime,
+	}
+	if err = grant.Insert(rt.ctx, rt.tx, boil.Infer()); err != nil {
+		rt.log.WithError(err).Error("codeGrant")
+		return nil, status.Error(codes.Internal, errDB)
+	}
+	return rt.clientAuthReply(user, client, grant, m.Nonce.String, now)
+}
+
+// refreshGrant exchanges a refresh token issued to the client.
+func (s *authServer) refreshGrant(ctx context.Context, tr *auth.TokenRequest) (*auth.AuthReply, error) {
+	rt, err := s.newTx(ctx, "Token", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	client, err := rt.authenticateClient(tr.GetClientId(), tr.GetClientSecret())
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	m, err := rt.useRefreshToken(tr.GetRefreshToken(), now)
+	if err != nil {
+		return nil, grantError(err)
+	}
+	grant, err := models.ClientGrants(models.ClientGrantWhere.Family.EQ(m.Family)).One(rt.ctx, rt.tx)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		rt.log.WithError(err).Error("refreshGrant")
+		return nil, status.Error(codes.Internal, errDB)
+	}
+	if grant == nil || grant.ClientID != client.ID {
+		rt.log.Warn(errClientGrant)
+		return nil, status.Error(codes.FailedPrecondition, errClientGrant)
+	}
+
+	user, err := models.FindUser(ctx, rt.tx, m.UserID)
+	if err != nil {
+		return nil, grantError(rt.dbAuthError("FindUser", "user", err))
+	}
+	return rt.clientAuthReply(user, client, grant, "", now)
+}
+
+// clientCredentialsGrant issues a token to a confidential client itself.
+// The token has no refresh token, the client can simply request a new one.
+func (s *authServer) clientCredentialsGrant(ctx context.Context, tr *auth.TokenRequest) (*auth.AuthReply, error) {
+	rt, err := s.newTx(ctx, "Token", true)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	client, err := rt.authenticateClient(tr.GetClientId(), tr.GetClientSecret())
+	if err != nil {
+		return nil, err
+	}
+	if !client.SecretHash.Valid {
+		rt.log.Warn(errClientAuth)
+		return nil, status.Error(codes.Unauthenticated, errClientAuth)
+	}
+	scopes, err := rt.grantScopes(client, tr.GetScopes())
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := rt.authReply(fmt.Sprintf("client:%s", client.Name), time.Now(), clientClaims(nil, client, scopes))
+	if err != nil {
+		return nil, err
+	}
+	reply.Scopes = scopes
+	return reply, nil
+}
+
+// pruneClientGrants deletes grants of which all refresh tokens are pruned.
+func (s *authServer) pruneClientGrants(ctx context.Context) (int64, error) {
+	db, err := s.mdb.Master(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return models.ClientGrants(qm.Where(
+		"not exists (select 1 from auth.refresh_tokens where refresh_tokens.family = client_grants.family)",
+	)).DeleteAll(ctx, db)
+}
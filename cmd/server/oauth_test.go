@@ -0,0 +1,316 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"testing"
+
+	auth "github.com/moapis/authenticator"
+	"github.com/moapis/authenticator/models"
+	"github.com/pascaldekloe/jwt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testVerifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+
+func testChallenge(verifier string) string {
+	h := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(h[:])
+}
+
+func Test_verifyPKCE(t *testing.T) {
+	tests := []struct {
+		name     string
+		verifier string
+		want     bool
+	}{
+		{"Match", testVerifier, true},
+		{"Mismatch", "foo", false},
+		{"Empty", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// RFC 7636 appendix B
+			if got := verifyPKCE("E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", tt.verifier); got != tt.want {
+				t.Errorf("verifyPKCE() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_grantError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"Unauthenticated", status.Error(codes.Unauthenticated, errAuthCode), codes.FailedPrecondition},
+		{"InvalidArgument", status.Error(codes.InvalidArgument, errMissingAuthCode), codes.InvalidArgument},
+		{"Other", errors.New("foo"), codes.Unknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(grantError(tt.err)); got != tt.want {
+				t.Errorf("grantError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_scopedClaims(t *testing.T) {
+	tests := []struct {
+		name          string
+		set           map[string]interface{}
+		audiences     []string
+		scopes        []string
+		wantSet       map[string]interface{}
+		wantAudiences []string
+	}{
+		{
+			"In scope",
+			map[string]interface{}{jwtUserID: 1, jwtGroups: []string{"admin", "blog"}},
+			[]string{"blog", "shop"},
+			[]string{"openid", "blog"},
+			map[string]interface{}{jwtUserID: 1, jwtGroups: []string{"blog"}},
+			[]string{"blog"},
+		},
+		{
+			"Out of scope",
+			map[string]interface{}{jwtUserID: 1, jwtGroups: []string{"admin"}},
+			[]string{"shop"},
+			[]string{"read"},
+			map[string]interface{}{jwtUserID: 1, jwtGroups: []string{}},
+			nil,
+		},
+		{
+			"No scopes",
+			map[string]interface{}{jwtUserID: 1, jwtGroups: []string{"admin"}},
+			[]string{"shop"},
+			nil,
+			map[string]interface{}{jwtUserID: 1, jwtGroups: []string{}},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSet, gotAudiences := scopedClaims(tt.set, tt.audiences, tt.scopes)
+			if !reflect.DeepEqual(gotSet, tt.wantSet) {
+				t.Errorf("scopedClaims() set = %v, want %v", gotSet, tt.wantSet)
+			}
+			if !reflect.DeepEqual(gotAudiences, tt.wantAudiences) {
+				t.Errorf("scopedClaims() audiences = %v, want %v", gotAudiences, tt.wantAudiences)
+			}
+		})
+	}
+}
+
+func Test_adminServer_clients(t *testing.T) {
+	s := &adminServer{authServer: tas}
+	ctx := adminTestCtx(t, "all@groups.com", "admin")
+
+	if _, err := s.CreateClient(ctx, &auth.Client{Name: "admin-client", RedirectUris: []string{"/relative"}}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("adminServer.CreateClient() error = %v, want %v", err, codes.InvalidArgument)
+	}
+	client, err := s.CreateClient(ctx, &auth.Client{
+		Name:         "admin-client",
+		RedirectUris: []string{"https://example.com/cb", "https://example.com/cb"},
+		Scopes:       []string{"read"},
+		Confidential: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.GetSecret() == "" || !client.GetConfidential() || !reflect.DeepEqual(client.GetRedirectUris(), []string{"https://example.com/cb"}) {
+		t.Errorf("adminServer.CreateClient() = %v", client)
+	}
+	if _, err = s.CreateClient(ctx, &auth.Client{Name: "admin-client"}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("adminServer.CreateClient() error = %v, want %v", err, codes.AlreadyExists)
+	}
+
+	got, err := s.GetClient(ctx, &auth.ResourceID{Id: client.GetId()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.GetSecret() != "" || got.GetName() != "admin-client" {
+		t.Errorf("adminServer.GetClient() = %v", got)
+	}
+	if got, err = s.UpdateClient(ctx, &auth.Client{Id: client.GetId(), Scopes: []string{"read", "write"}}); err != nil || !reflect.DeepEqual(got.GetScopes(), []string{"read", "write"}) {
+		t.Errorf("adminServer.UpdateClient() = %v, %v", got, err)
+	}
+	if list, err := s.ListClients(ctx, &auth.ListRequest{Filter: "admin-"}); err != nil || len(list.GetClients()) != 1 {
+		t.Errorf("adminServer.ListClients() = %v, %v", list, err)
+	}
+
+	if _, err = s.DeleteClient(ctx, &auth.ResourceID{Id: client.GetId()}); err != nil {
+		t.Error(err)
+	}
+	if _, err = s.GetClient(ctx, &auth.ResourceID{Id: client.GetId()}); status.Code(err) != codes.NotFound {
+		t.Errorf("adminServer.GetClient() error = %v, want %v", err, codes.NotFound)
+	}
+}
+
+// testClients creates a public and a confidential client,
+// returning the secret of the latter.
+func testClients(t *testing.T) string {
+	s := &adminServer{authServer: tas}
+	ctx := adminTestCtx(t, "all@groups.com", "admin")
+
+	if _, err := s.CreateClient(ctx, &auth.Client{
+		Name:         "spa",
+		RedirectUris: []string{"https://spa.example.com/cb"},
+		Scopes:       []string{"read", "write"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	backend, err := s.CreateClient(ctx, &auth.Client{
+		Name:         "backend",
+		RedirectUris: []string{"https://backend.example.com/cb"},
+		Scopes:       []string{"read"},
+		Confidential: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return backend.GetSecret()
+}
+
+func Test_authServer_oauth(t *testing.T) {
+	secret := testClients(t)
+
+	authorization := func(refresh string) *auth.ClientAuthorization {
+		return &auth.ClientAuthorization{
+			RefreshToken:        refresh,
+			ClientId:            "spa",
+			RedirectUri:         "https://spa.example.com/cb",
+			Scopes:              []string{"read"},
+			CodeChallenge:       testChallenge(testVerifier),
+			CodeChallengeMethod: pkceS256,
+		}
+	}
+
+	checks := []struct {
+		name string
+		ca   *auth.ClientAuthorization
+		want codes.Code
+	}{
+		{"Missing client", &auth.ClientAuthorization{}, codes.InvalidArgument},
+		{"Unknown client", &auth.ClientAuthorization{ClientId: "foo"}, codes.NotFound},
+		{"Unknown redirect", &auth.ClientAuthorization{ClientId: "spa", RedirectUri: "https://evil.com"}, codes.NotFound},
+		{"Scope", &auth.ClientAuthorization{ClientId: "spa", RedirectUri: "https://spa.example.com/cb", Scopes: []string{"admin"}}, codes.PermissionDenied},
+		{"Missing PKCE", &auth.ClientAuthorization{ClientId: "spa", RedirectUri: "https://spa.example.com/cb"}, codes.InvalidArgument},
+		{"Plain PKCE", &auth.ClientAuthorization{ClientId: "spa", RedirectUri: "https://spa.example.com/cb", CodeChallenge: testVerifier, CodeChallengeMethod: "plain"}, codes.InvalidArgument},
+		{"Confidential without PKCE", &auth.ClientAuthorization{ClientId: "backend", RedirectUri: "https://backend.example.com/cb"}, codes.OK},
+		{"Valid", authorization(""), codes.OK},
+	}
+	for _, tt := range checks {
+		t.Run("CheckClient "+tt.name, func(t *testing.T) {
+			if _, err := tas.CheckClient(testCtx, tt.ca); status.Code(err) != tt.want {
+				t.Errorf("authServer.CheckClient() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := tas.AuthorizeClient(testCtx, authorization("foo")); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("authServer.AuthorizeClient() error = %v, want %v", err, codes.Unauthenticated)
+	}
+	code, err := tas.AuthorizeClient(testCtx, authorization(loginTestUser(t).GetRefreshToken()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = tas.ExchangeAuthCode(testCtx, code); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("authServer.ExchangeAuthCode() with client code error = %v, want %v", err, codes.Unauthenticated)
+	}
+
+	codeRequest := func(verifier string) *auth.TokenRequest {
+		return &auth.TokenRequest{
+			GrantType:    grantAuthorizationCode,
+			ClientId:     "spa",
+			Code:         code.GetCode(),
+			RedirectUri:  "https://spa.example.com/cb",
+			CodeVerifier: verifier,
+		}
+	}
+
+	var reply *auth.AuthReply
+
+	tokens := []struct {
+		name string
+		tr   *auth.TokenRequest
+		want codes.Code
+	}{
+		{"Unsupported grant", &auth.TokenRequest{GrantType: "password"}, codes.Unimplemented},
+		{"Unknown client", &auth.TokenRequest{GrantType: grantAuthorizationCode, ClientId: "foo"}, codes.Unauthenticated},
+		{"Wrong client", &auth.TokenRequest{GrantType: grantAuthorizationCode, ClientId: "backend", ClientSecret: secret, Code: code.GetCode(), RedirectUri: "https://spa.example.com/cb"}, codes.FailedPrecondition},
+		{"Wrong verifier", codeRequest("foo"), codes.FailedPrecondition},
+		{"Code", codeRequest(testVerifier), codes.OK},
+		{"Reused code", codeRequest(testVerifier), codes.FailedPrecondition},
+		{"Wrong secret", &auth.TokenRequest{GrantType: grantClientCredentials, ClientId: "backend", ClientSecret: "foo"}, codes.Unauthenticated},
+		{"Public client credentials", &auth.TokenRequest{GrantType: grantClientCredentials, ClientId: "spa"}, codes.Unauthenticated},
+		{"Client credentials scope", &auth.TokenRequest{GrantType: grantClientCredentials, ClientId: "backend", ClientSecret: secret, Scopes: []string{"write"}}, codes.PermissionDenied},
+		{"Client credentials", &auth.TokenRequest{GrantType: grantClientCredentials, ClientId: "backend", ClientSecret: secret}, codes.OK},
+	}
+	for _, tt := range tokens {
+		t.Run("Token "+tt.name, func(t *testing.T) {
+			got, err := tas.Token(testCtx, tt.tr)
+			if status.Code(err) != tt.want {
+				t.Fatalf("authServer.Token() error = %v, want %v", err, tt.want)
+			}
+			if err != nil {
+				return
+			}
+			claims, err := jwt.ParseWithoutCheck([]byte(got.GetJwt()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if claims.Set[jwtClientID] != tt.tr.GetClientId() || claims.Set[jwtScope] != "read" {
+				t.Errorf("authServer.Token() claims = %v", claims.Set)
+			}
+			if groups, _ := claims.Set[jwtGroups].([]interface{}); len(groups) != 0 || len(claims.Audiences) != 0 {
+				t.Errorf("authServer.Token() groups = %v, audiences = %v, want only granted scopes", groups, claims.Audiences)
+			}
+			if tt.tr.GetGrantType() == grantAuthorizationCode {
+				reply = got
+			}
+		})
+	}
+	if reply.GetRefreshToken() == "" {
+		t.Fatalf("authServer.Token() = %v, want refresh token", reply)
+	}
+
+	if _, err = tas.EnrollTOTP(testCtx, reply); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("authServer.EnrollTOTP() with client token error = %v, want %v", err, codes.PermissionDenied)
+	}
+	if _, err = tas.RefreshToken(testCtx, reply); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("authServer.RefreshToken() with client token error = %v, want %v", err, codes.Unauthenticated)
+	}
+	refresh, err := tas.Token(testCtx, &auth.TokenRequest{GrantType: grantRefreshToken, ClientId: "spa", RefreshToken: reply.GetRefreshToken()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(refresh.GetScopes(), []string{"read"}) {
+		t.Errorf("authServer.Token() refresh = %v", refresh)
+	}
+	if _, err = tas.Token(testCtx, &auth.TokenRequest{GrantType: grantRefreshToken, ClientId: "backend", ClientSecret: secret, RefreshToken: refresh.GetRefreshToken()}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("authServer.Token() refresh by other client error = %v, want %v", err, codes.FailedPrecondition)
+	}
+
+	n, err := tas.pruneClientGrants(context.Background())
+	if err != nil || n != 0 {
+		t.Errorf("authServer.pruneClientGrants() = %v, %v, want 0", n, err)
+	}
+	db, err := tas.mdb.Master(testCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = models.Clients().DeleteAll(testCtx, db); err != nil {
+		t.Fatal(err)
+	}
+}
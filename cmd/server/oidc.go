@@ -0,0 +1,122 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	auth "github.com/moapis/authenticator"
+	"github.com/moapis/authenticator/models"
+	"github.com/moapis/authenticator/verify"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	errOpenIDScope = "Access token without openid scope"
+
+	// OpenID Connect scopes
+	scopeOpenID  = "openid"
+	scopeProfile = "profile"
+	scopeEmail   = "email"
+
+	// OpenID Connect claims
+	oidcAuthTime      = "auth_time"
+	oidcNonce         = "nonce"
+	oidcName          = "name"
+	oidcEmail         = "email"
+	oidcEmailVerified = "email_verified"
+)
+
+// oidcSubject is the stable subject identifier of user in OpenID Connect tokens.
+// Unlike the e-mail address, used as subject in other tokens, it never changes.
+func oidcSubject(user *models.User) string {
+	return strconv.Itoa(user.ID)
+}
+
+// oidcClaims returns the standard claims of user, as mapped from the granted scopes.
+func oidcClaims(user *models.User, scopes []string) map[string]interface{} {
+	set := make(map[string]interface{})
+	if containsString(scopes, scopeProfile) {
+		set[oidcName] = user.Name
+	}
+	if containsString(scopes, scopeEmail) {
+		set[oidcEmail] = user.Email
+		set[oidcEmailVerified] = user.VerifiedAt.Valid
+	}
+	return set
+}
+
+// familyAuthTime returns the time the user logged in, which started the refresh token family.
+func (rt *requestTx) familyAuthTime(family string) (time.Time, error) {
+	m, err := models.RefreshTokens(
+		models.RefreshTokenWhere.Family.EQ(family),
+		qm.OrderBy(models.RefreshTokenColumns.CreatedAt),
+	).One(rt.ctx, rt.tx)
+	if err != nil {
+		rt.log.WithError(err).Error("familyAuthTime")
+		return time.Time{}, status.Error(codes.Internal, errDB)
+	}
+	return m.CreatedAt, nil
+}
+
+// idToken builds an OpenID Connect ID token for user, issued to client.
+// The nonce is only included when not empty.
+func (rt *requestTx) idToken(user *models.User, client *models.Client, scopes []string, authTime null.Time, nonce string, issued time.Time) (string, error) {
+	set := oidcClaims(user, scopes)
+	if authTime.Valid {
+		set[oidcAuthTime] = authTime.Time.Unix()
+	}
+	if nonce != "" {
+		set[oidcNonce] = nonce
+	}
+	return rt.signToken(oidcSubject(user), issued, issued.Add(rt.s.conf.JWT.Expiry), set, client.Name)
+}
+
+func (s *authServer) UserInfo(ctx context.Context, _ *empty.Empty) (*auth.UserInfoReply, error) {
+	rt, err := s.newTx(ctx, "UserInfo", true)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.done()
+
+	claims, err := rt.checkJWT(verify.BearerToken(rt.ctx), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if rt.s.restrictedToken(claims) {
+		rt.log.WithField("audiences", claims.Audiences).Warn(errUserToken)
+		return nil, status.Error(codes.Unauthenticated, errUserToken)
+	}
+	scope, _ := claims.Set[jwtScope].(string)
+	scopes := strings.Fields(scope)
+	if _, ok := claims.Set[jwtClientID]; !ok || !containsString(scopes, scopeOpenID) {
+		rt.log.WithField("scope", scope).Warn(errOpenIDScope)
+		return nil, status.Error(codes.PermissionDenied, errOpenIDScope)
+	}
+	id, ok := claims.Number(jwtUserID)
+	if !ok {
+		rt.log.WithField("claims", claims.Set).Warn(errToken)
+		return nil, status.Error(codes.Unauthenticated, errToken)
+	}
+
+	user, err := models.FindUser(rt.ctx, rt.tx, int(id))
+	if err != nil {
+		return nil, rt.dbAuthError("FindUser", "user", err)
+	}
+
+	set := oidcClaims(user, scopes)
+	reply := &auth.UserInfoReply{Sub: oidcSubject(user)}
+	reply.Name, _ = set[oidcName].(string)
+	reply.Email, _ = set[oidcEmail].(string)
+	reply.EmailVerified, _ = set[oidcEmailVerified].(bool)
+	return reply, nil
+}
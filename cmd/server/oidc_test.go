@@ -0,0 +1,137 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	auth "github.com/moapis/authenticator"
+	"github.com/moapis/authenticator/models"
+	"github.com/pascaldekloe/jwt"
+	"github.com/volatiletech/null/v8"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func Test_oidcClaims(t *testing.T) {
+	user := &models.User{ID: 1, Email: "foo@bar.com", Name: "foo", VerifiedAt: null.TimeFrom(time.Unix(123, 0))}
+
+	tests := []struct {
+		name   string
+		scopes []string
+		want   map[string]interface{}
+	}{
+		{"OpenID only", []string{scopeOpenID}, map[string]interface{}{}},
+		{"Profile", []string{scopeOpenID, scopeProfile}, map[string]interface{}{oidcName: "foo"}},
+		{"Email", []string{scopeOpenID, scopeEmail}, map[string]interface{}{oidcEmail: "foo@bar.com", oidcEmailVerified: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := oidcClaims(user, tt.scopes); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("oidcClaims() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func bearerMD(token string) metadata.MD {
+	return metadata.Pairs("authorization", "Bearer "+token)
+}
+
+func Test_authServer_openID(t *testing.T) {
+	s := &adminServer{authServer: tas}
+	client, err := s.CreateClient(adminTestCtx(t, "all@groups.com", "admin"), &auth.Client{
+		Name:         "relying-party",
+		RedirectUris: []string{"https://rp.example.com/cb"},
+		Scopes:       []string{scopeOpenID, scopeEmail, "read"},
+		Confidential: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.DeleteClient(adminTestCtx(t, "all@groups.com", "admin"), &auth.ResourceID{Id: client.GetId()})
+
+	login := loginTestUser(t)
+	code, err := tas.AuthorizeClient(testCtx, &auth.ClientAuthorization{
+		RefreshToken: login.GetRefreshToken(),
+		ClientId:     "relying-party",
+		RedirectUri:  "https://rp.example.com/cb",
+		Scopes:       []string{scopeOpenID, scopeEmail},
+		Nonce:        "n-0S6_WzA2Mj",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply, err := tas.Token(testCtx, &auth.TokenRequest{
+		GrantType:    grantAuthorizationCode,
+		ClientId:     "relying-party",
+		ClientSecret: client.GetSecret(),
+		Code:         code.GetCode(),
+		RedirectUri:  "https://rp.example.com/cb",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := jwt.EdDSACheck([]byte(reply.GetIdToken()), []byte(testPubKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.Subject != "103" || !claims.AcceptAudience("relying-party") ||
+		claims.Set[oidcNonce] != "n-0S6_WzA2Mj" || claims.Set[oidcEmail] != "all@groups.com" || claims.Set[oidcEmailVerified] != true {
+		t.Errorf("authServer.Token() ID token = %v", claims)
+	}
+	if _, ok := claims.Number(oidcAuthTime); !ok {
+		t.Errorf("authServer.Token() ID token auth_time = %v", claims.Set[oidcAuthTime])
+	}
+	if _, ok := claims.Set[oidcName]; ok {
+		t.Errorf("authServer.Token() ID token name without profile scope")
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		want  *auth.UserInfoReply
+		code  codes.Code
+	}{
+		{"Missing token", "", nil, codes.InvalidArgument},
+		{"Login token", login.GetJwt(), nil, codes.PermissionDenied},
+		{"Access token", reply.GetJwt(), &auth.UserInfoReply{Sub: "103", Email: "all@groups.com", EmailVerified: true}, codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(testCtx, bearerMD(tt.token))
+			got, err := tas.UserInfo(ctx, &empty.Empty{})
+			if status.Code(err) != tt.code {
+				t.Fatalf("authServer.UserInfo() error = %v, want %v", err, tt.code)
+			}
+			if tt.want != nil && (got.GetSub() != tt.want.GetSub() || got.GetEmail() != tt.want.GetEmail() ||
+				got.GetEmailVerified() != tt.want.GetEmailVerified() || got.GetName() != tt.want.GetName()) {
+				t.Errorf("authServer.UserInfo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	refresh, err := tas.Token(testCtx, &auth.TokenRequest{
+		GrantType:    grantRefreshToken,
+		ClientId:     "relying-party",
+		ClientSecret: client.GetSecret(),
+		RefreshToken: reply.GetRefreshToken(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	refreshed, err := jwt.EdDSACheck([]byte(refresh.GetIdToken()), []byte(testPubKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := refreshed.Set[oidcNonce]; ok || refreshed.Set[oidcAuthTime] != claims.Set[oidcAuthTime] {
+		t.Errorf("authServer.Token() refreshed ID token = %v", refreshed.Set)
+	}
+}
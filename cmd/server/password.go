@@ -0,0 +1,217 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/scrypt"
+)
+
+// PasswordConfig sets the argon2id parameters for new password hashes.
+// Stored hashes with weaker parameters, or another algorithm,
+// are rehashed on the next successful login.
+type PasswordConfig struct {
+	Time    uint32 `json:"time,omitempty"`     // Number of passes over the memory
+	Memory  uint32 `json:"memory,omitempty"`   // Memory size in KiB
+	Threads uint8  `json:"threads,omitempty"`  // Degree of parallelism
+	KeyLen  uint32 `json:"key_len,omitempty"`  // Length of the hash in bytes
+	SaltLen uint32 `json:"salt_len,omitempty"` // Length of the random salt in bytes
+}
+
+var errPasswordConfig = errors.New("Password: time, memory, threads, key_len and salt_len must be positive")
+
+func (c *PasswordConfig) validate() error {
+	if c.Time == 0 || c.Memory == 0 || c.Threads == 0 || c.KeyLen == 0 || c.SaltLen == 0 {
+		return errPasswordConfig
+	}
+	return nil
+}
+
+// Limits on the parameters of stored hashes,
+// so that an imported hash can't exhaust the server's resources.
+const (
+	maxArgon2Time    = 64
+	maxArgon2Memory  = 1 << 20 // KiB
+	maxArgon2KeyLen  = 1024
+	maxScryptLogN    = 24
+	maxScryptBlock   = 64      // r
+	maxScryptMemory  = 1 << 30 // Bytes, 128 * N * r
+	maxScryptThreads = 64
+)
+
+const (
+	phcArgon2id = "argon2id"
+	phcArgon2i  = "argon2i"
+	phcScrypt   = "scrypt"
+)
+
+var (
+	errPHCFormat       = errors.New("Malformed password hash")
+	errPHCAlgorithm    = errors.New("Unsupported password hash algorithm")
+	errPHCParams       = errors.New("Invalid password hash parameters")
+	errPHCIncompatible = errors.New("Incompatible argon2 version")
+)
+
+// b64 is the base64 encoding of salts and hashes in PHC strings.
+var b64 = base64.RawStdEncoding
+
+// hash generates a random salt with read
+// and returns the argon2id hash of password as PHC string.
+func (c *PasswordConfig) hash(password string, read func([]byte) (int, error)) (string, error) {
+	salt := make([]byte, c.SaltLen)
+	if _, err := read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, c.Time, c.Memory, c.Threads, c.KeyLen)
+
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		phcArgon2id, argon2.Version, c.Memory, c.Time, c.Threads,
+		b64.EncodeToString(salt), b64.EncodeToString(key),
+	), nil
+}
+
+// phc holds the parts of a PHC string:
+// $<id>[$v=<version>][$<param>=<value>(,<param>=<value>)*][$<salt>[$<hash>]]
+type phc struct {
+	id      string
+	version int
+	params  map[string]int
+	salt    []byte
+	hash    []byte
+}
+
+func parsePHC(s string) (*phc, error) {
+	fields := strings.Split(s, "$")
+	if len(fields) < 2 || fields[0] != "" || fields[1] == "" {
+		return nil, errPHCFormat
+	}
+	p := &phc{id: fields[1]}
+	fields = fields[2:]
+
+	if len(fields) > 0 && strings.HasPrefix(fields[0], "v=") {
+		v, err := strconv.Atoi(strings.TrimPrefix(fields[0], "v="))
+		if err != nil {
+			return nil, errPHCFormat
+		}
+		p.version = v
+		fields = fields[1:]
+	}
+
+	if len(fields) > 0 && strings.Contains(fields[0], "=") {
+		p.params = make(map[string]int)
+		for _, kv := range strings.Split(fields[0], ",") {
+			i := strings.IndexByte(kv, '=')
+			if i < 1 {
+				return nil, errPHCFormat
+			}
+			v, err := strconv.Atoi(kv[i+1:])
+			if err != nil || v < 0 {
+				return nil, errPHCFormat
+			}
+			p.params[kv[:i]] = v
+		}
+		fields = fields[1:]
+	}
+
+	if len(fields) != 2 {
+		return nil, errPHCFormat
+	}
+	var err error
+	if p.salt, err = b64.DecodeString(fields[0]); err != nil {
+		return nil, errPHCFormat
+	}
+	if p.hash, err = b64.DecodeString(fields[1]); err != nil || len(p.hash) == 0 {
+		return nil, errPHCFormat
+	}
+	return p, nil
+}
+
+// isBcrypt reports if encoded is a bcrypt hash in modular crypt format.
+func isBcrypt(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") ||
+		strings.HasPrefix(encoded, "$2b$") ||
+		strings.HasPrefix(encoded, "$2y$")
+}
+
+// verifyPassword reports if password matches the encoded hash.
+// Supported are argon2id and argon2i PHC strings,
+// scrypt PHC strings with ln, r and p parameters and bcrypt hashes.
+// An error is returned for malformed or unsupported hashes.
+func verifyPassword(encoded, password string) (bool, error) {
+	if isBcrypt(encoded) {
+		switch err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password)); err {
+		case nil:
+			return true, nil
+		case bcrypt.ErrMismatchedHashAndPassword:
+			return false, nil
+		default:
+			return false, err
+		}
+	}
+
+	p, err := parsePHC(encoded)
+	if err != nil {
+		return false, err
+	}
+
+	var key []byte
+
+	switch p.id {
+	case phcArgon2id, phcArgon2i:
+		if p.version != argon2.Version {
+			return false, errPHCIncompatible
+		}
+		m, t, threads := p.params["m"], p.params["t"], p.params["p"]
+		if t < 1 || t > maxArgon2Time || m < 1 || m > maxArgon2Memory ||
+			threads < 1 || threads > 255 || len(p.hash) > maxArgon2KeyLen {
+			return false, errPHCParams
+		}
+		if p.id == phcArgon2id {
+			key = argon2.IDKey([]byte(password), p.salt, uint32(t), uint32(m), uint8(threads), uint32(len(p.hash)))
+		} else {
+			key = argon2.Key([]byte(password), p.salt, uint32(t), uint32(m), uint8(threads), uint32(len(p.hash)))
+		}
+
+	case phcScrypt:
+		ln, r, threads := p.params["ln"], p.params["r"], p.params["p"]
+		if ln < 1 || ln > maxScryptLogN || r < 1 || r > maxScryptBlock ||
+			threads < 1 || threads > maxScryptThreads || (128*r)<<uint(ln) > maxScryptMemory {
+			return false, errPHCParams
+		}
+		if key, err = scrypt.Key([]byte(password), p.salt, 1<<uint(ln), r, threads, len(p.hash)); err != nil {
+			return false, err
+		}
+
+	default:
+		return false, errPHCAlgorithm
+	}
+
+	return subtle.ConstantTimeCompare(key, p.hash) == 1, nil
+}
+
+// needsRehash reports if the encoded hash is weaker than c,
+// or uses another algorithm than argon2id.
+func (c *PasswordConfig) needsRehash(encoded string) bool {
+	if isBcrypt(encoded) {
+		return true
+	}
+	p, err := parsePHC(encoded)
+	if err != nil || p.id != phcArgon2id || p.version != argon2.Version {
+		return true
+	}
+	return uint32(p.params["t"]) < c.Time ||
+		uint32(p.params["m"]) < c.Memory ||
+		uint32(p.params["p"]) < uint32(c.Threads) ||
+		uint32(len(p.hash)) < c.KeyLen ||
+		uint32(len(p.salt)) < c.SaltLen
+}
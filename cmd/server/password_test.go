@@ -0,0 +1,186 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"crypto/rand"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Known answers from the argon2 reference implementation and passlib.
+const (
+	testArgon2id = "$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc"
+	testArgon2i  = "$argon2i$v=19$m=65536,t=2,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG"
+	testBcrypt   = "$2a$05$CCCCCCCCCCCCCCCCCCCCC.E5YPO9kmyuRGyh0XouQYb4YMJKvyOeW" // U*U
+	testScrypt   = "$scrypt$ln=16,r=8,p=1$aM15713r3Xsvxbi31lqr1Q$nFNh2CVHVjNldFVKDHDlm4CbdRSCdEBsjjJxD+iCs5E"
+)
+
+var testPasswordConfig = PasswordConfig{
+	Time:    1,
+	Memory:  1024,
+	Threads: 1,
+	KeyLen:  16,
+	SaltLen: 8,
+}
+
+func TestPasswordConfig_validate(t *testing.T) {
+	if err := testPasswordConfig.validate(); err != nil {
+		t.Errorf("PasswordConfig.validate() error = %v", err)
+	}
+	c := testPasswordConfig
+	c.SaltLen = 0
+	if err := c.validate(); err != errPasswordConfig {
+		t.Errorf("PasswordConfig.validate() error = %v, want %v", err, errPasswordConfig)
+	}
+}
+
+func TestPasswordConfig_hash(t *testing.T) {
+	got, err := testPasswordConfig.hash("secret", func(b []byte) (int, error) {
+		return copy(b, "saltsalt"), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = "$argon2id$v=19$m=1024,t=1,p=1$c2FsdHNhbHQ$"
+	if !strings.HasPrefix(got, want) {
+		t.Errorf("PasswordConfig.hash() = %v, want prefix %v", got, want)
+	}
+	if ok, err := verifyPassword(got, "secret"); !ok || err != nil {
+		t.Errorf("verifyPassword() = %v, %v, want %v", ok, err, true)
+	}
+
+	if _, err = testPasswordConfig.hash("secret", func([]byte) (int, error) {
+		return 0, errors.New("foo")
+	}); err == nil {
+		t.Errorf("PasswordConfig.hash() error = %v, want error", err)
+	}
+}
+
+func Test_parsePHC(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    *phc
+		wantErr bool
+	}{
+		{
+			"argon2id",
+			"$argon2id$v=19$m=1024,t=1,p=1$c2FsdA$aGFzaA",
+			&phc{"argon2id", 19, map[string]int{"m": 1024, "t": 1, "p": 1}, []byte("salt"), []byte("hash")},
+			false,
+		},
+		{
+			"Without version",
+			"$scrypt$ln=4,r=8,p=1$c2FsdA$aGFzaA",
+			&phc{"scrypt", 0, map[string]int{"ln": 4, "r": 8, "p": 1}, []byte("salt"), []byte("hash")},
+			false,
+		},
+		{
+			"Without parameters",
+			"$foo$c2FsdA$aGFzaA",
+			&phc{"foo", 0, nil, []byte("salt"), []byte("hash")},
+			false,
+		},
+		{"Empty", "", nil, true},
+		{"No leading dollar", "argon2id$v=19$m=1$c2FsdA$aGFzaA", nil, true},
+		{"Bad version", "$argon2id$v=x$m=1$c2FsdA$aGFzaA", nil, true},
+		{"Bad parameter", "$argon2id$v=19$m=x$c2FsdA$aGFzaA", nil, true},
+		{"Negative parameter", "$argon2id$v=19$m=-1$c2FsdA$aGFzaA", nil, true},
+		{"Missing parameter name", "$argon2id$v=19$=1$c2FsdA$aGFzaA", nil, true},
+		{"Missing hash", "$argon2id$v=19$m=1$c2FsdA", nil, true},
+		{"Empty hash", "$argon2id$v=19$m=1$c2FsdA$", nil, true},
+		{"Bad salt", "$argon2id$v=19$m=1$!$aGFzaA", nil, true},
+		{"Padded hash", "$argon2id$v=19$m=1$c2FsdA$aGFzaA==", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePHC(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parsePHC() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parsePHC() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_verifyPassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoded  string
+		password string
+		want     bool
+		wantErr  bool
+	}{
+		{"argon2id", testArgon2id, "password", true, false},
+		{"argon2id mismatch", testArgon2id, "wrong", false, false},
+		{"argon2i", testArgon2i, "password", true, false},
+		{"argon2i mismatch", testArgon2i, "wrong", false, false},
+		{"bcrypt", testBcrypt, "U*U", true, false},
+		{"bcrypt mismatch", testBcrypt, "wrong", false, false},
+		{"bcrypt malformed", "$2a$05$foo", "U*U", false, true},
+		{"scrypt", testScrypt, "password", true, false},
+		{"scrypt mismatch", testScrypt, "wrong", false, false},
+		{"Malformed", "foo", "password", false, true},
+		{"Unsupported", "$md5$c2FsdA$aGFzaA", "password", false, true},
+		{"argon2 version", "$argon2id$v=16$m=1024,t=1,p=1$c2FsdA$aGFzaA", "password", false, true},
+		{"argon2 without parameters", "$argon2id$v=19$c2FsdA$aGFzaA", "password", false, true},
+		{"argon2 memory", "$argon2id$v=19$m=2097152,t=1,p=1$c2FsdA$aGFzaA", "password", false, true},
+		{"argon2 time", "$argon2id$v=19$m=1024,t=65,p=1$c2FsdA$aGFzaA", "password", false, true},
+		{"argon2 threads", "$argon2id$v=19$m=1024,t=1,p=256$c2FsdA$aGFzaA", "password", false, true},
+		{"scrypt cost", "$scrypt$ln=25,r=8,p=1$c2FsdA$aGFzaA", "password", false, true},
+		{"scrypt block size", "$scrypt$ln=4,r=65,p=1$c2FsdA$aGFzaA", "password", false, true},
+		{"scrypt memory", "$scrypt$ln=24,r=64,p=1$c2FsdA$aGFzaA", "password", false, true},
+		{"scrypt threads", "$scrypt$ln=4,r=8,p=0$c2FsdA$aGFzaA", "password", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := verifyPassword(tt.encoded, tt.password)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("verifyPassword() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("verifyPassword() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPasswordConfig_needsRehash(t *testing.T) {
+	current, err := testPasswordConfig.hash("secret", rand.Read)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		encoded string
+		want    bool
+	}{
+		{"Current", current, false},
+		{"Stronger", testArgon2id, false},
+		{"Weaker memory", "$argon2id$v=19$m=512,t=1,p=1$c2FsdHNhbHQ$aGFzaGhhc2hoYXNoaGFzaA", true},
+		{"Weaker time", "$argon2id$v=19$m=1024,t=0,p=1$c2FsdHNhbHQ$aGFzaGhhc2hoYXNoaGFzaA", true},
+		{"Short salt", "$argon2id$v=19$m=1024,t=1,p=1$c2FsdA$aGFzaGhhc2hoYXNoaGFzaA", true},
+		{"Short hash", "$argon2id$v=19$m=1024,t=1,p=1$c2FsdHNhbHQ$aGFzaA", true},
+		{"argon2i", testArgon2i, true},
+		{"bcrypt", testBcrypt, true},
+		{"scrypt", testScrypt, true},
+		{"Malformed", "foo", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := testPasswordConfig.needsRehash(tt.encoded); got != tt.want {
+				t.Errorf("PasswordConfig.needsRehash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
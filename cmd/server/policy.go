@@ -0,0 +1,324 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/moapis/authenticator/models"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PolicyConfig sets the requirements for new passwords.
+// Zero values disable the corresponding rule.
+type PolicyConfig struct {
+	MinLength      int    `json:"min_length,omitempty"`      // Minimum amount of characters
+	CharClasses    int    `json:"char_classes,omitempty"`    // Minimum amount of classes from lower case, upper case, digits and symbols
+	RejectIdentity bool   `json:"reject_identity,omitempty"` // Reject passwords containing the user's e-mail or name
+	History        int    `json:"history,omitempty"`         // Amount of last passwords, including the current, which can't be reused
+	BreachedCorpus string `json:"breached_corpus,omitempty"` // Path to a breached password file, see breachedCorpus
+}
+
+var errPolicyConfig = errors.New("Password policy: min_length, char_classes and history can't be negative and char_classes can't exceed 4")
+
+func (c *PolicyConfig) validate() error {
+	if c.MinLength < 0 || c.CharClasses < 0 || c.CharClasses > 4 || c.History < 0 {
+		return errPolicyConfig
+	}
+	return nil
+}
+
+const (
+	errPasswordPolicy = "Password does not meet the policy"
+
+	// policyField is the field reported in policy violations.
+	policyField = "new_password"
+	// minIdentityLen is the minimal length of e-mail local part or name to be rejected in passwords.
+	// Shorter values are too likely to be part of a password by chance.
+	minIdentityLen = 3
+)
+
+// charClasses returns the amount of character classes in password.
+func charClasses(password string) int {
+	var lower, upper, digit, symbol int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+	return lower + upper + digit + symbol
+}
+
+// containsIdentity reports if password contains the local part of email or name,
+// ignoring case.
+func containsIdentity(password, email, name string) bool {
+	password = strings.ToLower(password)
+
+	if i := strings.LastIndexByte(email, '@'); i >= 0 {
+		email = email[:i]
+	}
+	for _, s := range []string{email, name} {
+		if len(s) >= minIdentityLen && strings.Contains(password, strings.ToLower(s)) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPolicy returns the violations of the policy by password, for user.
+// The password history and breached corpus are checked by the caller.
+func (c *PolicyConfig) checkPolicy(user *models.User, password string) []string {
+	var violations []string
+
+	if n := utf8.RuneCountInString(password); n < c.MinLength {
+		violations = append(violations, fmt.Sprintf("Must be at least %d characters long", c.MinLength))
+	}
+	if c.CharClasses > 0 && charClasses(password) < c.CharClasses {
+		violations = append(violations, fmt.Sprintf("Must contain at least %d of: lower case letters, upper case letters, digits and symbols", c.CharClasses))
+	}
+	if c.RejectIdentity && containsIdentity(password, user.Email, user.Name) {
+		violations = append(violations, "Must not contain your e-mail address or name")
+	}
+	return violations
+}
+
+// policyError returns an InvalidArgument error,
+// with the violations as BadRequest details.
+func policyError(violations []string) error {
+	br := &errdetails.BadRequest{
+		FieldViolations: make([]*errdetails.BadRequest_FieldViolation, len(violations)),
+	}
+	for i, v := range violations {
+		br.FieldViolations[i] = &errdetails.BadRequest_FieldViolation{
+			Field:       policyField,
+			Description: v,
+		}
+	}
+	st, err := status.New(codes.InvalidArgument, errPasswordPolicy).WithDetails(br)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, errPasswordPolicy)
+	}
+	return st.Err()
+}
+
+// breachedCorpus is a file of breached password hashes,
+// in the format of the "Have I Been Pwned" Pwned Passwords download, ordered by hash.
+// Each line holds the upper case hexadecimal SHA-1 hash of a password,
+// followed by a colon and the amount of times it was seen in breaches.
+// The file is searched in place, so it doesn't need to fit in memory.
+type breachedCorpus struct {
+	r    io.ReaderAt
+	size int64
+}
+
+var errCorpusLine = errors.New("Breached corpus: line too long")
+
+// corpusLineLen is the maximum length of a line in the breached corpus.
+const corpusLineLen = 128
+
+func openBreachedCorpus(name string) (*breachedCorpus, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &breachedCorpus{f, fi.Size()}, nil
+}
+
+// hashAfter returns the hash on the first line which starts at or after off.
+// An empty string is returned when there is no such line.
+// buf is used for reading and must be corpusLineLen*2 long.
+func (b *breachedCorpus) hashAfter(buf []byte, off int64) (string, error) {
+	start := off
+	if off > 0 {
+		// Include the preceding byte, to detect if off is the start of a line.
+		start--
+	}
+	n, err := b.r.ReadAt(buf, start)
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	line := buf[:n]
+
+	if off > 0 {
+		i := bytes.IndexByte(line, '\n')
+		if i < 0 {
+			if n < len(buf) {
+				return "", nil // Last line
+			}
+			return "", errCorpusLine
+		}
+		line = line[i+1:]
+	}
+	if i := bytes.IndexByte(line, '\n'); i >= 0 {
+		line = line[:i]
+	}
+	if i := bytes.IndexByte(line, ':'); i >= 0 {
+		line = line[:i]
+	}
+	return strings.ToUpper(string(bytes.TrimSpace(line))), nil
+}
+
+// contains reports if the SHA-1 hash of password is in the corpus.
+// It searches for the first offset where a line with a hash at or above
+// the wanted hash starts.
+func (b *breachedCorpus) contains(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	want := strings.ToUpper(hex.EncodeToString(sum[:]))
+	buf := make([]byte, corpusLineLen*2)
+
+	lo, hi := int64(0), b.size
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		hash, err := b.hashAfter(buf, mid)
+		if err != nil {
+			return false, err
+		}
+		if hash == "" || hash >= want {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+
+	hash, err := b.hashAfter(buf, lo)
+	return hash == want, err
+}
+
+// checkHistory reports if password matches the current password hash of user,
+// or one of the previous passwords kept by the policy.
+func (rt *requestTx) checkHistory(user *models.User, current, password string) (bool, error) {
+	n := rt.s.conf.Policy.History
+	if n == 0 {
+		return false, nil
+	}
+
+	var hashes []string
+	if current != "" {
+		hashes = append(hashes, current)
+	}
+	if n > 1 {
+		phs, err := user.PasswordHistories(
+			qm.OrderBy(models.PasswordHistoryColumns.CreatedAt+" desc, "+models.PasswordHistoryColumns.ID+" desc"),
+			qm.Limit(n-1),
+		).All(rt.ctx, rt.tx)
+		if err != nil {
+			rt.log.WithError(err).Error("checkHistory")
+			return false, status.Error(codes.Internal, errDB)
+		}
+		for _, ph := range phs {
+			hashes = append(hashes, ph.Hash)
+		}
+	}
+
+	for _, h := range hashes {
+		ok, err := verifyPassword(h, password)
+		if err != nil {
+			// Unverifiable hashes can't be matched and shouldn't prevent a new password.
+			rt.log.WithError(err).Warn("checkHistory")
+			continue
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// rotateHistory keeps hash as a previous password of user,
+// and deletes the ones which are no longer needed by the policy.
+func (rt *requestTx) rotateHistory(user *models.User, hash string) error {
+	n := rt.s.conf.Policy.History - 1
+	if n < 1 {
+		return nil
+	}
+
+	ph := &models.PasswordHistory{Hash: hash}
+	if err := user.AddPasswordHistories(rt.ctx, rt.tx, true, ph); err != nil {
+		rt.log.WithError(err).Error("rotateHistory")
+		return status.Error(codes.Internal, errDB)
+	}
+
+	keep := models.PasswordHistories(
+		qm.Select(models.PasswordHistoryColumns.ID),
+		models.PasswordHistoryWhere.UserID.EQ(user.ID),
+		qm.OrderBy(models.PasswordHistoryColumns.CreatedAt+" desc, "+models.PasswordHistoryColumns.ID+" desc"),
+		qm.Limit(n),
+	)
+	ids, err := keep.All(rt.ctx, rt.tx)
+	if err != nil {
+		rt.log.WithError(err).Error("rotateHistory")
+		return status.Error(codes.Internal, errDB)
+	}
+	keepIDs := make([]int, len(ids))
+	for i, ph := range ids {
+		keepIDs[i] = ph.ID
+	}
+
+	if _, err = models.PasswordHistories(
+		models.PasswordHistoryWhere.UserID.EQ(user.ID),
+		models.PasswordHistoryWhere.ID.NIN(keepIDs),
+	).DeleteAll(rt.ctx, rt.tx); err != nil {
+		rt.log.WithError(err).Error("rotateHistory")
+		return status.Error(codes.Internal, errDB)
+	}
+	return nil
+}
+
+// checkPasswordPolicy returns an InvalidArgument error with all violations,
+// if password does not meet the policy for user.
+// current is the hash of the password being replaced, if any.
+func (rt *requestTx) checkPasswordPolicy(user *models.User, current, password string) error {
+	c := &rt.s.conf.Policy
+	violations := c.checkPolicy(user, password)
+
+	if rt.s.breached != nil {
+		breached, err := rt.s.breached.contains(password)
+		if err != nil {
+			rt.log.WithError(err).Error("breachedCorpus")
+			return status.Error(codes.Internal, errFatal)
+		}
+		if breached {
+			violations = append(violations, "Appears in a list of breached passwords, please choose another")
+		}
+	}
+
+	reused, err := rt.checkHistory(user, current, password)
+	if err != nil {
+		return err
+	}
+	if reused {
+		violations = append(violations, fmt.Sprintf("Must not be one of your last %d passwords", c.History))
+	}
+
+	if len(violations) > 0 {
+		rt.log.WithField("violations", violations).Warn(errPasswordPolicy)
+		return policyError(violations)
+	}
+	return nil
+}
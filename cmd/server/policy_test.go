@@ -0,0 +1,339 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/moapis/authenticator/models"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPolicyConfig_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       PolicyConfig
+		wantErr bool
+	}{
+		{"Default", Default.Policy, false},
+		{"Disabled", PolicyConfig{}, false},
+		{"Negative length", PolicyConfig{MinLength: -1}, true},
+		{"Too many classes", PolicyConfig{CharClasses: 5}, true},
+		{"Negative history", PolicyConfig{History: -1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.c.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("PolicyConfig.validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_charClasses(t *testing.T) {
+	tests := []struct {
+		password string
+		want     int
+	}{
+		{"", 0},
+		{"abc", 1},
+		{"aBc", 2},
+		{"aB1", 3},
+		{"aB1!", 4},
+		{"éÉ٣ ", 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.password, func(t *testing.T) {
+			if got := charClasses(tt.password); got != tt.want {
+				t.Errorf("charClasses() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_containsIdentity(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		email    string
+		userName string
+		want     bool
+	}{
+		{"Unrelated", "correct horse", "john@example.com", "John Doe", false},
+		{"Email local part", "xJohn123", "john@example.com", "", true},
+		{"Full email", "john@example.com", "john@example.com", "", true},
+		{"Name", "my name is john doe", "jd@example.com", "John Doe", true},
+		{"Short local part", "jd123456", "jd@example.com", "", false},
+		{"Domain only", "example.com!", "john@example.com", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := containsIdentity(tt.password, tt.email, tt.userName); got != tt.want {
+				t.Errorf("containsIdentity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicyConfig_checkPolicy(t *testing.T) {
+	c := PolicyConfig{
+		MinLength:      8,
+		CharClasses:    3,
+		RejectIdentity: true,
+	}
+	user := &models.User{Email: "john@example.com", Name: "John Doe"}
+
+	tests := []struct {
+		name     string
+		password string
+		want     []string
+	}{
+		{
+			"Valid",
+			"Correct horse 1",
+			nil,
+		},
+		{
+			"All violations",
+			"john",
+			[]string{
+				"Must be at least 8 characters long",
+				"Must contain at least 3 of: lower case letters, upper case letters, digits and symbols",
+				"Must not contain your e-mail address or name",
+			},
+		},
+		{
+			"Multibyte length",
+			"Äöü1ÄÖÜ",
+			[]string{"Must be at least 8 characters long"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.checkPolicy(user, tt.password); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PolicyConfig.checkPolicy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := (&PolicyConfig{}).checkPolicy(user, "a"); got != nil {
+		t.Errorf("PolicyConfig.checkPolicy() = %v, want %v", got, nil)
+	}
+}
+
+// violations returns the field violations from a policy error.
+func violations(err error) []string {
+	var v []string
+	for _, d := range status.Convert(err).Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, fv := range br.GetFieldViolations() {
+				v = append(v, fv.GetField()+": "+fv.GetDescription())
+			}
+		}
+	}
+	return v
+}
+
+func Test_policyError(t *testing.T) {
+	err := policyError([]string{"foo", "bar"})
+	if c := status.Code(err); c != codes.InvalidArgument {
+		t.Errorf("policyError() code = %v, want %v", c, codes.InvalidArgument)
+	}
+	want := []string{"new_password: foo", "new_password: bar"}
+	if got := violations(err); !reflect.DeepEqual(got, want) {
+		t.Errorf("policyError() violations = %v, want %v", got, want)
+	}
+}
+
+func sha1Hex(password string) string {
+	sum := sha1.Sum([]byte(password))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+// testCorpus returns a sorted breached corpus with the hashes of passwords,
+// and filler lines between them.
+func testCorpus(newline string, passwords ...string) string {
+	var lines []string
+	for i := 0; i < 1000; i++ {
+		lines = append(lines, fmt.Sprintf("%s:%d", sha1Hex(fmt.Sprint("filler", i)), i+1))
+	}
+	for _, p := range passwords {
+		lines = append(lines, fmt.Sprintf("%s:%d", sha1Hex(p), 12345))
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, newline)
+}
+
+func Test_breachedCorpus_contains(t *testing.T) {
+	breached := []string{"password", "123456", "letmein"}
+
+	tests := []struct {
+		name    string
+		corpus  string
+		wantErr bool
+	}{
+		{"LF", testCorpus("\n", breached...) + "\n", false},
+		{"CRLF", testCorpus("\r\n", breached...) + "\r\n", false},
+		{"No trailing newline", testCorpus("\n", breached...), false},
+		{"Lower case", strings.ToLower(testCorpus("\n", breached...)), false},
+		{"Line too long", strings.Repeat("F", 1000), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &breachedCorpus{strings.NewReader(tt.corpus), int64(len(tt.corpus))}
+
+			for _, p := range append(breached, "filler0", "filler999") {
+				got, err := b.contains(p)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("breachedCorpus.contains(%q) error = %v, wantErr %v", p, err, tt.wantErr)
+				}
+				if !tt.wantErr && !got {
+					t.Errorf("breachedCorpus.contains(%q) = %v, want %v", p, got, true)
+				}
+			}
+			if tt.wantErr {
+				return
+			}
+			for _, p := range []string{"", "correct horse battery staple", "filler1000"} {
+				if got, err := b.contains(p); got || err != nil {
+					t.Errorf("breachedCorpus.contains(%q) = %v, %v, want %v", p, got, err, false)
+				}
+			}
+		})
+	}
+
+	// The password with the highest hash is on the last line,
+	// which has no trailing newline.
+	candidates := append([]string{}, breached...)
+	for i := 0; i < 1000; i++ {
+		candidates = append(candidates, fmt.Sprint("filler", i))
+	}
+	last := candidates[0]
+	for _, p := range candidates {
+		if sha1Hex(p) > sha1Hex(last) {
+			last = p
+		}
+	}
+	corpus := testCorpus("\n", breached...)
+	if lastLine := corpus[strings.LastIndexByte(corpus, '\n')+1:]; !strings.HasPrefix(lastLine, sha1Hex(last)+":") {
+		t.Fatalf("testCorpus() last line = %q, want hash %s", lastLine, sha1Hex(last))
+	}
+	b := &breachedCorpus{strings.NewReader(corpus), int64(len(corpus))}
+	if got, err := b.contains(last); !got || err != nil {
+		t.Errorf("breachedCorpus.contains(%q) = %v, %v, want %v", last, got, err, true)
+	}
+
+	empty := &breachedCorpus{strings.NewReader(""), 0}
+	if got, err := empty.contains("password"); got || err != nil {
+		t.Errorf("breachedCorpus.contains() = %v, %v, want %v", got, err, false)
+	}
+}
+
+func Test_openBreachedCorpus(t *testing.T) {
+	f, err := ioutil.TempFile("", "breached")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	if _, err = f.WriteString(testCorpus("\r\n", "password")); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	b, err := openBreachedCorpus(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := b.contains("password"); !got || err != nil {
+		t.Errorf("breachedCorpus.contains() = %v, %v, want %v", got, err, true)
+	}
+
+	if _, err = openBreachedCorpus("foo/bar"); err == nil {
+		t.Errorf("openBreachedCorpus() error = %v, want error", err)
+	}
+}
+
+func policyServer(c PolicyConfig, corpus string) *authServer {
+	conf := *tas.conf
+	conf.Policy = c
+	s := &authServer{
+		mdb:     mdb,
+		log:     tas.log,
+		conf:    &conf,
+		privKey: tas.privateKey(),
+		mfaKEK:  tas.mfaKEK,
+		mail:    tas.mail,
+	}
+	if corpus != "" {
+		s.breached = &breachedCorpus{strings.NewReader(corpus), int64(len(corpus))}
+	}
+	return s
+}
+
+func Test_requestTx_setUserPassword_policy(t *testing.T) {
+	s := policyServer(PolicyConfig{
+		MinLength:      8,
+		RejectIdentity: true,
+		History:        3,
+	}, testCorpus("\n", "breached password"))
+
+	rt, err := s.newTx(testCtx, "testing", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rt.done()
+
+	user := testUsers["allGroups"]
+
+	steps := []struct {
+		password string
+		want     []string
+	}{
+		{"short", []string{"new_password: Must be at least 8 characters long"}},
+		{"AllGroups password", []string{"new_password: Must not contain your e-mail address or name"}},
+		{"breached password", []string{"new_password: Appears in a list of breached passwords, please choose another"}},
+		{"first password", nil},
+		{"first password", []string{"new_password: Must not be one of your last 3 passwords"}},
+		{"second password", nil},
+		{"third password", nil},
+		{"first password", []string{"new_password: Must not be one of your last 3 passwords"}},
+		{"fourth password", nil},
+		{"first password", nil},
+	}
+	for i, st := range steps {
+		err := rt.setUserPassword(user, st.password, rand.Read)
+		if (err != nil) != (st.want != nil) {
+			t.Fatalf("%d: requestTx.setUserPassword(%q) error = %v, want %v", i, st.password, err, st.want)
+		}
+		if err == nil {
+			continue
+		}
+		if c := status.Code(err); c != codes.InvalidArgument {
+			t.Errorf("%d: requestTx.setUserPassword(%q) code = %v, want %v", i, st.password, c, codes.InvalidArgument)
+		}
+		if got := violations(err); !reflect.DeepEqual(got, st.want) {
+			t.Errorf("%d: requestTx.setUserPassword(%q) violations = %v, want %v", i, st.password, got, st.want)
+		}
+	}
+
+	n, err := user.PasswordHistories().Count(testCtx, rt.tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Errorf("password history count = %v, want %v", n, 2)
+	}
+}
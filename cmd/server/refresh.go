@@ -0,0 +1,165 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"errors"
+	"time"
+
+	auth "github.com/moapis/authenticator"
+	"github.com/moapis/authenticator/models"
+	"github.com/sirupsen/logrus"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// refreshLen is the amount of random bytes in a refresh token.
+	refreshLen = 32
+	// familyLen is the amount of random bytes in a refresh token family ID.
+	familyLen = 16
+)
+
+// hashRefreshToken returns the SHA-256 hash of tkn, as stored in the database.
+// Refresh tokens are random and long enough, so they don't need salting or stretching.
+func hashRefreshToken(tkn string) []byte {
+	h := sha256.Sum256([]byte(tkn))
+	return h[:]
+}
+
+// issueRefreshToken inserts a new refresh token for user.
+// A new family is started when family is empty.
+// Refresh tokens and families must be unique in the database,
+// so they are always read from crypto/rand and not from authServer.readRand.
+func (rt *requestTx) issueRefreshToken(userID int, family string, issued time.Time) (string, time.Time, error) {
+	log := rt.log.WithFields(logrus.Fields{"user_id": userID, "family": family})
+
+	var err error
+	if family == "" {
+		if family, err = randomString(rand.Read, familyLen); err != nil {
+			log.WithError(err).Error("issueRefreshToken")
+			return "", time.Time{}, status.Error(codes.Internal, errFatal)
+		}
+	}
+	tkn, err := randomString(rand.Read, refreshLen)
+	if err != nil {
+		log.WithError(err).Error("issueRefreshToken")
+		return "", time.Time{}, status.Error(codes.Internal, errFatal)
+	}
+
+	m := &models.RefreshToken{
+		UserID:    userID,
+		Family:    family,
+		Hash:      hashRefreshToken(tkn),
+		ExpiresAt: issued.Add(rt.s.conf.JWT.RefreshExpiry),
+	}
+	if err = m.Insert(rt.ctx, rt.tx, boil.Infer()); err != nil {
+		log.WithError(err).Error("issueRefreshToken")
+		return "", time.Time{}, status.Error(codes.Internal, errDB)
+	}
+	log.WithField("id", m.ID).Debug("issueRefreshToken")
+	return tkn, m.ExpiresAt, nil
+}
+
+// findRefreshToken looks up tkn by its hash, locking the entry for update.
+func (rt *requestTx) findRefreshToken(tkn string) (*models.RefreshToken, error) {
+	if tkn == "" {
+		rt.log.Warn(errMissingRefresh)
+		return nil, status.Error(codes.InvalidArgument, errMissingRefresh)
+	}
+	m, err := models.RefreshTokens(
+		models.RefreshTokenWhere.Hash.EQ(hashRefreshToken(tkn)),
+		qm.For("update"),
+	).One(rt.ctx, rt.tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			rt.log.WithError(err).Warn("findRefreshToken")
+			return nil, status.Error(codes.Unauthenticated, errRefreshToken)
+		}
+		rt.log.WithError(err).Error("findRefreshToken")
+		return nil, status.Error(codes.Internal, errDB)
+	}
+	rt.log = rt.log.WithFields(logrus.Fields{"refresh_id": m.ID, "family": m.Family})
+	return m, nil
+}
+
+// revokeRefreshFamily revokes all not yet revoked refresh tokens in family.
+func (rt *requestTx) revokeRefreshFamily(family string, revoked time.Time) error {
+	n, err := models.RefreshTokens(
+		models.RefreshTokenWhere.Family.EQ(family),
+		models.RefreshTokenWhere.RevokedAt.IsNull(),
+	).UpdateAll(rt.ctx, rt.tx, models.M{
+		models.RefreshTokenColumns.RevokedAt: null.TimeFrom(revoked),
+	})
+	if err != nil {
+		rt.log.WithError(err).Error("revokeRefreshFamily")
+		return status.Error(codes.Internal, errDB)
+	}
+	rt.log.WithField("n", n).Info("revokeRefreshFamily")
+	return nil
+}
+
+// useRefreshToken marks tkn as used and returns its database entry.
+// Use of an already used or revoked token indicates the token was stolen,
+// so all tokens in its family are revoked and committed, before returning an error.
+func (rt *requestTx) useRefreshToken(tkn string, now time.Time) (*models.RefreshToken, error) {
+	m, err := rt.findRefreshToken(tkn)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.UsedAt.Valid || m.RevokedAt.Valid {
+		rt.log.Warn(errRefreshReused)
+		if err = rt.revokeRefreshFamily(m.Family, now); err != nil {
+			return nil, err
+		}
+		if err = rt.commit(); err != nil {
+			return nil, err
+		}
+		return nil, status.Error(codes.Unauthenticated, errRefreshReused)
+	}
+	if m.ExpiresAt.Before(now) {
+		rt.log.WithField("expires_at", m.ExpiresAt).Warn(errRefreshExpired)
+		return nil, status.Error(codes.Unauthenticated, errRefreshExpired)
+	}
+
+	m.UsedAt = null.TimeFrom(now)
+	if _, err = m.Update(rt.ctx, rt.tx, boil.Whitelist(models.RefreshTokenColumns.UsedAt)); err != nil {
+		rt.log.WithError(err).Error("useRefreshToken")
+		return nil, status.Error(codes.Internal, errDB)
+	}
+	return m, nil
+}
+
+// refreshAuthReply builds a token for user, accompanied by a new refresh token in family.
+func (rt *requestTx) refreshAuthReply(user *models.User, family string, issued time.Time) (*auth.AuthReply, error) {
+	tkn, expires, err := rt.issueRefreshToken(user.ID, family, issued)
+	if err != nil {
+		return nil, err
+	}
+	reply, err := rt.userAuthReply(user, issued)
+	if err != nil {
+		return nil, err
+	}
+	reply.RefreshToken = tkn
+	reply.RefreshExpires = expires.Unix()
+	return reply, nil
+}
+
+// pruneRefreshTokens deletes refresh tokens which expired before now.
+func (s *authServer) pruneRefreshTokens(ctx context.Context, now time.Time) (int64, error) {
+	db, err := s.mdb.Master(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return models.RefreshTokens(models.RefreshTokenWhere.ExpiresAt.LT(now)).DeleteAll(ctx, db)
+}
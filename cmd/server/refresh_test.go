@@ -0,0 +1,185 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/moapis/authenticator/models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+func Test_hashRefreshToken(t *testing.T) {
+	a := hashRefreshToken("foo")
+	if len(a) != 32 {
+		t.Errorf("hashRefreshToken() = %v, want 32 bytes", a)
+	}
+	if !bytes.Equal(a, hashRefreshToken("foo")) {
+		t.Errorf("hashRefreshToken() = %v, want %v", hashRefreshToken("foo"), a)
+	}
+	if bytes.Equal(a, hashRefreshToken("bar")) {
+		t.Errorf("hashRefreshToken() = %v, want different hashes", a)
+	}
+}
+
+func Test_requestTx_issueRefreshToken(t *testing.T) {
+	issued := time.Unix(1000, 0)
+
+	tests := []struct {
+		name    string
+		family  string
+		wantErr bool
+	}{
+		{
+			"New family",
+			"",
+			false,
+		},
+		{
+			"Existing family",
+			"issue-family",
+			false,
+		},
+		{
+			"DB error",
+			"",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt, err := tas.newTx(testCtx, "testing", false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer rt.done()
+			if tt.wantErr {
+				rt.done()
+			}
+
+			tkn, expires, err := rt.issueRefreshToken(testUsers["allGroups"].ID, tt.family, issued)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("requestTx.issueRefreshToken() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if want := issued.Add(tas.conf.JWT.RefreshExpiry); !expires.Equal(want) {
+				t.Errorf("requestTx.issueRefreshToken() expires = %v, want %v", expires, want)
+			}
+
+			m, err := rt.findRefreshToken(tkn)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if m.UserID != testUsers["allGroups"].ID || m.Family == "" || (tt.family != "" && m.Family != tt.family) {
+				t.Errorf("requestTx.issueRefreshToken() = %v, want user %v in family %q", m, testUsers["allGroups"].ID, tt.family)
+			}
+		})
+	}
+}
+
+func Test_requestTx_revokeRefreshFamily(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			"Success",
+			false,
+		},
+		{
+			"DB error",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt, err := tas.newTx(testCtx, "testing", false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer rt.done()
+
+			var tkns []string
+			for i := 0; i < 2; i++ {
+				tkn, _, err := rt.issueRefreshToken(testUsers["allGroups"].ID, "revoke-family", time.Now())
+				if err != nil {
+					t.Fatal(err)
+				}
+				tkns = append(tkns, tkn)
+			}
+			if tt.wantErr {
+				rt.done()
+			}
+
+			if err = rt.revokeRefreshFamily("revoke-family", time.Now()); (err != nil) != tt.wantErr {
+				t.Errorf("requestTx.revokeRefreshFamily() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			for _, tkn := range tkns {
+				m, err := rt.findRefreshToken(tkn)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !m.RevokedAt.Valid {
+					t.Errorf("requestTx.revokeRefreshFamily() did not revoke %v", m.ID)
+				}
+			}
+		})
+	}
+}
+
+func Test_authServer_pruneRefreshTokens(t *testing.T) {
+	db, err := mdb.Master(testCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &models.RefreshToken{
+		UserID:    testUsers["allGroups"].ID,
+		Family:    "prune",
+		Hash:      hashRefreshToken("prune"),
+		ExpiresAt: time.Unix(500, 0),
+	}
+	if err = m.Insert(testCtx, db, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(501, 0)
+
+	tests := []struct {
+		name    string
+		want    int64
+		wantErr bool
+	}{
+		{
+			"Success",
+			1,
+			false,
+		},
+		{
+			"Nothing to prune",
+			0,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tas.pruneRefreshTokens(testCtx, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("authServer.pruneRefreshTokens() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("authServer.pruneRefreshTokens() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
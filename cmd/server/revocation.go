@@ -0,0 +1,118 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	auth "github.com/moapis/authenticator"
+	"github.com/moapis/authenticator/models"
+	"github.com/sirupsen/logrus"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// jtiLen is the amount of random bytes in a token ID.
+const jtiLen = 16
+
+// randomString returns n bytes from read, base64 URL encoded.
+// read defaults to crypto/rand.Read when nil.
+func randomString(read func([]byte) (int, error), n int) (string, error) {
+	if read == nil {
+		read = rand.Read
+	}
+	b := make([]byte, n)
+	if _, err := read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// newJTI returns a random token ID, for the jti claim.
+func (s *authServer) newJTI() (string, error) {
+	return randomString(s.readRand, jtiLen)
+}
+
+// maxTokenExpiry returns the longest lifetime of the tokens signed by the server.
+func (s *authServer) maxTokenExpiry() time.Duration {
+	max := mfaExpiry
+	for _, d := range []time.Duration{
+		s.conf.JWT.Expiry,
+		s.conf.JWT.LoginLinkExpiry,
+		s.conf.JWT.ResetExpiry,
+		s.conf.JWT.VerifyExpiry,
+	} {
+		if d > max {
+			max = d
+		}
+	}
+	return max
+}
+
+// revokeToken stores jti in the revocation list, until expires.
+// Revoking an already revoked token is not an error.
+func (rt *requestTx) revokeToken(jti string, expires time.Time) error {
+	log := rt.log.WithFields(logrus.Fields{"jti": jti, "expires": expires})
+	if jti == "" {
+		log.Warn(errMissingJTI)
+		return status.Error(codes.InvalidArgument, errMissingJTI)
+	}
+
+	m := &models.RevokedToken{
+		Jti:       jti,
+		ExpiresAt: expires,
+	}
+	if err := m.Upsert(rt.ctx, rt.tx, false, []string{models.RevokedTokenColumns.Jti}, boil.Infer(), boil.Infer()); err != nil {
+		log.WithError(err).Error("revokeToken")
+		return status.Error(codes.Internal, errDB)
+	}
+	log.Debug("revokeToken")
+	return nil
+}
+
+// isRevoked returns true if jti is in the revocation list.
+func (rt *requestTx) isRevoked(jti string) (bool, error) {
+	ok, err := models.RevokedTokens(models.RevokedTokenWhere.Jti.EQ(jti)).Exists(rt.ctx, rt.tx)
+	if err != nil {
+		rt.log.WithError(err).WithField("jti", jti).Error("isRevoked")
+		return false, status.Error(codes.Internal, errDB)
+	}
+	return ok, nil
+}
+
+// revokedTokens returns the revoked tokens which did not expire before now.
+func (rt *requestTx) revokedTokens(now time.Time) (*auth.RevokedTokens, error) {
+	ms, err := models.RevokedTokens(models.RevokedTokenWhere.ExpiresAt.GTE(now)).All(rt.ctx, rt.tx)
+	if err != nil {
+		rt.log.WithError(err).Error("revokedTokens")
+		return nil, status.Error(codes.Internal, errDB)
+	}
+	rt.log.WithField("tokens", len(ms)).Debug("revokedTokens")
+
+	list := &auth.RevokedTokens{
+		Tokens: make([]*auth.RevokedToken, len(ms)),
+	}
+	for i, m := range ms {
+		list.Tokens[i] = &auth.RevokedToken{
+			Jti:     m.Jti,
+			Expires: m.ExpiresAt.Unix(),
+		}
+	}
+	return list, nil
+}
+
+// pruneRevoked deletes revocations of tokens which expired before now.
+// Such tokens are rejected regardless.
+func (s *authServer) pruneRevoked(ctx context.Context, now time.Time) (int64, error) {
+	db, err := s.mdb.Master(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return models.RevokedTokens(models.RevokedTokenWhere.ExpiresAt.LT(now)).DeleteAll(ctx, db)
+}
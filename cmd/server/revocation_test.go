@@ -0,0 +1,297 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/moapis/authenticator/models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// zeroRand fills b with zeros, resulting in predictable token IDs.
+func zeroRand(b []byte) (int, error) {
+	for i := range b {
+		b[i] = 0
+	}
+	return len(b), nil
+}
+
+// insertRevoked commits a revocation of jti to the database.
+func insertRevoked(t *testing.T, jti string, expires time.Time) {
+	db, err := mdb.Master(testCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &models.RevokedToken{
+		Jti:       jti,
+		ExpiresAt: expires,
+	}
+	if err = m.Insert(testCtx, db, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_authServer_newJTI(t *testing.T) {
+	tests := []struct {
+		name     string
+		readRand func([]byte) (int, error)
+		want     string
+		wantErr  bool
+	}{
+		{
+			"Zero",
+			zeroRand,
+			"AAAAAAAAAAAAAAAAAAAAAA",
+			false,
+		},
+		{
+			"Read error",
+			func([]byte) (int, error) { return 0, errors.New("foo") },
+			"",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &authServer{readRand: tt.readRand}
+			got, err := s.newJTI()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("authServer.newJTI() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("authServer.newJTI() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	s := new(authServer)
+	a, err := s.newJTI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := s.newJTI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a == b || len(a) != 22 {
+		t.Errorf("authServer.newJTI() = %v, %v, want unique IDs of length 22", a, b)
+	}
+}
+
+func Test_authServer_maxTokenExpiry(t *testing.T) {
+	tests := []struct {
+		name string
+		conf JWTConfig
+		want time.Duration
+	}{
+		{"Zero", JWTConfig{}, mfaExpiry},
+		{"Expiry", JWTConfig{Expiry: time.Hour, ResetExpiry: time.Minute}, time.Hour},
+		{"Verify", JWTConfig{Expiry: time.Hour, VerifyExpiry: 48 * time.Hour}, 48 * time.Hour},
+		{"Login link", JWTConfig{LoginLinkExpiry: 15 * time.Minute}, 15 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &authServer{conf: &ServerConfig{JWT: tt.conf}}
+			if got := s.maxTokenExpiry(); got != tt.want {
+				t.Errorf("authServer.maxTokenExpiry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_requestTx_revokeToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		jti     string
+		wantErr bool
+	}{
+		{
+			"Missing token ID",
+			"",
+			true,
+		},
+		{
+			"Success",
+			"revoke-token",
+			false,
+		},
+		{
+			"DB error",
+			"revoke-token",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt, err := tas.newTx(testCtx, "testing", false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer rt.done()
+			if tt.name == "DB error" {
+				rt.done()
+			}
+
+			if err = rt.revokeToken(tt.jti, time.Now().Add(time.Hour)); (err != nil) != tt.wantErr {
+				t.Errorf("requestTx.revokeToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			// Revoking twice is not an error
+			if err = rt.revokeToken(tt.jti, time.Now().Add(time.Hour)); err != nil {
+				t.Errorf("requestTx.revokeToken() error = %v, wantErr %v", err, false)
+			}
+			revoked, err := rt.isRevoked(tt.jti)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !revoked {
+				t.Errorf("requestTx.revokeToken() did not revoke %q", tt.jti)
+			}
+		})
+	}
+}
+
+func Test_requestTx_isRevoked(t *testing.T) {
+	insertRevoked(t, "is-revoked", time.Now().Add(time.Hour))
+
+	tests := []struct {
+		name    string
+		jti     string
+		want    bool
+		wantErr bool
+	}{
+		{
+			"Revoked",
+			"is-revoked",
+			true,
+			false,
+		},
+		{
+			"Not revoked",
+			"not-revoked",
+			false,
+			false,
+		},
+		{
+			"DB error",
+			"is-revoked",
+			false,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt, err := tas.newTx(testCtx, "testing", true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer rt.done()
+			if tt.name == "DB error" {
+				rt.done()
+			}
+
+			got, err := rt.isRevoked(tt.jti)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("requestTx.isRevoked() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("requestTx.isRevoked() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_requestTx_revokedTokens(t *testing.T) {
+	now := time.Now()
+	insertRevoked(t, "listed", now.Add(time.Hour))
+	insertRevoked(t, "expired", now.Add(-time.Hour))
+
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			"Success",
+			false,
+		},
+		{
+			"DB error",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt, err := tas.newTx(testCtx, "testing", true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer rt.done()
+			if tt.wantErr {
+				rt.done()
+			}
+
+			got, err := rt.revokedTokens(now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("requestTx.revokedTokens() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			listed := make(map[string]int64)
+			for _, tkn := range got.GetTokens() {
+				listed[tkn.GetJti()] = tkn.GetExpires()
+			}
+			if exp, ok := listed["listed"]; !ok || exp != now.Add(time.Hour).Unix() {
+				t.Errorf("requestTx.revokedTokens() = %v, want %q expiring at %v", got, "listed", now.Add(time.Hour).Unix())
+			}
+			if _, ok := listed["expired"]; ok {
+				t.Errorf("requestTx.revokedTokens() = %v, want without %q", got, "expired")
+			}
+		})
+	}
+}
+
+func Test_authServer_pruneRevoked(t *testing.T) {
+	insertRevoked(t, "prune", time.Unix(1000, 0))
+	now := time.Unix(1001, 0)
+
+	tests := []struct {
+		name    string
+		want    int64
+		wantErr bool
+	}{
+		{
+			"Success",
+			1,
+			false,
+		},
+		{
+			"Nothing to prune",
+			0,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tas.pruneRevoked(testCtx, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("authServer.pruneRevoked() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("authServer.pruneRevoked() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/moapis/authenticator/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// retireKey marks the key with id as no longer used for signing.
+// It's expiry is set to the moment of retirement.
+func retireKey(ctx context.Context, exec boil.ContextExecutor, id string, retired time.Time) error {
+	kid, err := strconv.Atoi(id)
+	if err != nil {
+		return err
+	}
+	_, err = models.JWTKeys(models.JWTKeyWhere.ID.EQ(kid)).UpdateAll(ctx, exec, models.M{
+		models.JWTKeyColumns.RetiredAt: null.TimeFrom(retired),
+		models.JWTKeyColumns.ExpiresAt: null.TimeFrom(retired),
+	})
+	return err
+}
+
+// pruneKeys deletes keys which expired more than a token lifetime ago.
+// Tokens signed by such keys can no longer be valid.
+// The key this server signs with is never deleted,
+// even when its rotation is overdue.
+func (s *authServer) pruneKeys(ctx context.Context, now time.Time) (int64, error) {
+	db, err := s.mdb.Master(ctx)
+	if err != nil {
+		return 0, err
+	}
+	mods := []qm.QueryMod{
+		models.JWTKeyWhere.ExpiresAt.LT(null.TimeFrom(now.Add(-s.conf.JWT.Expiry))),
+	}
+	if id, err := strconv.Atoi(s.privateKey().id); err == nil {
+		mods = append(mods, models.JWTKeyWhere.ID.NEQ(id))
+	}
+	return models.JWTKeys(mods...).DeleteAll(ctx, db)
+}
+
+// Bounds of the backoff for retrying failed key updates.
+const (
+	minRetryWait = time.Second
+	maxRetryWait = 5 * time.Minute
+)
+
+// retryWait doubles the previous wait time,
+// bounded by minRetryWait and the lesser of maxRetryWait and every.
+func retryWait(wait, every time.Duration) time.Duration {
+	wait *= 2
+	if wait < minRetryWait {
+		wait = minRetryWait
+	}
+	if wait > maxRetryWait {
+		wait = maxRetryWait
+	}
+	if every > 0 && wait > every {
+		wait = every
+	}
+	return wait
+}
+
+func ticker(d time.Duration) (<-chan time.Time, func()) {
+	if d <= 0 {
+		return nil, func() {}
+	}
+	t := time.NewTicker(d)
+	return t.C, t.Stop
+}
+
+// maintainKeys rotates the signing key every JWT.RotateEvery
+// and prunes expired keys and revocations every JWT.PruneEvery, until ctx is done.
+// When keys are shared, the database is polled every JWT.Shared.Poll instead.
+// Errors are logged and failed key updates are retried with exponential backoff,
+// so that an overdue rotation doesn't wait for the next tick.
+func (s *authServer) maintainKeys(ctx context.Context, r io.Reader) {
+	every, update, name := s.conf.JWT.RotateEvery, s.updateKeyPair, "updateKeyPair"
+	if s.kek != nil {
+		every, update, name = s.conf.JWT.Shared.Poll, s.syncSharedKey, "syncSharedKey"
+	}
+
+	rotate, stopRotate := ticker(every)
+	defer stopRotate()
+	prune, stopPrune := ticker(s.conf.JWT.PruneEvery)
+	defer stopPrune()
+
+	log := s.log.WithField("method", "maintainKeys")
+
+	var (
+		retry   <-chan time.Time
+		backoff time.Duration
+	)
+	doUpdate := func() {
+		ctx, cancel := context.WithTimeout(ctx, time.Minute)
+		defer cancel()
+		if err := update(ctx, r); err != nil {
+			backoff = retryWait(backoff, every)
+			retry = time.After(backoff)
+			log.WithError(err).WithField("retry", backoff).Error(name)
+			return
+		}
+		retry, backoff = nil, 0
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.WithError(ctx.Err()).Info("Stopped")
+			return
+		case <-rotate:
+			doUpdate()
+		case <-retry:
+			doUpdate()
+		case now := <-prune:
+			ctx, cancel := context.WithTimeout(ctx, time.Minute)
+			n, err := s.pruneKeys(ctx, now)
+			if err != nil {
+				log.WithError(err).Error("pruneKeys")
+			} else {
+				log.WithField("n", n).Debug("pruneKeys")
+			}
+			n, err = s.pruneRevoked(ctx, now)
+			if err != nil {
+				log.WithError(err).Error("pruneRevoked")
+			} else {
+				log.WithField("n", n).Debug("pruneRevoked")
+			}
+			n, err = s.pruneRefreshTokens(ctx, now)
+			if err != nil {
+				log.WithError(err).Error("pruneRefreshTokens")
+			} else {
+				log.WithField("n", n).Debug("pruneRefreshTokens")
+			}
+			n, err = s.pruneAuthCodes(ctx, now)
+			if err != nil {
+				log.WithError(err).Error("pruneAuthCodes")
+			} else {
+				log.WithField("n", n).Debug("pruneAuthCodes")
+			}
+			n, err = s.pruneClientGrants(ctx)
+			if err != nil {
+				log.WithError(err).Error("pruneClientGrants")
+			} else {
+				log.WithField("n", n).Debug("pruneClientGrants")
+			}
+			n, err = s.pruneWebAuthnChallenges(ctx, now)
+			if err != nil {
+				log.WithError(err).Error("pruneWebAuthnChallenges")
+			} else {
+				log.WithField("n", n).Debug("pruneWebAuthnChallenges")
+			}
+			n, err = s.pruneLoginFailures(ctx, now)
+			if err != nil {
+				log.WithError(err).Error("pruneLoginFailures")
+			} else {
+				log.WithField("n", n).Debug("pruneLoginFailures")
+			}
+			n, err = s.pruneFederatedLogins(ctx, now)
+			if err != nil {
+				log.WithError(err).Error("pruneFederatedLogins")
+			} else {
+				log.WithField("n", n).Debug("pruneFederatedLogins")
+			}
+			cancel()
+		}
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moapis/authenticator/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+func Test_retireKey(t *testing.T) {
+	retired := time.Unix(1000, 0)
+
+	tests := []struct {
+		name    string
+		id      string
+		wantErr bool
+	}{
+		{
+			"Invalid ID",
+			"foo",
+			true,
+		},
+		{
+			"Success",
+			"10",
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, err := mdb.MasterTx(testCtx, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer tx.Rollback()
+
+			if err = retireKey(testCtx, tx, tt.id, retired); (err != nil) != tt.wantErr {
+				t.Errorf("retireKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			key, err := models.FindJWTKey(testCtx, tx, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !key.RetiredAt.Time.Equal(retired) || !key.ExpiresAt.Time.Equal(retired) {
+				t.Errorf("retireKey() = %v, %v, want %v", key.RetiredAt, key.ExpiresAt, retired)
+			}
+		})
+	}
+}
+
+func Test_authServer_pruneKeys(t *testing.T) {
+	db, err := mdb.Master(testCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []int{12, 13} {
+		expired := &models.JWTKey{
+			ID:        id,
+			PublicKey: []byte(testPubKey),
+			ExpiresAt: null.TimeFrom(time.Unix(1000, 0)),
+		}
+		if err = expired.Insert(testCtx, db, boil.Infer()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	defer models.JWTKeys(models.JWTKeyWhere.ID.EQ(13)).DeleteAll(testCtx, db)
+
+	// Overdue rotation, still signing with the expired key 13
+	s := &authServer{
+		mdb:     mdb,
+		log:     tas.log,
+		conf:    tas.conf,
+		privKey: privateKey{id: "13"},
+	}
+	now := time.Unix(1001, 0).Add(tas.conf.JWT.Expiry)
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		want    int64
+		wantErr bool
+	}{
+		{
+			"Success",
+			testCtx,
+			1,
+			false,
+		},
+		{
+			"Nothing to prune",
+			testCtx,
+			0,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.pruneKeys(tt.ctx, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("authServer.pruneKeys() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("authServer.pruneKeys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if ok, err := models.JWTKeyExists(testCtx, db, 13); err != nil || !ok {
+		t.Errorf("authServer.pruneKeys() deleted signing key: %v", err)
+	}
+}
+
+func Test_retryWait(t *testing.T) {
+	tests := []struct {
+		name  string
+		wait  time.Duration
+		every time.Duration
+		want  time.Duration
+	}{
+		{
+			"First retry",
+			0,
+			time.Hour,
+			minRetryWait,
+		},
+		{
+			"Double",
+			time.Minute,
+			time.Hour,
+			2 * time.Minute,
+		},
+		{
+			"Max wait",
+			4 * time.Minute,
+			time.Hour,
+			maxRetryWait,
+		},
+		{
+			"Rotation interval",
+			time.Minute,
+			90 * time.Second,
+			90 * time.Second,
+		},
+		{
+			"Rotation disabled",
+			time.Minute,
+			0,
+			2 * time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryWait(tt.wait, tt.every); got != tt.want {
+				t.Errorf("retryWait() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ticker(t *testing.T) {
+	c, stop := ticker(0)
+	if c != nil {
+		t.Errorf("ticker() = %v, want %v", c, nil)
+	}
+	stop()
+
+	c, stop = ticker(time.Millisecond)
+	defer stop()
+	select {
+	case <-c:
+	case <-time.After(time.Second):
+		t.Errorf("ticker() did not tick")
+	}
+}
+
+func Test_authServer_maintainKeys(t *testing.T) {
+	conf := *tas.conf
+	conf.JWT.RotateEvery = 10 * time.Millisecond
+	conf.JWT.PruneEvery = 10 * time.Millisecond
+
+	s := &authServer{
+		mdb:  mdb,
+		log:  tas.log,
+		conf: &conf,
+	}
+
+	ctx, cancel := context.WithTimeout(testCtx, 35*time.Millisecond)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.maintainKeys(ctx, strings.NewReader(strings.Repeat(testKeyInput, 10)))
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("authServer.maintainKeys() did not return")
+	}
+
+	if s.privateKey().id == "" {
+		t.Errorf("authServer.maintainKeys() did not rotate the key")
+	}
+}
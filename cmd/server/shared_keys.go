@@ -0,0 +1,216 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"time"
+
+	"github.com/moapis/authenticator/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// sharedKeyLock is the PostgreSQL advisory lock ID,
+// serializing shared key updates between server replicas.
+const sharedKeyLock = 0x6a77746b // "jwtk"
+
+const kekSize = 32
+
+var (
+	errNoKEK      = errors.New("No KEK or KEK file configured")
+	errKEKSize    = fmt.Errorf("KEK must be %d bytes", kekSize)
+	errSealedSize = errors.New("Encrypted data too short")
+	errSharedPoll = errors.New("Shared keys: poll interval must be positive")
+)
+
+// loadKEK decodes the key-encryption key from KEK or KEKFile.
+func (c *SharedKeyConfig) loadKEK() ([]byte, error) {
+	return loadKEK(c.KEK, c.KEKFile)
+}
+
+// loadKEK decodes a base64 encoded key-encryption key from enc,
+// or from file when enc is empty.
+func loadKEK(enc, file string) ([]byte, error) {
+	if enc == "" {
+		if file == "" {
+			return nil, errNoKEK
+		}
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		enc = string(bytes.TrimSpace(b))
+	}
+
+	kek, err := base64.StdEncoding.DecodeString(enc)
+	if err != nil {
+		return nil, err
+	}
+	if len(kek) != kekSize {
+		return nil, errKEKSize
+	}
+	return kek, nil
+}
+
+func newGCM(kek []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts plain with AES-GCM under kek.
+// ad is authenticated as additional data.
+// The returned value is the nonce, followed by the ciphertext.
+func seal(kek, plain, ad []byte, r io.Reader) ([]byte, error) {
+	gcm, err := newGCM(kek)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(r, nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plain, ad), nil
+}
+
+// unseal decrypts data sealed by seal.
+func unseal(kek, sealed, ad []byte) ([]byte, error) {
+	gcm, err := newGCM(kek)
+	if err != nil {
+		return nil, err
+	}
+	n := gcm.NonceSize()
+	if len(sealed) < n {
+		return nil, errSealedSize
+	}
+	return gcm.Open(nil, sealed[:n], sealed[n:], ad)
+}
+
+// sealKey encrypts priv with AES-GCM under kek.
+// The public key is authenticated as additional data,
+// binding the encrypted key to its database entry.
+func sealKey(kek []byte, priv ed25519.PrivateKey, pub ed25519.PublicKey, r io.Reader) ([]byte, error) {
+	return seal(kek, priv, pub, r)
+}
+
+// openKey decrypts a private key sealed by sealKey.
+func openKey(kek, sealed []byte, pub ed25519.PublicKey) (ed25519.PrivateKey, error) {
+	priv, err := unseal(kek, sealed, pub)
+	if err != nil {
+		return nil, err
+	}
+	return ed25519.PrivateKey(priv), nil
+}
+
+// syncSharedKey sets the signing key to the active shared key from the database.
+// A new shared key is generated when there is none,
+// or if the active key expires before the next poll.
+// Replicas are serialized by an advisory lock,
+// so that only one of them generates the new key.
+// The replaced key remains valid for signing during one poll interval,
+// giving the other replicas time to pick up the new key.
+func (s *authServer) syncSharedKey(ctx context.Context, r io.Reader) error {
+	log := s.log.WithField("method", "syncSharedKey")
+
+	tx, err := s.mdb.MasterTx(ctx, nil)
+	if err != nil {
+		log.WithError(err).Error("Begin TX")
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, "select pg_advisory_xact_lock($1);", sharedKeyLock); err != nil {
+		log.WithError(err).Error("Advisory lock")
+		return err
+	}
+
+	now := time.Now()
+	poll := s.conf.JWT.Shared.Poll
+
+	key, err := models.JWTKeys(
+		models.JWTKeyWhere.EncryptedKey.IsNotNull(),
+		models.JWTKeyWhere.RetiredAt.IsNull(),
+		qm.OrderBy(fmt.Sprintf("%s desc", models.JWTKeyColumns.ID)),
+	).One(ctx, tx)
+
+	switch {
+	case err == nil && (!key.ExpiresAt.Valid || key.ExpiresAt.Time.After(now.Add(poll))):
+		log = log.WithField("id", key.ID)
+
+		priv, err := openKey(s.kek, key.EncryptedKey.Bytes, key.PublicKey)
+		if err != nil {
+			log.WithError(err).Error("openKey")
+			return err
+		}
+		s.setPrivateKey(privateKey{id: strconv.Itoa(key.ID), key: priv})
+		log.Debug("Shared key in use")
+		return nil
+
+	case err != nil && !errors.Is(err, sql.ErrNoRows):
+		log.WithError(err).Error("Find shared key")
+		return err
+	}
+
+	pub, priv, err := ed25519.GenerateKey(r)
+	if err != nil {
+		return err
+	}
+	sealed, err := sealKey(s.kek, priv, pub, r)
+	if err != nil {
+		log.WithError(err).Error("sealKey")
+		return err
+	}
+
+	m := &models.JWTKey{
+		PublicKey:    pub,
+		EncryptedKey: null.BytesFrom(sealed),
+	}
+	if every := s.conf.JWT.RotateEvery; every > 0 {
+		m.ExpiresAt = null.TimeFrom(now.Add(every))
+	}
+	if err = m.Insert(ctx, tx, boil.Infer()); err != nil {
+		log.WithError(err).Error("Insert shared key")
+		return err
+	}
+	log = log.WithField("id", m.ID)
+
+	n, err := models.JWTKeys(
+		models.JWTKeyWhere.EncryptedKey.IsNotNull(),
+		models.JWTKeyWhere.RetiredAt.IsNull(),
+		models.JWTKeyWhere.ID.NEQ(m.ID),
+	).UpdateAll(ctx, tx, models.M{
+		models.JWTKeyColumns.RetiredAt: null.TimeFrom(now),
+		models.JWTKeyColumns.ExpiresAt: null.TimeFrom(now.Add(poll)),
+	})
+	if err != nil {
+		log.WithError(err).Error("Retire shared keys")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.WithError(err).Error("Commit")
+		return err
+	}
+
+	s.setPrivateKey(privateKey{id: strconv.Itoa(m.ID), key: priv})
+	log.WithField("retired", n).Info("Shared key update complete")
+
+	return nil
+}
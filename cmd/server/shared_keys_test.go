@@ -0,0 +1,236 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moapis/authenticator/models"
+)
+
+var testKEK = []byte("0123456789abcdef0123456789abcdef")
+
+func TestSharedKeyConfig_loadKEK(t *testing.T) {
+	f, err := ioutil.TempFile("", "kek")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	if _, err = f.WriteString(base64.StdEncoding.EncodeToString(testKEK) + "\n"); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	tests := []struct {
+		name    string
+		c       SharedKeyConfig
+		want    []byte
+		wantErr bool
+	}{
+		{
+			"Not configured",
+			SharedKeyConfig{},
+			nil,
+			true,
+		},
+		{
+			"KEK",
+			SharedKeyConfig{KEK: base64.StdEncoding.EncodeToString(testKEK)},
+			testKEK,
+			false,
+		},
+		{
+			"KEK file",
+			SharedKeyConfig{KEKFile: f.Name()},
+			testKEK,
+			false,
+		},
+		{
+			"Missing file",
+			SharedKeyConfig{KEKFile: "foo/bar"},
+			nil,
+			true,
+		},
+		{
+			"Base64 error",
+			SharedKeyConfig{KEK: "!!!"},
+			nil,
+			true,
+		},
+		{
+			"Size error",
+			SharedKeyConfig{KEK: base64.StdEncoding.EncodeToString([]byte("short"))},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.c.loadKEK()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SharedKeyConfig.loadKEK() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SharedKeyConfig.loadKEK() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_sealKey_openKey(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(strings.NewReader(testKeyInput))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sealed, err := sealKey(testKEK, priv, pub, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(sealed, priv.Seed()) {
+		t.Fatal("sealKey() output contains the private key")
+	}
+
+	otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		kek     []byte
+		sealed  []byte
+		pub     ed25519.PublicKey
+		wantErr bool
+	}{
+		{
+			"Success",
+			testKEK,
+			sealed,
+			pub,
+			false,
+		},
+		{
+			"Wrong KEK",
+			[]byte("abcdef0123456789abcdef0123456789"),
+			sealed,
+			pub,
+			true,
+		},
+		{
+			"Invalid KEK",
+			[]byte("short"),
+			sealed,
+			pub,
+			true,
+		},
+		{
+			"Wrong public key",
+			testKEK,
+			sealed,
+			otherPub,
+			true,
+		},
+		{
+			"Too short",
+			testKEK,
+			sealed[:4],
+			pub,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := openKey(tt.kek, tt.sealed, tt.pub)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("openKey() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !bytes.Equal(got, priv) {
+				t.Errorf("openKey() = %v, want %v", got, priv)
+			}
+		})
+	}
+
+	if _, err = sealKey(testKEK, priv, pub, strings.NewReader("")); err == nil {
+		t.Errorf("sealKey() error = %v, wantErr %v", err, true)
+	}
+}
+
+func Test_authServer_syncSharedKey(t *testing.T) {
+	newServer := func(poll time.Duration) *authServer {
+		conf := *tas.conf
+		conf.JWT.RotateEvery = time.Hour
+		conf.JWT.Shared = &SharedKeyConfig{Poll: poll}
+		return &authServer{
+			mdb:  mdb,
+			log:  tas.log,
+			conf: &conf,
+			kek:  testKEK,
+		}
+	}
+
+	first := newServer(time.Minute)
+	if err := first.syncSharedKey(testCtx, rand.Reader); err != nil {
+		t.Fatal(err)
+	}
+	active := first.privateKey()
+
+	// A second replica adopts the active key
+	second := newServer(time.Minute)
+	if err := second.syncSharedKey(testCtx, rand.Reader); err != nil {
+		t.Fatal(err)
+	}
+	if got := second.privateKey(); !reflect.DeepEqual(got, active) {
+		t.Errorf("authServer.syncSharedKey() = %v, want %v", got, active)
+	}
+
+	// The active key expires before the next poll, so it gets replaced
+	rotator := newServer(2 * time.Hour)
+	if err := rotator.syncSharedKey(testCtx, rand.Reader); err != nil {
+		t.Fatal(err)
+	}
+	rotated := rotator.privateKey()
+	if rotated.id == active.id {
+		t.Errorf("authServer.syncSharedKey() = %v, want new key", rotated.id)
+	}
+
+	if err := first.syncSharedKey(testCtx, rand.Reader); err != nil {
+		t.Fatal(err)
+	}
+	if got := first.privateKey(); !reflect.DeepEqual(got, rotated) {
+		t.Errorf("authServer.syncSharedKey() = %v, want %v", got, rotated)
+	}
+
+	db, err := mdb.Master(testCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, _ := strconv.Atoi(active.id)
+	old, err := models.FindJWTKey(testCtx, db, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !old.RetiredAt.Valid {
+		t.Errorf("authServer.syncSharedKey() did not retire key %v", old.ID)
+	}
+
+	// Wrong KEK can't decrypt the active key
+	wrong := newServer(time.Minute)
+	wrong.kek = []byte("abcdef0123456789abcdef0123456789")
+	if err := wrong.syncSharedKey(testCtx, rand.Reader); err == nil {
+		t.Errorf("authServer.syncSharedKey() error = %v, wantErr %v", err, true)
+	}
+}
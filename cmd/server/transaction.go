@@ -9,10 +9,10 @@ import (
 	"database/sql"
 	"fmt"
 	"html/template"
+	"strconv"
 	"time"
 
 	"github.com/friendsofgo/errors"
-	"golang.org/x/crypto/argon2"
 
 	auth "github.com/moapis/authenticator"
 	"github.com/moapis/authenticator/models"
@@ -20,6 +20,7 @@ import (
 	"github.com/moapis/mailer"
 	"github.com/pascaldekloe/jwt"
 	"github.com/sirupsen/logrus"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 	"google.golang.org/grpc/codes"
@@ -106,15 +107,27 @@ const (
 )
 
 func (rt *requestTx) authReply(subject string, issued time.Time, set map[string]interface{}, audiences ...string) (*auth.AuthReply, error) {
+	return rt.signedReply(subject, issued, issued.Add(rt.s.conf.JWT.Expiry), set, audiences...)
+}
+
+// signToken signs a token which expires at expires, with the current private key.
+func (rt *requestTx) signToken(subject string, issued, expires time.Time, set map[string]interface{}, audiences ...string) (string, error) {
+	jti, err := rt.s.newJTI()
+	if err != nil {
+		rt.log.WithError(err).Error("newJTI")
+		return "", status.Error(codes.Internal, errFatal)
+	}
+
 	prKey := rt.s.privateKey()
 	c := jwt.Claims{
 		KeyID: prKey.id,
 		Registered: jwt.Registered{
 			Issuer:    rt.s.conf.JWT.Issuer,
 			Subject:   subject,
-			Expires:   jwt.NewNumericTime(issued.Add(rt.s.conf.JWT.Expiry)),
+			Expires:   jwt.NewNumericTime(expires),
 			Audiences: audiences,
 			Issued:    jwt.NewNumericTime(issued),
+			ID:        jti,
 		},
 		Set: set,
 	}
@@ -122,11 +135,21 @@ func (rt *requestTx) authReply(subject string, issued time.Time, set map[string]
 
 	token, err := c.EdDSASign(prKey.key)
 	if err != nil {
-		rt.log.WithError(err).Error("authReply")
-		return nil, status.Error(codes.Internal, errToken)
+		rt.log.WithError(err).Error("signToken")
+		return "", status.Error(codes.Internal, errToken)
 	}
 	st := string(token)
-	rt.log.WithField("token", st).Debug("authReply")
+	rt.log.WithField("token", st).Debug("signToken")
+	return st, nil
+}
+
+// signedReply builds a token which expires at expires.
+// The transaction is committed when not read-only.
+func (rt *requestTx) signedReply(subject string, issued, expires time.Time, set map[string]interface{}, audiences ...string) (*auth.AuthReply, error) {
+	st, err := rt.signToken(subject, issued, expires, set, audiences...)
+	if err != nil {
+		return nil, err
+	}
 
 	if !rt.readOnly {
 		if err = rt.commit(); err != nil {
@@ -134,44 +157,64 @@ func (rt *requestTx) authReply(subject string, issued time.Time, set map[string]
 			return nil, status.Error(codes.Internal, errDB)
 		}
 	}
-	return &auth.AuthReply{Jwt: st}, nil
+	return &auth.AuthReply{
+		Jwt:     st,
+		Expires: expires.Unix(),
+	}, nil
 }
 
-func (rt *requestTx) userAuthReply(user *models.User, issued time.Time) (*auth.AuthReply, error) {
+// userClaims returns the claims set and audiences for a token of user.
+// Unverified users only get claims without groups and audiences.
+func (rt *requestTx) userClaims(user *models.User) (map[string]interface{}, []string, error) {
 	rt.log = rt.log.WithField("user", user)
-	audiences, err := user.Audiences(qm.Select(models.AudienceColumns.Name)).All(rt.ctx, rt.tx)
-	if err != nil {
-		rt.log.WithError(err).Error("userAuthReply")
-		return nil, status.Error(codes.Internal, errDB)
-	}
-	rt.log.WithField("audiences", audiences).Debug("userAuthReply")
 
-	groups, err := user.Groups(qm.Select(models.GroupColumns.Name)).All(rt.ctx, rt.tx)
-	if err != nil {
-		rt.log.WithError(err).Error("userAuthReply")
-		return nil, status.Error(codes.Internal, errDB)
-	}
-	rt.log.WithField("groups", groups).Debug("userAuthReply")
+	var (
+		gns = []string{}
+		ans []string
+	)
 
-	gns := make([]string, len(groups))
-	for i, g := range groups {
-		gns[i] = g.Name
-	}
+	if user.VerifiedAt.Valid {
+		audiences, err := user.Audiences(qm.Select(models.AudienceColumns.Name)).All(rt.ctx, rt.tx)
+		if err != nil {
+			rt.log.WithError(err).Error("userClaims")
+			return nil, nil, status.Error(codes.Internal, errDB)
+		}
+		rt.log.WithField("audiences", audiences).Debug("userClaims")
 
-	ans := make([]string, len(audiences))
-	for i, a := range audiences {
-		ans[i] = a.Name
+		groups, err := user.Groups(qm.Select(models.GroupColumns.Name)).All(rt.ctx, rt.tx)
+		if err != nil {
+			rt.log.WithError(err).Error("userClaims")
+			return nil, nil, status.Error(codes.Internal, errDB)
+		}
+		rt.log.WithField("groups", groups).Debug("userClaims")
+
+		gns = make([]string, len(groups))
+		for i, g := range groups {
+			gns[i] = g.Name
+		}
+
+		ans = make([]string, len(audiences))
+		for i, a := range audiences {
+			ans[i] = a.Name
+		}
+	} else {
+		rt.log.Debug("userClaims: user not verified")
 	}
 
-	return rt.authReply(
-		user.Email,
-		issued,
-		map[string]interface{}{
-			jwtUserID: user.ID,
-			jwtGroups: gns,
-		},
-		ans...,
-	)
+	return map[string]interface{}{
+		jwtUserID: user.ID,
+		jwtGroups: gns,
+	}, ans, nil
+}
+
+// userAuthReply builds a token for user, containing its groups and audiences.
+// Unverified users only get a token without groups and audiences.
+func (rt *requestTx) userAuthReply(user *models.User, issued time.Time) (*auth.AuthReply, error) {
+	set, ans, err := rt.userClaims(user)
+	if err != nil {
+		return nil, err
+	}
+	return rt.authReply(user.Email, issued, set, ans...)
 }
 
 func (rt *requestTx) findJWTKey(kid int) ([]byte, error) {
@@ -180,7 +223,7 @@ func (rt *requestTx) findJWTKey(kid int) ([]byte, error) {
 		rt.log.WithError(errors.New(errMissingKeyID)).Warn("getPubKey")
 		return nil, status.Error(codes.InvalidArgument, errMissingKeyID)
 	}
-	key, err := models.FindJWTKey(rt.ctx, rt.tx, kid, models.JWTKeyColumns.PublicKey)
+	key, err := models.FindJWTKey(rt.ctx, rt.tx, kid, models.JWTKeyColumns.PublicKey, models.JWTKeyColumns.ExpiresAt)
 	switch err {
 	case nil:
 		break
@@ -191,6 +234,13 @@ func (rt *requestTx) findJWTKey(kid int) ([]byte, error) {
 		rt.log.WithError(err).Error("getPubKey")
 		return nil, status.Error(codes.Internal, errDB)
 	}
+	// Tokens signed before expiry of the key remain valid for the token lifetime.
+	// The key this server signs with stays valid, even when its rotation is overdue.
+	if key.ExpiresAt.Valid && key.ExpiresAt.Time.Add(rt.s.conf.JWT.Expiry).Before(time.Now()) &&
+		strconv.Itoa(kid) != rt.s.privateKey().id {
+		rt.log.WithField("expires_at", key.ExpiresAt.Time).Warn(errKeyExpired)
+		return nil, status.Error(codes.NotFound, errKeyExpired)
+	}
 	return key.PublicKey, nil
 }
 
@@ -218,38 +268,66 @@ func (rt *requestTx) checkJWT(token string, valid time.Time) (*jwt.Claims, error
 		log.WithError(errors.New(errExpiredToken)).Warn("jwt.EdDSACheck()")
 		return nil, status.Error(codes.Unauthenticated, errExpiredToken)
 	}
+	// Tokens issued before the introduction of token IDs can't be revoked.
+	if claims.ID != "" {
+		revoked, err := rt.isRevoked(claims.ID)
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			log.WithError(errors.New(errRevokedToken)).Warn("checkJWT")
+			return nil, status.Error(codes.Unauthenticated, errRevokedToken)
+		}
+	}
 	return claims, nil
 }
 
-const (
-	// PasswordSaltLen is the amount of bytes used for salting passwords
-	PasswordSaltLen = 8
-	// Argon2Time sets the time argument to the argon2 password hasher
-	Argon2Time = 1
-	// Argon2Memory sets the memory argument to the argon2 password hasher
-	Argon2Memory = 64 * 1024
-	// Argon2Threads sets the threads argument to the argon2 password hasher
-	Argon2Threads = 2
-	// Argon2KeyLen sets the keyLen argument to the argon2 password hasher
-	Argon2KeyLen = 32
-)
-
+// setUserPassword sets a new password for user, after checking the password policy.
+// The replaced password is kept in the password history.
 func (rt *requestTx) setUserPassword(user *models.User, password string, read func([]byte) (int, error)) error {
 	log := rt.log.WithField("method", "setUserPassword()")
+	if err := rt.localPassword(user); err != nil {
+		return err
+	}
 	if password == "" {
 		log.Warn(errMissingPW)
 		return status.Error(codes.InvalidArgument, errMissingPW)
 	}
-	pwm := &models.Password{
-		UserID: user.ID,
-		Salt:   make([]byte, PasswordSaltLen),
+
+	var current string
+	switch pwm, err := user.Password().One(rt.ctx, rt.tx); err {
+	case nil:
+		current = pwm.Hash
+	case sql.ErrNoRows:
+	default:
+		log.WithError(err).Error("user.Password()")
+		return status.Error(codes.Internal, errDB)
 	}
-	if _, err := read(pwm.Salt); err != nil {
+
+	if err := rt.checkPasswordPolicy(user, current, password); err != nil {
+		return err
+	}
+	if current != "" {
+		if err := rt.rotateHistory(user, current); err != nil {
+			return err
+		}
+	}
+	return rt.storePassword(user, password, read)
+}
+
+// storePassword hashes and stores password for user, without policy checks.
+func (rt *requestTx) storePassword(user *models.User, password string, read func([]byte) (int, error)) error {
+	log := rt.log.WithField("method", "storePassword()")
+	hash, err := rt.s.conf.Password.hash(password, read)
+	if err != nil {
 		log.WithError(err).Error("Salt generation")
 		return status.Error(codes.Internal, errFatal)
 	}
-	pwm.Hash = argon2.IDKey([]byte(password), pwm.Salt, Argon2Time, Argon2Memory, Argon2Threads, Argon2KeyLen)
-	log = log.WithField("password_model", pwm)
+	pwm := &models.Password{
+		UserID: user.ID,
+		Hash:   hash,
+	}
+	log = log.WithField("user_id", user.ID)
 
 	if err := pwm.Upsert(
 		rt.ctx, rt.tx, true,
@@ -267,6 +345,31 @@ func (rt *requestTx) setUserPassword(user *models.User, password string, read fu
 	return nil
 }
 
+// markVerified sets the verification time of user, if not yet set.
+func (rt *requestTx) markVerified(user *models.User, verified time.Time) error {
+	log := rt.log.WithField("user", user)
+	if user.VerifiedAt.Valid {
+		log.Debug("markVerified: already verified")
+		return nil
+	}
+
+	user.VerifiedAt = null.TimeFrom(verified)
+	n, err := user.Update(rt.ctx, rt.tx, boil.Whitelist(
+		models.UserColumns.VerifiedAt,
+		models.UserColumns.UpdatedAt,
+	))
+	if err != nil {
+		log.WithError(err).Error("user.Update()")
+		return status.Error(codes.Internal, errDB)
+	}
+	if n == 0 {
+		log.Warn("markVerified: user not found")
+		return status.Error(codes.NotFound, errUserNotFound)
+	}
+	log.Debug("markVerified")
+	return nil
+}
+
 func (rt *requestTx) insertPwUser(email, name string) (*models.User, error) {
 	rt.log = rt.log.WithFields(logrus.Fields{"email": email, "name": name})
 	if email == "" {
@@ -331,17 +434,15 @@ func (rt *requestTx) authenticatePwUser(email, password string) (*models.User, e
 	if err != nil {
 		return nil, err
 	}
-	pwm, err := user.Password().One(rt.ctx, rt.tx)
+	backend, err := rt.credentialBackend(user)
 	if err != nil {
-		return nil, rt.dbAuthError("Get user password", "password", err)
+		return nil, err
 	}
 	if err := rt.enoughTime(time.Second); err != nil {
 		return nil, err
 	}
-
-	if string(pwm.Hash) != string(argon2.IDKey([]byte(password), pwm.Salt, Argon2Time, Argon2Memory, Argon2Threads, Argon2KeyLen)) {
-		log.WithError(errors.New(errCredentials)).Warn("Password missmatch")
-		return nil, status.Error(codes.Unauthenticated, errCredentials)
+	if err = backend.verify(rt, user, password); err != nil {
+		return nil, err
 	}
 	return user, nil
 }
@@ -387,7 +488,7 @@ func (rt *requestTx) getPubKey(kid int) (*auth.PublicKey, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &auth.PublicKey{Key: key}, nil
+	return &auth.PublicKey{Key: key, Kid: int32(kid)}, nil
 }
 
 type mailData struct {
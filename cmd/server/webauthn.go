@@ -0,0 +1,655 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"database/sql"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"time"
+
+	auth "github.com/moapis/authenticator"
+	"github.com/moapis/authenticator/models"
+	"github.com/sirupsen/logrus"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	webauthnChallengeLen = 32
+	webauthnMaxCredIDLen = 1023 // WebAuthn §5.8.3
+
+	ceremonyRegistration = "registration"
+	ceremonyLogin        = "login"
+
+	clientDataCreate = "webauthn.create"
+	clientDataGet    = "webauthn.get"
+
+	// Authenticator data flags, WebAuthn §6.1
+	flagUP = 0x01 // User present
+	flagUV = 0x04 // User verified
+	flagAT = 0x40 // Attested credential data included
+
+	// COSE algorithm identifiers
+	coseES256 = -7
+	coseEdDSA = -8
+	coseRS256 = -257
+
+	// COSE key parameters, RFC 8152 §7 and §13
+	coseKty    = 1
+	coseAlg    = 3
+	coseCrv    = -1 // n for RSA
+	coseX      = -2 // e for RSA
+	coseY      = -3
+	coseKtyOKP = 1
+	coseKtyEC2 = 2
+	coseKtyRSA = 3
+	coseP256   = 1
+	coseEd     = 6
+)
+
+// oidAAGUID identifies the certificate extension holding the AAGUID
+// of the authenticator model, in packed attestation certificates.
+var oidAAGUID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 45724, 1, 1, 4}
+
+var (
+	errAuthData    = errors.New("webauthn: malformed authenticator data")
+	errCOSEKey     = errors.New("webauthn: unsupported COSE key")
+	errSignature   = errors.New("webauthn: signature verification failed")
+	errAttestation = errors.New("webauthn: invalid attestation statement")
+	errAttFormat   = errors.New("webauthn: unsupported attestation format")
+)
+
+// webauthnUserHandle returns the WebAuthn user handle for userID.
+// The browser returns it on login with a discoverable credential.
+func webauthnUserHandle(userID int) []byte {
+	return []byte(strconv.Itoa(userID))
+}
+
+// clientData is the parsed clientDataJSON of a WebAuthn response.
+type clientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"` // base64url without padding
+	Origin    string `json:"origin"`
+}
+
+// authenticatorData as defined in WebAuthn §6.1.
+type authenticatorData struct {
+	rpIDHash  []byte
+	flags     byte
+	signCount uint32
+
+	// Attested credential data, only present when flagAT is set.
+	aaguid       []byte
+	credentialID []byte
+	publicKey    []byte // COSE encoded
+}
+
+// parseAuthenticatorData decodes b. Extensions are ignored.
+func parseAuthenticatorData(b []byte) (*authenticatorData, error) {
+	if len(b) < 37 {
+		return nil, errAuthData
+	}
+	ad := &authenticatorData{
+		rpIDHash:  b[:32],
+		flags:     b[32],
+		signCount: binary.BigEndian.Uint32(b[33:37]),
+	}
+	if ad.flags&flagAT == 0 {
+		return ad, nil
+	}
+
+	b = b[37:]
+	if len(b) < 18 {
+		return nil, errAuthData
+	}
+	ad.aaguid = b[:16]
+	n := int(binary.BigEndian.Uint16(b[16:18]))
+	b = b[18:]
+	if n > webauthnMaxCredIDLen || len(b) < n {
+		return nil, errAuthData
+	}
+	ad.credentialID, b = b[:n], b[n:]
+
+	_, rest, err := cborDecode(b)
+	if err != nil {
+		return nil, fmt.Errorf("webauthn: credential public key: %w", err)
+	}
+	ad.publicKey = b[:len(b)-len(rest)]
+	return ad, nil
+}
+
+// coseKey is a public key decoded from a COSE_Key (RFC 8152).
+type coseKey struct {
+	alg int64
+	pub crypto.PublicKey
+}
+
+// parseCOSEKey decodes ES256, EdDSA (Ed25519) and RS256 public keys.
+func parseCOSEKey(b []byte) (*coseKey, error) {
+	v, _, err := cborDecode(b)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := v.(map[interface{}]interface{})
+	if !ok {
+		return nil, errCOSEKey
+	}
+	kty, _ := m[int64(coseKty)].(int64)
+	alg, _ := m[int64(coseAlg)].(int64)
+
+	switch {
+	case kty == coseKtyEC2 && alg == coseES256:
+		crv, _ := m[int64(coseCrv)].(int64)
+		x, _ := m[int64(coseX)].([]byte)
+		y, _ := m[int64(coseY)].([]byte)
+		if crv != coseP256 || len(x) != 32 || len(y) != 32 {
+			return nil, errCOSEKey
+		}
+		pub := &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}
+		if !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+			return nil, errCOSEKey
+		}
+		return &coseKey{alg, pub}, nil
+
+	case kty == coseKtyOKP && alg == coseEdDSA:
+		crv, _ := m[int64(coseCrv)].(int64)
+		x, _ := m[int64(coseX)].([]byte)
+		if crv != coseEd || len(x) != ed25519.PublicKeySize {
+			return nil, errCOSEKey
+		}
+		return &coseKey{alg, ed25519.PublicKey(x)}, nil
+
+	case kty == coseKtyRSA && alg == coseRS256:
+		n, _ := m[int64(coseCrv)].([]byte)
+		e, _ := m[int64(coseX)].([]byte)
+		if len(n) == 0 || len(e) == 0 || len(e) > 4 {
+			return nil, errCOSEKey
+		}
+		pub := &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+		return &coseKey{alg, pub}, nil
+	}
+	return nil, errCOSEKey
+}
+
+// verifySignature checks sig over data, for the COSE algorithm alg.
+func verifySignature(alg int64, pub crypto.PublicKey, data, sig []byte) error {
+	switch pub := pub.(type) {
+	case *ecdsa.PublicKey:
+		if alg != coseES256 {
+			break
+		}
+		var es struct{ R, S *big.Int }
+		if rest, err := asn1.Unmarshal(sig, &es); err != nil || len(rest) > 0 {
+			return errSignature
+		}
+		h := sha256.Sum256(data)
+		if ecdsa.Verify(pub, h[:], es.R, es.S) {
+			return nil
+		}
+		return errSignature
+
+	case ed25519.PublicKey:
+		if alg != coseEdDSA {
+			break
+		}
+		if ed25519.Verify(pub, data, sig) {
+			return nil
+		}
+		return errSignature
+
+	case *rsa.PublicKey:
+		if alg != coseRS256 {
+			break
+		}
+		h := sha256.Sum256(data)
+		if rsa.VerifyPKCS1v15(pub, crypto.SHA256, h[:], sig) == nil {
+			return nil
+		}
+		return errSignature
+	}
+	return errCOSEKey
+}
+
+// verifyAttestation checks the attestation statement of a new credential,
+// for the "none" and "packed" formats (WebAuthn §8.2 and §8.7).
+// Packed attestation certificates are checked against the requirements of §8.2.1,
+// but their chain is not verified, as no trust anchors are configured.
+func verifyAttestation(format string, stmt map[interface{}]interface{}, authData []byte, ad *authenticatorData, key *coseKey, clientDataHash []byte) error {
+	switch format {
+	case "none":
+		if len(stmt) != 0 {
+			return errAttestation
+		}
+		return nil
+
+	case "packed":
+		alg, ok := stmt["alg"].(int64)
+		if !ok {
+			return errAttestation
+		}
+		sig, ok := stmt["sig"].([]byte)
+		if !ok {
+			return errAttestation
+		}
+		signed := append(append([]byte(nil), authData...), clientDataHash...)
+
+		x5c, ok := stmt["x5c"].([]interface{})
+		if !ok {
+			// Self attestation, signed with the credential key
+			if _, exists := stmt["x5c"]; exists || alg != key.alg {
+				return errAttestation
+			}
+			return verifySignature(alg, key.pub, signed, sig)
+		}
+		if len(x5c) == 0 {
+			return errAttestation
+		}
+		der, ok := x5c[0].([]byte)
+		if !ok {
+			return errAttestation
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return fmt.Errorf("webauthn: attestation certificate: %w", err)
+		}
+		if err = checkAttestationCert(cert, ad.aaguid); err != nil {
+			return err
+		}
+		return verifySignature(alg, cert.PublicKey, signed, sig)
+	}
+	return errAttFormat
+}
+
+// checkAttestationCert checks the packed attestation certificate requirements of WebAuthn §8.2.1.
+func checkAttestationCert(cert *x509.Certificate, aaguid []byte) error {
+	if cert.Version != 3 || cert.IsCA {
+		return errAttestation
+	}
+	var ou bool
+	for _, s := range cert.Subject.OrganizationalUnit {
+		ou = ou || s == "Authenticator Attestation"
+	}
+	if !ou {
+		return errAttestation
+	}
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(oidAAGUID) {
+			continue
+		}
+		var id []byte
+		if _, err := asn1.Unmarshal(ext.Value, &id); err != nil || ext.Critical || !bytes.Equal(id, aaguid) {
+			return errAttestation
+		}
+	}
+	return nil
+}
+
+func (s *authServer) webauthnEnabled() bool {
+	return s.conf.WebAuthn != nil
+}
+
+// checkClientData parses clientDataJSON and checks its type and origin.
+// The decoded challenge is returned.
+func (c *WebAuthnConfig) checkClientData(b []byte, typ string) ([]byte, error) {
+	var cd clientData
+	if err := json.Unmarshal(b, &cd); err != nil {
+		return nil, fmt.Errorf("webauthn: client data: %w", err)
+	}
+	if cd.Type != typ {
+		return nil, fmt.Errorf("webauthn: client data type %q, want %q", cd.Type, typ)
+	}
+	var origin bool
+	for _, o := range c.Origins {
+		origin = origin || o == cd.Origin
+	}
+	if !origin {
+		return nil, fmt.Errorf("webauthn: origin %q not allowed", cd.Origin)
+	}
+	challenge, err := base64.RawURLEncoding.DecodeString(cd.Challenge)
+	if err != nil {
+		return nil, fmt.Errorf("webauthn: challenge: %w", err)
+	}
+	return challenge, nil
+}
+
+// checkAuthData checks the relying party ID hash and user presence.
+func (c *WebAuthnConfig) checkAuthData(ad *authenticatorData) error {
+	rpIDHash := sha256.Sum256([]byte(c.RPID))
+	if !bytes.Equal(ad.rpIDHash, rpIDHash[:]) {
+		return errors.New("webauthn: relying party ID mismatch")
+	}
+	if ad.flags&flagUP == 0 {
+		return errors.New("webauthn: user not present")
+	}
+	return nil
+}
+
+// issueChallenge stores a new random challenge for ceremony.
+func (rt *requestTx) issueChallenge(userID null.Int, ceremony string, now time.Time, r io.Reader) (*models.WebauthnChallenge, error) {
+	m := &models.WebauthnChallenge{
+		UserID:    userID,
+		Challenge: make([]byte, webauthnChallengeLen),
+		Ceremony:  ceremony,
+		ExpiresAt: now.Add(rt.s.conf.WebAuthn.timeout()),
+	}
+	if _, err := io.ReadFull(r, m.Challenge); err != nil {
+		rt.log.WithError(err).Error("issueChallenge")
+		return nil, status.Error(codes.Internal, errFatal)
+	}
+	if err := m.Insert(rt.ctx, rt.tx, boil.Infer()); err != nil {
+		rt.log.WithError(err).Error("issueChallenge")
+		return nil, status.Error(codes.Internal, errDB)
+	}
+	return m, nil
+}
+
+// useChallenge deletes the challenge, so that it can't be used again.
+// Unknown or expired challenges, or challenges issued for another ceremony are rejected.
+func (rt *requestTx) useChallenge(challenge []byte, ceremony string, now time.Time) (*models.WebauthnChallenge, error) {
+	m, err := models.WebauthnChallenges(
+		models.WebauthnChallengeWhere.Challenge.EQ(challenge),
+		qm.For("update"),
+	).One(rt.ctx, rt.tx)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		rt.log.Warn(errWebAuthnChallenge)
+		return nil, status.Error(codes.Unauthenticated, errWebAuthnChallenge)
+	case err != nil:
+		rt.log.WithError(err).Error("useChallenge")
+		return nil, status.Error(codes.Internal, errDB)
+	}
+
+	if _, err = m.Delete(rt.ctx, rt.tx); err != nil {
+		rt.log.WithError(err).Error("useChallenge")
+		return nil, status.Error(codes.Internal, errDB)
+	}
+	if m.Ceremony != ceremony || !now.Before(m.ExpiresAt) {
+		rt.log.WithField("challenge", m).Warn(errWebAuthnChallenge)
+		return nil, status.Error(codes.Unauthenticated, errWebAuthnChallenge)
+	}
+	return m, nil
+}
+
+// credentialIDs returns the IDs of all credentials of the user.
+func (rt *requestTx) credentialIDs(userID int) ([][]byte, error) {
+	creds, err := models.WebauthnCredentials(
+		qm.Select(models.WebauthnCredentialColumns.CredentialID),
+		models.WebauthnCredentialWhere.UserID.EQ(userID),
+	).All(rt.ctx, rt.tx)
+	if err != nil {
+		rt.log.WithError(err).Error("credentialIDs")
+		return nil, status.Error(codes.Internal, errDB)
+	}
+	ids := make([][]byte, len(creds))
+	for i, c := range creds {
+		ids[i] = c.CredentialID
+	}
+	return ids, nil
+}
+
+// webauthnChallenge builds the reply for the ceremony options.
+func (rt *requestTx) webauthnChallenge(m *models.WebauthnChallenge) (*auth.WebAuthnChallenge, error) {
+	conf := rt.s.conf.WebAuthn
+	reply := &auth.WebAuthnChallenge{
+		Challenge: m.Challenge,
+		RpId:      conf.RPID,
+		RpName:    conf.rpName(),
+		Expires:   m.ExpiresAt.Unix(),
+	}
+	if m.UserID.Valid {
+		ids, err := rt.credentialIDs(m.UserID.Int)
+		if err != nil {
+			return nil, err
+		}
+		reply.CredentialIds = ids
+	}
+	return reply, nil
+}
+
+// beginWebAuthnRegistration issues a registration challenge for user.
+func (rt *requestTx) beginWebAuthnRegistration(user *models.User, now time.Time, r io.Reader) (*auth.WebAuthnChallenge, error) {
+	if !rt.s.webauthnEnabled() {
+		rt.log.Warn(errWebAuthnDisabled)
+		return nil, status.Error(codes.FailedPrecondition, errWebAuthnDisabled)
+	}
+
+	m, err := rt.issueChallenge(null.IntFrom(user.ID), ceremonyRegistration, now, r)
+	if err != nil {
+		return nil, err
+	}
+	reply, err := rt.webauthnChallenge(m)
+	if err != nil {
+		return nil, err
+	}
+	reply.UserId = webauthnUserHandle(user.ID)
+	reply.UserName = user.Email
+	reply.UserDisplayName = user.Name
+	return reply, nil
+}
+
+// finishWebAuthnRegistration verifies the attestation and stores the new credential of user.
+func (rt *requestTx) finishWebAuthnRegistration(user *models.User, att *auth.WebAuthnAttestation, now time.Time) (*models.WebauthnCredential, error) {
+	conf := rt.s.conf.WebAuthn
+	if conf == nil {
+		rt.log.Warn(errWebAuthnDisabled)
+		return nil, status.Error(codes.FailedPrecondition, errWebAuthnDisabled)
+	}
+
+	challenge, err := conf.checkClientData(att.GetClientDataJson(), clientDataCreate)
+	if err != nil {
+		rt.log.WithError(err).Warn(errWebAuthnResponse)
+		return nil, status.Error(codes.InvalidArgument, errWebAuthnResponse)
+	}
+	ch, err := rt.useChallenge(challenge, ceremonyRegistration, now)
+	if err != nil {
+		return nil, err
+	}
+	if ch.UserID.Int != user.ID {
+		rt.log.WithField("challenge", ch).Warn(errWebAuthnChallenge)
+		return nil, status.Error(codes.Unauthenticated, errWebAuthnChallenge)
+	}
+
+	v, _, err := cborDecode(att.GetAttestationObject())
+	if err != nil {
+		rt.log.WithError(err).Warn(errWebAuthnResponse)
+		return nil, status.Error(codes.InvalidArgument, errWebAuthnResponse)
+	}
+	obj, _ := v.(map[interface{}]interface{})
+	format, _ := obj["fmt"].(string)
+	stmt, _ := obj["attStmt"].(map[interface{}]interface{})
+	authData, _ := obj["authData"].([]byte)
+	if stmt == nil || authData == nil {
+		rt.log.WithField("attestation_object", obj).Warn(errWebAuthnResponse)
+		return nil, status.Error(codes.InvalidArgument, errWebAuthnResponse)
+	}
+
+	ad, err := parseAuthenticatorData(authData)
+	if err == nil && ad.flags&flagAT == 0 {
+		err = errAuthData
+	}
+	if err != nil {
+		rt.log.WithError(err).Warn(errWebAuthnResponse)
+		return nil, status.Error(codes.InvalidArgument, errWebAuthnResponse)
+	}
+	if err = conf.checkAuthData(ad); err != nil {
+		rt.log.WithError(err).Warn(errWebAuthnResponse)
+		return nil, status.Error(codes.Unauthenticated, errWebAuthnResponse)
+	}
+	key, err := parseCOSEKey(ad.publicKey)
+	if err != nil {
+		rt.log.WithError(err).Warn(errWebAuthnResponse)
+		return nil, status.Error(codes.InvalidArgument, errWebAuthnResponse)
+	}
+
+	clientDataHash := sha256.Sum256(att.GetClientDataJson())
+	if err = verifyAttestation(format, stmt, authData, ad, key, clientDataHash[:]); err != nil {
+		rt.log.WithError(err).WithField("fmt", format).Warn(errWebAuthnResponse)
+		return nil, status.Error(codes.Unauthenticated, errWebAuthnResponse)
+	}
+
+	exists, err := models.WebauthnCredentials(models.WebauthnCredentialWhere.CredentialID.EQ(ad.credentialID)).Exists(rt.ctx, rt.tx)
+	if err != nil {
+		rt.log.WithError(err).Error("finishWebAuthnRegistration")
+		return nil, status.Error(codes.Internal, errDB)
+	}
+	if exists {
+		rt.log.Warn(errWebAuthnExists)
+		return nil, status.Error(codes.AlreadyExists, errWebAuthnExists)
+	}
+
+	m := &models.WebauthnCredential{
+		UserID:       user.ID,
+		CredentialID: ad.credentialID,
+		PublicKey:    ad.publicKey,
+		SignCount:    int64(ad.signCount),
+	}
+	if err = m.Insert(rt.ctx, rt.tx, boil.Infer()); err != nil {
+		rt.log.WithError(err).Error("finishWebAuthnRegistration")
+		return nil, status.Error(codes.Internal, errDB)
+	}
+	rt.log.WithFields(logrus.Fields{"user": user, "fmt": format}).Info("WebAuthn credential registered")
+	return m, nil
+}
+
+// beginWebAuthnLogin issues a login challenge.
+// When email is empty, any discoverable credential is accepted.
+func (rt *requestTx) beginWebAuthnLogin(email string, now time.Time, r io.Reader) (*auth.WebAuthnChallenge, error) {
+	if !rt.s.webauthnEnabled() {
+		rt.log.Warn(errWebAuthnDisabled)
+		return nil, status.Error(codes.FailedPrecondition, errWebAuthnDisabled)
+	}
+
+	var userID null.Int
+	if email != "" {
+		user, err := rt.findUserByEmail(email)
+		if err != nil {
+			return nil, err
+		}
+		userID = null.IntFrom(user.ID)
+	}
+
+	m, err := rt.issueChallenge(userID, ceremonyLogin, now, r)
+	if err != nil {
+		return nil, err
+	}
+	return rt.webauthnChallenge(m)
+}
+
+// finishWebAuthnLogin verifies the assertion and updates the sign counter of the credential.
+// It returns the user owning the credential and whether the authenticator verified the user,
+// for example by PIN or biometrics.
+func (rt *requestTx) finishWebAuthnLogin(as *auth.WebAuthnAssertion, now time.Time) (*models.User, bool, error) {
+	conf := rt.s.conf.WebAuthn
+	if conf == nil {
+		rt.log.Warn(errWebAuthnDisabled)
+		return nil, false, status.Error(codes.FailedPrecondition, errWebAuthnDisabled)
+	}
+
+	challenge, err := conf.checkClientData(as.GetClientDataJson(), clientDataGet)
+	if err != nil {
+		rt.log.WithError(err).Warn(errWebAuthnResponse)
+		return nil, false, status.Error(codes.InvalidArgument, errWebAuthnResponse)
+	}
+	ch, err := rt.useChallenge(challenge, ceremonyLogin, now)
+	if err != nil {
+		return nil, false, err
+	}
+
+	cred, err := models.WebauthnCredentials(
+		models.WebauthnCredentialWhere.CredentialID.EQ(as.GetCredentialId()),
+		qm.For("update"),
+	).One(rt.ctx, rt.tx)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		rt.log.Warn(errWebAuthnCredential)
+		return nil, false, status.Error(codes.Unauthenticated, errWebAuthnCredential)
+	case err != nil:
+		rt.log.WithError(err).Error("finishWebAuthnLogin")
+		return nil, false, status.Error(codes.Internal, errDB)
+	}
+	rt.log = rt.log.WithField("credential", cred.ID)
+
+	if (ch.UserID.Valid && ch.UserID.Int != cred.UserID) ||
+		(len(as.GetUserHandle()) > 0 && !bytes.Equal(as.GetUserHandle(), webauthnUserHandle(cred.UserID))) {
+		rt.log.Warn(errWebAuthnCredential)
+		return nil, false, status.Error(codes.Unauthenticated, errWebAuthnCredential)
+	}
+
+	ad, err := parseAuthenticatorData(as.GetAuthenticatorData())
+	if err != nil {
+		rt.log.WithError(err).Warn(errWebAuthnResponse)
+		return nil, false, status.Error(codes.InvalidArgument, errWebAuthnResponse)
+	}
+	if err = conf.checkAuthData(ad); err != nil {
+		rt.log.WithError(err).Warn(errWebAuthnResponse)
+		return nil, false, status.Error(codes.Unauthenticated, errWebAuthnResponse)
+	}
+
+	key, err := parseCOSEKey(cred.PublicKey)
+	if err != nil {
+		rt.log.WithError(err).Error("finishWebAuthnLogin")
+		return nil, false, status.Error(codes.Internal, errFatal)
+	}
+	clientDataHash := sha256.Sum256(as.GetClientDataJson())
+	signed := append(append([]byte(nil), as.GetAuthenticatorData()...), clientDataHash[:]...)
+	if err = verifySignature(key.alg, key.pub, signed, as.GetSignature()); err != nil {
+		rt.log.WithError(err).Warn(errWebAuthnResponse)
+		return nil, false, status.Error(codes.Unauthenticated, errWebAuthnResponse)
+	}
+
+	// Authenticators without a counter always report 0.
+	// Otherwise the counter must increase, or the authenticator might be cloned.
+	if (ad.signCount != 0 || cred.SignCount != 0) && int64(ad.signCount) <= cred.SignCount {
+		rt.log.WithFields(logrus.Fields{"stored": cred.SignCount, "received": ad.signCount}).Warn(errWebAuthnCounter)
+		return nil, false, status.Error(codes.Unauthenticated, errWebAuthnCounter)
+	}
+	cred.SignCount = int64(ad.signCount)
+	if _, err = cred.Update(rt.ctx, rt.tx, boil.Whitelist(models.WebauthnCredentialColumns.SignCount, models.WebauthnCredentialColumns.UpdatedAt)); err != nil {
+		rt.log.WithError(err).Error("finishWebAuthnLogin")
+		return nil, false, status.Error(codes.Internal, errDB)
+	}
+
+	user, err := models.FindUser(rt.ctx, rt.tx, cred.UserID)
+	if err != nil {
+		return nil, false, rt.dbAuthError("finishWebAuthnLogin", "user", err)
+	}
+	return user, ad.flags&flagUV != 0, nil
+}
+
+// pruneWebAuthnChallenges deletes challenges which expired before now.
+func (s *authServer) pruneWebAuthnChallenges(ctx context.Context, now time.Time) (int64, error) {
+	db, err := s.mdb.Master(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return models.WebauthnChallenges(models.WebauthnChallengeWhere.ExpiresAt.LT(now)).DeleteAll(ctx, db)
+}
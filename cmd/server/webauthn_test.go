@@ -0,0 +1,724 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	auth "github.com/moapis/authenticator"
+	"github.com/moapis/authenticator/models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testOrigin = "http://localhost:1234"
+
+var testAAGUID = []byte("0123456789abcdef")
+
+// testAuthenticator is a software WebAuthn authenticator with an ES256 credential.
+type testAuthenticator struct {
+	key   *ecdsa.PrivateKey
+	id    []byte
+	count uint32
+}
+
+func newTestAuthenticator(t *testing.T) *testAuthenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := make([]byte, 16)
+	if _, err = rand.Read(id); err != nil {
+		t.Fatal(err)
+	}
+	return &testAuthenticator{key: key, id: id}
+}
+
+func ecdsaSign(t *testing.T, key *ecdsa.PrivateKey, data []byte) []byte {
+	h := sha256.Sum256(data)
+	r, s, err := ecdsa.Sign(rand.Reader, key, h[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := asn1.Marshal(struct{ R, S *big.Int }{r, s})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sig
+}
+
+// p256Bytes returns i as 32 byte big-endian coordinate.
+func p256Bytes(i *big.Int) []byte {
+	b := i.Bytes()
+	return append(make([]byte, 32-len(b)), b...)
+}
+
+func (a *testAuthenticator) coseKey() []byte {
+	return cborEncode(map[interface{}]interface{}{
+		int64(coseKty): int64(coseKtyEC2),
+		int64(coseAlg): int64(coseES256),
+		int64(coseCrv): int64(coseP256),
+		int64(coseX):   p256Bytes(a.key.X),
+		int64(coseY):   p256Bytes(a.key.Y),
+	})
+}
+
+// authData increments the sign counter and returns authenticator data for rpID.
+// Attested credential data is included when flags has flagAT.
+func (a *testAuthenticator) authData(rpID string, flags byte) []byte {
+	a.count++
+	h := sha256.Sum256([]byte(rpID))
+	b := append(h[:], flags, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(b[33:], a.count)
+	if flags&flagAT != 0 {
+		b = append(b, testAAGUID...)
+		b = append(b, byte(len(a.id)>>8), byte(len(a.id)))
+		b = append(b, a.id...)
+		b = append(b, a.coseKey()...)
+	}
+	return b
+}
+
+func testClientData(t *testing.T, typ string, challenge []byte, origin string) []byte {
+	b, err := json.Marshal(clientData{
+		Type:      typ,
+		Challenge: base64.RawURLEncoding.EncodeToString(challenge),
+		Origin:    origin,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// attestation returns a "packed" self attestation for the credential.
+func (a *testAuthenticator) attestation(t *testing.T, jwt string, challenge []byte) *auth.WebAuthnAttestation {
+	cd := testClientData(t, clientDataCreate, challenge, testOrigin)
+	ad := a.authData("localhost", flagUP|flagAT)
+	h := sha256.Sum256(cd)
+
+	return &auth.WebAuthnAttestation{
+		Jwt:            jwt,
+		ClientDataJson: cd,
+		AttestationObject: cborEncode(map[interface{}]interface{}{
+			"fmt": "packed",
+			"attStmt": map[interface{}]interface{}{
+				"alg": int64(coseES256),
+				"sig": ecdsaSign(t, a.key, append(append([]byte(nil), ad...), h[:]...)),
+			},
+			"authData": ad,
+		}),
+	}
+}
+
+// assertion returns a signed login response for challenge.
+func (a *testAuthenticator) assertion(t *testing.T, challenge []byte, flags byte) *auth.WebAuthnAssertion {
+	cd := testClientData(t, clientDataGet, challenge, testOrigin)
+	ad := a.authData("localhost", flags)
+	h := sha256.Sum256(cd)
+
+	return &auth.WebAuthnAssertion{
+		CredentialId:      a.id,
+		ClientDataJson:    cd,
+		AuthenticatorData: ad,
+		Signature:         ecdsaSign(t, a.key, append(append([]byte(nil), ad...), h[:]...)),
+	}
+}
+
+func Test_parseAuthenticatorData(t *testing.T) {
+	a := newTestAuthenticator(t)
+
+	got, err := parseAuthenticatorData(a.authData("localhost", flagUP|flagAT))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got.credentialID, a.id) || !bytes.Equal(got.publicKey, a.coseKey()) ||
+		!bytes.Equal(got.aaguid, testAAGUID) || got.signCount != 1 {
+		t.Errorf("parseAuthenticatorData() = %v", got)
+	}
+
+	full := a.authData("localhost", flagUP|flagAT)
+	long := append([]byte(nil), full[:55]...)
+	binary.BigEndian.PutUint16(long[53:], webauthnMaxCredIDLen+1)
+
+	tests := []struct {
+		name string
+		b    []byte
+	}{
+		{"Short", full[:36]},
+		{"Short attested data", full[:50]},
+		{"Short credential ID", full[:60]},
+		{"Credential ID too long", append(long, make([]byte, webauthnMaxCredIDLen+1)...)},
+		{"Short public key", full[:len(full)-1]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseAuthenticatorData(tt.b); err == nil {
+				t.Errorf("parseAuthenticatorData() error = %v, wantErr %v", err, true)
+			}
+		})
+	}
+}
+
+func Test_parseCOSEKey(t *testing.T) {
+	a := newTestAuthenticator(t)
+	x, y := p256Bytes(a.key.X), p256Bytes(a.key.Y)
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		key     map[interface{}]interface{}
+		want    int64
+		wantErr bool
+	}{
+		{
+			"ES256",
+			map[interface{}]interface{}{int64(coseKty): int64(coseKtyEC2), int64(coseAlg): int64(coseES256), int64(coseCrv): int64(coseP256), int64(coseX): x, int64(coseY): y},
+			coseES256,
+			false,
+		},
+		{
+			"Point not on curve",
+			map[interface{}]interface{}{int64(coseKty): int64(coseKtyEC2), int64(coseAlg): int64(coseES256), int64(coseCrv): int64(coseP256), int64(coseX): make([]byte, 32), int64(coseY): make([]byte, 32)},
+			0,
+			true,
+		},
+		{
+			"EdDSA",
+			map[interface{}]interface{}{int64(coseKty): int64(coseKtyOKP), int64(coseAlg): int64(coseEdDSA), int64(coseCrv): int64(coseEd), int64(coseX): []byte(edPub)},
+			coseEdDSA,
+			false,
+		},
+		{
+			"Wrong curve",
+			map[interface{}]interface{}{int64(coseKty): int64(coseKtyOKP), int64(coseAlg): int64(coseEdDSA), int64(coseCrv): int64(4), int64(coseX): []byte(edPub)},
+			0,
+			true,
+		},
+		{
+			"RS256",
+			map[interface{}]interface{}{int64(coseKty): int64(coseKtyRSA), int64(coseAlg): int64(coseRS256), int64(coseCrv): testRSAKey.N.Bytes(), int64(coseX): []byte{1, 0, 1}},
+			coseRS256,
+			false,
+		},
+		{
+			"Unsupported algorithm",
+			map[interface{}]interface{}{int64(coseKty): int64(coseKtyEC2), int64(coseAlg): int64(-35)},
+			0,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCOSEKey(cborEncode(tt.key))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseCOSEKey() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.alg != tt.want {
+				t.Errorf("parseCOSEKey() alg = %v, want %v", got.alg, tt.want)
+			}
+		})
+	}
+
+	if _, err := parseCOSEKey([]byte{0x01}); err == nil {
+		t.Errorf("parseCOSEKey() error = %v, wantErr %v", err, true)
+	}
+}
+
+var testRSAKey = func() *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}()
+
+func Test_verifySignature(t *testing.T) {
+	data := []byte("signed data")
+	ecKey := newTestAuthenticator(t).key
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := sha256.Sum256(data)
+	rsaSig, err := rsa.SignPKCS1v15(rand.Reader, testRSAKey, crypto.SHA256, h[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		alg     int64
+		pub     crypto.PublicKey
+		sig     []byte
+		wantErr bool
+	}{
+		{"ES256", coseES256, &ecKey.PublicKey, ecdsaSign(t, ecKey, data), false},
+		{"ES256 bad signature", coseES256, &ecKey.PublicKey, ecdsaSign(t, ecKey, []byte("other")), true},
+		{"ES256 malformed", coseES256, &ecKey.PublicKey, []byte("foo"), true},
+		{"EdDSA", coseEdDSA, edPub, ed25519.Sign(edPriv, data), false},
+		{"EdDSA bad signature", coseEdDSA, edPub, ed25519.Sign(edPriv, []byte("other")), true},
+		{"RS256", coseRS256, &testRSAKey.PublicKey, rsaSig, false},
+		{"RS256 bad signature", coseRS256, &testRSAKey.PublicKey, rsaSig[1:], true},
+		{"Algorithm mismatch", coseEdDSA, &ecKey.PublicKey, ecdsaSign(t, ecKey, data), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifySignature(tt.alg, tt.pub, data, tt.sig); (err != nil) != tt.wantErr {
+				t.Errorf("verifySignature() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// testAttestationCert returns a packed attestation certificate and its key.
+func testAttestationCert(t *testing.T, ou string, isCA bool, aaguid []byte) ([]byte, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ext, err := asn1.Marshal(aaguid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject: pkix.Name{
+			Country:            []string{"NL"},
+			Organization:       []string{"Test"},
+			OrganizationalUnit: []string{ou},
+			CommonName:         "Test authenticator",
+		},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+		ExtraExtensions:       []pkix.Extension{{Id: oidAAGUID, Value: ext}},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der, key
+}
+
+func Test_verifyAttestation(t *testing.T) {
+	a := newTestAuthenticator(t)
+	authData := a.authData("localhost", flagUP|flagAT)
+	ad, err := parseAuthenticatorData(authData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := parseCOSEKey(ad.publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientDataHash := sha256.Sum256([]byte("client data"))
+	signed := append(append([]byte(nil), authData...), clientDataHash[:]...)
+
+	cert, certKey := testAttestationCert(t, "Authenticator Attestation", false, testAAGUID)
+	caCert, caKey := testAttestationCert(t, "Authenticator Attestation", true, testAAGUID)
+	ouCert, ouKey := testAttestationCert(t, "Other", false, testAAGUID)
+	idCert, idKey := testAttestationCert(t, "Authenticator Attestation", false, []byte("fedcba9876543210"))
+
+	tests := []struct {
+		name    string
+		format  string
+		stmt    map[interface{}]interface{}
+		wantErr bool
+	}{
+		{
+			"None",
+			"none",
+			map[interface{}]interface{}{},
+			false,
+		},
+		{
+			"None with statement",
+			"none",
+			map[interface{}]interface{}{"alg": int64(coseES256)},
+			true,
+		},
+		{
+			"Packed self",
+			"packed",
+			map[interface{}]interface{}{"alg": int64(coseES256), "sig": ecdsaSign(t, a.key, signed)},
+			false,
+		},
+		{
+			"Packed self algorithm mismatch",
+			"packed",
+			map[interface{}]interface{}{"alg": int64(coseEdDSA), "sig": ecdsaSign(t, a.key, signed)},
+			true,
+		},
+		{
+			"Packed self bad signature",
+			"packed",
+			map[interface{}]interface{}{"alg": int64(coseES256), "sig": ecdsaSign(t, a.key, authData)},
+			true,
+		},
+		{
+			"Packed missing signature",
+			"packed",
+			map[interface{}]interface{}{"alg": int64(coseES256)},
+			true,
+		},
+		{
+			"Packed x5c",
+			"packed",
+			map[interface{}]interface{}{"alg": int64(coseES256), "sig": ecdsaSign(t, certKey, signed), "x5c": []interface{}{cert}},
+			false,
+		},
+		{
+			"Packed x5c signed by credential",
+			"packed",
+			map[interface{}]interface{}{"alg": int64(coseES256), "sig": ecdsaSign(t, a.key, signed), "x5c": []interface{}{cert}},
+			true,
+		},
+		{
+			"Packed x5c CA",
+			"packed",
+			map[interface{}]interface{}{"alg": int64(coseES256), "sig": ecdsaSign(t, caKey, signed), "x5c": []interface{}{caCert}},
+			true,
+		},
+		{
+			"Packed x5c OU",
+			"packed",
+			map[interface{}]interface{}{"alg": int64(coseES256), "sig": ecdsaSign(t, ouKey, signed), "x5c": []interface{}{ouCert}},
+			true,
+		},
+		{
+			"Packed x5c AAGUID",
+			"packed",
+			map[interface{}]interface{}{"alg": int64(coseES256), "sig": ecdsaSign(t, idKey, signed), "x5c": []interface{}{idCert}},
+			true,
+		},
+		{
+			"Packed x5c malformed",
+			"packed",
+			map[interface{}]interface{}{"alg": int64(coseES256), "sig": ecdsaSign(t, certKey, signed), "x5c": []interface{}{[]byte("foo")}},
+			true,
+		},
+		{
+			"Packed x5c empty",
+			"packed",
+			map[interface{}]interface{}{"alg": int64(coseES256), "sig": ecdsaSign(t, certKey, signed), "x5c": []interface{}{}},
+			true,
+		},
+		{
+			"Unsupported format",
+			"fido-u2f",
+			map[interface{}]interface{}{},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifyAttestation(tt.format, tt.stmt, authData, ad, key, clientDataHash[:]); (err != nil) != tt.wantErr {
+				t.Errorf("verifyAttestation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWebAuthnConfig_checkClientData(t *testing.T) {
+	conf := &WebAuthnConfig{RPID: "localhost", Origins: []string{testOrigin}}
+	challenge := []byte("challenge")
+
+	tests := []struct {
+		name    string
+		b       []byte
+		wantErr bool
+	}{
+		{"Valid", testClientData(t, clientDataGet, challenge, testOrigin), false},
+		{"Wrong type", testClientData(t, clientDataCreate, challenge, testOrigin), true},
+		{"Wrong origin", testClientData(t, clientDataGet, challenge, "https://evil.com"), true},
+		{"Bad challenge", []byte(`{"type":"webauthn.get","origin":"http://localhost:1234","challenge":"!"}`), true},
+		{"Malformed", []byte("foo"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := conf.checkClientData(tt.b, clientDataGet)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WebAuthnConfig.checkClientData() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !bytes.Equal(got, challenge) {
+				t.Errorf("WebAuthnConfig.checkClientData() = %s, want %s", got, challenge)
+			}
+		})
+	}
+}
+
+func Test_requestTx_WebAuthn(t *testing.T) {
+	rt, err := tas.newTx(testCtx, "testing", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Nothing gets committed
+	defer rt.done()
+
+	user := testUsers["oneGroup"]
+	now := time.Now()
+	a := newTestAuthenticator(t)
+
+	reg, err := rt.beginWebAuthnRegistration(user, now, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(reg.GetUserId(), webauthnUserHandle(user.ID)) || reg.GetRpId() != "localhost" ||
+		len(reg.GetChallenge()) != webauthnChallengeLen || len(reg.GetCredentialIds()) != 0 {
+		t.Errorf("requestTx.beginWebAuthnRegistration() = %v", reg)
+	}
+
+	// Registration challenges can't be used for another user
+	other, err := rt.beginWebAuthnRegistration(testUsers["noGroup"], now, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = rt.finishWebAuthnRegistration(user, a.attestation(t, "", other.GetChallenge()), now); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("requestTx.finishWebAuthnRegistration() error = %v, want %v", err, codes.Unauthenticated)
+	}
+
+	cred, err := rt.finishWebAuthnRegistration(user, a.attestation(t, "", reg.GetChallenge()), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(cred.CredentialID, a.id) || cred.SignCount != int64(a.count) {
+		t.Errorf("requestTx.finishWebAuthnRegistration() = %v", cred)
+	}
+
+	// The challenge is single use
+	if _, err = rt.finishWebAuthnRegistration(user, a.attestation(t, "", reg.GetChallenge()), now); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("requestTx.finishWebAuthnRegistration() error = %v, want %v", err, codes.Unauthenticated)
+	}
+	// The credential can't be registered twice
+	again, err := rt.beginWebAuthnRegistration(user, now, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again.GetCredentialIds()) != 1 || !bytes.Equal(again.GetCredentialIds()[0], a.id) {
+		t.Errorf("requestTx.beginWebAuthnRegistration() credential IDs = %v, want %v", again.GetCredentialIds(), a.id)
+	}
+	if _, err = rt.finishWebAuthnRegistration(user, a.attestation(t, "", again.GetChallenge()), now); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("requestTx.finishWebAuthnRegistration() error = %v, want %v", err, codes.AlreadyExists)
+	}
+
+	login, err := rt.beginWebAuthnLogin(user.Email, now, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(login.GetCredentialIds()) != 1 || len(login.GetUserId()) != 0 {
+		t.Errorf("requestTx.beginWebAuthnLogin() = %v", login)
+	}
+	if _, err = rt.beginWebAuthnLogin("nobody@example.com", now, rand.Reader); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("requestTx.beginWebAuthnLogin() error = %v, want %v", err, codes.Unauthenticated)
+	}
+
+	challenge := func() []byte {
+		c, err := rt.beginWebAuthnLogin("", now, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c.GetChallenge()
+	}
+
+	unknown := newTestAuthenticator(t)
+	otherUser := a.assertion(t, challenge(), flagUP)
+	otherUser.UserHandle = webauthnUserHandle(testUsers["noGroup"].ID)
+	badSig := a.assertion(t, challenge(), flagUP)
+	badSig.Signature = ecdsaSign(t, unknown.key, badSig.AuthenticatorData)
+	fresh, err := rt.beginWebAuthnRegistration(user, now, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrongCeremony := a.assertion(t, fresh.GetChallenge(), flagUP)
+	replayed := a.assertion(t, challenge(), flagUP)
+	a.count--
+
+	tests := []struct {
+		name   string
+		as     *auth.WebAuthnAssertion
+		want   codes.Code
+		wantUV bool
+	}{
+		{
+			"Allowed credential",
+			a.assertion(t, login.GetChallenge(), flagUP),
+			codes.OK,
+			false,
+		},
+		{
+			"Discoverable credential, user verified",
+			func() *auth.WebAuthnAssertion {
+				as := a.assertion(t, challenge(), flagUP|flagUV)
+				as.UserHandle = webauthnUserHandle(user.ID)
+				return as
+			}(),
+			codes.OK,
+			true,
+		},
+		{
+			"Used challenge",
+			a.assertion(t, login.GetChallenge(), flagUP),
+			codes.Unauthenticated,
+			false,
+		},
+		{
+			"Registration challenge",
+			wrongCeremony,
+			codes.Unauthenticated,
+			false,
+		},
+		{
+			"Unknown credential",
+			unknown.assertion(t, challenge(), flagUP),
+			codes.Unauthenticated,
+			false,
+		},
+		{
+			"User handle mismatch",
+			otherUser,
+			codes.Unauthenticated,
+			false,
+		},
+		{
+			"Bad signature",
+			badSig,
+			codes.Unauthenticated,
+			false,
+		},
+		{
+			"User not present",
+			a.assertion(t, challenge(), 0),
+			codes.Unauthenticated,
+			false,
+		},
+		{
+			"Sign counter not increased",
+			replayed,
+			codes.Unauthenticated,
+			false,
+		},
+		{
+			"Malformed client data",
+			&auth.WebAuthnAssertion{CredentialId: a.id, ClientDataJson: []byte("foo")},
+			codes.InvalidArgument,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, uv, err := rt.finishWebAuthnLogin(tt.as, now)
+			if status.Code(err) != tt.want {
+				t.Errorf("requestTx.finishWebAuthnLogin() error = %v, want %v", err, tt.want)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got.ID != user.ID || uv != tt.wantUV {
+				t.Errorf("requestTx.finishWebAuthnLogin() = %v, %v, want %v, %v", got.ID, uv, user.ID, tt.wantUV)
+			}
+		})
+	}
+}
+
+func Test_requestTx_WebAuthn_disabled(t *testing.T) {
+	conf := *tas.conf
+	conf.WebAuthn = nil
+	s := &authServer{
+		mdb:  mdb,
+		log:  tas.log,
+		conf: &conf,
+	}
+
+	rt, err := s.newTx(testCtx, "testing", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rt.done()
+
+	now := time.Now()
+	user := testUsers["noGroup"]
+	if _, err = rt.beginWebAuthnRegistration(user, now, rand.Reader); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("requestTx.beginWebAuthnRegistration() error = %v, want %v", err, codes.FailedPrecondition)
+	}
+	if _, err = rt.finishWebAuthnRegistration(user, &auth.WebAuthnAttestation{}, now); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("requestTx.finishWebAuthnRegistration() error = %v, want %v", err, codes.FailedPrecondition)
+	}
+	if _, err = rt.beginWebAuthnLogin("", now, rand.Reader); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("requestTx.beginWebAuthnLogin() error = %v, want %v", err, codes.FailedPrecondition)
+	}
+	if _, _, err = rt.finishWebAuthnLogin(&auth.WebAuthnAssertion{}, now); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("requestTx.finishWebAuthnLogin() error = %v, want %v", err, codes.FailedPrecondition)
+	}
+}
+
+func Test_authServer_pruneWebAuthnChallenges(t *testing.T) {
+	db, err := mdb.Master(testCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &models.WebauthnChallenge{
+		Challenge: []byte("prune"),
+		Ceremony:  ceremonyLogin,
+		ExpiresAt: time.Unix(500, 0),
+	}
+	if err = m.Insert(testCtx, db, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Unix(501, 0)
+	tests := []struct {
+		name    string
+		want    int64
+		wantErr bool
+	}{
+		{
+			"Expired challenge",
+			1,
+			false,
+		},
+		{
+			"Nothing to prune",
+			0,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tas.pruneWebAuthnChallenges(testCtx, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("authServer.pruneWebAuthnChallenges() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("authServer.pruneWebAuthnChallenges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
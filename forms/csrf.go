@@ -0,0 +1,72 @@
+package forms
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"strings"
+
+	"github.com/moapis/ehtml"
+	clog "github.com/usrpro/clog15"
+)
+
+const (
+	// CSRFCookie is the name of the cookie holding the CSRF token.
+	CSRFCookie = "csrf"
+	// CSRFField is the name of the form field,
+	// in which the CSRF token needs to be posted back.
+	CSRFField = "csrf_token"
+
+	// csrfLen is the amount of random bytes in a CSRF token.
+	csrfLen = 32
+)
+
+const errCSRF = "Invalid or missing CSRF token, please reload the form"
+
+// csrfToken returns the CSRF token from the request cookie.
+// If there is none, a new token is generated and set in a cookie.
+// The cookie is only sent over HTTPS if the ServerAddress is HTTPS.
+func (f *Forms) csrfToken(w http.ResponseWriter, r *http.Request) (string, error) {
+	if cookie, err := r.Cookie(CSRFCookie); err == nil && len(cookie.Value) == base64.RawURLEncoding.EncodedLen(csrfLen) {
+		return cookie.Value, nil
+	}
+
+	b := make([]byte, csrfLen)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	tkn := base64.RawURLEncoding.EncodeToString(b)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     CSRFCookie,
+		Value:    tkn,
+		Path:     "/",
+		Secure:   strings.HasPrefix(f.Paths.server(), "https://"),
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+	return tkn, nil
+}
+
+// checkCSRF reports if the posted CSRF token matches the cookie.
+// It always succeeds when the Forms are not Hardened.
+// On failure, a "403 Forbidden" error page is rendered.
+func (f *Forms) checkCSRF(w http.ResponseWriter, r *http.Request) bool {
+	if !f.Hardened {
+		return true
+	}
+
+	cookie, err := r.Cookie(CSRFCookie)
+	if err == nil && cookie.Value != "" &&
+		subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(r.PostFormValue(CSRFField))) == 1 {
+		return true
+	}
+
+	ctx := clog.AddArgs(r.Context(), "method", "checkCSRF")
+	clog.Warn(ctx, errCSRF, "err", err)
+	if err := f.EP.Render(w, &ehtml.Data{Req: r, Code: http.StatusForbidden, Msg: errCSRF}); err != nil {
+		clog.Error(ctx, "During handling error", "err", err)
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+package forms
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	auth "github.com/moapis/authenticator"
+	"google.golang.org/grpc"
+)
+
+var testCSRF = base64.RawURLEncoding.EncodeToString(make([]byte, csrfLen))
+
+func TestForms_csrfToken(t *testing.T) {
+	tests := []struct {
+		name       string
+		paths      *Paths
+		cookie     string
+		wantCookie bool
+		wantSecure bool
+	}{
+		{"New token", nil, "", true, false},
+		{"Invalid cookie", nil, "foo", true, false},
+		{"Existing cookie", nil, testCSRF, false, false},
+		{"HTTPS", &Paths{ServerAddress: "https://example.com"}, "", true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &Forms{Paths: tt.paths}
+			r := httptest.NewRequest("GET", "/login", nil)
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: CSRFCookie, Value: tt.cookie})
+			}
+			w := httptest.NewRecorder()
+
+			got, err := f.csrfToken(w, r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cookies := w.Result().Cookies()
+			if !tt.wantCookie {
+				if got != tt.cookie || len(cookies) != 0 {
+					t.Errorf("Forms.csrfToken() = %v, %v, want %v without cookie", got, cookies, tt.cookie)
+				}
+				return
+			}
+			if len(cookies) != 1 {
+				t.Fatalf("Forms.csrfToken() cookies = %v, want 1", cookies)
+			}
+			c := cookies[0]
+			if c.Value != got || len(got) != len(testCSRF) {
+				t.Errorf("Forms.csrfToken() = %v, cookie %v", got, c.Value)
+			}
+			if c.Secure != tt.wantSecure || !c.HttpOnly || c.SameSite != http.SameSiteStrictMode {
+				t.Errorf("Forms.csrfToken() cookie = %v", c)
+			}
+		})
+	}
+}
+
+func TestForms_checkCSRF(t *testing.T) {
+	tests := []struct {
+		name     string
+		hardened bool
+		cookie   string
+		body     string
+		want     bool
+	}{
+		{"Not hardened", false, "", "", true},
+		{"Match", true, testCSRF, "csrf_token=" + testCSRF, true},
+		{"Mismatch", true, testCSRF, "csrf_token=foo", false},
+		{"Missing field", true, testCSRF, "", false},
+		{"Missing cookie", true, "", "csrf_token=" + testCSRF, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &Forms{Hardened: tt.hardened}
+			r := httptest.NewRequest("POST", "/login", strings.NewReader(tt.body))
+			r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: CSRFCookie, Value: tt.cookie})
+			}
+			w := httptest.NewRecorder()
+
+			if got := f.checkCSRF(w, r); got != tt.want {
+				t.Errorf("Forms.checkCSRF() = %v, want %v", got, tt.want)
+			}
+			if !tt.want && w.Code != http.StatusForbidden {
+				t.Errorf("Forms.checkCSRF() status = %v, want %v", w.Code, http.StatusForbidden)
+			}
+		})
+	}
+}
+
+// codeClient fakes the server for authorization code creation.
+type codeClient struct {
+	auth.AuthenticatorClient
+}
+
+func (codeClient) CreateAuthCode(ctx context.Context, in *auth.AuthReply, opts ...grpc.CallOption) (*auth.AuthCode, error) {
+	if in.GetRefreshToken() != "foobar" {
+		return nil, errors.New("foo")
+	}
+	return &auth.AuthCode{Code: "spanac"}, nil
+}
+
+func TestForms_loginRedirect_hardened(t *testing.T) {
+	tests := []struct {
+		name     string
+		reply    *auth.AuthReply
+		wantCode int
+		wantLoc  string
+	}{
+		{
+			"Success",
+			&auth.AuthReply{Jwt: "jwt", RefreshToken: "foobar"},
+			http.StatusSeeOther,
+			"http://example.com/foo?code=spanac&hello=world",
+		},
+		{
+			"Client error",
+			&auth.AuthReply{Jwt: "jwt", RefreshToken: "foo"},
+			http.StatusInternalServerError,
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &Forms{Client: codeClient{}, Hardened: true}
+			u, _ := url.Parse("http://example.com/foo?hello=world")
+			r := httptest.NewRequest("POST", "/login", nil)
+			w := httptest.NewRecorder()
+
+			f.loginRedirect(context.Background(), w, r, u, tt.reply)
+
+			resp := w.Result()
+			if resp.StatusCode != tt.wantCode {
+				t.Errorf("Forms.loginRedirect() status = %v, want: %v", resp.StatusCode, tt.wantCode)
+			}
+			if got := resp.Header.Get("Location"); got != tt.wantLoc {
+				t.Errorf("Forms.loginRedirect() Location = %v, want: %v", got, tt.wantLoc)
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package forms
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	auth "github.com/moapis/authenticator"
+	"github.com/moapis/ehtml"
+	clog "github.com/usrpro/clog15"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// FederatedCookie is the name of the cookie holding the state and redirect URL
+	// during a federated login.
+	FederatedCookie = "federated"
+
+	// federatedMaxAge is the lifetime of the FederatedCookie, in seconds.
+	federatedMaxAge = 600
+)
+
+const (
+	federatedState    = "Invalid or expired federated login, please try again"
+	federatedProvider = "Login at identity provider failed"
+)
+
+var errFederatedState = errors.New(federatedState)
+
+// ProviderLink is a link to login at an upstream identity provider.
+type ProviderLink struct {
+	Name string
+	URL  template.URL
+}
+
+// providerLinks returns the links to the FederatedHandler for all Providers,
+// preserving the query of r.
+func (f *Forms) providerLinks(r *http.Request) []ProviderLink {
+	if len(f.Providers) == 0 {
+		return nil
+	}
+	links := make([]ProviderLink, len(f.Providers))
+	for i, name := range f.Providers {
+		q := r.URL.Query()
+		q.Set("provider", name)
+		links[i] = ProviderLink{
+			Name: name,
+			URL:  template.URL(fmt.Sprintf("%s?%s", f.Paths.federated(), q.Encode())),
+		}
+	}
+	return links
+}
+
+// setFederatedCookie binds the state and redirect URL of a federated login to the user-agent.
+// The cookie is sent on the top-level navigation back from the provider.
+func (f *Forms) setFederatedCookie(w http.ResponseWriter, state, redirect string, maxAge int) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     FederatedCookie,
+		Value:    url.Values{"state": {state}, "redirect": {redirect}}.Encode(),
+		Path:     f.Paths.federated(),
+		MaxAge:   maxAge,
+		Secure:   strings.HasPrefix(f.Paths.server(), "https://"),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// federatedSession returns the redirect URL from the FederatedCookie,
+// if its state matches the state in the callback URL.
+func (f *Forms) federatedSession(ctx context.Context, r *http.Request) (*url.URL, error) {
+	cookie, err := r.Cookie(FederatedCookie)
+	if err != nil {
+		clog.Warn(ctx, federatedState, "err", err)
+		return nil, errFederatedState
+	}
+	values, err := url.ParseQuery(cookie.Value)
+	state := values.Get("state")
+	if err != nil || state == "" ||
+		subtle.ConstantTimeCompare([]byte(state), []byte(r.URL.Query().Get("state"))) != 1 {
+		clog.Warn(ctx, federatedState, "err", err)
+		return nil, errFederatedState
+	}
+	return f.parseRedirect(ctx, values.Get("redirect"))
+}
+
+func (f *Forms) federatedGet(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	ctx = clog.AddArgs(ctx, "method", "federatedGet")
+
+	rURL, err := f.getRedirect(r)
+	if err != nil {
+		if err := f.EP.Render(w, &ehtml.Data{Req: r, Code: http.StatusBadRequest, Msg: err.Error()}); err != nil {
+			clog.Error(ctx, "During handling error", "err", err)
+		}
+		return
+	}
+
+	reply, err := f.Client.BeginFederatedLogin(ctx, &auth.FederatedRequest{
+		Provider:    r.URL.Query().Get("provider"),
+		RedirectUri: f.Paths.server() + f.Paths.federatedCallback(),
+	})
+	if err != nil {
+		data := &ehtml.Data{Req: r, Code: http.StatusInternalServerError, Msg: "Internal server error"}
+		switch status.Code(err) {
+		case codes.NotFound:
+			clog.Info(ctx, "BeginFederatedLogin gRPC call", "err", err)
+			data.Code, data.Msg = http.StatusNotFound, "Unknown identity provider"
+		case codes.Unavailable:
+			clog.Warn(ctx, "BeginFederatedLogin gRPC call", "err", err)
+			data.Code, data.Msg = http.StatusServiceUnavailable, "Identity provider unavailable"
+		default:
+			clog.Error(ctx, "BeginFederatedLogin gRPC call", "err", err)
+		}
+		if err := f.EP.Render(w, data); err != nil {
+			clog.Error(ctx, "During handling error", "err", err)
+		}
+		return
+	}
+
+	f.setFederatedCookie(w, reply.GetState(), rURL.String(), federatedMaxAge)
+	http.Redirect(w, r, reply.GetUrl(), http.StatusFound)
+}
+
+// federatedCallback completes the login with the authorization code from the provider.
+// A POST request comes from the "mfa" form, served when the user has two-factor authentication enabled.
+func (f *Forms) federatedCallback(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	ctx = clog.AddArgs(forwardClientIP(ctx, r), "method", "federatedCallback")
+
+	rURL, err := f.federatedSession(ctx, r)
+	if err != nil {
+		if err := f.EP.Render(w, &ehtml.Data{Req: r, Code: http.StatusBadRequest, Msg: err.Error()}); err != nil {
+			clog.Error(ctx, "During handling error", "err", err)
+		}
+		return
+	}
+	done := func(ctx context.Context, w http.ResponseWriter, r *http.Request, reply *auth.AuthReply) {
+		f.setFederatedCookie(w, "", "", -1)
+		f.loginRedirect(ctx, w, r, rURL, reply)
+	}
+
+	if r.Method == http.MethodPost {
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("mfa_token") == "" {
+			clog.Warn(ctx, "Parseform", "err", err)
+			if err := f.EP.Render(w, &ehtml.Data{Req: r, Code: http.StatusBadRequest, Msg: "Malformed form data"}); err != nil {
+				clog.Error(ctx, "During handling error", "err", err)
+			}
+			return
+		}
+		f.mfaPost(ctx, w, r, done, r.PostForm.Get("mfa_token"))
+		return
+	}
+
+	values := r.URL.Query()
+	if e := values.Get("error"); e != "" {
+		clog.Info(ctx, federatedProvider, "error", e, "error_description", values.Get("error_description"))
+		f.setFederatedCookie(w, "", "", -1)
+		if err := f.EP.Render(w, &ehtml.Data{Req: r, Code: http.StatusUnauthorized, Msg: federatedProvider}); err != nil {
+			clog.Error(ctx, "During handling error", "err", err)
+		}
+		return
+	}
+
+	reply, err := f.Client.FinishFederatedLogin(ctx, &auth.FederatedCallback{
+		State: values.Get("state"),
+		Code:  values.Get("code"),
+	})
+	if err == nil {
+		if reply.GetMfaRequired() {
+			f.renderMFAForm(w, r, reply.GetJwt(), nil)
+			return
+		}
+		done(ctx, w, r, reply)
+		return
+	}
+
+	data := &ehtml.Data{Req: r, Code: http.StatusInternalServerError, Msg: "Internal server error"}
+	switch status.Code(err) {
+	case codes.Unauthenticated, codes.InvalidArgument:
+		clog.Info(ctx, "FinishFederatedLogin gRPC call", "err", err)
+		data.Code, data.Msg = http.StatusUnauthorized, federatedProvider
+	case codes.PermissionDenied:
+		clog.Info(ctx, "FinishFederatedLogin gRPC call", "err", err)
+		data.Code, data.Msg = http.StatusForbidden, status.Convert(err).Message()
+	case codes.Unavailable:
+		clog.Warn(ctx, "FinishFederatedLogin gRPC call", "err", err)
+		data.Code, data.Msg = http.StatusServiceUnavailable, "Identity provider unavailable"
+	default:
+		clog.Error(ctx, "FinishFederatedLogin gRPC call", "err", err)
+	}
+	f.setFederatedCookie(w, "", "", -1)
+	if err := f.EP.Render(w, data); err != nil {
+		clog.Error(ctx, "During handling error", "err", err)
+	}
+}
+
+// FederatedHandler returns the handler starting a login at an upstream OpenID Connect provider.
+// A GET request with the "provider" and RedirectKey in its query redirects to the provider.
+// The provider redirects back to the handler from FederatedCallbackHandler,
+// which needs to be served under the "/callback" path of Paths.Federated.
+// Its URL needs to be registered at the provider.
+func (f *Forms) FederatedHandler() http.Handler {
+	return &federatedHandler{f}
+}
+
+type federatedHandler struct {
+	*Forms
+}
+
+func (h *federatedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r = r.WithContext(clog.AddArgs(r.Context(), "pkg", "authenticator.forms", "handler", "Federated"))
+
+	if r.Method != http.MethodGet {
+		w.Header().Add("Allow", http.MethodGet)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	h.federatedGet(w, r)
+}
+
+// FederatedCallbackHandler returns the handler for the callback from an upstream provider.
+// The login is completed over gRPC and redirects like the handler from LoginHander.
+// If the user has two-factor authentication enabled,
+// the "mfa" form is served and its POST completes the login.
+func (f *Forms) FederatedCallbackHandler() http.Handler {
+	return &federatedCallbackHandler{f}
+}
+
+type federatedCallbackHandler struct {
+	*Forms
+}
+
+func (h *federatedCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r = r.WithContext(clog.AddArgs(r.Context(), "pkg", "authenticator.forms", "handler", "FederatedCallback"))
+
+	switch r.Method {
+	case http.MethodGet:
+		h.federatedCallback(w, r)
+	case http.MethodPost:
+		if !h.checkCSRF(w, r) {
+			return
+		}
+		h.federatedCallback(w, r)
+	default:
+		w.Header().Add("Allow", AllowedMethods)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,159 @@
+package forms
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	auth "github.com/moapis/authenticator"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// federatedClient fakes the server with a single upstream provider "stub".
+type federatedClient struct {
+	auth.AuthenticatorClient
+}
+
+func (federatedClient) BeginFederatedLogin(ctx context.Context, in *auth.FederatedRequest, opts ...grpc.CallOption) (*auth.FederatedRedirect, error) {
+	switch {
+	case in.GetProvider() != "stub":
+		return nil, status.Error(codes.NotFound, "Unknown identity provider")
+	case in.GetRedirectUri() != DefaultServerAddress+DefaultFederatedCallback:
+		return nil, status.Error(codes.InvalidArgument, "Wrong redirect URI")
+	}
+	return &auth.FederatedRedirect{Url: "https://idp.com/authorize?state=xyz", State: "xyz"}, nil
+}
+
+func (federatedClient) FinishFederatedLogin(ctx context.Context, in *auth.FederatedCallback, opts ...grpc.CallOption) (*auth.AuthReply, error) {
+	if in.GetState() != "xyz" {
+		return nil, status.Error(codes.Unauthenticated, "Invalid federated login state")
+	}
+	switch in.GetCode() {
+	case "good":
+		return &auth.AuthReply{Jwt: "jwt", RefreshToken: "foobar"}, nil
+	case "mfa":
+		return &auth.AuthReply{Jwt: "mfa", MfaRequired: true}, nil
+	case "denied":
+		return nil, status.Error(codes.PermissionDenied, "Identity not linked to a user")
+	}
+	return nil, status.Error(codes.Unauthenticated, "Authorization code rejected by identity provider")
+}
+
+func (federatedClient) VerifyMFA(ctx context.Context, in *auth.MFACode, opts ...grpc.CallOption) (*auth.AuthReply, error) {
+	if in.GetJwt() != "mfa" || in.GetCode() != "123456" {
+		return nil, status.Error(codes.Unauthenticated, "Invalid code")
+	}
+	return &auth.AuthReply{Jwt: "jwt", RefreshToken: "foobar"}, nil
+}
+
+func TestForms_providerLinks(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/login?redirect=http%3A%2F%2Flocalhost%3A1234%2Fapp", nil)
+
+	f := &Forms{}
+	if got := f.providerLinks(r); got != nil {
+		t.Errorf("Forms.providerLinks() = %v, want nil", got)
+	}
+
+	f.Providers = []string{"stub"}
+	want := []ProviderLink{{"stub", "/federated?provider=stub&redirect=http%3A%2F%2Flocalhost%3A1234%2Fapp"}}
+	if got := f.providerLinks(r); !reflect.DeepEqual(got, want) {
+		t.Errorf("Forms.providerLinks() = %v, want %v", got, want)
+	}
+}
+
+func TestForms_FederatedHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		wantCode   int
+		wantLoc    string
+		wantCookie bool
+	}{
+		{"Method not allowed", "POST", "/federated?provider=stub&redirect=http%3A%2F%2Flocalhost%3A1234%2Fapp", http.StatusMethodNotAllowed, "", false},
+		{"Missing redirect", "GET", "/federated?provider=stub", http.StatusBadRequest, "", false},
+		{"Unknown provider", "GET", "/federated?provider=foo&redirect=http%3A%2F%2Flocalhost%3A1234%2Fapp", http.StatusNotFound, "", false},
+		{"Redirect", "GET", "/federated?provider=stub&redirect=http%3A%2F%2Flocalhost%3A1234%2Fapp", http.StatusFound, "https://idp.com/authorize?state=xyz", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &Forms{Client: federatedClient{}}
+			w := httptest.NewRecorder()
+
+			f.FederatedHandler().ServeHTTP(w, httptest.NewRequest(tt.method, tt.target, nil))
+
+			resp := w.Result()
+			if resp.StatusCode != tt.wantCode {
+				t.Errorf("Forms.FederatedHandler() status = %v, want: %v", resp.StatusCode, tt.wantCode)
+			}
+			if got := resp.Header.Get("Location"); got != tt.wantLoc {
+				t.Errorf("Forms.FederatedHandler() Location = %v, want: %v", got, tt.wantLoc)
+			}
+			if got := len(resp.Cookies()) == 1; got != tt.wantCookie {
+				t.Fatalf("Forms.FederatedHandler() cookies = %v, want: %v", resp.Cookies(), tt.wantCookie)
+			}
+			if !tt.wantCookie {
+				return
+			}
+			c := resp.Cookies()[0]
+			values, _ := url.ParseQuery(c.Value)
+			if c.Name != FederatedCookie || !c.HttpOnly || c.SameSite != http.SameSiteLaxMode ||
+				values.Get("state") != "xyz" || values.Get("redirect") != "http://localhost:1234/app" {
+				t.Errorf("Forms.FederatedHandler() cookie = %v", c)
+			}
+		})
+	}
+}
+
+func TestForms_FederatedCallbackHandler(t *testing.T) {
+	session := url.Values{"state": {"xyz"}, "redirect": {"http://localhost:1234/app"}}.Encode()
+
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		cookie   string
+		body     string
+		wantCode int
+		wantLoc  string
+	}{
+		{"Method not allowed", "PUT", "/federated/callback?state=xyz&code=good", session, "", http.StatusMethodNotAllowed, ""},
+		{"Missing cookie", "GET", "/federated/callback?state=xyz&code=good", "", "", http.StatusBadRequest, ""},
+		{"State mismatch", "GET", "/federated/callback?state=abc&code=good", session, "", http.StatusBadRequest, ""},
+		{"Provider error", "GET", "/federated/callback?state=xyz&error=access_denied", session, "", http.StatusUnauthorized, ""},
+		{"Invalid code", "GET", "/federated/callback?state=xyz&code=bad", session, "", http.StatusUnauthorized, ""},
+		{"Not linked", "GET", "/federated/callback?state=xyz&code=denied", session, "", http.StatusForbidden, ""},
+		{"Login", "GET", "/federated/callback?state=xyz&code=good", session, "", http.StatusSeeOther, "http://localhost:1234/app?jwt=jwt"},
+		{"MFA form", "GET", "/federated/callback?state=xyz&code=mfa", session, "", http.StatusOK, ""},
+		{"MFA missing token", "POST", "/federated/callback?state=xyz&code=mfa", session, "code=123456", http.StatusBadRequest, ""},
+		{"MFA wrong code", "POST", "/federated/callback?state=xyz&code=mfa", session, "mfa_token=mfa&code=000000", http.StatusUnauthorized, ""},
+		{"MFA login", "POST", "/federated/callback?state=xyz&code=mfa", session, "mfa_token=mfa&code=123456", http.StatusSeeOther, "http://localhost:1234/app?jwt=jwt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &Forms{Client: federatedClient{}}
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: FederatedCookie, Value: tt.cookie})
+			}
+			w := httptest.NewRecorder()
+
+			f.FederatedCallbackHandler().ServeHTTP(w, r)
+
+			resp := w.Result()
+			if resp.StatusCode != tt.wantCode {
+				t.Errorf("Forms.FederatedCallbackHandler() status = %v, want: %v", resp.StatusCode, tt.wantCode)
+			}
+			if got := resp.Header.Get("Location"); got != tt.wantLoc {
+				t.Errorf("Forms.FederatedCallbackHandler() Location = %v, want: %v", got, tt.wantLoc)
+			}
+		})
+	}
+}
@@ -2,10 +2,13 @@ package forms
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"html/template"
 	"net/http"
 	"net/url"
+	"path"
+	"strings"
 	"sync"
 
 	auth "github.com/moapis/authenticator"
@@ -20,9 +23,14 @@ const (
 
 // Titles passed to templates
 var (
-	LoginTitle   = "Please login"
-	ResetPWTitle = "Reset password"
-	SetPWTitle   = "Set new password"
+	LoginTitle            = "Please login"
+	MFATitle              = "Two-factor authentication"
+	PasskeyTitle          = "Sign in with a passkey"
+	PasskeyRegisterTitle  = "Create a passkey"
+	ResetPWTitle          = "Reset password"
+	SetPWTitle            = "Set new password"
+	LoginLinkTitle        = "Sign in by e-mail"
+	LoginLinkConfirmTitle = "Continue signing in"
 )
 
 // Flash message targets the user with info, warning or error message
@@ -43,22 +51,26 @@ const (
 
 // Navigation links to other forms
 type Navigation struct {
-	Login, Reset, Set template.URL
+	Login, Reset, Set, Passkey, LoginLink template.URL
 }
 
 func navigation(r *http.Request, p *Paths) Navigation {
 	if r.URL.RawQuery == "" {
 		return Navigation{
-			Login: template.URL(p.login()),
-			Reset: template.URL(p.resetPW()),
-			Set:   template.URL(p.setPW()),
+			Login:     template.URL(p.login()),
+			Reset:     template.URL(p.resetPW()),
+			Set:       template.URL(p.setPW()),
+			Passkey:   template.URL(p.passkey()),
+			LoginLink: template.URL(p.loginLink()),
 		}
 	}
 
 	return Navigation{
-		Login: template.URL(fmt.Sprintf("%s?%s", p.login(), r.URL.RawQuery)),
-		Reset: template.URL(fmt.Sprintf("%s?%s", p.resetPW(), r.URL.RawQuery)),
-		Set:   template.URL(fmt.Sprintf("%s?%s", p.setPW(), r.URL.RawQuery)),
+		Login:     template.URL(fmt.Sprintf("%s?%s", p.login(), r.URL.RawQuery)),
+		Reset:     template.URL(fmt.Sprintf("%s?%s", p.resetPW(), r.URL.RawQuery)),
+		Set:       template.URL(fmt.Sprintf("%s?%s", p.setPW(), r.URL.RawQuery)),
+		Passkey:   template.URL(fmt.Sprintf("%s?%s", p.passkey(), r.URL.RawQuery)),
+		LoginLink: template.URL(fmt.Sprintf("%s?%s", p.loginLink(), r.URL.RawQuery)),
 	}
 }
 
@@ -69,6 +81,19 @@ type FormData struct {
 	Nav       Navigation
 	SubmitURL string
 	Data      interface{} // As set on the Forms object
+	// MFAToken is set on the "mfa" form,
+	// and needs to be posted back in the "mfa_token" field.
+	MFAToken string
+	// WebAuthn is set on the "passkey" and "passkey-register" forms.
+	WebAuthn *WebAuthnOptions
+	// Violations is set on the "setpw" form,
+	// when the new password does not meet the server's password policy.
+	Violations []string
+	// CSRFToken is set on all forms when Forms.Hardened,
+	// and needs to be posted back in the "csrf_token" field.
+	CSRFToken string
+	// Providers links to login at the upstream providers from Forms.Providers.
+	Providers []ProviderLink
 }
 
 type bufferPool struct {
@@ -96,15 +121,25 @@ type TemplateName string
 
 // Predefined Template Names.
 const (
-	LoginTmpl   TemplateName = "login"
-	ResetPWTmpl TemplateName = "reset"
-	SetPWTmpl   TemplateName = "setpw"
+	LoginTmpl            TemplateName = "login"
+	MFATmpl              TemplateName = "mfa"
+	PasskeyTmpl          TemplateName = "passkey"
+	PasskeyRegisterTmpl  TemplateName = "passkey-register"
+	ResetPWTmpl          TemplateName = "reset"
+	SetPWTmpl            TemplateName = "setpw"
+	LoginLinkTmpl        TemplateName = "login-link"
+	LoginLinkConfirmTmpl TemplateName = "login-link-confirm"
 )
 
 var defaultTmpl = map[TemplateName]*template.Template{
-	LoginTmpl:   template.Must(template.New(string(LoginTmpl)).Parse(DefaultLoginTmpl)),
-	ResetPWTmpl: template.Must(template.New(string(ResetPWTmpl)).Parse(DefaultResetPWTmpl)),
-	SetPWTmpl:   template.Must(template.New(string(SetPWTmpl)).Parse(DefaultSetPWTmpl)),
+	LoginTmpl:            template.Must(template.New(string(LoginTmpl)).Parse(DefaultLoginTmpl)),
+	MFATmpl:              template.Must(template.New(string(MFATmpl)).Parse(DefaultMFATmpl)),
+	PasskeyTmpl:          template.Must(template.New(string(PasskeyTmpl)).Parse(DefaultPasskeyTmpl)),
+	PasskeyRegisterTmpl:  template.Must(template.New(string(PasskeyRegisterTmpl)).Parse(DefaultPasskeyRegisterTmpl)),
+	ResetPWTmpl:          template.Must(template.New(string(ResetPWTmpl)).Parse(DefaultResetPWTmpl)),
+	SetPWTmpl:            template.Must(template.New(string(SetPWTmpl)).Parse(DefaultSetPWTmpl)),
+	LoginLinkTmpl:        template.Must(template.New(string(LoginLinkTmpl)).Parse(DefaultLoginLinkTmpl)),
+	LoginLinkConfirmTmpl: template.Must(template.New(string(LoginLinkConfirmTmpl)).Parse(DefaultLoginLinkConfirmTmpl)),
 }
 
 // Forms implements http.Forms.
@@ -120,6 +155,20 @@ type Forms struct {
 
 	Client auth.AuthenticatorClient
 	Paths  *Paths
+
+	// Hardened enables the hardened session mode.
+	// Upon login, the client is redirected with a one-time authorization code
+	// under Paths.CodeKey, in place of the tokens.
+	// See middleware.Client.Hardened for the exchange of the code.
+	// Without hardened mode, only the jwt is embedded and no refresh token is delivered.
+	// All forms are protected by a CSRF token,
+	// which is set in a cookie and needs to be posted back with the form.
+	Hardened bool
+
+	// Providers are the names of the upstream identity providers,
+	// as configured on the server, which are offered for login.
+	// See FederatedHandler.
+	Providers []string
 }
 
 func (f *Forms) template(tn TemplateName) *template.Template {
@@ -132,20 +181,39 @@ func (f *Forms) template(tn TemplateName) *template.Template {
 	return defaultTmpl[tn]
 }
 
-func (f *Forms) renderForm(w http.ResponseWriter, r *http.Request, tn TemplateName, title string, flash *Flash, status ...int) {
-	buf := resPool.Get()
-	defer resPool.Put(buf)
-
-	data := &FormData{
+func (f *Forms) formData(r *http.Request, title string, flash *Flash) *FormData {
+	return &FormData{
 		Title:     title,
 		Flash:     flash,
 		Nav:       navigation(r, f.Paths),
 		SubmitURL: r.URL.String(),
 		Data:      f.Data,
+		Providers: f.providerLinks(r),
 	}
+}
+
+func (f *Forms) renderForm(w http.ResponseWriter, r *http.Request, tn TemplateName, title string, flash *Flash, status ...int) {
+	f.render(w, r, tn, f.formData(r, title, flash), status...)
+}
+
+func (f *Forms) render(w http.ResponseWriter, r *http.Request, tn TemplateName, data *FormData, status ...int) {
+	buf := resPool.Get()
+	defer resPool.Put(buf)
 
 	ctx := clog.AddArgs(r.Context(), "method", "renderForm", "data", data)
 
+	if f.Hardened && data.CSRFToken == "" {
+		tkn, err := f.csrfToken(w, r)
+		if err != nil {
+			clog.Error(ctx, "CSRF token", "err", err)
+			if err := f.EP.Render(w, &ehtml.Data{Req: r, Code: http.StatusInternalServerError, Msg: "CSRF token error"}); err != nil {
+				clog.Error(ctx, "During handling error", "err", err)
+			}
+			return
+		}
+		data.CSRFToken = tkn
+	}
+
 	if err := f.template(tn).Execute(buf, data); err != nil {
 		clog.Error(ctx, "Template execution", "err", err)
 		if err := f.EP.Render(w, &ehtml.Data{Req: r, Code: http.StatusInternalServerError, Msg: "Template execution error"}); err != nil {
@@ -163,24 +231,55 @@ func (f *Forms) renderForm(w http.ResponseWriter, r *http.Request, tn TemplateNa
 	}
 }
 
-var ()
+// allowedRedirect reports if u matches any of the allowed origins and path prefixes.
+// An entry without path allows the whole origin.
+// An empty allowed list permits any URL.
+func allowedRedirect(allowed []string, u *url.URL) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	p := path.Clean("/" + u.Path)
 
+	for _, a := range allowed {
+		au, err := url.Parse(a)
+		if err != nil || au.Scheme != u.Scheme || !strings.EqualFold(au.Host, u.Host) {
+			continue
+		}
+		prefix := strings.TrimSuffix(au.Path, "/")
+		if prefix == "" || p == prefix || strings.HasPrefix(p, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// getRedirect returns the absolute http(s) URL under RedirectKey,
+// if it is permitted by Paths.AllowedRedirects.
 func (f *Forms) getRedirect(r *http.Request) (u *url.URL, err error) {
 	values := r.URL.Query()
 	ctx := clog.AddArgs(r.Context(), "method", "getRedirect", "url_values", values)
 
-	if red := values.Get(f.Paths.redirectKey()); red != "" {
-		if u, err = url.Parse(red); err == nil {
-			return u, nil
-		}
-		clog.Warn(ctx, redirectInvalid, "err", err, "red", red)
-		err = errRedirectInvalid
-	} else {
+	return f.parseRedirect(ctx, values.Get(f.Paths.redirectKey()))
+}
+
+// parseRedirect returns red as absolute http(s) URL,
+// if it is permitted by Paths.AllowedRedirects.
+func (f *Forms) parseRedirect(ctx context.Context, red string) (u *url.URL, err error) {
+	if red == "" {
 		clog.Warn(ctx, redirectMissing)
-		err = errRedirectMissing
+		return nil, errRedirectMissing
+	}
+
+	if u, err = url.Parse(red); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.User != nil {
+		clog.Warn(ctx, redirectInvalid, "err", err, "red", red)
+		return nil, errRedirectInvalid
+	}
+	if !allowedRedirect(f.Paths.allowedRedirects(), u) {
+		clog.Warn(ctx, redirectNotAllowed, "red", red)
+		return nil, errRedirectNotAllowed
 	}
 
-	return
+	return u, nil
 }
 
 // Paths are used for generating Redirect responses,
@@ -192,6 +291,10 @@ type Paths struct {
 	SetPW         string `json:"set_pw,omitempty"`
 	ResetPW       string `json:"reset_pw,omitempty"`
 	Login         string `json:"login,omitempty"`
+	Passkey       string `json:"passkey,omitempty"`
+	LoginLink     string `json:"login_link,omitempty"`
+	// Federated login path. Its callback is served under "/callback" of this path.
+	Federated string `json:"federated,omitempty"`
 	// RedirectKey for redirect URL in request Query.
 	// Upon successfull authentication, the client is redirected to the URL under this key.
 	// Login request: https://example.com/login?redirect=https://secured.com/admin?key=value
@@ -200,16 +303,35 @@ type Paths struct {
 	// TokenKey is under which key the JSON web token will be embedded in the URL query,
 	// when executing the redirect.
 	TokenKey string `json:"token_key,omitempty"`
+	// CodeKey is under which key the authorization code will be embedded in the URL query,
+	// when executing the redirect in hardened session mode.
+	CodeKey string `json:"code_key,omitempty"`
+	// AllowedRedirects restricts the URLs accepted under RedirectKey.
+	// Each entry is an origin, optionally followed by a path prefix.
+	// For example "https://secured.com/admin" allows "https://secured.com/admin/users",
+	// but not "https://secured.com/other" or "https://evil.com/admin".
+	// When empty, any absolute http or https URL is accepted,
+	// which is only advised during development.
+	AllowedRedirects []string `json:"allowed_redirects,omitempty"`
 }
 
 // Defaults when Forms.Paths is nil, or field is empty.
 const (
-	DefaultServerAddress = "http://localhost:1234"
-	DefaultSetPWPath     = "/set-password"
-	DefaultResetPWPath   = "/reset-password"
-	DefaultLoginPath     = "/login"
-	DefaultRedirectKey   = "redirect"
-	DefaultTokenKey      = "jwt"
+	DefaultServerAddress       = "http://localhost:1234"
+	DefaultSetPWPath           = "/set-password"
+	DefaultResetPWPath         = "/reset-password"
+	DefaultLoginPath           = "/login"
+	DefaultPasskeyPath         = "/passkey"
+	DefaultPasskeyRegisterPath = "/passkey-register"
+	DefaultLoginLinkPath       = "/login-link"
+	DefaultAuthorizePath       = "/authorize"
+	DefaultTokenPath           = "/token"
+	DefaultUserInfoPath        = "/userinfo"
+	DefaultFederatedPath       = "/federated"
+	DefaultFederatedCallback   = DefaultFederatedPath + "/callback"
+	DefaultRedirectKey         = "redirect"
+	DefaultTokenKey            = "jwt"
+	DefaultCodeKey             = "code"
 )
 
 func (p *Paths) server() string {
@@ -240,6 +362,31 @@ func (p *Paths) login() string {
 	return p.Login
 }
 
+func (p *Paths) passkey() string {
+	if p == nil || p.Passkey == "" {
+		return DefaultPasskeyPath
+	}
+	return p.Passkey
+}
+
+func (p *Paths) loginLink() string {
+	if p == nil || p.LoginLink == "" {
+		return DefaultLoginLinkPath
+	}
+	return p.LoginLink
+}
+
+func (p *Paths) federated() string {
+	if p == nil || p.Federated == "" {
+		return DefaultFederatedPath
+	}
+	return p.Federated
+}
+
+func (p *Paths) federatedCallback() string {
+	return p.federated() + "/callback"
+}
+
 func (p *Paths) redirectKey() string {
 	if p == nil || p.RedirectKey == "" {
 		return DefaultRedirectKey
@@ -254,6 +401,20 @@ func (p *Paths) tokenKey() string {
 	return p.TokenKey
 }
 
+func (p *Paths) codeKey() string {
+	if p == nil || p.CodeKey == "" {
+		return DefaultCodeKey
+	}
+	return p.CodeKey
+}
+
+func (p *Paths) allowedRedirects() []string {
+	if p == nil {
+		return nil
+	}
+	return p.AllowedRedirects
+}
+
 // callbackURL is generated from the incomming request Query and the new desired path.
 func (p *Paths) callbackURL(values url.Values, path string) *auth.CallBackUrl {
 	params := make(map[string]*auth.StringSlice, len(values))
@@ -28,18 +28,22 @@ func Test_navigation(t *testing.T) {
 			"Without query vars",
 			httptest.NewRequest("GET", "/login", nil),
 			Navigation{
-				Login: DefaultLoginPath,
-				Reset: DefaultResetPWPath,
-				Set:   DefaultSetPWPath,
+				Login:     DefaultLoginPath,
+				Reset:     DefaultResetPWPath,
+				Set:       DefaultSetPWPath,
+				Passkey:   DefaultPasskeyPath,
+				LoginLink: DefaultLoginLinkPath,
 			},
 		},
 		{
 			"With query vars",
 			httptest.NewRequest("GET", "/login"+query, nil),
 			Navigation{
-				Login: DefaultLoginPath + query,
-				Reset: DefaultResetPWPath + query,
-				Set:   DefaultSetPWPath + query,
+				Login:     DefaultLoginPath + query,
+				Reset:     DefaultResetPWPath + query,
+				Set:       DefaultSetPWPath + query,
+				Passkey:   DefaultPasskeyPath + query,
+				LoginLink: DefaultLoginLinkPath + query,
 			},
 		},
 	}
@@ -233,19 +237,43 @@ func TestForms_getRedirect(t *testing.T) {
 		name    string
 		r       *http.Request
 		wantU   *url.URL
-		wantErr bool
+		wantErr error
 	}{
 		{
 			"Valid redirect",
 			httptest.NewRequest("GET", "/login?redirect=http://example.com/foo?hello=world", nil),
 			wantU,
-			false,
+			nil,
 		},
 		{
 			"Missing redirect",
 			httptest.NewRequest("GET", "/login", nil),
 			nil,
-			true,
+			errRedirectMissing,
+		},
+		{
+			"Relative redirect",
+			httptest.NewRequest("GET", "/login?redirect=/foo", nil),
+			nil,
+			errRedirectInvalid,
+		},
+		{
+			"Protocol relative redirect",
+			httptest.NewRequest("GET", "/login?redirect=//evil.com/foo", nil),
+			nil,
+			errRedirectInvalid,
+		},
+		{
+			"Javascript redirect",
+			httptest.NewRequest("GET", "/login?redirect=javascript:alert(1)", nil),
+			nil,
+			errRedirectInvalid,
+		},
+		{
+			"User info",
+			httptest.NewRequest("GET", "/login?redirect=http://example.com@evil.com/foo", nil),
+			nil,
+			errRedirectInvalid,
 		},
 	}
 	for _, tt := range tests {
@@ -253,7 +281,7 @@ func TestForms_getRedirect(t *testing.T) {
 			f := &Forms{}
 
 			gotU, err := f.getRedirect(tt.r)
-			if (err != nil) != tt.wantErr {
+			if err != tt.wantErr {
 				t.Errorf("Forms.getRedirect() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
@@ -265,6 +293,35 @@ func TestForms_getRedirect(t *testing.T) {
 	}
 }
 
+func TestForms_getRedirect_allowed(t *testing.T) {
+	f := &Forms{Paths: &Paths{AllowedRedirects: []string{"https://example.com", "http://secured.com/admin/"}}}
+
+	tests := []struct {
+		name    string
+		red     string
+		wantErr error
+	}{
+		{"Origin", "https://example.com/foo?hello=world", nil},
+		{"Upper case host", "https://EXAMPLE.com/foo", nil},
+		{"Path prefix", "http://secured.com/admin/users", nil},
+		{"Exact path", "http://secured.com/admin", nil},
+		{"Other scheme", "http://example.com/foo", errRedirectNotAllowed},
+		{"Other host", "https://evil.com/foo", errRedirectNotAllowed},
+		{"Suffixed host", "https://example.com.evil.com/foo", errRedirectNotAllowed},
+		{"Other path", "http://secured.com/other", errRedirectNotAllowed},
+		{"Partial path", "http://secured.com/administrator", errRedirectNotAllowed},
+		{"Path traversal", "http://secured.com/admin/../other", errRedirectNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/login?"+url.Values{"redirect": {tt.red}}.Encode(), nil)
+			if _, err := f.getRedirect(r); err != tt.wantErr {
+				t.Errorf("Forms.getRedirect() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestPaths_callbackURL(t *testing.T) {
 	type args struct {
 		r    *http.Request
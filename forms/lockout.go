@@ -0,0 +1,54 @@
+package forms
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// forwardedKey is the gRPC metadata key for the client's IP address.
+// The authenticator server uses it to count failed logins per source IP,
+// if this server is configured as trusted proxy.
+const forwardedKey = "x-forwarded-for"
+
+// forwardClientIP adds the IP address of the client of r to the outgoing gRPC metadata.
+func forwardClientIP(ctx context.Context, r *http.Request) context.Context {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return metadata.AppendToOutgoingContext(ctx, forwardedKey, host)
+}
+
+// lockedFlash returns a Flash if err is a lockout by the server,
+// after too many failed attempts.
+// The Retry-After header is set from the retry info in the status details.
+func lockedFlash(w http.ResponseWriter, err error) (*Flash, bool) {
+	s := status.Convert(err)
+	if s.Code() != codes.ResourceExhausted {
+		return nil, false
+	}
+
+	msg := "Too many attempts, try again later"
+	for _, d := range s.Details() {
+		ri, ok := d.(*errdetails.RetryInfo)
+		if !ok {
+			continue
+		}
+		if retry, err := ptypes.Duration(ri.GetRetryDelay()); err == nil && retry > 0 {
+			retry = (retry + time.Second - 1).Truncate(time.Second)
+			w.Header().Set("Retry-After", strconv.Itoa(int(retry.Seconds())))
+			msg = fmt.Sprintf("Too many attempts, try again in %s", retry)
+		}
+	}
+	return &Flash{ErrFlashLvl, msg}, true
+}
@@ -0,0 +1,112 @@
+package forms
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func Test_forwardClientIP(t *testing.T) {
+	tests := []struct {
+		name       string
+		remoteAddr string
+		want       []string
+	}{
+		{
+			"IPv4",
+			"192.0.2.1:1234",
+			[]string{"192.0.2.1"},
+		},
+		{
+			"IPv6",
+			"[2001:db8::1]:1234",
+			[]string{"2001:db8::1"},
+		},
+		{
+			"Without port",
+			"192.0.2.1",
+			[]string{"192.0.2.1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/login", nil)
+			r.RemoteAddr = tt.remoteAddr
+
+			md, _ := metadata.FromOutgoingContext(forwardClientIP(context.Background(), r))
+			if got := md.Get(forwardedKey); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("forwardClientIP() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_lockedFlash(t *testing.T) {
+	withRetry, err := status.New(codes.ResourceExhausted, "foo").WithDetails(
+		&errdetails.RetryInfo{RetryDelay: ptypes.DurationProto(89500 * time.Millisecond)},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		err            error
+		want           *Flash
+		wantOk         bool
+		wantRetryAfter string
+	}{
+		{
+			"Other error",
+			errors.New("foo"),
+			nil,
+			false,
+			"",
+		},
+		{
+			"Unauthenticated",
+			status.Error(codes.Unauthenticated, "foo"),
+			nil,
+			false,
+			"",
+		},
+		{
+			"Without retry info",
+			status.Error(codes.ResourceExhausted, "foo"),
+			&Flash{ErrFlashLvl, "Too many attempts, try again later"},
+			true,
+			"",
+		},
+		{
+			"With retry info",
+			withRetry.Err(),
+			&Flash{ErrFlashLvl, "Too many attempts, try again in 1m30s"},
+			true,
+			"90",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			got, ok := lockedFlash(w, tt.err)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("lockedFlash() got = %v, want %v", got, tt.want)
+			}
+			if ok != tt.wantOk {
+				t.Errorf("lockedFlash() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if got := w.Header().Get("Retry-After"); got != tt.wantRetryAfter {
+				t.Errorf("lockedFlash() Retry-After = %v, want %v", got, tt.wantRetryAfter)
+			}
+		})
+	}
+}
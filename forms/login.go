@@ -27,27 +27,63 @@ const DefaultLoginTmpl = `{{ define "login" -}}
 <body>
 	<h1>{{ .Title }}</h1>
 	<form method="post" action="{{ .SubmitURL }}">
+		{{- if .CSRFToken }}
+		<input type="hidden" name="csrf_token" value="{{ .CSRFToken }}">
+		{{- end }}
 		<input type="email" placeholder="Email" name="email" required>
 		<input type="password" placeholder="Password" name="password" required>
 		<button type="submit">Sign In</button>
 	</form>
+	{{- range .Providers }}
+	<p><a href="{{ .URL }}">Sign in with {{ .Name }}</a></p>
+	{{- end }}
 	{{- if .Flash }}
 	<p>{{ .Flash.Lvl }}: {{ .Flash.Msg }}</p>
 	{{- end }}
 	<p><a href="{{ .Nav.Reset }}">Password reset</a></p>
+	<p><a href="{{ .Nav.LoginLink }}">Sign in by e-mail</a></p>
+</body>
+</html>
+{{- end -}}
+`
+
+// DefaultMFATmpl is a placeholder template for the two-factor authentication step of `Login`
+const DefaultMFATmpl = `{{ define "mfa" -}}
+<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="utf-8">
+	<title>{{ .Title }}</title>
+</head>
+<body>
+	<h1>{{ .Title }}</h1>
+	<form method="post" action="{{ .SubmitURL }}">
+		{{- if .CSRFToken }}
+		<input type="hidden" name="csrf_token" value="{{ .CSRFToken }}">
+		{{- end }}
+		<input type="hidden" name="mfa_token" value="{{ .MFAToken }}">
+		<input type="text" placeholder="Code" name="code" autocomplete="one-time-code" required>
+		<button type="submit">Verify</button>
+	</form>
+	{{- if .Flash }}
+	<p>{{ .Flash.Lvl }}: {{ .Flash.Msg }}</p>
+	{{- end }}
+	<p><a href="{{ .Nav.Login }}">Back to login</a></p>
 </body>
 </html>
 {{- end -}}
 `
 
 const (
-	redirectMissing = "Missing redirect in URL"
-	redirectInvalid = "Invalid redirect URL"
+	redirectMissing    = "Missing redirect in URL"
+	redirectInvalid    = "Invalid redirect URL"
+	redirectNotAllowed = "Redirect URL not allowed"
 )
 
 var (
-	errRedirectMissing = errors.New(redirectMissing)
-	errRedirectInvalid = errors.New(redirectInvalid)
+	errRedirectMissing    = errors.New(redirectMissing)
+	errRedirectInvalid    = errors.New(redirectInvalid)
+	errRedirectNotAllowed = errors.New(redirectNotAllowed)
 )
 
 func (f *Forms) loginGet(w http.ResponseWriter, r *http.Request) {
@@ -63,20 +99,46 @@ func (f *Forms) loginGet(w http.ResponseWriter, r *http.Request) {
 	f.renderForm(w, r, LoginTmpl, LoginTitle, nil)
 }
 
-func (*Forms) loginRedirect(w http.ResponseWriter, r *http.Request, u *url.URL, tkn string) {
+// loginRedirect embeds the jwt in the redirect URL.
+// The refresh token is never embedded, as URLs end up in logs and browser history.
+// When Hardened, the refresh token is exchanged for a one-time authorization code,
+// which is embedded in place of the jwt.
+func (f *Forms) loginRedirect(ctx context.Context, w http.ResponseWriter, r *http.Request, u *url.URL, reply *auth.AuthReply) {
 	q := u.Query()
-	q.Set("jwt", tkn)
+	if f.Hardened {
+		code, err := f.Client.CreateAuthCode(ctx, reply)
+		if err != nil {
+			clog.Error(ctx, "CreateAuthCode gRPC call", "err", err)
+			if err := f.EP.Render(w, &ehtml.Data{Req: r, Code: http.StatusInternalServerError, Msg: "Internal server error"}); err != nil {
+				clog.Error(ctx, "During handling error", "err", err)
+			}
+			return
+		}
+		q.Set(f.Paths.codeKey(), code.GetCode())
+	} else {
+		q.Set("jwt", reply.GetJwt())
+	}
 
 	u.RawQuery = q.Encode()
 
 	http.Redirect(w, r, u.String(), http.StatusSeeOther)
 }
 
+// loginDone completes a successful login with the obtained tokens.
+type loginDone func(ctx context.Context, w http.ResponseWriter, r *http.Request, reply *auth.AuthReply)
+
+// redirectDone returns a loginDone which redirects to u.
+func (f *Forms) redirectDone(u *url.URL) loginDone {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, reply *auth.AuthReply) {
+		f.loginRedirect(ctx, w, r, u, reply)
+	}
+}
+
 func (f *Forms) loginPost(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
 	defer cancel()
 
-	ctx = clog.AddArgs(ctx, "method", "postHandler")
+	ctx = clog.AddArgs(forwardClientIP(ctx, r), "method", "postHandler")
 
 	if err := r.ParseForm(); err != nil {
 		clog.Warn(ctx, "Parseform", "err", err)
@@ -93,6 +155,18 @@ func (f *Forms) loginPost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	f.passwordLogin(ctx, w, r, f.redirectDone(rURL))
+}
+
+// passwordLogin checks the posted credentials and calls done upon success.
+// If the user has two-factor authentication enabled,
+// the "mfa" form is served instead and its POST completes the login.
+func (f *Forms) passwordLogin(ctx context.Context, w http.ResponseWriter, r *http.Request, done loginDone) {
+	if tkn := r.PostForm.Get("mfa_token"); tkn != "" {
+		f.mfaPost(ctx, w, r, done, tkn)
+		return
+	}
+
 	var missing []string
 
 	email, password := r.PostForm.Get("email"), r.PostForm.Get("password")
@@ -115,7 +189,17 @@ func (f *Forms) loginPost(w http.ResponseWriter, r *http.Request) {
 		Password: password,
 	})
 	if err == nil {
-		f.loginRedirect(w, r, rURL, reply.GetJwt())
+		if reply.GetMfaRequired() {
+			f.renderMFAForm(w, r, reply.GetJwt(), nil)
+			return
+		}
+		done(ctx, w, r, reply)
+		return
+	}
+
+	if flash, ok := lockedFlash(w, err); ok {
+		clog.Info(ctx, "AuthenticatePwUser gRPC call", "err", err)
+		f.renderForm(w, r, LoginTmpl, LoginTitle, flash, http.StatusTooManyRequests)
 		return
 	}
 
@@ -136,9 +220,53 @@ func (f *Forms) loginPost(w http.ResponseWriter, r *http.Request) {
 	f.renderForm(w, r, LoginTmpl, LoginTitle, flash, sc)
 }
 
+// renderMFAForm renders the "mfa" form, embedding the token from AuthenticatePwUser.
+func (f *Forms) renderMFAForm(w http.ResponseWriter, r *http.Request, tkn string, flash *Flash, status ...int) {
+	data := f.formData(r, MFATitle, flash)
+	data.MFAToken = tkn
+	f.render(w, r, MFATmpl, data, status...)
+}
+
+// mfaPost completes login with the code from the "mfa" form.
+func (f *Forms) mfaPost(ctx context.Context, w http.ResponseWriter, r *http.Request, done loginDone, tkn string) {
+	code := r.PostForm.Get("code")
+	if code == "" {
+		clog.Warn(ctx, "Missing form data", "missing", "code")
+		f.renderMFAForm(w, r, tkn, &Flash{ErrFlashLvl, "Missing form data: Code"}, http.StatusBadRequest)
+		return
+	}
+
+	reply, err := f.Client.VerifyMFA(ctx, &auth.MFACode{
+		Jwt:  tkn,
+		Code: code,
+	})
+	if err == nil {
+		done(ctx, w, r, reply)
+		return
+	}
+
+	var (
+		flash *Flash
+		sc    int
+	)
+
+	s, ok := status.FromError(err)
+	if !ok || s.Code() != codes.Unauthenticated {
+		clog.Error(ctx, "VerifyMFA gRPC call", "err", err)
+		flash, sc = &Flash{ErrFlashLvl, "Internal server error"}, http.StatusInternalServerError
+	} else {
+		clog.Info(ctx, "VerifyMFA gRPC call", "err", err)
+		flash, sc = &Flash{ErrFlashLvl, "Invalid code"}, http.StatusUnauthorized
+	}
+
+	f.renderMFAForm(w, r, tkn, flash, sc)
+}
+
 // LoginHander returns the handler taking care of login GET and POST requests.
 // GET serves the "login" form template.
 // POST checks the user's credentials over gRPC.
+// If the user has two-factor authentication enabled,
+// the "mfa" form is served and its POST completes the login.
 func (f *Forms) LoginHander() http.Handler {
 	return &loginHandler{f}
 }
@@ -154,6 +282,9 @@ func (h *loginHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	case http.MethodGet:
 		h.loginGet(w, r)
 	case http.MethodPost:
+		if !h.checkCSRF(w, r) {
+			return
+		}
 		h.loginPost(w, r)
 	default:
 		w.Header().Add("Allow", AllowedMethods)
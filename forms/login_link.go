@@ -0,0 +1,229 @@
+package forms
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	auth "github.com/moapis/authenticator"
+	"github.com/moapis/ehtml"
+	clog "github.com/usrpro/clog15"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultLoginLinkTmpl is a placeholder template for requesting a login link
+const DefaultLoginLinkTmpl = `{{ define "login-link" -}}
+<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="utf-8">
+	<title>{{ .Title }}</title>
+</head>
+<body>
+	<h1>{{ .Title }}</h1>
+	<form method="post" action="{{ .SubmitURL }}">
+		{{- if .CSRFToken }}
+		<input type="hidden" name="csrf_token" value="{{ .CSRFToken }}">
+		{{- end }}
+		<input type="email" placeholder="Email" name="email" required>
+		<button type="submit">Send link</button>
+	</form>
+	{{- if .Flash }}
+	<p>{{ .Flash.Lvl }}: {{ .Flash.Msg }}</p>
+	{{- end }}
+	<p><a href="{{ .Nav.Login }}">Sign in with a password</a></p>
+</body>
+</html>
+{{- end -}}
+`
+
+// DefaultLoginLinkConfirmTmpl is a placeholder template for the landing page of a login link
+const DefaultLoginLinkConfirmTmpl = `{{ define "login-link-confirm" -}}
+<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="utf-8">
+	<title>{{ .Title }}</title>
+</head>
+<body>
+	<h1>{{ .Title }}</h1>
+	<form method="post" action="{{ .SubmitURL }}">
+		{{- if .CSRFToken }}
+		<input type="hidden" name="csrf_token" value="{{ .CSRFToken }}">
+		{{- end }}
+		<button type="submit">Sign In</button>
+	</form>
+	{{- if .Flash }}
+	<p>{{ .Flash.Lvl }}: {{ .Flash.Msg }}</p>
+	{{- end }}
+</body>
+</html>
+{{- end -}}
+`
+
+const loginLinkInvalid = "Login link is invalid, expired or already used, please request a new one."
+
+// loginLinkGet serves the "login-link" form, or the "login-link-confirm" form
+// when the URL holds the token from a login link.
+// The token is only used on POST, as mail scanners may follow links.
+func (f *Forms) loginLinkGet(w http.ResponseWriter, r *http.Request) {
+	ctx := clog.AddArgs(r.Context(), "method", "loginLinkGet")
+
+	if _, err := f.getRedirect(r); err != nil {
+		if err := f.EP.Render(w, &ehtml.Data{Req: r, Code: http.StatusBadRequest, Msg: err.Error()}); err != nil {
+			clog.Error(ctx, "During handling error", "err", err)
+		}
+		return
+	}
+
+	if r.URL.Query().Get(f.Paths.tokenKey()) != "" {
+		f.renderForm(w, r, LoginLinkConfirmTmpl, LoginLinkConfirmTitle, nil)
+		return
+	}
+	f.renderForm(w, r, LoginLinkTmpl, LoginLinkTitle, nil)
+}
+
+func (f *Forms) loginLinkPost(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	ctx = clog.AddArgs(forwardClientIP(ctx, r), "method", "loginLinkPost")
+
+	if err := r.ParseForm(); err != nil {
+		clog.Warn(ctx, "Parseform", "err", err)
+		fl := &Flash{ErrFlashLvl, "Malformed form data"}
+		f.renderForm(w, r, LoginLinkTmpl, LoginLinkTitle, fl, http.StatusBadRequest)
+		return
+	}
+
+	rURL, err := f.getRedirect(r)
+	if err != nil {
+		if err := f.EP.Render(w, &ehtml.Data{Req: r, Code: http.StatusBadRequest, Msg: err.Error()}); err != nil {
+			clog.Error(ctx, "During handling error", "err", err)
+		}
+		return
+	}
+
+	done := f.redirectDone(rURL)
+	if tkn := r.PostForm.Get("mfa_token"); tkn != "" {
+		f.mfaPost(ctx, w, r, done, tkn)
+		return
+	}
+	if tkn := r.URL.Query().Get(f.Paths.tokenKey()); tkn != "" {
+		f.loginLinkAuthenticate(ctx, w, r, done, tkn)
+		return
+	}
+	f.loginLinkSend(ctx, w, r)
+}
+
+// loginLinkSend requests a login link for the posted e-mail address.
+func (f *Forms) loginLinkSend(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	email := r.PostForm.Get("email")
+	if email == "" {
+		clog.Warn(ctx, "Missing email in form")
+		fl := &Flash{ErrFlashLvl, "Missing form data: email"}
+		f.renderForm(w, r, LoginLinkTmpl, LoginLinkTitle, fl, http.StatusBadRequest)
+		return
+	}
+
+	_, err := f.Client.SendLoginLink(ctx, &auth.UserEmail{
+		Email: email,
+		Url:   f.Paths.callbackURL(r.URL.Query(), f.Paths.loginLink()),
+	})
+	if err == nil {
+		if err = f.EP.Render(w, &ehtml.Data{Req: r, Code: http.StatusOK, Msg: "Login link sent"}); err != nil {
+			clog.Error(ctx, "EP.Render", "err", err)
+		}
+		return
+	}
+
+	if flash, ok := lockedFlash(w, err); ok {
+		clog.Info(ctx, "SendLoginLink gRPC call", "err", err)
+		f.renderForm(w, r, LoginLinkTmpl, LoginLinkTitle, flash, http.StatusTooManyRequests)
+		return
+	}
+
+	var (
+		flash *Flash
+		sc    int
+	)
+
+	switch status.Code(err) {
+	case codes.NotFound:
+		clog.Info(ctx, "SendLoginLink gRPC call", "err", err)
+		flash, sc = &Flash{ErrFlashLvl, "email not found"}, http.StatusUnauthorized
+	case codes.FailedPrecondition:
+		clog.Info(ctx, "SendLoginLink gRPC call", "err", err)
+		flash, sc = &Flash{ErrFlashLvl, "Please sign in with your password"}, http.StatusForbidden
+	default:
+		clog.Error(ctx, "SendLoginLink gRPC call", "err", err)
+		flash, sc = &Flash{ErrFlashLvl, "Internal server error"}, http.StatusInternalServerError
+	}
+
+	f.renderForm(w, r, LoginLinkTmpl, LoginLinkTitle, flash, sc)
+}
+
+// loginLinkAuthenticate exchanges the token from a login link and calls done upon success.
+// If the user has two-factor authentication enabled,
+// the "mfa" form is served instead and its POST completes the login.
+func (f *Forms) loginLinkAuthenticate(ctx context.Context, w http.ResponseWriter, r *http.Request, done loginDone, tkn string) {
+	reply, err := f.Client.AuthenticateLoginLink(ctx, &auth.AuthReply{Jwt: tkn})
+	if err == nil {
+		if reply.GetMfaRequired() {
+			f.renderMFAForm(w, r, reply.GetJwt(), nil)
+			return
+		}
+		done(ctx, w, r, reply)
+		return
+	}
+
+	data := &ehtml.Data{Req: r, Code: http.StatusInternalServerError, Msg: "Internal server error"}
+	switch status.Code(err) {
+	case codes.Unauthenticated, codes.InvalidArgument:
+		clog.Info(ctx, "AuthenticateLoginLink gRPC call", "err", err)
+		data.Code, data.Msg = http.StatusUnauthorized, loginLinkInvalid
+	case codes.FailedPrecondition:
+		clog.Info(ctx, "AuthenticateLoginLink gRPC call", "err", err)
+		data.Code, data.Msg = http.StatusForbidden, "Please sign in with your password"
+	default:
+		clog.Error(ctx, "AuthenticateLoginLink gRPC call", "err", err)
+	}
+	if err := f.EP.Render(w, data); err != nil {
+		clog.Error(ctx, "During handling error", "err", err)
+	}
+}
+
+// LoginLinkHandler returns the handler for login without password, through a link sent by e-mail.
+// GET serves the "login-link" form template, or the "login-link-confirm" form template
+// when the URL holds the token from a login link.
+// POST of the "login-link" form sends the link over gRPC,
+// which returns to this handler with the current query and the token under Paths.TokenKey.
+// POST of the "login-link-confirm" form exchanges the token over gRPC and redirects
+// like the handler from LoginHander.
+// If the user has two-factor authentication enabled,
+// the "mfa" form is served and its POST completes the login.
+func (f *Forms) LoginLinkHandler() http.Handler {
+	return &loginLinkHandler{f}
+}
+
+type loginLinkHandler struct {
+	*Forms
+}
+
+func (h *loginLinkHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r = r.WithContext(clog.AddArgs(r.Context(), "pkg", "authenticator.forms", "handler", "LoginLink"))
+
+	switch r.Method {
+	case http.MethodGet:
+		h.loginLinkGet(w, r)
+	case http.MethodPost:
+		if !h.checkCSRF(w, r) {
+			return
+		}
+		h.loginLinkPost(w, r)
+	default:
+		w.Header().Add("Allow", AllowedMethods)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,99 @@
+package forms
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	auth "github.com/moapis/authenticator"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// loginLinkClient fakes the server for login links.
+type loginLinkClient struct {
+	auth.AuthenticatorClient
+}
+
+func (loginLinkClient) SendLoginLink(ctx context.Context, in *auth.UserEmail, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if in.GetUrl().GetBaseUrl() != DefaultServerAddress+DefaultLoginLinkPath {
+		return nil, status.Error(codes.InvalidArgument, "Wrong callback URL")
+	}
+	switch in.GetEmail() {
+	case "foo@bar.com":
+		return &empty.Empty{}, nil
+	case "ldap@bar.com":
+		return nil, status.Error(codes.FailedPrecondition, "User does not have a local password")
+	}
+	return nil, status.Error(codes.NotFound, "User not found")
+}
+
+func (loginLinkClient) AuthenticateLoginLink(ctx context.Context, in *auth.AuthReply, opts ...grpc.CallOption) (*auth.AuthReply, error) {
+	switch in.GetJwt() {
+	case "good":
+		return &auth.AuthReply{Jwt: "jwt", RefreshToken: "foobar"}, nil
+	case "mfa":
+		return &auth.AuthReply{Jwt: "mfa", MfaRequired: true}, nil
+	}
+	return nil, status.Error(codes.Unauthenticated, "Token is revoked")
+}
+
+func (loginLinkClient) VerifyMFA(ctx context.Context, in *auth.MFACode, opts ...grpc.CallOption) (*auth.AuthReply, error) {
+	if in.GetJwt() != "mfa" || in.GetCode() != "123456" {
+		return nil, status.Error(codes.Unauthenticated, "Invalid code")
+	}
+	return &auth.AuthReply{Jwt: "jwt", RefreshToken: "foobar"}, nil
+}
+
+func TestForms_LoginLinkHandler(t *testing.T) {
+	const redirect = "redirect=http%3A%2F%2Flocalhost%3A1234%2Fapp"
+
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		body     string
+		wantCode int
+		wantLoc  string
+		wantBody string
+	}{
+		{"Method not allowed", "PUT", "/login-link?" + redirect, "", http.StatusMethodNotAllowed, "", ""},
+		{"Missing redirect", "GET", "/login-link", "", http.StatusBadRequest, "", ""},
+		{"Email form", "GET", "/login-link?" + redirect, "", http.StatusOK, "", `name="email"`},
+		{"Confirm form", "GET", "/login-link?jwt=good&" + redirect, "", http.StatusOK, "", "Sign In"},
+		{"Missing email", "POST", "/login-link?" + redirect, "", http.StatusBadRequest, "", "Missing form data: email"},
+		{"Unknown email", "POST", "/login-link?" + redirect, "email=no@bar.com", http.StatusUnauthorized, "", "email not found"},
+		{"Backend user", "POST", "/login-link?" + redirect, "email=ldap@bar.com", http.StatusForbidden, "", "Please sign in with your password"},
+		{"Sent", "POST", "/login-link?" + redirect, "email=foo@bar.com", http.StatusOK, "", ""},
+		{"Invalid token", "POST", "/login-link?jwt=used&" + redirect, "", http.StatusUnauthorized, "", ""},
+		{"Login", "POST", "/login-link?jwt=good&" + redirect, "", http.StatusSeeOther, "http://localhost:1234/app?jwt=jwt", ""},
+		{"MFA form", "POST", "/login-link?jwt=mfa&" + redirect, "", http.StatusOK, "", `name="mfa_token"`},
+		{"MFA wrong code", "POST", "/login-link?jwt=mfa&" + redirect, "mfa_token=mfa&code=000000", http.StatusUnauthorized, "", ""},
+		{"MFA login", "POST", "/login-link?jwt=mfa&" + redirect, "mfa_token=mfa&code=123456", http.StatusSeeOther, "http://localhost:1234/app?jwt=jwt", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &Forms{Client: loginLinkClient{}}
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+
+			f.LoginLinkHandler().ServeHTTP(w, r)
+
+			resp := w.Result()
+			if resp.StatusCode != tt.wantCode {
+				t.Errorf("Forms.LoginLinkHandler() status = %v, want: %v", resp.StatusCode, tt.wantCode)
+			}
+			if got := resp.Header.Get("Location"); got != tt.wantLoc {
+				t.Errorf("Forms.LoginLinkHandler() Location = %v, want: %v", got, tt.wantLoc)
+			}
+			if got := w.Body.String(); !strings.Contains(got, tt.wantBody) {
+				t.Errorf("Forms.LoginLinkHandler() body = %v, want: %v", got, tt.wantBody)
+			}
+		})
+	}
+}
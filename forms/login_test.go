@@ -13,6 +13,8 @@ import (
 
 	auth "github.com/moapis/authenticator"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const defaultLoginTmplOut = `<!DOCTYPE html>
@@ -29,6 +31,7 @@ const defaultLoginTmplOut = `<!DOCTYPE html>
 		<button type="submit">Sign In</button>
 	</form>
 	<p><a href="/reset-password?redirect=http://example.com/foo?hello=world">Password reset</a></p>
+	<p><a href="/login-link?redirect=http://example.com/foo?hello=world">Sign in by e-mail</a></p>
 </body>
 </html>`
 
@@ -89,7 +92,6 @@ func TestForms_loginGet(t *testing.T) {
 
 func TestForms_loginRedirect(t *testing.T) {
 	f := &Forms{}
-	w := httptest.NewRecorder()
 	r := httptest.NewRequest("POST", "/login?redirect=http://example.com/foo?hello=world", nil)
 	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
@@ -98,17 +100,41 @@ func TestForms_loginRedirect(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	f.loginRedirect(w, r, u, "spanac")
+	tests := []struct {
+		name  string
+		reply *auth.AuthReply
+		want  string
+	}{
+		{
+			"JWT only",
+			&auth.AuthReply{Jwt: "spanac"},
+			"http://example.com/foo?hello=world&jwt=spanac",
+		},
+		{
+			"With refresh token",
+			&auth.AuthReply{Jwt: "spanac", RefreshToken: "foobar"},
+			"http://example.com/foo?hello=world&jwt=spanac",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := url.Parse(u.String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			w := httptest.NewRecorder()
+			f.loginRedirect(context.Background(), w, r, u, tt.reply)
 
-	resp := w.Result()
+			resp := w.Result()
 
-	if resp.StatusCode != http.StatusSeeOther {
-		t.Errorf("Forms.formHandler() status = %v, want: %v", resp.StatusCode, http.StatusSeeOther)
-	}
+			if resp.StatusCode != http.StatusSeeOther {
+				t.Errorf("Forms.formHandler() status = %v, want: %v", resp.StatusCode, http.StatusSeeOther)
+			}
 
-	want := "http://example.com/foo?hello=world&jwt=spanac"
-	if got := resp.Header.Get("Location"); got != want {
-		t.Errorf("Forms.formHandler() Location = %v, want: %v", got, want)
+			if got := resp.Header.Get("Location"); got != tt.want {
+				t.Errorf("Forms.formHandler() Location = %v, want: %v", got, tt.want)
+			}
+		})
 	}
 }
 
@@ -127,6 +153,7 @@ const loginFlashOut = `<!DOCTYPE html>
 	</form>
 	<p>%s</p>
 	<p><a href="/reset-password?redirect=http://example.com/foo?hello=world">Password reset</a></p>
+	<p><a href="/login-link?redirect=http://example.com/foo?hello=world">Sign in by e-mail</a></p>
 </body>
 </html>`
 
@@ -289,3 +316,113 @@ func Test_loginHandler_ServeHTTP(t *testing.T) {
 		t.Errorf("loginHandler.ServeHTTP() Allow = %v, want: %v", got, want)
 	}
 }
+
+const mfaFlashOut = `<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="utf-8">
+	<title>Two-factor authentication</title>
+</head>
+<body>
+	<h1>Two-factor authentication</h1>
+	<form method="post" action="/login?redirect=http://example.com/foo?hello=world">
+		<input type="hidden" name="mfa_token" value="mfa-token">
+		<input type="text" placeholder="Code" name="code" autocomplete="one-time-code" required>
+		<button type="submit">Verify</button>
+	</form>%s
+	<p><a href="/login?redirect=http://example.com/foo?hello=world">Back to login</a></p>
+</body>
+</html>`
+
+// mfaClient fakes the server for a user with two-factor authentication.
+type mfaClient struct {
+	auth.AuthenticatorClient
+}
+
+func (mfaClient) AuthenticatePwUser(ctx context.Context, in *auth.UserPassword, opts ...grpc.CallOption) (*auth.AuthReply, error) {
+	return &auth.AuthReply{Jwt: "mfa-token", MfaRequired: true}, nil
+}
+
+func (mfaClient) VerifyMFA(ctx context.Context, in *auth.MFACode, opts ...grpc.CallOption) (*auth.AuthReply, error) {
+	switch {
+	case in.GetJwt() != "mfa-token":
+		return nil, status.Error(codes.Internal, "wrong token")
+	case in.GetCode() == "123456":
+		return &auth.AuthReply{Jwt: "spanac", RefreshToken: "foobar"}, nil
+	case in.GetCode() == "000000":
+		return nil, status.Error(codes.Unauthenticated, "wrong code")
+	default:
+		return nil, status.Error(codes.Internal, "foo")
+	}
+}
+
+func TestForms_loginPost_mfa(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantCode int
+		wantBody string
+		wantLoc  string
+	}{
+		{
+			"Password step",
+			"email=mfa%40localhost&password=mfa",
+			http.StatusOK,
+			fmt.Sprintf(mfaFlashOut, ""),
+			"",
+		},
+		{
+			"Missing code",
+			"mfa_token=mfa-token",
+			http.StatusBadRequest,
+			fmt.Sprintf(mfaFlashOut, "\n\t<p>error: Missing form data: Code</p>"),
+			"",
+		},
+		{
+			"Wrong code",
+			"mfa_token=mfa-token&code=000000",
+			http.StatusUnauthorized,
+			fmt.Sprintf(mfaFlashOut, "\n\t<p>error: Invalid code</p>"),
+			"",
+		},
+		{
+			"Internal server error",
+			"mfa_token=mfa-token&code=111111",
+			http.StatusInternalServerError,
+			fmt.Sprintf(mfaFlashOut, "\n\t<p>error: Internal server error</p>"),
+			"",
+		},
+		{
+			"Success and redirect",
+			"mfa_token=mfa-token&code=123456",
+			http.StatusSeeOther,
+			"",
+			"http://example.com/foo?hello=world&jwt=spanac",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &Forms{Client: mfaClient{}}
+			r := httptest.NewRequest("POST", "/login?redirect=http://example.com/foo?hello=world", strings.NewReader(tt.body))
+			r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+
+			f.loginPost(w, r)
+
+			resp := w.Result()
+			if resp.StatusCode != tt.wantCode {
+				t.Errorf("Forms.loginPost() status = %v, want: %v", resp.StatusCode, tt.wantCode)
+			}
+			if got := resp.Header.Get("Location"); got != tt.wantLoc {
+				t.Errorf("Forms.loginPost() Location = %v, want: %v", got, tt.wantLoc)
+			}
+			if tt.wantBody == "" {
+				return
+			}
+			body, _ := ioutil.ReadAll(resp.Body)
+			if got := string(body); got != tt.wantBody {
+				t.Errorf("Forms.loginPost() = \n%v\nwant\n%v", got, tt.wantBody)
+			}
+		})
+	}
+}
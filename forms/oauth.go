@@ -0,0 +1,324 @@
+package forms
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	auth "github.com/moapis/authenticator"
+	"github.com/moapis/ehtml"
+	clog "github.com/usrpro/clog15"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// OAuth 2.0 error codes, as defined in RFC 6749.
+const (
+	oauthInvalidRequest          = "invalid_request"
+	oauthInvalidClient           = "invalid_client"
+	oauthInvalidGrant            = "invalid_grant"
+	oauthInvalidScope            = "invalid_scope"
+	oauthUnsupportedGrantType    = "unsupported_grant_type"
+	oauthUnsupportedResponseType = "unsupported_response_type"
+	oauthServerError             = "server_error"
+)
+
+// oauthErrors maps gRPC status codes from the OAuth 2.0 RPCs to OAuth error codes.
+var oauthErrors = map[codes.Code]string{
+	codes.InvalidArgument:    oauthInvalidRequest,
+	codes.Unauthenticated:    oauthInvalidClient,
+	codes.FailedPrecondition: oauthInvalidGrant,
+	codes.PermissionDenied:   oauthInvalidScope,
+	codes.Unimplemented:      oauthUnsupportedGrantType,
+}
+
+func oauthError(err error) string {
+	if e, ok := oauthErrors[status.Code(err)]; ok {
+		return e
+	}
+	return oauthServerError
+}
+
+const clientMissing = "Missing client_id or redirect_uri"
+
+// authorizeRequest returns the OAuth 2.0 authorization request from the URL query.
+func authorizeRequest(values url.Values) *auth.ClientAuthorization {
+	return &auth.ClientAuthorization{
+		ClientId:            values.Get("client_id"),
+		RedirectUri:         values.Get("redirect_uri"),
+		Scopes:              strings.Fields(values.Get("scope")),
+		CodeChallenge:       values.Get("code_challenge"),
+		CodeChallengeMethod: values.Get("code_challenge_method"),
+		Nonce:               values.Get("nonce"),
+	}
+}
+
+// authorizeRedirect redirects the user-agent back to the client,
+// with the state from the authorization request.
+func authorizeRedirect(w http.ResponseWriter, r *http.Request, ca *auth.ClientAuthorization, params url.Values) {
+	u, _ := url.Parse(ca.GetRedirectUri()) // Checked by the server
+	q := u.Query()
+	for k, v := range params {
+		q[k] = v
+	}
+	if state := r.URL.Query().Get("state"); state != "" {
+		q.Set("state", state)
+	}
+	u.RawQuery = q.Encode()
+
+	http.Redirect(w, r, u.String(), http.StatusFound)
+}
+
+// checkAuthorization verifies the authorization request with the server.
+// Errors about the client or redirect URI are rendered as an error page,
+// as the redirect URI cannot be trusted.
+// Other errors are returned to the client by redirect.
+func (f *Forms) checkAuthorization(ctx context.Context, w http.ResponseWriter, r *http.Request) (*auth.ClientAuthorization, bool) {
+	ca := authorizeRequest(r.URL.Query())
+	if ca.GetClientId() == "" || ca.GetRedirectUri() == "" {
+		clog.Warn(ctx, clientMissing)
+		if err := f.EP.Render(w, &ehtml.Data{Req: r, Code: http.StatusBadRequest, Msg: clientMissing}); err != nil {
+			clog.Error(ctx, "During handling error", "err", err)
+		}
+		return nil, false
+	}
+
+	_, err := f.Client.CheckClient(ctx, ca)
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.NotFound:
+		clog.Warn(ctx, "CheckClient gRPC call", "err", err)
+		if err := f.EP.Render(w, &ehtml.Data{Req: r, Code: http.StatusBadRequest, Msg: status.Convert(err).Message()}); err != nil {
+			clog.Error(ctx, "During handling error", "err", err)
+		}
+		return nil, false
+	case codes.InvalidArgument, codes.PermissionDenied:
+		clog.Info(ctx, "CheckClient gRPC call", "err", err)
+		authorizeRedirect(w, r, ca, url.Values{
+			"error":             {oauthError(err)},
+			"error_description": {status.Convert(err).Message()},
+		})
+		return nil, false
+	default:
+		clog.Error(ctx, "CheckClient gRPC call", "err", err)
+		if err := f.EP.Render(w, &ehtml.Data{Req: r, Code: http.StatusInternalServerError, Msg: "Internal server error"}); err != nil {
+			clog.Error(ctx, "During handling error", "err", err)
+		}
+		return nil, false
+	}
+
+	if rt := r.URL.Query().Get("response_type"); rt != "code" {
+		clog.Info(ctx, oauthUnsupportedResponseType, "response_type", rt)
+		authorizeRedirect(w, r, ca, url.Values{"error": {oauthUnsupportedResponseType}})
+		return nil, false
+	}
+
+	return ca, true
+}
+
+func (f *Forms) authorizeGet(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	ctx = clog.AddArgs(ctx, "method", "authorizeGet")
+
+	if _, ok := f.checkAuthorization(ctx, w, r); !ok {
+		return
+	}
+
+	f.renderForm(w, r, LoginTmpl, LoginTitle, nil)
+}
+
+func (f *Forms) authorizePost(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	ctx = clog.AddArgs(forwardClientIP(ctx, r), "method", "authorizePost")
+
+	if err := r.ParseForm(); err != nil {
+		clog.Warn(ctx, "Parseform", "err", err)
+		fl := &Flash{ErrFlashLvl, "Malformed form data"}
+		f.renderForm(w, r, LoginTmpl, LoginTitle, fl, http.StatusBadRequest)
+		return
+	}
+
+	ca, ok := f.checkAuthorization(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	f.passwordLogin(ctx, w, r, func(ctx context.Context, w http.ResponseWriter, r *http.Request, reply *auth.AuthReply) {
+		ca.RefreshToken = reply.GetRefreshToken()
+
+		code, err := f.Client.AuthorizeClient(ctx, ca)
+		switch status.Code(err) {
+		case codes.OK:
+		case codes.InvalidArgument, codes.PermissionDenied:
+			clog.Info(ctx, "AuthorizeClient gRPC call", "err", err)
+			authorizeRedirect(w, r, ca, url.Values{"error": {oauthError(err)}})
+			return
+		default:
+			clog.Error(ctx, "AuthorizeClient gRPC call", "err", err)
+			authorizeRedirect(w, r, ca, url.Values{"error": {oauthServerError}})
+			return
+		}
+		authorizeRedirect(w, r, ca, url.Values{"code": {code.GetCode()}})
+	})
+}
+
+// AuthorizeHandler returns the handler for the OAuth 2.0 authorization endpoint.
+// Only the authorization code response type is supported.
+// GET verifies the client and serves the "login" form template.
+// POST checks the user's credentials and redirects back to the client
+// with an authorization code, to be exchanged at the TokenHandler.
+func (f *Forms) AuthorizeHandler() http.Handler {
+	return &authorizeHandler{f}
+}
+
+type authorizeHandler struct {
+	*Forms
+}
+
+func (h *authorizeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r = r.WithContext(clog.AddArgs(r.Context(), "pkg", "authenticator.forms", "handler", "Authorize"))
+
+	switch r.Method {
+	case http.MethodGet:
+		h.authorizeGet(w, r)
+	case http.MethodPost:
+		if !h.checkCSRF(w, r) {
+			return
+		}
+		h.authorizePost(w, r)
+	default:
+		w.Header().Add("Allow", AllowedMethods)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// TokenResponse is the successful response of the token endpoint.
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+	IDToken      string `json:"id_token,omitempty"`
+}
+
+// TokenError is the error response of the token endpoint.
+type TokenError struct {
+	Error       string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+func writeJSON(ctx context.Context, w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	w.WriteHeader(code)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		clog.Warn(ctx, "Write to client", "err", err)
+	}
+}
+
+// tokenRequest returns the token request from the posted form.
+// Client credentials are taken from HTTP Basic authentication,
+// or the form when absent.
+func tokenRequest(r *http.Request) *auth.TokenRequest {
+	tr := &auth.TokenRequest{
+		GrantType:    r.PostForm.Get("grant_type"),
+		ClientId:     r.PostForm.Get("client_id"),
+		ClientSecret: r.PostForm.Get("client_secret"),
+		Code:         r.PostForm.Get("code"),
+		RedirectUri:  r.PostForm.Get("redirect_uri"),
+		CodeVerifier: r.PostForm.Get("code_verifier"),
+		RefreshToken: r.PostForm.Get("refresh_token"),
+		Scopes:       strings.Fields(r.PostForm.Get("scope")),
+	}
+
+	if id, secret, ok := r.BasicAuth(); ok {
+		// RFC 6749, section 2.3.1: form-urlencoded before Basic encoding.
+		if v, err := url.QueryUnescape(id); err == nil {
+			id = v
+		}
+		if v, err := url.QueryUnescape(secret); err == nil {
+			secret = v
+		}
+		tr.ClientId, tr.ClientSecret = id, secret
+	}
+	return tr
+}
+
+func (f *Forms) tokenPost(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	ctx = clog.AddArgs(forwardClientIP(ctx, r), "method", "tokenPost")
+
+	if err := r.ParseForm(); err != nil {
+		clog.Warn(ctx, "Parseform", "err", err)
+		writeJSON(ctx, w, http.StatusBadRequest, &TokenError{Error: oauthInvalidRequest, Description: "Malformed form data"})
+		return
+	}
+
+	tr := tokenRequest(r)
+	reply, err := f.Client.Token(ctx, tr)
+	if err != nil {
+		te := &TokenError{Error: oauthError(err), Description: status.Convert(err).Message()}
+
+		switch te.Error {
+		case oauthServerError:
+			clog.Error(ctx, "Token gRPC call", "err", err)
+			te.Description = "Internal server error"
+			writeJSON(ctx, w, http.StatusInternalServerError, te)
+		case oauthInvalidClient:
+			clog.Info(ctx, "Token gRPC call", "err", err)
+			w.Header().Set("WWW-Authenticate", `Basic realm="token"`)
+			writeJSON(ctx, w, http.StatusUnauthorized, te)
+		default:
+			clog.Info(ctx, "Token gRPC call", "err", err)
+			writeJSON(ctx, w, http.StatusBadRequest, te)
+		}
+		return
+	}
+
+	resp := &TokenResponse{
+		AccessToken:  reply.GetJwt(),
+		TokenType:    "Bearer",
+		RefreshToken: reply.GetRefreshToken(),
+		Scope:        strings.Join(reply.GetScopes(), " "),
+		IDToken:      reply.GetIdToken(),
+	}
+	if exp := reply.GetExpires(); exp > 0 {
+		resp.ExpiresIn = exp - time.Now().Unix()
+	}
+	writeJSON(ctx, w, http.StatusOK, resp)
+}
+
+// TokenHandler returns the handler for the OAuth 2.0 token endpoint.
+// It accepts POST requests only, with client authentication by HTTP Basic
+// or client_id and client_secret form fields.
+// Responses are JSON encoded, as defined in RFC 6749.
+func (f *Forms) TokenHandler() http.Handler {
+	return &tokenHandler{f}
+}
+
+type tokenHandler struct {
+	*Forms
+}
+
+func (h *tokenHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r = r.WithContext(clog.AddArgs(r.Context(), "pkg", "authenticator.forms", "handler", "Token"))
+
+	if r.Method != http.MethodPost {
+		w.Header().Add("Allow", http.MethodPost)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	h.tokenPost(w, r)
+}
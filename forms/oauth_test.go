@@ -0,0 +1,178 @@
+package forms
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	auth "github.com/moapis/authenticator"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// oauthClient fakes the server for an OAuth 2.0 client "spa",
+// with redirect URI "http://spa.com/cb" and scope "read".
+type oauthClient struct {
+	auth.AuthenticatorClient
+}
+
+func (oauthClient) CheckClient(ctx context.Context, in *auth.ClientAuthorization, opts ...grpc.CallOption) (*empty.Empty, error) {
+	switch {
+	case in.GetClientId() != "spa" || in.GetRedirectUri() != "http://spa.com/cb":
+		return nil, status.Error(codes.NotFound, "Unknown client")
+	case in.GetCodeChallenge() == "":
+		return nil, status.Error(codes.InvalidArgument, "Missing PKCE")
+	}
+	for _, s := range in.GetScopes() {
+		if s != "read" {
+			return nil, status.Error(codes.PermissionDenied, "Scope not allowed")
+		}
+	}
+	return &empty.Empty{}, nil
+}
+
+func (oauthClient) AuthenticatePwUser(ctx context.Context, in *auth.UserPassword, opts ...grpc.CallOption) (*auth.AuthReply, error) {
+	if in.GetPassword() != "pass" {
+		return nil, status.Error(codes.Unauthenticated, "wrong password")
+	}
+	return &auth.AuthReply{Jwt: "jwt", RefreshToken: "foobar"}, nil
+}
+
+func (oauthClient) AuthorizeClient(ctx context.Context, in *auth.ClientAuthorization, opts ...grpc.CallOption) (*auth.AuthCode, error) {
+	if in.GetRefreshToken() != "foobar" {
+		return nil, status.Error(codes.Unauthenticated, "wrong refresh token")
+	}
+	return &auth.AuthCode{Code: "spanac"}, nil
+}
+
+func (oauthClient) Token(ctx context.Context, in *auth.TokenRequest, opts ...grpc.CallOption) (*auth.AuthReply, error) {
+	switch {
+	case in.GetGrantType() != "authorization_code":
+		return nil, status.Error(codes.Unimplemented, "Unsupported grant type")
+	case in.GetClientId() != "spa" || in.GetClientSecret() != "s3cr=t":
+		return nil, status.Error(codes.Unauthenticated, "Client authentication failed")
+	case in.GetCode() != "spanac":
+		return nil, status.Error(codes.FailedPrecondition, "Invalid authorization code")
+	}
+	return &auth.AuthReply{Jwt: "jwt", RefreshToken: "refresh", Scopes: []string{"read"}, IdToken: "id"}, nil
+}
+
+const testAuthorizeQuery = "/authorize?response_type=code&client_id=spa&redirect_uri=http%3A%2F%2Fspa.com%2Fcb&scope=read&state=xyz&code_challenge=abc&code_challenge_method=S256"
+
+func TestForms_AuthorizeHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		body     string
+		wantCode int
+		wantLoc  string
+	}{
+		{"Method not allowed", "PUT", testAuthorizeQuery, "", http.StatusMethodNotAllowed, ""},
+		{"Missing client", "GET", "/authorize?response_type=code", "", http.StatusBadRequest, ""},
+		{"Unknown client", "GET", "/authorize?client_id=foo&redirect_uri=http%3A%2F%2Fevil.com", "", http.StatusBadRequest, ""},
+		{
+			"Missing PKCE", "GET",
+			"/authorize?response_type=code&client_id=spa&redirect_uri=http%3A%2F%2Fspa.com%2Fcb&state=xyz", "",
+			http.StatusFound, "http://spa.com/cb?error=invalid_request&error_description=Missing+PKCE&state=xyz",
+		},
+		{
+			"Invalid scope", "GET",
+			"/authorize?response_type=code&client_id=spa&redirect_uri=http%3A%2F%2Fspa.com%2Fcb&scope=admin&code_challenge=abc", "",
+			http.StatusFound, "http://spa.com/cb?error=invalid_scope&error_description=Scope+not+allowed",
+		},
+		{
+			"Response type", "GET",
+			"/authorize?response_type=token&client_id=spa&redirect_uri=http%3A%2F%2Fspa.com%2Fcb&code_challenge=abc", "",
+			http.StatusFound, "http://spa.com/cb?error=unsupported_response_type",
+		},
+		{"Login form", "GET", testAuthorizeQuery, "", http.StatusOK, ""},
+		{"Wrong password", "POST", testAuthorizeQuery, "email=user%40localhost&password=foo", http.StatusUnauthorized, ""},
+		{"Authorized", "POST", testAuthorizeQuery, "email=user%40localhost&password=pass", http.StatusFound, "http://spa.com/cb?code=spanac&state=xyz"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &Forms{Client: oauthClient{}}
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+
+			f.AuthorizeHandler().ServeHTTP(w, r)
+
+			resp := w.Result()
+			if resp.StatusCode != tt.wantCode {
+				t.Errorf("Forms.AuthorizeHandler() status = %v, want: %v", resp.StatusCode, tt.wantCode)
+			}
+			if got := resp.Header.Get("Location"); got != tt.wantLoc {
+				t.Errorf("Forms.AuthorizeHandler() Location = %v, want: %v", got, tt.wantLoc)
+			}
+		})
+	}
+}
+
+func TestForms_TokenHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		body      string
+		basic     bool
+		wantCode  int
+		wantError string
+	}{
+		{"Method not allowed", "GET", "", false, http.StatusMethodNotAllowed, ""},
+		{"Unsupported grant", "POST", "grant_type=password", false, http.StatusBadRequest, oauthUnsupportedGrantType},
+		{"Client form", "POST", "grant_type=authorization_code&client_id=spa&client_secret=s3cr%3Dt&code=spanac", false, http.StatusOK, ""},
+		{"Client basic", "POST", "grant_type=authorization_code&code=spanac", true, http.StatusOK, ""},
+		{"Client missing", "POST", "grant_type=authorization_code&code=spanac", false, http.StatusUnauthorized, oauthInvalidClient},
+		{"Invalid grant", "POST", "grant_type=authorization_code&code=foo", true, http.StatusBadRequest, oauthInvalidGrant},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &Forms{Client: oauthClient{}}
+			r := httptest.NewRequest(tt.method, "/token", strings.NewReader(tt.body))
+			r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+			if tt.basic {
+				r.SetBasicAuth("spa", "s3cr%3Dt")
+			}
+			w := httptest.NewRecorder()
+
+			f.TokenHandler().ServeHTTP(w, r)
+
+			resp := w.Result()
+			if resp.StatusCode != tt.wantCode {
+				t.Fatalf("Forms.TokenHandler() status = %v, want: %v", resp.StatusCode, tt.wantCode)
+			}
+			if tt.method != http.MethodPost {
+				return
+			}
+			if got := resp.Header.Get("Cache-Control"); got != "no-store" {
+				t.Errorf("Forms.TokenHandler() Cache-Control = %v, want: no-store", got)
+			}
+
+			if tt.wantError != "" {
+				var te TokenError
+				if err := json.NewDecoder(resp.Body).Decode(&te); err != nil {
+					t.Fatal(err)
+				}
+				if te.Error != tt.wantError {
+					t.Errorf("Forms.TokenHandler() error = %v, want: %v", te.Error, tt.wantError)
+				}
+				return
+			}
+
+			var got TokenResponse
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			want := TokenResponse{AccessToken: "jwt", TokenType: "Bearer", RefreshToken: "refresh", Scope: "read", IDToken: "id"}
+			if got != want {
+				t.Errorf("Forms.TokenHandler() = %v, want: %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,471 @@
+package forms
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	auth "github.com/moapis/authenticator"
+	"github.com/moapis/ehtml"
+	clog "github.com/usrpro/clog15"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// passkeyScript holds the base64 helpers shared by the passkey templates.
+const passkeyScript = `const dec = s => Uint8Array.from(atob(s), c => c.charCodeAt(0));
+	const enc = b => btoa(String.fromCharCode(...new Uint8Array(b)));
+	const form = document.getElementById("passkey");
+	const fail = err => { document.getElementById("passkey-error").textContent = err.message; };`
+
+// DefaultPasskeyTmpl is a placeholder template for `PasskeyLogin`.
+// The script calls navigator.credentials.get() with the options in .WebAuthn
+// and posts the response.
+const DefaultPasskeyTmpl = `{{ define "passkey" -}}
+<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="utf-8">
+	<title>{{ .Title }}</title>
+</head>
+<body>
+	<h1>{{ .Title }}</h1>
+	<form id="passkey" method="post" action="{{ .SubmitURL }}">
+		{{- if .CSRFToken }}
+		<input type="hidden" name="csrf_token" value="{{ .CSRFToken }}">
+		{{- end }}
+		<input type="hidden" name="credential_id">
+		<input type="hidden" name="client_data">
+		<input type="hidden" name="authenticator_data">
+		<input type="hidden" name="signature">
+		<input type="hidden" name="user_handle">
+		<button type="submit">Sign in with a passkey</button>
+	</form>
+	<p id="passkey-error"></p>
+	{{- if .Flash }}
+	<p>{{ .Flash.Lvl }}: {{ .Flash.Msg }}</p>
+	{{- end }}
+	<p><a href="{{ .Nav.Login }}">Sign in with a password</a></p>
+	{{- if .WebAuthn }}
+	<script>
+	const options = {{ .WebAuthn }};
+	` + passkeyScript + `
+	form.addEventListener("submit", e => {
+		e.preventDefault();
+		navigator.credentials.get({publicKey: {
+			challenge: dec(options.challenge),
+			rpId: options.rpId,
+			timeout: options.timeout,
+			userVerification: "preferred",
+			allowCredentials: (options.credentialIds || []).map(id => ({type: "public-key", id: dec(id)})),
+		}}).then(cred => {
+			form.elements.credential_id.value = enc(cred.rawId);
+			form.elements.client_data.value = enc(cred.response.clientDataJSON);
+			form.elements.authenticator_data.value = enc(cred.response.authenticatorData);
+			form.elements.signature.value = enc(cred.response.signature);
+			form.elements.user_handle.value = cred.response.userHandle ? enc(cred.response.userHandle) : "";
+			form.submit();
+		}, fail);
+	});
+	</script>
+	{{- end }}
+</body>
+</html>
+{{- end -}}
+`
+
+// DefaultPasskeyRegisterTmpl is a placeholder template for `PasskeyRegister`.
+// The script calls navigator.credentials.create() with the options in .WebAuthn
+// and posts the response.
+const DefaultPasskeyRegisterTmpl = `{{ define "passkey-register" -}}
+<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="utf-8">
+	<title>{{ .Title }}</title>
+</head>
+<body>
+	<h1>{{ .Title }}</h1>
+	<form id="passkey" method="post" action="{{ .SubmitURL }}">
+		{{- if .CSRFToken }}
+		<input type="hidden" name="csrf_token" value="{{ .CSRFToken }}">
+		{{- end }}
+		<input type="hidden" name="client_data">
+		<input type="hidden" name="attestation_object">
+		<button type="submit">Create passkey</button>
+	</form>
+	<p id="passkey-error"></p>
+	{{- if .Flash }}
+	<p>{{ .Flash.Lvl }}: {{ .Flash.Msg }}</p>
+	{{- end }}
+	{{- if .WebAuthn }}
+	<script>
+	const options = {{ .WebAuthn }};
+	` + passkeyScript + `
+	form.addEventListener("submit", e => {
+		e.preventDefault();
+		navigator.credentials.create({publicKey: {
+			challenge: dec(options.challenge),
+			rp: {id: options.rpId, name: options.rpName},
+			user: {id: dec(options.userId), name: options.userName, displayName: options.userDisplayName},
+			pubKeyCredParams: [-7, -8, -257].map(alg => ({type: "public-key", alg: alg})),
+			excludeCredentials: (options.credentialIds || []).map(id => ({type: "public-key", id: dec(id)})),
+			authenticatorSelection: {residentKey: "preferred", userVerification: "preferred"},
+			timeout: options.timeout,
+		}}).then(cred => {
+			form.elements.client_data.value = enc(cred.response.clientDataJSON);
+			form.elements.attestation_object.value = enc(cred.response.attestationObject);
+			form.submit();
+		}, fail);
+	});
+	</script>
+	{{- end }}
+</body>
+</html>
+{{- end -}}
+`
+
+// WebAuthnOptions are passed to the "passkey" and "passkey-register" templates,
+// for building the options of navigator.credentials.get() and navigator.credentials.create().
+// When executed in a script element, it renders as a JSON object,
+// with binary fields in standard base64 encoding.
+type WebAuthnOptions struct {
+	Challenge       []byte   `json:"challenge"`
+	RPID            string   `json:"rpId"`
+	RPName          string   `json:"rpName"`
+	UserID          []byte   `json:"userId,omitempty"`
+	UserName        string   `json:"userName,omitempty"`
+	UserDisplayName string   `json:"userDisplayName,omitempty"`
+	CredentialIDs   [][]byte `json:"credentialIds"`
+	Timeout         int64    `json:"timeout,omitempty"` // Milliseconds
+}
+
+func webauthnOptions(c *auth.WebAuthnChallenge) *WebAuthnOptions {
+	timeout := time.Until(time.Unix(c.GetExpires(), 0))
+	if timeout < 0 {
+		timeout = 0
+	}
+	return &WebAuthnOptions{
+		Challenge:       c.GetChallenge(),
+		RPID:            c.GetRpId(),
+		RPName:          c.GetRpName(),
+		UserID:          c.GetUserId(),
+		UserName:        c.GetUserName(),
+		UserDisplayName: c.GetUserDisplayName(),
+		CredentialIDs:   c.GetCredentialIds(),
+		Timeout:         timeout.Milliseconds(),
+	}
+}
+
+// decodeFields decodes the base64 encoded form fields.
+// The names of empty fields are returned in missing.
+func decodeFields(form url.Values, names ...string) (values [][]byte, missing []string, err error) {
+	values = make([][]byte, len(names))
+	for i, name := range names {
+		v := form.Get(name)
+		if v == "" {
+			missing = append(missing, name)
+			continue
+		}
+		if values[i], err = base64.StdEncoding.DecodeString(v); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return values, missing, nil
+}
+
+// renderPasskeyForm renders tn with a new challenge from begin.
+// If the challenge can't be obtained, the form is rendered without it.
+func (f *Forms) renderPasskeyForm(ctx context.Context, w http.ResponseWriter, r *http.Request, tn TemplateName, title string, begin func(context.Context) (*auth.WebAuthnChallenge, error), flash *Flash, sc int) {
+	data := f.formData(r, title, flash)
+
+	c, err := begin(ctx)
+	if err != nil {
+		clog.Error(ctx, "WebAuthn challenge gRPC call", "err", err)
+		if flash == nil {
+			data.Flash = &Flash{ErrFlashLvl, "Internal server error"}
+			sc = http.StatusInternalServerError
+		}
+	} else {
+		data.WebAuthn = webauthnOptions(c)
+	}
+
+	f.render(w, r, tn, data, sc)
+}
+
+func (f *Forms) beginPasskeyLogin(ctx context.Context) (*auth.WebAuthnChallenge, error) {
+	return f.Client.BeginWebAuthnLogin(ctx, &auth.UserData{})
+}
+
+func (f *Forms) passkeyGet(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	ctx = clog.AddArgs(ctx, "method", "passkeyGet")
+
+	if _, err := f.getRedirect(r); err != nil {
+		if err := f.EP.Render(w, &ehtml.Data{Req: r, Code: http.StatusBadRequest, Msg: err.Error()}); err != nil {
+			clog.Error(ctx, "During handling error", "err", err)
+		}
+		return
+	}
+
+	f.renderPasskeyForm(ctx, w, r, PasskeyTmpl, PasskeyTitle, f.beginPasskeyLogin, nil, http.StatusOK)
+}
+
+func (f *Forms) passkeyPost(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	ctx = clog.AddArgs(ctx, "method", "passkeyPost")
+
+	if err := r.ParseForm(); err != nil {
+		clog.Warn(ctx, "Parseform", "err", err)
+		fl := &Flash{ErrFlashLvl, "Malformed form data"}
+		f.renderPasskeyForm(ctx, w, r, PasskeyTmpl, PasskeyTitle, f.beginPasskeyLogin, fl, http.StatusBadRequest)
+		return
+	}
+
+	rURL, err := f.getRedirect(r)
+	if err != nil {
+		if err := f.EP.Render(w, &ehtml.Data{Req: r, Code: http.StatusBadRequest, Msg: err.Error()}); err != nil {
+			clog.Error(ctx, "During handling error", "err", err)
+		}
+		return
+	}
+
+	if tkn := r.PostForm.Get("mfa_token"); tkn != "" {
+		f.mfaPost(ctx, w, r, f.redirectDone(rURL), tkn)
+		return
+	}
+
+	values, missing, err := decodeFields(r.PostForm, "credential_id", "client_data", "authenticator_data", "signature")
+	var userHandle []byte
+	if err == nil {
+		// Only set for discoverable credentials
+		userHandle, err = base64.StdEncoding.DecodeString(r.PostForm.Get("user_handle"))
+	}
+	if err != nil || len(missing) > 0 {
+		clog.Warn(ctx, "Malformed passkey response", "err", err, "missing", missing)
+		fl := &Flash{ErrFlashLvl, "Malformed passkey response"}
+		f.renderPasskeyForm(ctx, w, r, PasskeyTmpl, PasskeyTitle, f.beginPasskeyLogin, fl, http.StatusBadRequest)
+		return
+	}
+
+	reply, err := f.Client.FinishWebAuthnLogin(ctx, &auth.WebAuthnAssertion{
+		CredentialId:      values[0],
+		ClientDataJson:    values[1],
+		AuthenticatorData: values[2],
+		Signature:         values[3],
+		UserHandle:        userHandle,
+	})
+	if err == nil {
+		if reply.GetMfaRequired() {
+			f.renderMFAForm(w, r, reply.GetJwt(), nil)
+			return
+		}
+		f.loginRedirect(ctx, w, r, rURL, reply)
+		return
+	}
+
+	var (
+		flash *Flash
+		sc    int
+	)
+
+	switch status.Code(err) {
+	case codes.Unauthenticated, codes.InvalidArgument:
+		clog.Info(ctx, "FinishWebAuthnLogin gRPC call", "err", err)
+		flash, sc = &Flash{ErrFlashLvl, "Passkey verification failed"}, http.StatusUnauthorized
+	default:
+		clog.Error(ctx, "FinishWebAuthnLogin gRPC call", "err", err)
+		flash, sc = &Flash{ErrFlashLvl, "Internal server error"}, http.StatusInternalServerError
+	}
+
+	f.renderPasskeyForm(ctx, w, r, PasskeyTmpl, PasskeyTitle, f.beginPasskeyLogin, flash, sc)
+}
+
+// PasskeyLoginHandler returns the handler taking care of passkey login.
+// GET serves the "passkey" form template, with a new challenge.
+// POST verifies the passkey response over gRPC,
+// and redirects like the handler from LoginHander.
+// If the authenticator did not verify the user and it has two-factor authentication enabled,
+// the "mfa" form is served and its POST completes the login.
+func (f *Forms) PasskeyLoginHandler() http.Handler {
+	return &passkeyHandler{f}
+}
+
+type passkeyHandler struct {
+	*Forms
+}
+
+func (h *passkeyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r = r.WithContext(clog.AddArgs(r.Context(), "pkg", "authenticator.forms", "handler", "PasskeyLogin"))
+
+	switch r.Method {
+	case http.MethodGet:
+		h.passkeyGet(w, r)
+	case http.MethodPost:
+		if !h.checkCSRF(w, r) {
+			return
+		}
+		h.passkeyPost(w, r)
+	default:
+		w.Header().Add("Allow", AllowedMethods)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// beginPasskeyRegister returns a function obtaining a registration challenge for tkn.
+func (f *Forms) beginPasskeyRegister(tkn string) func(context.Context) (*auth.WebAuthnChallenge, error) {
+	return func(ctx context.Context) (*auth.WebAuthnChallenge, error) {
+		return f.Client.BeginWebAuthnRegistration(ctx, &auth.AuthReply{Jwt: tkn})
+	}
+}
+
+// passkeyTokenError renders the error page when the token in the URL is missing or rejected.
+func (f *Forms) passkeyTokenError(ctx context.Context, w http.ResponseWriter, r *http.Request, err error) {
+	data := &ehtml.Data{Req: r, Code: http.StatusBadRequest, Msg: "Missing token in URL"}
+	if err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			clog.Info(ctx, "BeginWebAuthnRegistration gRPC call", "err", err)
+			data.Code, data.Msg = http.StatusUnauthorized, "Token verification failed, please login again."
+		} else {
+			clog.Error(ctx, "BeginWebAuthnRegistration gRPC call", "err", err)
+			data.Code, data.Msg = http.StatusInternalServerError, "Internal server error"
+		}
+	}
+	if err := f.EP.Render(w, data); err != nil {
+		clog.Error(ctx, "During handling error", "err", err)
+	}
+}
+
+func (f *Forms) passkeyRegisterGet(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	ctx = clog.AddArgs(ctx, "method", "passkeyRegisterGet")
+
+	tkn := r.URL.Query().Get("jwt")
+	if tkn == "" {
+		f.passkeyTokenError(ctx, w, r, nil)
+		return
+	}
+
+	c, err := f.beginPasskeyRegister(tkn)(ctx)
+	if err != nil {
+		f.passkeyTokenError(ctx, w, r, err)
+		return
+	}
+
+	data := f.formData(r, PasskeyRegisterTitle, nil)
+	data.WebAuthn = webauthnOptions(c)
+	f.render(w, r, PasskeyRegisterTmpl, data)
+}
+
+func (f *Forms) passkeyRegisterDone(w http.ResponseWriter, r *http.Request) {
+	ctx := clog.AddArgs(r.Context(), "method", "passkeyRegisterDone")
+
+	rURL, err := f.getRedirect(r)
+	if err != nil {
+		if err := f.EP.Render(w, &ehtml.Data{Req: r, Code: http.StatusOK,
+			Msg: "Passkey registered succesfully. You can now close this window",
+		}); err != nil {
+			clog.Error(ctx, "During handling error", "err", err)
+		}
+		return
+	}
+
+	http.Redirect(w, r, rURL.String(), http.StatusSeeOther)
+}
+
+func (f *Forms) passkeyRegisterPost(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	ctx = clog.AddArgs(ctx, "method", "passkeyRegisterPost")
+
+	tkn := r.URL.Query().Get("jwt")
+	if tkn == "" {
+		f.passkeyTokenError(ctx, w, r, nil)
+		return
+	}
+	begin := f.beginPasskeyRegister(tkn)
+
+	if err := r.ParseForm(); err != nil {
+		clog.Warn(ctx, "Parseform", "err", err)
+		fl := &Flash{ErrFlashLvl, "Malformed form data"}
+		f.renderPasskeyForm(ctx, w, r, PasskeyRegisterTmpl, PasskeyRegisterTitle, begin, fl, http.StatusBadRequest)
+		return
+	}
+
+	values, missing, err := decodeFields(r.PostForm, "client_data", "attestation_object")
+	if err != nil || len(missing) > 0 {
+		clog.Warn(ctx, "Malformed passkey response", "err", err, "missing", missing)
+		fl := &Flash{ErrFlashLvl, "Malformed passkey response"}
+		f.renderPasskeyForm(ctx, w, r, PasskeyRegisterTmpl, PasskeyRegisterTitle, begin, fl, http.StatusBadRequest)
+		return
+	}
+
+	_, err = f.Client.FinishWebAuthnRegistration(ctx, &auth.WebAuthnAttestation{
+		Jwt:               tkn,
+		ClientDataJson:    values[0],
+		AttestationObject: values[1],
+	})
+	if err == nil {
+		f.passkeyRegisterDone(w, r)
+		return
+	}
+
+	var (
+		flash *Flash
+		sc    int
+	)
+
+	switch status.Code(err) {
+	case codes.Unauthenticated, codes.InvalidArgument:
+		clog.Info(ctx, "FinishWebAuthnRegistration gRPC call", "err", err)
+		flash, sc = &Flash{ErrFlashLvl, "Passkey verification failed"}, http.StatusUnauthorized
+	case codes.AlreadyExists:
+		clog.Info(ctx, "FinishWebAuthnRegistration gRPC call", "err", err)
+		flash, sc = &Flash{ErrFlashLvl, "Passkey already registered"}, http.StatusConflict
+	default:
+		clog.Error(ctx, "FinishWebAuthnRegistration gRPC call", "err", err)
+		flash, sc = &Flash{ErrFlashLvl, "Internal server error"}, http.StatusInternalServerError
+	}
+
+	f.renderPasskeyForm(ctx, w, r, PasskeyRegisterTmpl, PasskeyRegisterTitle, begin, flash, sc)
+}
+
+// PasskeyRegisterHandler returns the handler taking care of passkey registration,
+// for the user identified by the token under the "jwt" key in the URL query.
+// GET serves the "passkey-register" form template, with a new challenge.
+// POST verifies and stores the passkey over gRPC.
+// On success the client is redirected to the URL under the redirect key, if present.
+func (f *Forms) PasskeyRegisterHandler() http.Handler {
+	return &passkeyRegisterHandler{f}
+}
+
+type passkeyRegisterHandler struct {
+	*Forms
+}
+
+func (h *passkeyRegisterHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r = r.WithContext(clog.AddArgs(r.Context(), "pkg", "authenticator.forms", "handler", "PasskeyRegister"))
+
+	switch r.Method {
+	case http.MethodGet:
+		h.passkeyRegisterGet(w, r)
+	case http.MethodPost:
+		if !h.checkCSRF(w, r) {
+			return
+		}
+		h.passkeyRegisterPost(w, r)
+	default:
+		w.Header().Add("Allow", AllowedMethods)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,496 @@
+package forms
+
+import (
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	auth "github.com/moapis/authenticator"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// passkeyClient fakes the server for passkey registration and login.
+// The behaviour depends on the token and the client data.
+type passkeyClient struct {
+	mfaClient
+}
+
+var testChallenge = &auth.WebAuthnChallenge{
+	Challenge:       []byte("challenge"),
+	RpId:            "localhost",
+	RpName:          "Test",
+	UserId:          []byte("1"),
+	UserName:        "user@localhost",
+	UserDisplayName: "user",
+	CredentialIds:   [][]byte{[]byte("cred")},
+}
+
+func passkeyError(data []byte) error {
+	switch string(data) {
+	case "unauthenticated":
+		return status.Error(codes.Unauthenticated, "foo")
+	case "exists":
+		return status.Error(codes.AlreadyExists, "foo")
+	case "internal":
+		return status.Error(codes.Internal, "foo")
+	}
+	return nil
+}
+
+func (passkeyClient) BeginWebAuthnRegistration(ctx context.Context, in *auth.AuthReply, opts ...grpc.CallOption) (*auth.WebAuthnChallenge, error) {
+	switch in.GetJwt() {
+	case "user-token":
+		return testChallenge, nil
+	case "internal":
+		return nil, status.Error(codes.Internal, "foo")
+	default:
+		return nil, status.Error(codes.Unauthenticated, "foo")
+	}
+}
+
+func (passkeyClient) FinishWebAuthnRegistration(ctx context.Context, in *auth.WebAuthnAttestation, opts ...grpc.CallOption) (*auth.WebAuthnCredential, error) {
+	if err := passkeyError(in.GetClientDataJson()); err != nil {
+		return nil, err
+	}
+	return &auth.WebAuthnCredential{CredentialId: []byte("cred")}, nil
+}
+
+func (passkeyClient) BeginWebAuthnLogin(ctx context.Context, in *auth.UserData, opts ...grpc.CallOption) (*auth.WebAuthnChallenge, error) {
+	return &auth.WebAuthnChallenge{Challenge: []byte("challenge"), RpId: "localhost"}, nil
+}
+
+func (passkeyClient) FinishWebAuthnLogin(ctx context.Context, in *auth.WebAuthnAssertion, opts ...grpc.CallOption) (*auth.AuthReply, error) {
+	if err := passkeyError(in.GetClientDataJson()); err != nil {
+		return nil, err
+	}
+	if string(in.GetUserHandle()) == "mfa" {
+		return &auth.AuthReply{Jwt: "mfa-token", MfaRequired: true}, nil
+	}
+	return &auth.AuthReply{Jwt: "spanac", RefreshToken: "foobar"}, nil
+}
+
+// failingClient fails every passkey call
+type failingClient struct {
+	auth.AuthenticatorClient
+}
+
+func (failingClient) BeginWebAuthnLogin(ctx context.Context, in *auth.UserData, opts ...grpc.CallOption) (*auth.WebAuthnChallenge, error) {
+	return nil, status.Error(codes.FailedPrecondition, "WebAuthn disabled")
+}
+
+func Test_webauthnOptions(t *testing.T) {
+	c := proto.Clone(testChallenge).(*auth.WebAuthnChallenge)
+	c.Expires = time.Now().Add(time.Minute).Unix()
+
+	got := webauthnOptions(c)
+	if got.Timeout <= 50000 || got.Timeout > 60000 {
+		t.Errorf("webauthnOptions() timeout = %v, want about %v", got.Timeout, 60000)
+	}
+	got.Timeout = 0
+
+	want := &WebAuthnOptions{
+		Challenge:       []byte("challenge"),
+		RPID:            "localhost",
+		RPName:          "Test",
+		UserID:          []byte("1"),
+		UserName:        "user@localhost",
+		UserDisplayName: "user",
+		CredentialIDs:   [][]byte{[]byte("cred")},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("webauthnOptions() = %v, want %v", got, want)
+	}
+
+	if got = webauthnOptions(testChallenge); got.Timeout != 0 {
+		t.Errorf("webauthnOptions() timeout = %v, want %v", got.Timeout, 0)
+	}
+}
+
+func Test_decodeFields(t *testing.T) {
+	tests := []struct {
+		name        string
+		form        url.Values
+		want        [][]byte
+		wantMissing []string
+		wantErr     bool
+	}{
+		{
+			"Complete",
+			url.Values{"a": {"Zm9v"}, "b": {"YmFy"}},
+			[][]byte{[]byte("foo"), []byte("bar")},
+			nil,
+			false,
+		},
+		{
+			"Missing",
+			url.Values{"a": {"Zm9v"}},
+			[][]byte{[]byte("foo"), nil},
+			[]string{"b"},
+			false,
+		},
+		{
+			"Malformed",
+			url.Values{"a": {"!"}},
+			nil,
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, missing, err := decodeFields(tt.form, "a", "b")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("decodeFields() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decodeFields() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(missing, tt.wantMissing) {
+				t.Errorf("decodeFields() missing = %v, want %v", missing, tt.wantMissing)
+			}
+		})
+	}
+}
+
+const passkeyQuery = "?redirect=http://example.com/foo?hello=world"
+
+// b64 encodes s for posting in a passkey form
+func b64(s string) string {
+	return url.QueryEscape(base64.StdEncoding.EncodeToString([]byte(s)))
+}
+
+func assertionBody(clientData, userHandle string) string {
+	return strings.Join([]string{
+		"credential_id=" + b64("cred"),
+		"client_data=" + b64(clientData),
+		"authenticator_data=" + b64("authData"),
+		"signature=" + b64("sig"),
+		"user_handle=" + b64(userHandle),
+	}, "&")
+}
+
+func TestForms_passkey(t *testing.T) {
+	tests := []struct {
+		name         string
+		client       auth.AuthenticatorClient
+		method       string
+		target       string
+		body         string
+		wantCode     int
+		wantLoc      string
+		wantContains []string
+	}{
+		{
+			"GET without redirect",
+			passkeyClient{},
+			"GET",
+			"/passkey",
+			"",
+			http.StatusBadRequest,
+			"",
+			[]string{"Missing redirect in URL"},
+		},
+		{
+			"GET",
+			passkeyClient{},
+			"GET",
+			"/passkey" + passkeyQuery,
+			"",
+			http.StatusOK,
+			"",
+			[]string{
+				`<form id="passkey" method="post" action="/passkey?redirect=http://example.com/foo?hello=world">`,
+				`const options = {"challenge":"Y2hhbGxlbmdl","rpId":"localhost","rpName":"","credentialIds":null};`,
+				"navigator.credentials.get(",
+			},
+		},
+		{
+			"GET challenge error",
+			failingClient{},
+			"GET",
+			"/passkey" + passkeyQuery,
+			"",
+			http.StatusInternalServerError,
+			"",
+			[]string{"<p>error: Internal server error</p>"},
+		},
+		{
+			"POST without redirect",
+			passkeyClient{},
+			"POST",
+			"/passkey",
+			assertionBody("ok", ""),
+			http.StatusBadRequest,
+			"",
+			[]string{"Missing redirect in URL"},
+		},
+		{
+			"POST missing fields",
+			passkeyClient{},
+			"POST",
+			"/passkey" + passkeyQuery,
+			"credential_id=" + b64("cred"),
+			http.StatusBadRequest,
+			"",
+			[]string{"<p>error: Malformed passkey response</p>", "const options ="},
+		},
+		{
+			"POST malformed user handle",
+			passkeyClient{},
+			"POST",
+			"/passkey" + passkeyQuery,
+			assertionBody("ok", "") + "!",
+			http.StatusBadRequest,
+			"",
+			[]string{"<p>error: Malformed passkey response</p>"},
+		},
+		{
+			"POST verification failed",
+			passkeyClient{},
+			"POST",
+			"/passkey" + passkeyQuery,
+			assertionBody("unauthenticated", ""),
+			http.StatusUnauthorized,
+			"",
+			[]string{"<p>error: Passkey verification failed</p>", "const options ="},
+		},
+		{
+			"POST internal error",
+			passkeyClient{},
+			"POST",
+			"/passkey" + passkeyQuery,
+			assertionBody("internal", ""),
+			http.StatusInternalServerError,
+			"",
+			[]string{"<p>error: Internal server error</p>"},
+		},
+		{
+			"POST MFA required",
+			passkeyClient{},
+			"POST",
+			"/passkey" + passkeyQuery,
+			assertionBody("ok", "mfa"),
+			http.StatusOK,
+			"",
+			[]string{`<input type="hidden" name="mfa_token" value="mfa-token">`},
+		},
+		{
+			"POST MFA code",
+			passkeyClient{},
+			"POST",
+			"/passkey" + passkeyQuery,
+			"mfa_token=mfa-token&code=123456",
+			http.StatusSeeOther,
+			"http://example.com/foo?hello=world&jwt=spanac",
+			nil,
+		},
+		{
+			"POST success",
+			passkeyClient{},
+			"POST",
+			"/passkey" + passkeyQuery,
+			assertionBody("ok", "1"),
+			http.StatusSeeOther,
+			"http://example.com/foo?hello=world&jwt=spanac",
+			nil,
+		},
+		{
+			"Method not allowed",
+			passkeyClient{},
+			"PUT",
+			"/passkey" + passkeyQuery,
+			"",
+			http.StatusMethodNotAllowed,
+			"",
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &Forms{Client: tt.client}
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+
+			f.PasskeyLoginHandler().ServeHTTP(w, r)
+
+			resp := w.Result()
+			if resp.StatusCode != tt.wantCode {
+				t.Errorf("passkeyHandler.ServeHTTP() status = %v, want: %v", resp.StatusCode, tt.wantCode)
+			}
+			if got := resp.Header.Get("Location"); got != tt.wantLoc {
+				t.Errorf("passkeyHandler.ServeHTTP() Location = %v, want: %v", got, tt.wantLoc)
+			}
+			body, _ := ioutil.ReadAll(resp.Body)
+			for _, want := range tt.wantContains {
+				if !strings.Contains(string(body), want) {
+					t.Errorf("passkeyHandler.ServeHTTP() = \n%s\nwant containing\n%s", body, want)
+				}
+			}
+		})
+	}
+}
+
+func TestForms_passkeyRegister(t *testing.T) {
+	attestation := func(clientData string) string {
+		return "client_data=" + b64(clientData) + "&attestation_object=" + b64("attestation")
+	}
+
+	tests := []struct {
+		name         string
+		method       string
+		target       string
+		body         string
+		wantCode     int
+		wantLoc      string
+		wantContains []string
+	}{
+		{
+			"GET without token",
+			"GET",
+			"/passkey-register",
+			"",
+			http.StatusBadRequest,
+			"",
+			[]string{"Missing token in URL"},
+		},
+		{
+			"GET invalid token",
+			"GET",
+			"/passkey-register?jwt=foo",
+			"",
+			http.StatusUnauthorized,
+			"",
+			[]string{"Token verification failed, please login again."},
+		},
+		{
+			"GET internal error",
+			"GET",
+			"/passkey-register?jwt=internal",
+			"",
+			http.StatusInternalServerError,
+			"",
+			[]string{"Internal server error"},
+		},
+		{
+			"GET",
+			"GET",
+			"/passkey-register?jwt=user-token",
+			"",
+			http.StatusOK,
+			"",
+			[]string{
+				`<form id="passkey" method="post" action="/passkey-register?jwt=user-token">`,
+				`const options = {"challenge":"Y2hhbGxlbmdl","rpId":"localhost","rpName":"Test","userId":"MQ==","userName":"user@localhost","userDisplayName":"user","credentialIds":["Y3JlZA=="]};`,
+				"navigator.credentials.create(",
+			},
+		},
+		{
+			"POST without token",
+			"POST",
+			"/passkey-register",
+			attestation("ok"),
+			http.StatusBadRequest,
+			"",
+			[]string{"Missing token in URL"},
+		},
+		{
+			"POST missing fields",
+			"POST",
+			"/passkey-register?jwt=user-token",
+			"client_data=" + b64("ok"),
+			http.StatusBadRequest,
+			"",
+			[]string{"<p>error: Malformed passkey response</p>", "const options ="},
+		},
+		{
+			"POST verification failed",
+			"POST",
+			"/passkey-register?jwt=user-token",
+			attestation("unauthenticated"),
+			http.StatusUnauthorized,
+			"",
+			[]string{"<p>error: Passkey verification failed</p>", "const options ="},
+		},
+		{
+			"POST already registered",
+			"POST",
+			"/passkey-register?jwt=user-token",
+			attestation("exists"),
+			http.StatusConflict,
+			"",
+			[]string{"<p>error: Passkey already registered</p>"},
+		},
+		{
+			"POST internal error",
+			"POST",
+			"/passkey-register?jwt=user-token",
+			attestation("internal"),
+			http.StatusInternalServerError,
+			"",
+			[]string{"<p>error: Internal server error</p>"},
+		},
+		{
+			"POST success",
+			"POST",
+			"/passkey-register?jwt=user-token",
+			attestation("ok"),
+			http.StatusOK,
+			"",
+			[]string{"Passkey registered succesfully. You can now close this window"},
+		},
+		{
+			"POST success with redirect",
+			"POST",
+			"/passkey-register?jwt=user-token&redirect=http://example.com/foo",
+			attestation("ok"),
+			http.StatusSeeOther,
+			"http://example.com/foo",
+			nil,
+		},
+		{
+			"Method not allowed",
+			"PUT",
+			"/passkey-register?jwt=user-token",
+			"",
+			http.StatusMethodNotAllowed,
+			"",
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &Forms{Client: passkeyClient{}}
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+
+			f.PasskeyRegisterHandler().ServeHTTP(w, r)
+
+			resp := w.Result()
+			if resp.StatusCode != tt.wantCode {
+				t.Errorf("passkeyRegisterHandler.ServeHTTP() status = %v, want: %v", resp.StatusCode, tt.wantCode)
+			}
+			if got := resp.Header.Get("Location"); got != tt.wantLoc {
+				t.Errorf("passkeyRegisterHandler.ServeHTTP() Location = %v, want: %v", got, tt.wantLoc)
+			}
+			body, _ := ioutil.ReadAll(resp.Body)
+			for _, want := range tt.wantContains {
+				if !strings.Contains(string(body), want) {
+					t.Errorf("passkeyRegisterHandler.ServeHTTP() = \n%s\nwant containing\n%s", body, want)
+				}
+			}
+		})
+	}
+}
@@ -23,6 +23,9 @@ const DefaultResetPWTmpl = `{{ define "reset" -}}
 <body>
 	<h1>Password reset</h1>
 	<form method="post" action="{{ .SubmitURL }}">
+		{{- if .CSRFToken }}
+		<input type="hidden" name="csrf_token" value="{{ .CSRFToken }}">
+		{{- end }}
 		<input type="text" placeholder="Email" name="email" required>
 		<button type="submit">Submit</button>
 	</form>
@@ -42,7 +45,7 @@ func (f *Forms) resetPWPost(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
 	defer cancel()
 
-	ctx = clog.AddArgs(ctx, "method", "resetPWPost")
+	ctx = clog.AddArgs(forwardClientIP(ctx, r), "method", "resetPWPost")
 
 	if err := r.ParseForm(); err != nil {
 		clog.Warn(ctx, "Parseform", "err", err)
@@ -70,6 +73,12 @@ func (f *Forms) resetPWPost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if flash, ok := lockedFlash(w, err); ok {
+		clog.Info(ctx, "ResetUserPW gRPC call", "err", err)
+		f.renderForm(w, r, ResetPWTmpl, ResetPWTitle, flash, http.StatusTooManyRequests)
+		return
+	}
+
 	var (
 		flash *Flash
 		sc    int
@@ -108,6 +117,9 @@ func (h *resetPWHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	case http.MethodGet:
 		h.resetPWGet(w, r)
 	case http.MethodPost:
+		if !h.checkCSRF(w, r) {
+			return
+		}
 		h.resetPWPost(w, r)
 	default:
 		w.Header().Add("Allow", AllowedMethods)
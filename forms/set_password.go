@@ -9,6 +9,7 @@ import (
 	auth "github.com/moapis/authenticator"
 	"github.com/moapis/ehtml"
 	clog "github.com/usrpro/clog15"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -24,12 +25,22 @@ const DefaultSetPWTmpl = `{{ define "setpw" -}}
 <body>
 	<h1>Set a new password</h1>
 	<form method="post" action="{{ .SubmitURL }}">
+		{{- if .CSRFToken }}
+		<input type="hidden" name="csrf_token" value="{{ .CSRFToken }}">
+		{{- end }}
 		<input type="password" placeholder="Password" name="password" required>
 		<button type="submit">Submit</button>
 	</form>
 	{{- if .Flash }}
 	<p>{{ .Flash.Lvl }}: {{ .Flash.Msg }}</p>
 	{{- end }}
+	{{- if .Violations }}
+	<ul>
+		{{- range .Violations }}
+		<li>{{ . }}</li>
+		{{- end }}
+	</ul>
+	{{- end }}
 </body>
 </html>
 {{- end -}}
@@ -67,6 +78,27 @@ func (f *Forms) setPWRedirect(w http.ResponseWriter, r *http.Request) {
 		http.StatusSeeOther)
 }
 
+// policyViolations returns the descriptions of the field violations,
+// if err is a rejection of the new password by the server's password policy.
+func policyViolations(err error) ([]string, bool) {
+	s := status.Convert(err)
+	if s.Code() != codes.InvalidArgument {
+		return nil, false
+	}
+
+	var violations []string
+	for _, d := range s.Details() {
+		br, ok := d.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+		for _, v := range br.GetFieldViolations() {
+			violations = append(violations, v.GetDescription())
+		}
+	}
+	return violations, len(violations) > 0
+}
+
 func (f *Forms) setPWPost(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
 	defer cancel()
@@ -106,6 +138,14 @@ func (f *Forms) setPWPost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if violations, ok := policyViolations(err); ok {
+		clog.Info(ctx, "ChangeUserPw gRPC call", "err", err)
+		data := f.formData(r, SetPWTitle, &Flash{ErrFlashLvl, "Password does not meet the requirements"})
+		data.Violations = violations
+		f.render(w, r, SetPWTmpl, data, http.StatusBadRequest)
+		return
+	}
+
 	s, ok := status.FromError(err)
 	if !ok || s.Code() != codes.Unauthenticated {
 		clog.Error(ctx, "ChangeUserPw gRPC call", "err", err)
@@ -140,6 +180,9 @@ func (h *setPWHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	case http.MethodGet:
 		h.setPWGet(w, r)
 	case http.MethodPost:
+		if !h.checkCSRF(w, r) {
+			return
+		}
 		h.setPWPost(w, r)
 	default:
 		w.Header().Add("Allow", AllowedMethods)
@@ -6,13 +6,17 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
 	auth "github.com/moapis/authenticator"
 	"github.com/moapis/ehtml"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const defaultSetPWTmplOut = `<!DOCTYPE html>
@@ -267,6 +271,114 @@ func TestForms_setPWPost(t *testing.T) {
 	}
 }
 
+func policyError(t *testing.T, violations ...string) error {
+	br := new(errdetails.BadRequest)
+	for _, v := range violations {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       "new_password",
+			Description: v,
+		})
+	}
+	st, err := status.New(codes.InvalidArgument, "Password does not meet the policy").WithDetails(br)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return st.Err()
+}
+
+func Test_policyViolations(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		want   []string
+		wantOK bool
+	}{
+		{
+			"Nil",
+			nil,
+			nil,
+			false,
+		},
+		{
+			"Other code",
+			status.Error(codes.Unauthenticated, "foo"),
+			nil,
+			false,
+		},
+		{
+			"Without details",
+			status.Error(codes.InvalidArgument, "Missing password"),
+			nil,
+			false,
+		},
+		{
+			"Violations",
+			policyError(t, "Too short", "Too simple"),
+			[]string{"Too short", "Too simple"},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := policyViolations(tt.err)
+			if !reflect.DeepEqual(got, tt.want) || ok != tt.wantOK {
+				t.Errorf("policyViolations() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+// policyClient fakes a server rejecting every new password.
+type policyClient struct {
+	auth.AuthenticatorClient
+	err error
+}
+
+func (c policyClient) ChangeUserPw(ctx context.Context, in *auth.NewUserPassword, opts ...grpc.CallOption) (*auth.ChangePwReply, error) {
+	return nil, c.err
+}
+
+const setPWPolicyOut = `<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="utf-8">
+	<title>Set password</title>
+</head>
+<body>
+	<h1>Set a new password</h1>
+	<form method="post" action="/setpw?jwt=xxxxxxxx">
+		<input type="password" placeholder="Password" name="password" required>
+		<button type="submit">Submit</button>
+	</form>
+	<p>error: Password does not meet the requirements</p>
+	<ul>
+		<li>Must be at least 8 characters long</li>
+		<li>Must not contain your e-mail address or name</li>
+	</ul>
+</body>
+</html>`
+
+func TestForms_setPWPost_policy(t *testing.T) {
+	f := &Forms{Client: policyClient{err: policyError(t,
+		"Must be at least 8 characters long",
+		"Must not contain your e-mail address or name",
+	)}}
+	r := httptest.NewRequest("POST", "/setpw?jwt=xxxxxxxx", strings.NewReader("password=admin"))
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	f.setPWPost(w, r)
+
+	resp := w.Result()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Forms.setPWPost() status = %v, want: %v", resp.StatusCode, http.StatusBadRequest)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	if got := string(body); got != setPWPolicyOut {
+		t.Errorf("Forms.setPWPost() = \n%v\nwant\n%v", got, setPWPolicyOut)
+	}
+}
+
 func TestForms_SetPWHandler(t *testing.T) {
 	f := &Forms{}
 	f.SetPWHandler()
@@ -0,0 +1,116 @@
+package forms
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/moapis/authenticator/verify"
+	clog "github.com/usrpro/clog15"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const bearerPrefix = "Bearer "
+
+// UserInfo is the response of the OpenID Connect userinfo endpoint.
+// Claims are omitted when their scope is not granted to the access token.
+type UserInfo struct {
+	Subject       string `json:"sub"`
+	Name          string `json:"name,omitempty"`
+	Email         string `json:"email,omitempty"`
+	EmailVerified *bool  `json:"email_verified,omitempty"`
+}
+
+// getBearer token from the Authorization header.
+// An empty string is returned if there is no bearer token.
+func getBearer(r *http.Request) string {
+	h := r.Header.Get("Authorization")
+	if len(h) > len(bearerPrefix) && strings.EqualFold(h[:len(bearerPrefix)], bearerPrefix) {
+		return strings.TrimSpace(h[len(bearerPrefix):])
+	}
+	return ""
+}
+
+// bearerError responds with an RFC 6750 error in the WWW-Authenticate header.
+// Without error code, only the authentication scheme is challenged.
+func bearerError(ctx context.Context, w http.ResponseWriter, code int, e, desc string) {
+	if e == "" {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		w.WriteHeader(code)
+		return
+	}
+	w.Header().Set("WWW-Authenticate", fmt.Sprintf("Bearer error=%q, error_description=%q", e, desc))
+	writeJSON(ctx, w, code, &TokenError{Error: e, Description: desc})
+}
+
+func (f *Forms) userInfo(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	ctx = clog.AddArgs(forwardClientIP(ctx, r), "method", "userInfo")
+
+	tkn := getBearer(r)
+	if tkn == "" {
+		clog.Info(ctx, "Missing bearer token")
+		bearerError(ctx, w, http.StatusUnauthorized, "", "")
+		return
+	}
+	ctx = metadata.AppendToOutgoingContext(ctx, verify.AuthorizationKey, bearerPrefix+tkn)
+
+	reply, err := f.Client.UserInfo(ctx, &empty.Empty{})
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.InvalidArgument, codes.Unauthenticated:
+		clog.Info(ctx, "UserInfo gRPC call", "err", err)
+		bearerError(ctx, w, http.StatusUnauthorized, "invalid_token", status.Convert(err).Message())
+		return
+	case codes.PermissionDenied:
+		clog.Info(ctx, "UserInfo gRPC call", "err", err)
+		bearerError(ctx, w, http.StatusForbidden, "insufficient_scope", status.Convert(err).Message())
+		return
+	default:
+		clog.Error(ctx, "UserInfo gRPC call", "err", err)
+		writeJSON(ctx, w, http.StatusInternalServerError, &TokenError{Error: oauthServerError, Description: "Internal server error"})
+		return
+	}
+
+	info := &UserInfo{
+		Subject: reply.GetSub(),
+		Name:    reply.GetName(),
+		Email:   reply.GetEmail(),
+	}
+	if info.Email != "" {
+		verified := reply.GetEmailVerified()
+		info.EmailVerified = &verified
+	}
+	writeJSON(ctx, w, http.StatusOK, info)
+}
+
+// UserInfoHandler returns the handler for the OpenID Connect userinfo endpoint.
+// It accepts GET and POST requests, authenticated by the access token
+// from the TokenHandler in the "Authorization: Bearer" header.
+// The access token needs to be granted the "openid" scope.
+func (f *Forms) UserInfoHandler() http.Handler {
+	return &userInfoHandler{f}
+}
+
+type userInfoHandler struct {
+	*Forms
+}
+
+func (h *userInfoHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r = r.WithContext(clog.AddArgs(r.Context(), "pkg", "authenticator.forms", "handler", "UserInfo"))
+
+	switch r.Method {
+	case http.MethodGet, http.MethodPost:
+		h.userInfo(w, r)
+	default:
+		w.Header().Add("Allow", AllowedMethods)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
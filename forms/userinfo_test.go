@@ -0,0 +1,86 @@
+package forms
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	auth "github.com/moapis/authenticator"
+	"github.com/moapis/authenticator/verify"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// userInfoClient fakes the server for the access token "spanac".
+type userInfoClient struct {
+	auth.AuthenticatorClient
+}
+
+func (userInfoClient) UserInfo(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*auth.UserInfoReply, error) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	switch v := md.Get(verify.AuthorizationKey); {
+	case len(v) != 1:
+		return nil, status.Error(codes.InvalidArgument, "Missing token")
+	case v[0] == "Bearer scopeless":
+		return nil, status.Error(codes.PermissionDenied, "Access token without openid scope")
+	case v[0] != "Bearer spanac":
+		return nil, status.Error(codes.Unauthenticated, "JWT error")
+	}
+	return &auth.UserInfoReply{Sub: "1", Email: "foo@bar.com"}, nil
+}
+
+func TestForms_UserInfoHandler(t *testing.T) {
+	verified := false
+
+	tests := []struct {
+		name          string
+		method        string
+		authorization string
+		wantCode      int
+		wantChallenge string
+		want          *UserInfo
+	}{
+		{"Method not allowed", "PUT", "", http.StatusMethodNotAllowed, "", nil},
+		{"Missing token", "GET", "", http.StatusUnauthorized, "Bearer", nil},
+		{"Invalid token", "GET", "Bearer foo", http.StatusUnauthorized, `Bearer error="invalid_token", error_description="JWT error"`, nil},
+		{"Insufficient scope", "GET", "Bearer scopeless", http.StatusForbidden, `Bearer error="insufficient_scope", error_description="Access token without openid scope"`, nil},
+		{"GET", "GET", "Bearer spanac", http.StatusOK, "", &UserInfo{Subject: "1", Email: "foo@bar.com", EmailVerified: &verified}},
+		{"POST", "POST", "bearer spanac", http.StatusOK, "", &UserInfo{Subject: "1", Email: "foo@bar.com", EmailVerified: &verified}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &Forms{Client: userInfoClient{}}
+			r := httptest.NewRequest(tt.method, "/userinfo", nil)
+			if tt.authorization != "" {
+				r.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+
+			f.UserInfoHandler().ServeHTTP(w, r)
+
+			resp := w.Result()
+			if resp.StatusCode != tt.wantCode {
+				t.Fatalf("Forms.UserInfoHandler() status = %v, want: %v", resp.StatusCode, tt.wantCode)
+			}
+			if got := resp.Header.Get("WWW-Authenticate"); got != tt.wantChallenge {
+				t.Errorf("Forms.UserInfoHandler() WWW-Authenticate = %v, want: %v", got, tt.wantChallenge)
+			}
+			if tt.want == nil {
+				return
+			}
+			got := new(UserInfo)
+			if err := json.NewDecoder(resp.Body).Decode(got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Forms.UserInfoHandler() = %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/moapis/authenticator/verify"
+	"github.com/pascaldekloe/jwt"
+	log "github.com/usrpro/clog15"
+)
+
+const (
+	authorizationHeader   = "Authorization"
+	wwwAuthenticateHeader = "WWW-Authenticate"
+	bearerPrefix          = "bearer "
+)
+
+// Error codes of RFC 6750, section 3.1.
+const (
+	errInvalidToken      = "invalid_token"
+	errInsufficientScope = "insufficient_scope"
+)
+
+const (
+	errMissingBearer = "Missing bearer token"
+	errInvalidBearer = "Invalid bearer token"
+)
+
+// getBearer token from the Authorization header.
+// An empty string is returned if there is no bearer token.
+func getBearer(r *http.Request) string {
+	h := r.Header.Get(authorizationHeader)
+	if len(h) > len(bearerPrefix) && strings.EqualFold(h[:len(bearerPrefix)], bearerPrefix) {
+		return strings.TrimSpace(h[len(bearerPrefix):])
+	}
+	return ""
+}
+
+// tokenErrors are described to the client by their message.
+var tokenErrors = []error{
+	verify.ErrExpired,
+	verify.ErrNotYetValid,
+	verify.ErrTooOld,
+	verify.ErrIssuer,
+	verify.ErrAudience,
+	verify.ErrRevoked,
+	verify.ErrKeyNotFound,
+}
+
+// describe a token verification error, without leaking its details.
+func describe(err error) string {
+	for _, te := range tokenErrors {
+		if errors.Is(err, te) {
+			return te.Error()
+		}
+	}
+	return errInvalidBearer
+}
+
+// apiError is the JSON body of API error responses.
+type apiError struct {
+	Code        string `json:"error,omitempty"`
+	Description string `json:"error_description"`
+}
+
+// apiError responds with status and the "WWW-Authenticate" header of RFC 6750.
+// An empty code is omitted, as for requests without a token.
+func (c *Client) apiError(ctx context.Context, w http.ResponseWriter, status int, code, description string) {
+	log.Info(ctx, "apiError", "status", status, "error", code, "description", description)
+
+	var params []string
+	if c.Realm != "" {
+		params = append(params, fmt.Sprintf("realm=%q", c.Realm))
+	}
+	if code != "" {
+		params = append(params, fmt.Sprintf("error=%q", code), fmt.Sprintf("error_description=%q", description))
+	}
+	challenge := "Bearer"
+	if len(params) > 0 {
+		challenge = fmt.Sprint(challenge, " ", strings.Join(params, ", "))
+	}
+
+	w.Header().Set(wwwAuthenticateHeader, challenge)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(apiError{code, description})
+}
+
+// APIMiddleware checks for a valid token in the "Authorization: Bearer" header,
+// for use with JSON APIs.
+// The claims from the token are added to the request context under "ClaimsKey" and type "Claims"
+//
+// If the token is missing or invalid, "401 Unauthorized" is returned.
+// If the user is not member of the correct group or audience, "403 Forbidden" is returned.
+// Both carry a "WWW-Authenticate" header with the error details, as defined in RFC 6750.
+// In case of a call error to the AuthenticatorClient,
+// internal server error will be transmitted to the client.
+// In all cases "next.ServeHttp()" is not called, halting the middleware call chain.
+func (c *Client) APIMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := log.AddArgs(r.Context(), "module", "authenticator")
+
+		tkn := getBearer(r)
+		if tkn == "" {
+			c.apiError(ctx, w, http.StatusUnauthorized, "", errMissingBearer)
+			return
+		}
+
+		claims, err := c.Verificator.Token(ctx, tkn)
+		if err != nil {
+			var ve *verify.VerificationErr
+			if !errors.As(err, &ve) {
+				log.Error(ctx, intServErr, "err", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(intServErr))
+				return
+			}
+			log.Info(ctx, "token rejected", "reason", err)
+			c.apiError(ctx, w, http.StatusUnauthorized, errInvalidToken, describe(err))
+			return
+		}
+		log.Debug(ctx, "token verified", "claims", claims)
+
+		if err = c.authorize(claims); err != nil {
+			c.apiError(ctx, w, http.StatusForbidden, errInsufficientScope, err.Error())
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), ClaimsKey, Claims{claims})))
+	})
+}
+
+var errAudience = errors.New("Required audience not found")
+
+// authorize checks the Groups and Audiences requirements against the claims.
+func (c *Client) authorize(claims *jwt.Claims) error {
+	if err := c.isGroupMember(claims.Set); err != nil {
+		return err
+	}
+	if len(c.Audiences) > 0 && !verify.HasAnyEntry(c.Audiences, claims.Audiences) {
+		return errAudience
+	}
+	return nil
+}
+
+// Require returns a copy of the Client with Groups and Audiences replaced.
+// It allows for per-route requirements, for example with Gorilla mux:
+//
+//	admin := router.PathPrefix("/admin").Subrouter()
+//	admin.Use(client.Require([]string{"admin"}, nil).APIMiddleware)
+func (c *Client) Require(groups, audiences []string) *Client {
+	rc := *c
+	rc.Groups, rc.Audiences = groups, audiences
+	return &rc
+}
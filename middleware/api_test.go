@@ -0,0 +1,253 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/moapis/authenticator/verify"
+	"github.com/pascaldekloe/jwt"
+)
+
+func Test_getBearer(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{"No header", "", ""},
+		{"Bearer", "Bearer foo", "foo"},
+		{"Lower case", "bearer foo", "foo"},
+		{"Other scheme", "Basic foo", ""},
+		{"Empty token", "Bearer ", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "http://example.com/api", nil)
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+			if got := getBearer(r); got != tt.want {
+				t.Errorf("getBearer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_describe(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"Untyped", &verify.VerificationErr{}, errInvalidBearer},
+		{"Typed", fmt.Errorf("foo: %w", verify.ErrExpired), verify.ErrExpired.Error()},
+		{"Other", fmt.Errorf("foo"), errInvalidBearer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := describe(tt.err); got != tt.want {
+				t.Errorf("describe() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_apiError(t *testing.T) {
+	tests := []struct {
+		name   string
+		realm  string
+		code   string
+		want   string
+		wantBd string
+	}{
+		{
+			"Missing token",
+			"",
+			"",
+			"Bearer",
+			`{"error_description":"foo"}`,
+		},
+		{
+			"Realm",
+			"example",
+			"",
+			`Bearer realm="example"`,
+			`{"error_description":"foo"}`,
+		},
+		{
+			"Error code",
+			"example",
+			errInvalidToken,
+			`Bearer realm="example", error="invalid_token", error_description="foo"`,
+			`{"error":"invalid_token","error_description":"foo"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{Realm: tt.realm}
+			w := httptest.NewRecorder()
+			c.apiError(context.Background(), w, http.StatusUnauthorized, tt.code, "foo")
+
+			res := w.Result()
+			if res.StatusCode != http.StatusUnauthorized {
+				t.Errorf("Client.apiError() status: %d, want: %d", res.StatusCode, http.StatusUnauthorized)
+			}
+			if got := res.Header.Get("WWW-Authenticate"); got != tt.want {
+				t.Errorf("Client.apiError() WWW-Authenticate = %s, want %s", got, tt.want)
+			}
+			body, _ := ioutil.ReadAll(res.Body)
+			if got := strings.TrimSpace(string(body)); got != tt.wantBd {
+				t.Errorf("Client.apiError() body = %s, want %s", got, tt.wantBd)
+			}
+		})
+	}
+}
+
+func TestClient_authorize(t *testing.T) {
+	claims := &jwt.Claims{
+		Registered: jwt.Registered{Audiences: []string{"foo"}},
+		Set:        map[string]interface{}{"groups": []interface{}{"bar"}},
+	}
+	tests := []struct {
+		name    string
+		client  *Client
+		wantErr error
+	}{
+		{"No requirements", &Client{}, nil},
+		{"Group", &Client{Groups: []string{"bar"}}, nil},
+		{"Wrong group", &Client{Groups: []string{"spanac"}}, errGroup},
+		{"Audience", &Client{Audiences: []string{"foo"}}, nil},
+		{"Wrong audience", &Client{Audiences: []string{"spanac"}}, errAudience},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.client.authorize(claims); err != tt.wantErr {
+				t.Errorf("Client.authorize() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_Require(t *testing.T) {
+	c := &Client{Groups: []string{"foo"}, LoginURL: "/foo"}
+	got := c.Require([]string{"bar"}, []string{"baz"})
+
+	want := &Client{Groups: []string{"bar"}, Audiences: []string{"baz"}, LoginURL: "/foo"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Client.Require() = %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(c.Groups, []string{"foo"}) {
+		t.Errorf("Client.Require() modified the Client: %v", c)
+	}
+}
+
+func TestClient_APIMiddleware(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := r.Context().Value(ClaimsKey).(Claims)
+		if !ok {
+			t.Errorf("Claims in context %v type %T", r.Context().Value(ClaimsKey), r.Context().Value(ClaimsKey))
+		}
+		if claims.Subject != testUser {
+			t.Errorf("Claims.subject = %s, want: %s", claims.Subject, testUser)
+		}
+		w.Write([]byte("OK"))
+	})
+
+	ectx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	bearer := func(tkn string) *http.Request {
+		r := httptest.NewRequest("GET", "http://example.com/api", nil)
+		r.Header.Set("Authorization", "Bearer "+tkn)
+		return r
+	}
+
+	tests := []struct {
+		name   string
+		client *Client
+		r      *http.Request
+		status int
+		header string
+	}{
+		{
+			"No token",
+			testClient,
+			httptest.NewRequest("GET", "http://example.com/api", nil),
+			http.StatusUnauthorized,
+			"Bearer",
+		},
+		{
+			"Token in URL is ignored",
+			testClient,
+			httptest.NewRequest("GET", "http://example.com/api?jwt="+validTkn, nil),
+			http.StatusUnauthorized,
+			"Bearer",
+		},
+		{
+			"Invalid token",
+			testClient,
+			bearer("spanac"),
+			http.StatusUnauthorized,
+			`Bearer error="invalid_token", error_description="Invalid bearer token"`,
+		},
+		{
+			"Internal server error",
+			testClient,
+			bearer(validTkn).WithContext(ectx),
+			http.StatusInternalServerError,
+			"",
+		},
+		{
+			"Valid token",
+			testClient,
+			bearer(validTkn),
+			http.StatusOK,
+			"",
+		},
+		{
+			"Group and audience",
+			testClient.Require([]string{"primary"}, []string{"authenticator"}),
+			bearer(validTkn),
+			http.StatusOK,
+			"",
+		},
+		{
+			"Wrong group",
+			testClient.Require([]string{"foobar"}, nil),
+			bearer(validTkn),
+			http.StatusForbidden,
+			`Bearer error="insufficient_scope", error_description="Not member of any required group"`,
+		},
+		{
+			"Wrong audience",
+			testClient.Require(nil, []string{"foobar"}),
+			bearer(validTkn),
+			http.StatusForbidden,
+			`Bearer error="insufficient_scope", error_description="Required audience not found"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tt.client.APIMiddleware(next).ServeHTTP(w, tt.r)
+
+			res := w.Result()
+			if res.StatusCode != tt.status {
+				t.Errorf("Client.APIMiddleware() status: %d, want: %d", res.StatusCode, tt.status)
+			}
+			if got := res.Header.Get("WWW-Authenticate"); got != tt.header {
+				t.Errorf("Client.APIMiddleware() WWW-Authenticate = %s, want %s", got, tt.header)
+			}
+		})
+	}
+}
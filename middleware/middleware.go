@@ -5,6 +5,9 @@
 // Package middleware provides means of verifying JWTs generated by
 // `cmd/admin`'s login handler or similar mechanisms.
 // It is compatible with Gorilla mux middleware.
+//
+// Client.Middleware is meant for browser routes and redirects to a login page.
+// Client.APIMiddleware is meant for JSON APIs and responds with RFC 6750 errors.
 package middleware
 
 import (
@@ -12,12 +15,17 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"path"
+	"strings"
 	"time"
 
 	"github.com/moapis/authenticator"
 	"github.com/moapis/authenticator/verify"
 	"github.com/pascaldekloe/jwt"
 	log "github.com/usrpro/clog15"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -27,14 +35,49 @@ const (
 	DefaultRedirectKey = "redirect"
 )
 
+const (
+	// DefaultCodeKey is the default value for Client.CodeKey
+	DefaultCodeKey = "code"
+)
+
+const (
+	jwtKey     = "jwt"
+	refreshKey = "refresh"
+)
+
+// CookieOptions for the jwt and refresh cookies.
+type CookieOptions struct {
+	// Domain of the cookies.
+	// When empty, cookies are only sent to the host which set them.
+	Domain string
+	// Path of the cookies.
+	// Defaults to "/".
+	Path string
+	// Secure cookies are only sent over HTTPS.
+	Secure bool
+	// HTTPOnly cookies are not accessible from JavaScript.
+	HTTPOnly bool
+	// SameSite restricts sending the cookies with cross-site requests.
+	// Lax mode still sends them when following a link to this server.
+	SameSite http.SameSite
+}
+
+// hardenedCookie is used in hardened session mode,
+// when Client.Cookie is nil.
+var hardenedCookie = CookieOptions{
+	Secure:   true,
+	HTTPOnly: true,
+	SameSite: http.SameSiteLaxMode,
+}
+
 // getJwt from url or cookie.
 // Token in url take precedence.
 func getJwt(r *http.Request) (string, bool, error) {
-	if tkn := r.URL.Query().Get("jwt"); tkn != "" {
+	if tkn := r.URL.Query().Get(jwtKey); tkn != "" {
 		return tkn, true, nil
 	}
 
-	cookie, err := r.Cookie("jwt")
+	cookie, err := r.Cookie(jwtKey)
 	if err != nil {
 		return "", false, fmt.Errorf("getJwt: %w", err)
 	}
@@ -42,6 +85,16 @@ func getJwt(r *http.Request) (string, bool, error) {
 	return cookie.Value, false, nil
 }
 
+// getCookie returns the value of the named cookie.
+// An empty string is returned if there is no such cookie.
+func getCookie(r *http.Request, name string) string {
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
+
 // Client holds a Verificator and AuthenticatorClient
 type Client struct {
 	Verificator *verify.Verificator
@@ -52,6 +105,15 @@ type Client struct {
 	// If Groups is empty, checking is disabled.
 	Groups []string
 
+	// Audiences of which at least 1 needs to be in the token,
+	// in addition to the Verificator Audiences.
+	// If Audiences is empty, checking is disabled.
+	Audiences []string
+
+	// Realm is sent in the "WWW-Authenticate" header by APIMiddleware.
+	// It is omitted when empty.
+	Realm string
+
 	// LoginURL is the path to a login handler.
 	// Defaults to "/login".
 	LoginURL string
@@ -66,7 +128,59 @@ type Client struct {
 	// Scheme, hostname and optionaly port number of this host.
 	// It is used for redirecting back to this server after login.
 	ServerAddress string
+
+	// AllowedRedirects restricts the URLs embedded under RedirectKey.
+	// Each entry is an origin, optionally followed by a path prefix,
+	// as in forms.Paths.AllowedRedirects.
+	// Requests for any other URL are refused with "403 Forbidden",
+	// instead of being redirected to login.
+	// Checking is disabled when empty.
+	AllowedRedirects []string
+
+	// RefreshWithin sets how long before expiry the token is refreshed,
+	// using the refresh token.
 	RefreshWithin time.Duration
+
+	// Deprecated: refresh tokens are no longer accepted in the url,
+	// the refresh token cookie expires with the refresh token.
+	RefreshExpiry time.Duration
+
+	// Cookie sets the options of the jwt and refresh cookies.
+	// The refresh cookie is always HttpOnly.
+	// When nil, cookies only have their path set to "/".
+	// In hardened session mode, a nil Cookie results in
+	// Secure, HttpOnly and SameSite=Lax cookies.
+	Cookie *CookieOptions
+
+	// Hardened enables the hardened session mode.
+	// Tokens in the url are ignored.
+	// Instead, a one-time authorization code in the url, under CodeKey,
+	// is exchanged for the tokens, which are set in cookies.
+	// The client is then redirected to the same url, without the code.
+	// Use together with forms.Forms.Hardened on the login server.
+	Hardened bool
+
+	// CodeKey is under which key the authorization code is expected in the url query.
+	// Defaults to "code".
+	CodeKey string
+}
+
+func (c *Client) codeKey() string {
+	if c.CodeKey == "" {
+		return DefaultCodeKey
+	}
+	return c.CodeKey
+}
+
+func (c *Client) cookieOptions() *CookieOptions {
+	switch {
+	case c.Cookie != nil:
+		return c.Cookie
+	case c.Hardened:
+		return &hardenedCookie
+	default:
+		return &CookieOptions{}
+	}
 }
 
 func (c *Client) loginRedirect(ctx context.Context, w http.ResponseWriter, r *http.Request, err error) {
@@ -80,38 +194,106 @@ func (c *Client) loginRedirect(ctx context.Context, w http.ResponseWriter, r *ht
 		rk = DefaultRedirectKey
 	}
 
-	// Take out any old jwt of url, perserve any other arguments
-	url := r.URL
-	q := url.Query()
-	q.Del("jwt")
+	// Take out any old tokens of url, perserve any other arguments
+	q := r.URL.Query()
+	q.Del(jwtKey)
+	q.Del(refreshKey)
+	q.Del(c.codeKey())
 
 	var qs string
 	if len(q) > 0 {
 		qs = fmt.Sprint("?", q.Encode())
 	}
 
+	red := fmt.Sprint(c.ServerAddress, r.URL.Path)
+	if u, err := url.Parse(red); err != nil || !allowedRedirect(c.AllowedRedirects, u) {
+		log.Warn(ctx, errRedirect, "redirect", red, "err", err)
+		http.Error(w, errRedirect, http.StatusForbidden)
+		return
+	}
+
 	http.Redirect(w, r,
 		// http://serv.com/login?redirect=http://here.com/path?foo=bar
-		fmt.Sprintf("%s?%s=%s%s%s", lu, rk, c.ServerAddress, url.Path, qs),
+		fmt.Sprintf("%s?%s=%s%s", lu, rk, red, qs),
 		http.StatusSeeOther,
 	)
 }
 
-func (c *Client) refreshToken(ctx context.Context, tkn string) (string, error) {
-	reply, err := c.Verificator.Client.RefreshToken(ctx, &authenticator.AuthReply{Jwt: tkn})
+const errRedirect = "Redirect URL not allowed"
+
+// allowedRedirect reports if u matches any of the allowed origins and path prefixes.
+// An entry without path allows the whole origin.
+// An empty allowed list permits any URL.
+func allowedRedirect(allowed []string, u *url.URL) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	p := path.Clean("/" + u.Path)
+
+	for _, a := range allowed {
+		au, err := url.Parse(a)
+		if err != nil || au.Scheme != u.Scheme || !strings.EqualFold(au.Host, u.Host) {
+			continue
+		}
+		prefix := strings.TrimSuffix(au.Path, "/")
+		if prefix == "" || p == prefix || strings.HasPrefix(p, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// refreshToken exchanges the refresh token for a new jwt and refresh token.
+func (c *Client) refreshToken(ctx context.Context, refresh string) (*authenticator.AuthReply, error) {
+	return c.Verificator.Client.RefreshToken(ctx, &authenticator.AuthReply{RefreshToken: refresh})
+}
+
+// exchangeCode exchanges the authorization code for tokens, which are set in new cookies.
+// The client is redirected to the same url, without the code.
+func (c *Client) exchangeCode(ctx context.Context, w http.ResponseWriter, r *http.Request, code string) {
+	reply, err := c.Verificator.Client.ExchangeAuthCode(ctx, &authenticator.AuthCode{Code: code})
 	if err != nil {
-		return "", err
+		switch status.Code(err) {
+		case codes.Unauthenticated, codes.InvalidArgument:
+			c.loginRedirect(ctx, w, r, err)
+		default:
+			log.Error(ctx, intServErr, "err", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(intServErr))
+		}
+		return
 	}
-	return reply.GetJwt(), nil
+	log.Info(ctx, "authorization code exchanged")
+
+	c.newCookie(w, r, jwtKey, reply.GetJwt(), time.Unix(reply.GetExpires(), 0))
+	c.newCookie(w, r, refreshKey, reply.GetRefreshToken(), time.Unix(reply.GetRefreshExpires(), 0))
+
+	u := *r.URL
+	q := u.Query()
+	q.Del(c.codeKey())
+	u.RawQuery = q.Encode()
+
+	http.Redirect(w, r, u.RequestURI(), http.StatusSeeOther)
 }
 
-// newCookie with the jwt token is added to the writer and current request.
-func (c *Client) newCookie(w http.ResponseWriter, r *http.Request, tkn string, expires time.Time) {
+// newCookie with a token under name is added to the writer.
+// A zero expires results in a session cookie.
+func (c *Client) newCookie(w http.ResponseWriter, r *http.Request, name, tkn string, expires time.Time) {
+	opts := c.cookieOptions()
+	path := opts.Path
+	if path == "" {
+		path = "/"
+	}
+
 	cookie := &http.Cookie{
-		Name:    "jwt",
-		Value:   tkn,
-		Path:    "/",
-		Expires: expires,
+		Name:     name,
+		Value:    tkn,
+		Path:     path,
+		Domain:   opts.Domain,
+		Expires:  expires,
+		Secure:   opts.Secure,
+		HttpOnly: opts.HTTPOnly || name == refreshKey,
+		SameSite: opts.SameSite,
 	}
 
 	http.SetCookie(w, cookie)
@@ -136,7 +318,10 @@ func (c *Client) assertVerErr(ctx context.Context, w http.ResponseWriter, r *htt
 	}
 }
 
-var errGroup = errors.New("Not member of any required group")
+var (
+	errGroup         = errors.New("Not member of any required group")
+	errMissingCookie = errors.New("Missing jwt cookie")
+)
 
 func (c *Client) isGroupMember(set map[string]interface{}) error {
 	if len(c.Groups) == 0 {
@@ -171,8 +356,10 @@ type claimsKeyType struct{}
 // ClaimsKey is under which key Claims will be stored in the request Context.
 var ClaimsKey claimsKeyType
 
-// Middleware checks for a valid authentication token, named "jwt", in url or cookie.
+// Middleware checks for a valid authentication token, named "jwt", in url or cookie,
+// for use with browser routes. See APIMiddleware for JSON APIs.
 // A token in the URL is copied to a newly set cookie in the response headers.
+// The refresh token, named "refresh", is only read from its cookie.
 // The claims from the token added to the request context under "ClaimsKey" and type "Claims"
 //
 // If the token is missing, invalid, expired
@@ -182,30 +369,61 @@ var ClaimsKey claimsKeyType
 // internal server error will be transmitted to the client.
 // In both cases "next.ServeHttp()" is not called, halting the middleware call chain.
 //
-// When the token is close to expire, "AuthenticatorClient.RefreshToken()" is called.
-// The resulting new token is set in a new cookie.
-// An error from RefreshToken is only logged, "next.ServeHttp()" will be called regardless.
+// In hardened session mode, tokens are only read from cookies.
+// An authorization code in the url is exchanged for new cookies,
+// after which the client is redirected to the url without the code.
+//
+// When the token is close to expire, missing or invalid,
+// and a refresh token is available, "AuthenticatorClient.RefreshToken()" is called.
+// The resulting new tokens are set in new cookies.
+// Concurrent requests with the same refresh token share a single call,
+// as the server revokes all tokens of a session when a refresh token is reused.
+// An error from RefreshToken is only logged.
+// "next.ServeHttp()" will be called regardless, if the old token is still valid.
 func (c *Client) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := log.AddArgs(r.Context(), "module", "authenticator")
 
-		tkn, newCookie, err := getJwt(r)
-		if err != nil {
-			c.loginRedirect(ctx, w, r, err)
-			return
+		if c.Hardened {
+			if code := r.URL.Query().Get(c.codeKey()); code != "" {
+				c.exchangeCode(ctx, w, r, code)
+				return
+			}
 		}
 
-		claims, err := c.Verificator.Token(ctx, tkn)
-		if c.assertVerErr(ctx, w, r, err) {
-			return
+		var (
+			claims    *jwt.Claims
+			tkn       string
+			newCookie bool
+			err       error
+		)
+		if c.Hardened {
+			if tkn = getCookie(r, jwtKey); tkn == "" {
+				err = errMissingCookie
+			}
+		} else {
+			tkn, newCookie, err = getJwt(r)
+		}
+		if err == nil {
+			claims, err = c.Verificator.Token(ctx, tkn)
+			if err != nil && !errors.As(err, &verErr) {
+				c.assertVerErr(ctx, w, r, err)
+				return
+			}
 		}
-		log.Debug(ctx, "token verified", "claims", claims)
 
-		if claims.Expires.Time().Before(
+		var (
+			refresh        = getCookie(r, refreshKey)
+			newRefresh     bool
+			refreshExpires time.Time
+		)
+		if refresh != "" && (err != nil || claims.Expires.Time().Before(
 			time.Now().Add(c.RefreshWithin),
-		) {
-			if t, err := c.refreshToken(ctx, tkn); err == nil {
-				tkn, newCookie = t, true
+		)) {
+			if reply, rerr := refreshes.do(ctx, refresh, c.refreshToken); rerr == nil {
+				tkn, newCookie = reply.GetJwt(), true
+				refresh, newRefresh = reply.GetRefreshToken(), true
+				refreshExpires = time.Unix(reply.GetRefreshExpires(), 0)
 
 				claims, err = c.Verificator.Token(ctx, tkn)
 				if c.assertVerErr(ctx, w, r, err) {
@@ -214,17 +432,26 @@ func (c *Client) Middleware(next http.Handler) http.Handler {
 				log.Info(ctx, "token refreshed", "claims", claims)
 
 			} else {
-				log.Error(ctx, "refreshToken", "err", err)
+				log.Error(ctx, "refreshToken", "err", rerr)
 			}
 		}
 
-		if err = c.isGroupMember(claims.Set); err != nil {
+		if err != nil {
+			c.loginRedirect(ctx, w, r, err)
+			return
+		}
+		log.Debug(ctx, "token verified", "claims", claims)
+
+		if err = c.authorize(claims); err != nil {
 			c.loginRedirect(ctx, w, r, err)
 			return
 		}
 
 		if newCookie {
-			c.newCookie(w, r, tkn, claims.Expires.Time())
+			c.newCookie(w, r, jwtKey, tkn, claims.Expires.Time())
+		}
+		if newRefresh {
+			c.newCookie(w, r, refreshKey, refresh, refreshExpires)
 		}
 
 		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), ClaimsKey, Claims{claims})))
@@ -10,6 +10,7 @@ import (
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
@@ -102,6 +103,11 @@ func TestClient_loginRedirect(t *testing.T) {
 			uri:  "http://example.com/foo?jwt=spanac&foo=bar",
 			want: `<a href="/login?redirect=http://example.com/foo?foo=bar">See Other</a>.`,
 		},
+		{
+			name: "uri with jwt and refresh",
+			uri:  "http://example.com/foo?jwt=spanac&refresh=spanac",
+			want: `<a href="/login?redirect=http://example.com/foo">See Other</a>.`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -126,6 +132,64 @@ func TestClient_loginRedirect(t *testing.T) {
 	}
 }
 
+func Test_allowedRedirect(t *testing.T) {
+	allowed := []string{"https://example.com", "http://secured.com/admin/"}
+
+	tests := []struct {
+		name    string
+		allowed []string
+		u       string
+		want    bool
+	}{
+		{"Empty list", nil, "http://evil.com", true},
+		{"Origin", allowed, "https://example.com/foo", true},
+		{"Path prefix", allowed, "http://secured.com/admin/users", true},
+		{"Exact path", allowed, "http://secured.com/admin", true},
+		{"Other scheme", allowed, "http://example.com/foo", false},
+		{"Other host", allowed, "https://evil.com/foo", false},
+		{"Other path", allowed, "http://secured.com/other", false},
+		{"Partial path", allowed, "http://secured.com/administrator", false},
+		{"Path traversal", allowed, "http://secured.com/admin/../other", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := url.Parse(tt.u)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := allowedRedirect(tt.allowed, u); got != tt.want {
+				t.Errorf("allowedRedirect() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_loginRedirect_allowed(t *testing.T) {
+	client := Client{
+		ServerAddress:    "http://example.com",
+		AllowedRedirects: []string{"http://example.com/admin"},
+	}
+
+	tests := []struct {
+		name string
+		uri  string
+		want int
+	}{
+		{"Allowed", "http://example.com/admin/foo", http.StatusSeeOther},
+		{"Not allowed", "http://example.com/foo", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			client.loginRedirect(context.Background(), w, httptest.NewRequest("GET", tt.uri, nil), nil)
+
+			if got := w.Result().StatusCode; got != tt.want {
+				t.Errorf("Client.loginRedirect() statuscode: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
 var (
 	testClient *Client
 	validTkn   string
@@ -163,33 +227,48 @@ func init() {
 		ServerAddress: "http://example.com",
 	}
 
-	ar, err := testClient.Verificator.Client.AuthenticatePwUser(
+	ar, err := login(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	validTkn = ar.GetJwt()
+}
+
+// login returns a new jwt and refresh token for testUser.
+func login(ctx context.Context) (*authenticator.AuthReply, error) {
+	return testClient.Verificator.Client.AuthenticatePwUser(
 		ctx, &authenticator.UserPassword{
 			Email:    testUser,
 			Password: "admin",
 		},
 	)
-	if err != nil {
-		log.Fatal(err)
-	}
-	validTkn = ar.GetJwt()
 }
 
 func TestClient_refreshToken(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	got, err := testClient.refreshToken(ctx, validTkn)
+	ar, err := login(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := testClient.refreshToken(ctx, ar.GetRefreshToken())
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	if got == validTkn {
-		t.Errorf("Client.refreshToken() token %s not refreshed. Got: %s", validTkn, got)
+	if got.GetJwt() == ar.GetJwt() || got.GetRefreshToken() == ar.GetRefreshToken() {
+		t.Errorf("Client.refreshToken() tokens %v not refreshed. Got: %v", ar, got)
+	}
+
+	// Refresh tokens are single use
+	if _, err = testClient.refreshToken(ctx, ar.GetRefreshToken()); err == nil {
+		t.Errorf("Client.refreshToken() err: %v, Want error", err)
 	}
 
 	cancel()
-	if _, err = testClient.refreshToken(ctx, validTkn); err == nil {
+	if _, err = testClient.refreshToken(ctx, got.GetRefreshToken()); err == nil {
 		t.Errorf("Client.refreshToken() err: %v, Want error", err)
 	}
 
@@ -197,7 +276,7 @@ func TestClient_refreshToken(t *testing.T) {
 
 func TestClient_newCookie(t *testing.T) {
 	handler := func(w http.ResponseWriter, r *http.Request) {
-		testClient.newCookie(w, r, "spanac", time.Unix(123, 456))
+		testClient.newCookie(w, r, "jwt", "spanac", time.Unix(123, 456))
 	}
 
 	req := httptest.NewRequest("GET", "http://example.com/foo", nil)
@@ -315,12 +394,27 @@ func TestClient_Middleware(t *testing.T) {
 	groupClient := *testClient
 	groupClient.Groups = []string{"foobar"}
 
+	refreshTkns := make([]string, 2)
+	for i := range refreshTkns {
+		ar, err := login(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		refreshTkns[i] = ar.GetRefreshToken()
+	}
+
 	cookReq := httptest.NewRequest("GET", "http://example.com/foo", nil)
 	cookReq.AddCookie(&http.Cookie{
 		Name:  "jwt",
 		Value: validTkn,
 	})
 
+	refreshReq := func(target, refresh string) *http.Request {
+		r := httptest.NewRequest("GET", target, nil)
+		r.AddCookie(&http.Cookie{Name: "refresh", Value: refresh})
+		return r
+	}
+
 	type want struct {
 		status int
 		body   string
@@ -376,6 +470,42 @@ func TestClient_Middleware(t *testing.T) {
 				"OK",
 			},
 		},
+		{
+			"Refresh using refresh token",
+			&refrClient,
+			refreshReq("http://example.com/foo?jwt="+validTkn, refreshTkns[0]),
+			want{
+				http.StatusOK,
+				"OK",
+			},
+		},
+		{
+			"Only refresh token",
+			testClient,
+			refreshReq("http://example.com/foo", refreshTkns[1]),
+			want{
+				http.StatusOK,
+				"OK",
+			},
+		},
+		{
+			"Invalid refresh token",
+			testClient,
+			refreshReq("http://example.com/foo", "spanac"),
+			want{
+				http.StatusSeeOther,
+				`<a href="/login?redirect=http://example.com/foo">See Other</a>.`,
+			},
+		},
+		{
+			"Refresh token in URL ignored",
+			testClient,
+			httptest.NewRequest("GET", "http://example.com/foo?refresh="+refreshTkns[1], nil),
+			want{
+				http.StatusSeeOther,
+				`<a href="/login?redirect=http://example.com/foo">See Other</a>.`,
+			},
+		},
 		{
 			"Valid token in cookie",
 			testClient,
@@ -412,3 +542,171 @@ func TestClient_Middleware(t *testing.T) {
 		})
 	}
 }
+
+func Test_getCookie(t *testing.T) {
+	withCookie := httptest.NewRequest("GET", "http://example.com/secret", nil)
+	withCookie.AddCookie(&http.Cookie{
+		Name:  "jwt",
+		Value: "foobar",
+	})
+
+	if got := getCookie(withCookie, "jwt"); got != "foobar" {
+		t.Errorf("getCookie() = %v, want %v", got, "foobar")
+	}
+	if got := getCookie(withCookie, "refresh"); got != "" {
+		t.Errorf("getCookie() = %v, want %v", got, "")
+	}
+}
+
+func TestClient_newCookie_options(t *testing.T) {
+	tests := []struct {
+		name   string
+		client *Client
+		cookie string
+		want   *http.Cookie
+	}{
+		{
+			"Hardened",
+			&Client{Hardened: true},
+			"jwt",
+			&http.Cookie{
+				Name:     "jwt",
+				Value:    "spanac",
+				Path:     "/",
+				Expires:  time.Unix(123, 456),
+				Secure:   true,
+				HttpOnly: true,
+				SameSite: http.SameSiteLaxMode,
+			},
+		},
+		{
+			"Options",
+			&Client{Hardened: true, Cookie: &CookieOptions{
+				Domain:   "example.com",
+				Path:     "/foo",
+				Secure:   true,
+				SameSite: http.SameSiteStrictMode,
+			}},
+			"jwt",
+			&http.Cookie{
+				Name:     "jwt",
+				Value:    "spanac",
+				Path:     "/foo",
+				Domain:   "example.com",
+				Expires:  time.Unix(123, 456),
+				Secure:   true,
+				SameSite: http.SameSiteStrictMode,
+			},
+		},
+		{
+			"Refresh",
+			&Client{},
+			"refresh",
+			&http.Cookie{
+				Name:     "refresh",
+				Value:    "spanac",
+				Path:     "/",
+				Expires:  time.Unix(123, 456),
+				HttpOnly: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tt.client.newCookie(w, httptest.NewRequest("GET", "http://example.com/foo", nil), tt.cookie, "spanac", time.Unix(123, 456))
+
+			cookies := w.Result().Cookies()
+			if len(cookies) != 1 {
+				t.Fatalf("Client.newCookie() cookies = %v, want 1", cookies)
+			}
+			if got := cookies[0]; got.String() != tt.want.String() {
+				t.Errorf("Client.newCookie() resp:\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_Middleware_hardened(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("OK"))
+	})
+
+	hardClient := *testClient
+	hardClient.Hardened = true
+
+	ar, err := login(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := testClient.Verificator.Client.CreateAuthCode(context.Background(), ar)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cookReq := httptest.NewRequest("GET", "http://example.com/foo", nil)
+	cookReq.AddCookie(&http.Cookie{
+		Name:  "jwt",
+		Value: validTkn,
+	})
+
+	tests := []struct {
+		name     string
+		r        *http.Request
+		status   int
+		location string
+		cookies  int
+	}{
+		{
+			"Token in URL is ignored",
+			httptest.NewRequest("GET", "http://example.com/foo?jwt="+validTkn, nil),
+			http.StatusSeeOther,
+			"/login?redirect=http://example.com/foo",
+			0,
+		},
+		{
+			"Valid token in cookie",
+			cookReq,
+			http.StatusOK,
+			"",
+			0,
+		},
+		{
+			"Authorization code",
+			httptest.NewRequest("GET", "http://example.com/foo?bar=baz&code="+code.GetCode(), nil),
+			http.StatusSeeOther,
+			"/foo?bar=baz",
+			2,
+		},
+		{
+			"Reused authorization code",
+			httptest.NewRequest("GET", "http://example.com/foo?code="+code.GetCode(), nil),
+			http.StatusSeeOther,
+			"/login?redirect=http://example.com/foo",
+			0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			hardClient.Middleware(next).ServeHTTP(w, tt.r)
+
+			res := w.Result()
+			if res.StatusCode != tt.status {
+				t.Errorf("Client.Middleware() status: %d, want: %d", res.StatusCode, tt.status)
+			}
+			if got := res.Header.Get("Location"); got != tt.location {
+				t.Errorf("Client.Middleware() location: %s, want: %s", got, tt.location)
+			}
+			cookies := res.Cookies()
+			if len(cookies) != tt.cookies {
+				t.Fatalf("Client.Middleware() cookies: %v, want %d", cookies, tt.cookies)
+			}
+			for _, c := range cookies {
+				if !c.Secure || !c.HttpOnly || c.SameSite != http.SameSiteLaxMode {
+					t.Errorf("Client.Middleware() cookie %v not hardened", c)
+				}
+			}
+		})
+	}
+}
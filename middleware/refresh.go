@@ -0,0 +1,77 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package middleware
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/moapis/authenticator"
+)
+
+// refreshGrace is how long the reply of a refresh is kept,
+// for requests which were sent with the old refresh token cookie
+// before the browser received the new one.
+const refreshGrace = 10 * time.Second
+
+// refreshCall is an in-flight or completed refresh.
+type refreshCall struct {
+	done    chan struct{}
+	reply   *authenticator.AuthReply
+	err     error
+	expires time.Time // Set upon completion
+}
+
+// refreshFlight deduplicates refreshes of the same refresh token.
+type refreshFlight struct {
+	mu    sync.Mutex
+	calls map[string]*refreshCall
+}
+
+// refreshes is shared by all Clients, refresh tokens are unique.
+var refreshes refreshFlight
+
+// do calls fn with refresh, unless a call with refresh is in flight
+// or completed successfully less than refreshGrace ago.
+// In that case the reply of that call is returned.
+func (f *refreshFlight) do(ctx context.Context, refresh string, fn func(context.Context, string) (*authenticator.AuthReply, error)) (*authenticator.AuthReply, error) {
+	now := time.Now()
+
+	f.mu.Lock()
+	if f.calls == nil {
+		f.calls = make(map[string]*refreshCall)
+	}
+	for k, c := range f.calls {
+		if !c.expires.IsZero() && now.After(c.expires) {
+			delete(f.calls, k)
+		}
+	}
+	if c, ok := f.calls[refresh]; ok {
+		f.mu.Unlock()
+		select {
+		case <-c.done:
+			return c.reply, c.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	c := &refreshCall{done: make(chan struct{})}
+	f.calls[refresh] = c
+	f.mu.Unlock()
+
+	c.reply, c.err = fn(ctx, refresh)
+
+	f.mu.Lock()
+	if c.err != nil {
+		delete(f.calls, refresh)
+	} else {
+		c.expires = time.Now().Add(refreshGrace)
+	}
+	f.mu.Unlock()
+	close(c.done)
+
+	return c.reply, c.err
+}
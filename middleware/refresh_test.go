@@ -0,0 +1,79 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package middleware
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/moapis/authenticator"
+)
+
+func Test_refreshFlight_do(t *testing.T) {
+	var (
+		f       refreshFlight
+		calls   int32
+		release = make(chan struct{})
+	)
+	fn := func(ctx context.Context, refresh string) (*authenticator.AuthReply, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		if refresh == "bad" {
+			return nil, errors.New("bad refresh token")
+		}
+		return &authenticator.AuthReply{Jwt: "jwt", RefreshToken: refresh + "-new"}, nil
+	}
+
+	var wg sync.WaitGroup
+	replies := make([]*authenticator.AuthReply, 5)
+	for i := range replies {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			replies[i], _ = f.do(context.Background(), "foo", fn)
+		}(i)
+	}
+	// Let all callers join the flight before it completes.
+	for deadline := time.Now().Add(time.Second); atomic.LoadInt32(&calls) == 0 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("refreshFlight.do() calls = %d, want 1", calls)
+	}
+	for i, got := range replies {
+		if got.GetRefreshToken() != "foo-new" {
+			t.Errorf("refreshFlight.do() reply %d = %v, want foo-new", i, got)
+		}
+	}
+
+	// Completed replies are kept for refreshGrace.
+	if got, err := f.do(context.Background(), "foo", fn); err != nil || got.GetRefreshToken() != "foo-new" || calls != 1 {
+		t.Errorf("refreshFlight.do() = %v, %v, calls %d, want foo-new from the previous call", got, err, calls)
+	}
+
+	// Errors are not kept.
+	for i := 0; i < 2; i++ {
+		if _, err := f.do(context.Background(), "bad", fn); err == nil {
+			t.Error("refreshFlight.do() err = nil, want error")
+		}
+	}
+	if calls != 3 {
+		t.Errorf("refreshFlight.do() calls = %d, want 3", calls)
+	}
+
+	// Expired replies are dropped.
+	f.calls["foo"].expires = time.Now().Add(-time.Second)
+	if _, err := f.do(context.Background(), "foo", fn); err != nil || calls != 4 {
+		t.Errorf("refreshFlight.do() err = %v, calls %d, want a new call", err, calls)
+	}
+}
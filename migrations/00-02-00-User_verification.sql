@@ -0,0 +1,14 @@
+-- Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+-- Use of this source code is governed by a License that can be found in the LICENSE file.
+-- SPDX-License-Identifier: BSD-3-Clause
+
+-- +migrate Up
+
+ALTER TABLE auth.users ADD COLUMN verified_at timestamp with time zone;
+
+-- Users existing before this migration already had full access.
+UPDATE auth.users SET verified_at = created_at;
+
+-- +migrate Down
+
+ALTER TABLE auth.users DROP COLUMN verified_at;
@@ -0,0 +1,18 @@
+-- Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+-- Use of this source code is governed by a License that can be found in the LICENSE file.
+-- SPDX-License-Identifier: BSD-3-Clause
+
+-- +migrate Up
+
+ALTER TABLE auth.jwt_keys
+    ADD COLUMN retired_at timestamp with time zone,
+    ADD COLUMN expires_at timestamp with time zone;
+
+-- Keys from before this migration are no longer used for signing.
+UPDATE auth.jwt_keys SET retired_at = now(), expires_at = now();
+
+-- +migrate Down
+
+ALTER TABLE auth.jwt_keys
+    DROP COLUMN retired_at,
+    DROP COLUMN expires_at;
@@ -0,0 +1,12 @@
+-- Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+-- Use of this source code is governed by a License that can be found in the LICENSE file.
+-- SPDX-License-Identifier: BSD-3-Clause
+
+-- +migrate Up
+
+-- Encrypted private key, only set for keys shared between server replicas.
+ALTER TABLE auth.jwt_keys ADD COLUMN encrypted_key bytea;
+
+-- +migrate Down
+
+ALTER TABLE auth.jwt_keys DROP COLUMN encrypted_key;
@@ -0,0 +1,19 @@
+-- Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+-- Use of this source code is governed by a License that can be found in the LICENSE file.
+-- SPDX-License-Identifier: BSD-3-Clause
+
+-- +migrate Up
+
+-- Revoked tokens, identified by their jti claim.
+-- Entries can be removed after expiry of the token.
+create table auth.revoked_tokens (
+	id serial not null primary key,
+	jti character varying(64) not null,
+	expires_at timestamp with time zone not null,
+	created_at timestamp with time zone not null,
+	unique (jti)
+);
+
+-- +migrate Down
+
+drop table auth.revoked_tokens;
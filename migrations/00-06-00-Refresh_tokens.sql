@@ -0,0 +1,26 @@
+-- Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+-- Use of this source code is governed by a License that can be found in the LICENSE file.
+-- SPDX-License-Identifier: BSD-3-Clause
+
+-- +migrate Up
+
+-- Refresh tokens are stored as SHA-256 hash.
+-- Each use replaces the token by a new one in the same family.
+-- Reuse of a replaced token revokes the whole family.
+create table auth.refresh_tokens (
+	id serial not null primary key,
+	user_id integer not null references auth.users (id) on delete cascade,
+	family character varying(64) not null,
+	hash bytea not null,
+	expires_at timestamp with time zone not null,
+	used_at timestamp with time zone,
+	revoked_at timestamp with time zone,
+	created_at timestamp with time zone not null,
+	unique (hash)
+);
+
+create index on auth.refresh_tokens (family);
+
+-- +migrate Down
+
+drop table auth.refresh_tokens;
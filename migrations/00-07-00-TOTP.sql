@@ -0,0 +1,36 @@
+-- Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+-- Use of this source code is governed by a License that can be found in the LICENSE file.
+-- SPDX-License-Identifier: BSD-3-Clause
+
+-- +migrate Up
+
+-- TOTP secrets are stored encrypted with the MFA key-encryption key.
+-- A secret is pending until confirmed_at is set.
+-- last_counter holds the time step of the last accepted code,
+-- so that a code can't be used twice.
+create table auth.totp_secrets (
+	id serial not null primary key,
+	user_id integer not null references auth.users (id) on delete cascade,
+	encrypted_secret bytea not null,
+	last_counter bigint not null default 0,
+	confirmed_at timestamp with time zone,
+	created_at timestamp with time zone not null,
+	updated_at timestamp with time zone not null,
+	unique (user_id)
+);
+
+-- One-time recovery codes, stored as SHA-256 hash.
+create table auth.recovery_codes (
+	id serial not null primary key,
+	user_id integer not null references auth.users (id) on delete cascade,
+	hash bytea not null,
+	used_at timestamp with time zone,
+	created_at timestamp with time zone not null
+);
+
+create index on auth.recovery_codes (user_id);
+
+-- +migrate Down
+
+drop table auth.recovery_codes;
+drop table auth.totp_secrets;
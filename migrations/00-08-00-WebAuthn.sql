@@ -0,0 +1,41 @@
+-- Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+-- Use of this source code is governed by a License that can be found in the LICENSE file.
+-- SPDX-License-Identifier: BSD-3-Clause
+
+-- +migrate Up
+
+-- WebAuthn credentials (passkeys), with their COSE encoded public key.
+-- sign_count holds the last signature counter reported by the authenticator,
+-- for detection of cloned authenticators.
+create table auth.webauthn_credentials (
+	id serial not null primary key,
+	user_id integer not null references auth.users (id) on delete cascade,
+	credential_id bytea not null,
+	public_key bytea not null,
+	sign_count bigint not null default 0,
+	created_at timestamp with time zone not null,
+	updated_at timestamp with time zone not null,
+	unique (credential_id)
+);
+
+create index on auth.webauthn_credentials (user_id);
+
+-- Challenges issued for WebAuthn registration and login ceremonies.
+-- A challenge can only be used once.
+-- user_id is null for login with a discoverable credential.
+create table auth.webauthn_challenges (
+	id serial not null primary key,
+	user_id integer references auth.users (id) on delete cascade,
+	challenge bytea not null,
+	ceremony varchar(16) not null,
+	expires_at timestamp with time zone not null,
+	created_at timestamp with time zone not null,
+	unique (challenge)
+);
+
+create index on auth.webauthn_challenges (expires_at);
+
+-- +migrate Down
+
+drop table auth.webauthn_challenges;
+drop table auth.webauthn_credentials;
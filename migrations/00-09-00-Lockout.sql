@@ -0,0 +1,27 @@
+-- Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+-- Use of this source code is governed by a License that can be found in the LICENSE file.
+-- SPDX-License-Identifier: BSD-3-Clause
+
+-- +migrate Up
+
+-- Failure counters for brute-force protection.
+-- scope is "account" or "reset" with the e-mail address as subject,
+-- or "ip" with the source IP address as subject.
+-- Once failures reach the configured threshold,
+-- the subject is locked until locked_until.
+create table auth.login_failures (
+	id serial not null primary key,
+	scope varchar(16) not null,
+	subject varchar(128) not null,
+	failures integer not null default 0,
+	locked_until timestamp with time zone,
+	last_failure_at timestamp with time zone not null,
+	created_at timestamp with time zone not null,
+	unique (scope, subject)
+);
+
+create index on auth.login_failures (last_failure_at);
+
+-- +migrate Down
+
+drop table auth.login_failures;
@@ -0,0 +1,39 @@
+-- Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+-- Use of this source code is governed by a License that can be found in the LICENSE file.
+-- SPDX-License-Identifier: BSD-3-Clause
+
+-- +migrate Up
+
+-- Password hashes are stored as PHC string, including algorithm, parameters and salt:
+-- $argon2id$v=19$m=65536,t=1,p=2$<salt>$<hash>
+-- Imported bcrypt ($2a$, $2b$, $2y$) and scrypt ($scrypt$ln=..,r=..,p=..$<salt>$<hash>)
+-- hashes are verified as well, and replaced by argon2id on the next successful login.
+-- Existing hashes used the former compile-time argon2id parameters.
+alter table auth.passwords rename column hash to raw_hash;
+alter table auth.passwords add column hash varchar(255);
+
+update auth.passwords set hash = '$argon2id$v=19$m=65536,t=1,p=2$'
+	|| rtrim(replace(encode(salt, 'base64'), E'\n', ''), '=') || '$'
+	|| rtrim(replace(encode(raw_hash, 'base64'), E'\n', ''), '=');
+
+alter table auth.passwords alter column hash set not null;
+alter table auth.passwords drop column salt;
+alter table auth.passwords drop column raw_hash;
+
+-- +migrate Down
+
+alter table auth.passwords rename column hash to encoded_hash;
+alter table auth.passwords add column salt bytea;
+alter table auth.passwords add column hash bytea;
+
+-- Only hashes with the former compile-time parameters can be converted back.
+-- Other users need to reset their password.
+delete from auth.passwords where encoded_hash not like '$argon2id$v=19$m=65536,t=1,p=2$%';
+
+update auth.passwords set
+	salt = decode(rpad(split_part(encoded_hash, '$', 5), (length(split_part(encoded_hash, '$', 5)) + 3) / 4 * 4, '='), 'base64'),
+	hash = decode(rpad(split_part(encoded_hash, '$', 6), (length(split_part(encoded_hash, '$', 6)) + 3) / 4 * 4, '='), 'base64');
+
+alter table auth.passwords alter column salt set not null;
+alter table auth.passwords alter column hash set not null;
+alter table auth.passwords drop column encoded_hash;
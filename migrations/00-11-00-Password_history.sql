@@ -0,0 +1,21 @@
+-- Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+-- Use of this source code is governed by a License that can be found in the LICENSE file.
+-- SPDX-License-Identifier: BSD-3-Clause
+
+-- +migrate Up
+
+-- Previous password hashes, in PHC string format,
+-- so that users can't reuse their last passwords.
+-- Only the amount needed by the configured policy is kept.
+create table auth.password_history (
+	id serial not null primary key,
+	user_id integer not null references auth.users (id) on delete cascade,
+	hash varchar(255) not null,
+	created_at timestamp with time zone not null
+);
+
+create index on auth.password_history (user_id, created_at);
+
+-- +migrate Down
+
+drop table auth.password_history;
@@ -0,0 +1,23 @@
+-- Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+-- Use of this source code is governed by a License that can be found in the LICENSE file.
+-- SPDX-License-Identifier: BSD-3-Clause
+
+-- +migrate Up
+
+-- One-time authorization codes are stored as SHA-256 hash.
+-- A code takes the place of a used refresh token,
+-- and is exchanged for new tokens in the same refresh token family.
+create table auth.auth_codes (
+	id serial not null primary key,
+	user_id integer not null references auth.users (id) on delete cascade,
+	family character varying(64) not null,
+	hash bytea not null,
+	expires_at timestamp with time zone not null,
+	used_at timestamp with time zone,
+	created_at timestamp with time zone not null,
+	unique (hash)
+);
+
+-- +migrate Down
+
+drop table auth.auth_codes;
@@ -0,0 +1,49 @@
+-- Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+-- Use of this source code is governed by a License that can be found in the LICENSE file.
+-- SPDX-License-Identifier: BSD-3-Clause
+
+-- +migrate Up
+
+-- OAuth 2.0 clients are identified by their name.
+-- Confidential clients have the SHA-256 hash of their secret stored,
+-- public clients have none and need to use PKCE.
+create table auth.clients (
+	id serial not null primary key,
+	name character varying(64) not null,
+	description character varying(120) not null default '',
+	secret_hash bytea,
+	redirect_uris text[] not null default '{}',
+	scopes text[] not null default '{}',
+	created_at timestamp with time zone not null,
+	updated_at timestamp with time zone not null,
+	unique (name)
+);
+
+-- Client grants record which client and scopes a refresh token family was issued to,
+-- so that refreshed tokens keep the same claims.
+create table auth.client_grants (
+	id serial not null primary key,
+	client_id integer not null references auth.clients (id) on delete cascade,
+	family character varying(64) not null,
+	scopes text[] not null default '{}',
+	created_at timestamp with time zone not null,
+	unique (family)
+);
+
+-- Authorization codes issued to a client are bound to its redirect URI and PKCE challenge.
+alter table auth.auth_codes
+	add column client_id integer references auth.clients (id) on delete cascade,
+	add column redirect_uri text,
+	add column code_challenge character varying(128),
+	add column scopes text[] not null default '{}';
+
+-- +migrate Down
+
+alter table auth.auth_codes
+	drop column client_id,
+	drop column redirect_uri,
+	drop column code_challenge,
+	drop column scopes;
+
+drop table auth.client_grants;
+drop table auth.clients;
@@ -0,0 +1,24 @@
+-- Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+-- Use of this source code is governed by a License that can be found in the LICENSE file.
+-- SPDX-License-Identifier: BSD-3-Clause
+
+-- +migrate Up
+
+-- OpenID Connect ID tokens carry the nonce from the authorization request
+-- and the time the user authenticated.
+alter table auth.auth_codes
+	add column nonce character varying(255),
+	add column auth_time timestamp with time zone;
+
+-- Refreshed ID tokens keep the original authentication time.
+alter table auth.client_grants
+	add column auth_time timestamp with time zone;
+
+-- +migrate Down
+
+alter table auth.client_grants
+	drop column auth_time;
+
+alter table auth.auth_codes
+	drop column nonce,
+	drop column auth_time;
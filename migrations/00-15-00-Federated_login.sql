@@ -0,0 +1,40 @@
+-- Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+-- Use of this source code is governed by a License that can be found in the LICENSE file.
+-- SPDX-License-Identifier: BSD-3-Clause
+
+-- +migrate Up
+
+-- Identities at upstream OpenID Connect providers, linked to a local user.
+create table auth.user_identities (
+	id serial not null primary key,
+	user_id integer not null references auth.users (id) on delete cascade,
+	issuer character varying(255) not null,
+	subject character varying(255) not null,
+	created_at timestamp with time zone not null,
+	updated_at timestamp with time zone not null,
+	unique (issuer, subject)
+);
+
+create index on auth.user_identities (user_id);
+
+-- Pending logins at upstream providers, identified by the hash of their state parameter.
+-- The nonce and PKCE code verifier are kept for the callback.
+-- A state can only be used once.
+create table auth.federated_logins (
+	id serial not null primary key,
+	provider character varying(64) not null,
+	state_hash bytea not null,
+	nonce character varying(64) not null,
+	code_verifier character varying(128) not null,
+	redirect_uri text not null,
+	expires_at timestamp with time zone not null,
+	created_at timestamp with time zone not null,
+	unique (state_hash)
+);
+
+create index on auth.federated_logins (expires_at);
+
+-- +migrate Down
+
+drop table auth.federated_logins;
+drop table auth.user_identities;
@@ -0,0 +1,13 @@
+-- Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+-- Use of this source code is governed by a License that can be found in the LICENSE file.
+-- SPDX-License-Identifier: BSD-3-Clause
+
+-- +migrate Up
+
+-- Name of the configured credential backend verifying the password of the user.
+-- Local passwords are used when null.
+alter table auth.users add column credential_backend character varying(64);
+
+-- +migrate Down
+
+alter table auth.users drop column credential_backend;
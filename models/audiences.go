@@ -515,7 +515,7 @@ func (audienceL) LoadUsers(ctx context.Context, e boil.ContextExecutor, singular
 		one := new(User)
 		var localJoinCol int
 
-		err = results.Scan(&one.ID, &one.Email, &one.Name, &one.CreatedAt, &one.UpdatedAt, &localJoinCol)
+		err = results.Scan(&one.ID, &one.Email, &one.Name, &one.CreatedAt, &one.UpdatedAt, &one.VerifiedAt, &one.CredentialBackend, &localJoinCol)
 		if err != nil {
 			return errors.Wrap(err, "failed to scan eager loaded results for users")
 		}
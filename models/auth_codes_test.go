@@ -0,0 +1,1000 @@
+// Code generated by SQLBoiler 4.1.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/randomize"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testAuthCodes(t *testing.T) {
+	t.Parallel()
+
+	query := AuthCodes()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testAuthCodesDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AuthCode{}
+	if err = randomize.Struct(seed, o, authCodeDBTypes, true, authCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := AuthCodes().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testAuthCodesQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AuthCode{}
+	if err = randomize.Struct(seed, o, authCodeDBTypes, true, authCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := AuthCodes().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := AuthCodes().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testAuthCodesSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AuthCode{}
+	if err = randomize.Struct(seed, o, authCodeDBTypes, true, authCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := AuthCodeSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := AuthCodes().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testAuthCodesExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AuthCode{}
+	if err = randomize.Struct(seed, o, authCodeDBTypes, true, authCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := AuthCodeExists(ctx, tx, o.ID)
+	if err != nil {
+		t.Errorf("Unable to check if AuthCode exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected AuthCodeExists to return true, but got false.")
+	}
+}
+
+func testAuthCodesFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AuthCode{}
+	if err = randomize.Struct(seed, o, authCodeDBTypes, true, authCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	authCodeFound, err := FindAuthCode(ctx, tx, o.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if authCodeFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testAuthCodesBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AuthCode{}
+	if err = randomize.Struct(seed, o, authCodeDBTypes, true, authCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = AuthCodes().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testAuthCodesOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AuthCode{}
+	if err = randomize.Struct(seed, o, authCodeDBTypes, true, authCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := AuthCodes().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testAuthCodesAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	authCodeOne := &AuthCode{}
+	authCodeTwo := &AuthCode{}
+	if err = randomize.Struct(seed, authCodeOne, authCodeDBTypes, false, authCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthCode struct: %s", err)
+	}
+	if err = randomize.Struct(seed, authCodeTwo, authCodeDBTypes, false, authCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = authCodeOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = authCodeTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := AuthCodes().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testAuthCodesCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	authCodeOne := &AuthCode{}
+	authCodeTwo := &AuthCode{}
+	if err = randomize.Struct(seed, authCodeOne, authCodeDBTypes, false, authCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthCode struct: %s", err)
+	}
+	if err = randomize.Struct(seed, authCodeTwo, authCodeDBTypes, false, authCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = authCodeOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = authCodeTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := AuthCodes().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func authCodeBeforeInsertHook(ctx context.Context, e boil.ContextExecutor, o *AuthCode) error {
+	*o = AuthCode{}
+	return nil
+}
+
+func authCodeAfterInsertHook(ctx context.Context, e boil.ContextExecutor, o *AuthCode) error {
+	*o = AuthCode{}
+	return nil
+}
+
+func authCodeAfterSelectHook(ctx context.Context, e boil.ContextExecutor, o *AuthCode) error {
+	*o = AuthCode{}
+	return nil
+}
+
+func authCodeBeforeUpdateHook(ctx context.Context, e boil.ContextExecutor, o *AuthCode) error {
+	*o = AuthCode{}
+	return nil
+}
+
+func authCodeAfterUpdateHook(ctx context.Context, e boil.ContextExecutor, o *AuthCode) error {
+	*o = AuthCode{}
+	return nil
+}
+
+func authCodeBeforeDeleteHook(ctx context.Context, e boil.ContextExecutor, o *AuthCode) error {
+	*o = AuthCode{}
+	return nil
+}
+
+func authCodeAfterDeleteHook(ctx context.Context, e boil.ContextExecutor, o *AuthCode) error {
+	*o = AuthCode{}
+	return nil
+}
+
+func authCodeBeforeUpsertHook(ctx context.Context, e boil.ContextExecutor, o *AuthCode) error {
+	*o = AuthCode{}
+	return nil
+}
+
+func authCodeAfterUpsertHook(ctx context.Context, e boil.ContextExecutor, o *AuthCode) error {
+	*o = AuthCode{}
+	return nil
+}
+
+func testAuthCodesHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	ctx := context.Background()
+	empty := &AuthCode{}
+	o := &AuthCode{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, authCodeDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize AuthCode object: %s", err)
+	}
+
+	AddAuthCodeHook(boil.BeforeInsertHook, authCodeBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	authCodeBeforeInsertHooks = []AuthCodeHook{}
+
+	AddAuthCodeHook(boil.AfterInsertHook, authCodeAfterInsertHook)
+	if err = o.doAfterInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	authCodeAfterInsertHooks = []AuthCodeHook{}
+
+	AddAuthCodeHook(boil.AfterSelectHook, authCodeAfterSelectHook)
+	if err = o.doAfterSelectHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	authCodeAfterSelectHooks = []AuthCodeHook{}
+
+	AddAuthCodeHook(boil.BeforeUpdateHook, authCodeBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	authCodeBeforeUpdateHooks = []AuthCodeHook{}
+
+	AddAuthCodeHook(boil.AfterUpdateHook, authCodeAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	authCodeAfterUpdateHooks = []AuthCodeHook{}
+
+	AddAuthCodeHook(boil.BeforeDeleteHook, authCodeBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	authCodeBeforeDeleteHooks = []AuthCodeHook{}
+
+	AddAuthCodeHook(boil.AfterDeleteHook, authCodeAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	authCodeAfterDeleteHooks = []AuthCodeHook{}
+
+	AddAuthCodeHook(boil.BeforeUpsertHook, authCodeBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	authCodeBeforeUpsertHooks = []AuthCodeHook{}
+
+	AddAuthCodeHook(boil.AfterUpsertHook, authCodeAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	authCodeAfterUpsertHooks = []AuthCodeHook{}
+}
+
+func testAuthCodesInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AuthCode{}
+	if err = randomize.Struct(seed, o, authCodeDBTypes, true, authCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := AuthCodes().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testAuthCodesInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AuthCode{}
+	if err = randomize.Struct(seed, o, authCodeDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize AuthCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(authCodeColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := AuthCodes().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testAuthCodeToOneUserUsingUser(t *testing.T) {
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var local AuthCode
+	var foreign User
+
+	seed := randomize.NewSeed()
+	if err := randomize.Struct(seed, &local, authCodeDBTypes, false, authCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthCode struct: %s", err)
+	}
+	if err := randomize.Struct(seed, &foreign, userDBTypes, false, userColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize User struct: %s", err)
+	}
+
+	if err := foreign.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	local.UserID = foreign.ID
+	if err := local.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := local.User().One(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if check.ID != foreign.ID {
+		t.Errorf("want: %v, got %v", foreign.ID, check.ID)
+	}
+
+	slice := AuthCodeSlice{&local}
+	if err = local.L.LoadUser(ctx, tx, false, (*[]*AuthCode)(&slice), nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.User == nil {
+		t.Error("struct should have been eager loaded")
+	}
+
+	local.R.User = nil
+	if err = local.L.LoadUser(ctx, tx, true, &local, nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.User == nil {
+		t.Error("struct should have been eager loaded")
+	}
+}
+
+func testAuthCodeToOneClientUsingClient(t *testing.T) {
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var local AuthCode
+	var foreign Client
+
+	seed := randomize.NewSeed()
+	if err := randomize.Struct(seed, &local, authCodeDBTypes, true, authCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthCode struct: %s", err)
+	}
+	if err := randomize.Struct(seed, &foreign, clientDBTypes, false, clientColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Client struct: %s", err)
+	}
+
+	if err := foreign.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	queries.Assign(&local.ClientID, foreign.ID)
+	if err := local.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := local.Client().One(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !queries.Equal(check.ID, foreign.ID) {
+		t.Errorf("want: %v, got %v", foreign.ID, check.ID)
+	}
+
+	slice := AuthCodeSlice{&local}
+	if err = local.L.LoadClient(ctx, tx, false, (*[]*AuthCode)(&slice), nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.Client == nil {
+		t.Error("struct should have been eager loaded")
+	}
+
+	local.R.Client = nil
+	if err = local.L.LoadClient(ctx, tx, true, &local, nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.Client == nil {
+		t.Error("struct should have been eager loaded")
+	}
+}
+
+func testAuthCodeToOneSetOpUserUsingUser(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a AuthCode
+	var b, c User
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, authCodeDBTypes, false, strmangle.SetComplement(authCodePrimaryKeyColumns, authCodeColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &b, userDBTypes, false, strmangle.SetComplement(userPrimaryKeyColumns, userColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &c, userDBTypes, false, strmangle.SetComplement(userPrimaryKeyColumns, userColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, x := range []*User{&b, &c} {
+		err = a.SetUser(ctx, tx, i != 0, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if a.R.User != x {
+			t.Error("relationship struct not set to correct value")
+		}
+
+		if x.R.AuthCodes[0] != &a {
+			t.Error("failed to append to foreign relationship struct")
+		}
+		if a.UserID != x.ID {
+			t.Error("foreign key was wrong value", a.UserID)
+		}
+
+		zero := reflect.Zero(reflect.TypeOf(a.UserID))
+		reflect.Indirect(reflect.ValueOf(&a.UserID)).Set(zero)
+
+		if err = a.Reload(ctx, tx); err != nil {
+			t.Fatal("failed to reload", err)
+		}
+
+		if a.UserID != x.ID {
+			t.Error("foreign key was wrong value", a.UserID, x.ID)
+		}
+	}
+}
+func testAuthCodeToOneSetOpClientUsingClient(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a AuthCode
+	var b, c Client
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, authCodeDBTypes, false, strmangle.SetComplement(authCodePrimaryKeyColumns, authCodeColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &b, clientDBTypes, false, strmangle.SetComplement(clientPrimaryKeyColumns, clientColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &c, clientDBTypes, false, strmangle.SetComplement(clientPrimaryKeyColumns, clientColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, x := range []*Client{&b, &c} {
+		err = a.SetClient(ctx, tx, i != 0, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if a.R.Client != x {
+			t.Error("relationship struct not set to correct value")
+		}
+
+		if x.R.AuthCodes[0] != &a {
+			t.Error("failed to append to foreign relationship struct")
+		}
+		if !queries.Equal(a.ClientID, x.ID) {
+			t.Error("foreign key was wrong value", a.ClientID)
+		}
+
+		zero := reflect.Zero(reflect.TypeOf(a.ClientID))
+		reflect.Indirect(reflect.ValueOf(&a.ClientID)).Set(zero)
+
+		if err = a.Reload(ctx, tx); err != nil {
+			t.Fatal("failed to reload", err)
+		}
+
+		if !queries.Equal(a.ClientID, x.ID) {
+			t.Error("foreign key was wrong value", a.ClientID, x.ID)
+		}
+	}
+}
+
+func testAuthCodeToOneRemoveOpClientUsingClient(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a AuthCode
+	var b Client
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, authCodeDBTypes, false, strmangle.SetComplement(authCodePrimaryKeyColumns, authCodeColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &b, clientDBTypes, false, strmangle.SetComplement(clientPrimaryKeyColumns, clientColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = a.SetClient(ctx, tx, true, &b); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = a.RemoveClient(ctx, tx, &b); err != nil {
+		t.Error("failed to remove relationship")
+	}
+
+	count, err := a.Client().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 0 {
+		t.Error("want no relationships remaining")
+	}
+
+	if a.R.Client != nil {
+		t.Error("R struct entry should be nil")
+	}
+
+	if !queries.IsValuerNil(a.ClientID) {
+		t.Error("foreign key value should be nil")
+	}
+
+	if len(b.R.AuthCodes) != 0 {
+		t.Error("failed to remove a from b's relationships")
+	}
+}
+
+func testAuthCodesReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AuthCode{}
+	if err = randomize.Struct(seed, o, authCodeDBTypes, true, authCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testAuthCodesReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AuthCode{}
+	if err = randomize.Struct(seed, o, authCodeDBTypes, true, authCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := AuthCodeSlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testAuthCodesSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AuthCode{}
+	if err = randomize.Struct(seed, o, authCodeDBTypes, true, authCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := AuthCodes().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	authCodeDBTypes = map[string]string{`ID`: `integer`, `UserID`: `integer`, `Family`: `character varying`, `Hash`: `bytea`, `ExpiresAt`: `timestamp with time zone`, `UsedAt`: `timestamp with time zone`, `CreatedAt`: `timestamp with time zone`, `ClientID`: `integer`, `RedirectURI`: `text`, `CodeChallenge`: `character varying`, `Scopes`: `ARRAYtext`, `Nonce`: `character varying`, `AuthTime`: `timestamp with time zone`}
+	_               = bytes.MinRead
+)
+
+func testAuthCodesUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(authCodePrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(authCodeAllColumns) == len(authCodePrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AuthCode{}
+	if err = randomize.Struct(seed, o, authCodeDBTypes, true, authCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := AuthCodes().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, authCodeDBTypes, true, authCodePrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize AuthCode struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testAuthCodesSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(authCodeAllColumns) == len(authCodePrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AuthCode{}
+	if err = randomize.Struct(seed, o, authCodeDBTypes, true, authCodeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AuthCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := AuthCodes().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, authCodeDBTypes, true, authCodePrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize AuthCode struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(authCodeAllColumns, authCodePrimaryKeyColumns) {
+		fields = authCodeAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			authCodeAllColumns,
+			authCodePrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := AuthCodeSlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testAuthCodesUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(authCodeAllColumns) == len(authCodePrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := AuthCode{}
+	if err = randomize.Struct(seed, &o, authCodeDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize AuthCode struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert AuthCode: %s", err)
+	}
+
+	count, err := AuthCodes().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, authCodeDBTypes, false, authCodePrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize AuthCode struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert AuthCode: %s", err)
+	}
+
+	count, err = AuthCodes().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
@@ -13,140 +13,343 @@ import "testing"
 // Separating the tests thusly grants avoidance of Postgres deadlocks.
 func TestParent(t *testing.T) {
 	t.Run("Audiences", testAudiences)
+	t.Run("AuthCodes", testAuthCodes)
+	t.Run("ClientGrants", testClientGrants)
+	t.Run("Clients", testClients)
+	t.Run("FederatedLogins", testFederatedLogins)
 	t.Run("Groups", testGroups)
 	t.Run("JWTKeys", testJWTKeys)
+	t.Run("LoginFailures", testLoginFailures)
+	t.Run("PasswordHistories", testPasswordHistories)
 	t.Run("Passwords", testPasswords)
+	t.Run("RecoveryCodes", testRecoveryCodes)
+	t.Run("RefreshTokens", testRefreshTokens)
+	t.Run("RevokedTokens", testRevokedTokens)
+	t.Run("TotpSecrets", testTotpSecrets)
+	t.Run("UserIdentities", testUserIdentities)
 	t.Run("Users", testUsers)
+	t.Run("WebauthnChallenges", testWebauthnChallenges)
+	t.Run("WebauthnCredentials", testWebauthnCredentials)
 }
 
 func TestDelete(t *testing.T) {
 	t.Run("Audiences", testAudiencesDelete)
+	t.Run("AuthCodes", testAuthCodesDelete)
+	t.Run("ClientGrants", testClientGrantsDelete)
+	t.Run("Clients", testClientsDelete)
+	t.Run("FederatedLogins", testFederatedLoginsDelete)
 	t.Run("Groups", testGroupsDelete)
 	t.Run("JWTKeys", testJWTKeysDelete)
+	t.Run("LoginFailures", testLoginFailuresDelete)
+	t.Run("PasswordHistories", testPasswordHistoriesDelete)
 	t.Run("Passwords", testPasswordsDelete)
+	t.Run("RecoveryCodes", testRecoveryCodesDelete)
+	t.Run("RefreshTokens", testRefreshTokensDelete)
+	t.Run("RevokedTokens", testRevokedTokensDelete)
+	t.Run("TotpSecrets", testTotpSecretsDelete)
+	t.Run("UserIdentities", testUserIdentitiesDelete)
 	t.Run("Users", testUsersDelete)
+	t.Run("WebauthnChallenges", testWebauthnChallengesDelete)
+	t.Run("WebauthnCredentials", testWebauthnCredentialsDelete)
 }
 
 func TestQueryDeleteAll(t *testing.T) {
 	t.Run("Audiences", testAudiencesQueryDeleteAll)
+	t.Run("AuthCodes", testAuthCodesQueryDeleteAll)
+	t.Run("ClientGrants", testClientGrantsQueryDeleteAll)
+	t.Run("Clients", testClientsQueryDeleteAll)
+	t.Run("FederatedLogins", testFederatedLoginsQueryDeleteAll)
 	t.Run("Groups", testGroupsQueryDeleteAll)
 	t.Run("JWTKeys", testJWTKeysQueryDeleteAll)
+	t.Run("LoginFailures", testLoginFailuresQueryDeleteAll)
+	t.Run("PasswordHistories", testPasswordHistoriesQueryDeleteAll)
 	t.Run("Passwords", testPasswordsQueryDeleteAll)
+	t.Run("RecoveryCodes", testRecoveryCodesQueryDeleteAll)
+	t.Run("RefreshTokens", testRefreshTokensQueryDeleteAll)
+	t.Run("RevokedTokens", testRevokedTokensQueryDeleteAll)
+	t.Run("TotpSecrets", testTotpSecretsQueryDeleteAll)
+	t.Run("UserIdentities", testUserIdentitiesQueryDeleteAll)
 	t.Run("Users", testUsersQueryDeleteAll)
+	t.Run("WebauthnChallenges", testWebauthnChallengesQueryDeleteAll)
+	t.Run("WebauthnCredentials", testWebauthnCredentialsQueryDeleteAll)
 }
 
 func TestSliceDeleteAll(t *testing.T) {
 	t.Run("Audiences", testAudiencesSliceDeleteAll)
+	t.Run("AuthCodes", testAuthCodesSliceDeleteAll)
+	t.Run("ClientGrants", testClientGrantsSliceDeleteAll)
+	t.Run("Clients", testClientsSliceDeleteAll)
+	t.Run("FederatedLogins", testFederatedLoginsSliceDeleteAll)
 	t.Run("Groups", testGroupsSliceDeleteAll)
 	t.Run("JWTKeys", testJWTKeysSliceDeleteAll)
+	t.Run("LoginFailures", testLoginFailuresSliceDeleteAll)
+	t.Run("PasswordHistories", testPasswordHistoriesSliceDeleteAll)
 	t.Run("Passwords", testPasswordsSliceDeleteAll)
+	t.Run("RecoveryCodes", testRecoveryCodesSliceDeleteAll)
+	t.Run("RefreshTokens", testRefreshTokensSliceDeleteAll)
+	t.Run("RevokedTokens", testRevokedTokensSliceDeleteAll)
+	t.Run("TotpSecrets", testTotpSecretsSliceDeleteAll)
+	t.Run("UserIdentities", testUserIdentitiesSliceDeleteAll)
 	t.Run("Users", testUsersSliceDeleteAll)
+	t.Run("WebauthnChallenges", testWebauthnChallengesSliceDeleteAll)
+	t.Run("WebauthnCredentials", testWebauthnCredentialsSliceDeleteAll)
 }
 
 func TestExists(t *testing.T) {
 	t.Run("Audiences", testAudiencesExists)
+	t.Run("AuthCodes", testAuthCodesExists)
+	t.Run("ClientGrants", testClientGrantsExists)
+	t.Run("Clients", testClientsExists)
+	t.Run("FederatedLogins", testFederatedLoginsExists)
 	t.Run("Groups", testGroupsExists)
 	t.Run("JWTKeys", testJWTKeysExists)
+	t.Run("LoginFailures", testLoginFailuresExists)
+	t.Run("PasswordHistories", testPasswordHistoriesExists)
 	t.Run("Passwords", testPasswordsExists)
+	t.Run("RecoveryCodes", testRecoveryCodesExists)
+	t.Run("RefreshTokens", testRefreshTokensExists)
+	t.Run("RevokedTokens", testRevokedTokensExists)
+	t.Run("TotpSecrets", testTotpSecretsExists)
+	t.Run("UserIdentities", testUserIdentitiesExists)
 	t.Run("Users", testUsersExists)
+	t.Run("WebauthnChallenges", testWebauthnChallengesExists)
+	t.Run("WebauthnCredentials", testWebauthnCredentialsExists)
 }
 
 func TestFind(t *testing.T) {
 	t.Run("Audiences", testAudiencesFind)
+	t.Run("AuthCodes", testAuthCodesFind)
+	t.Run("ClientGrants", testClientGrantsFind)
+	t.Run("Clients", testClientsFind)
+	t.Run("FederatedLogins", testFederatedLoginsFind)
 	t.Run("Groups", testGroupsFind)
 	t.Run("JWTKeys", testJWTKeysFind)
+	t.Run("LoginFailures", testLoginFailuresFind)
+	t.Run("PasswordHistories", testPasswordHistoriesFind)
 	t.Run("Passwords", testPasswordsFind)
+	t.Run("RecoveryCodes", testRecoveryCodesFind)
+	t.Run("RefreshTokens", testRefreshTokensFind)
+	t.Run("RevokedTokens", testRevokedTokensFind)
+	t.Run("TotpSecrets", testTotpSecretsFind)
+	t.Run("UserIdentities", testUserIdentitiesFind)
 	t.Run("Users", testUsersFind)
+	t.Run("WebauthnChallenges", testWebauthnChallengesFind)
+	t.Run("WebauthnCredentials", testWebauthnCredentialsFind)
 }
 
 func TestBind(t *testing.T) {
 	t.Run("Audiences", testAudiencesBind)
+	t.Run("AuthCodes", testAuthCodesBind)
+	t.Run("ClientGrants", testClientGrantsBind)
+	t.Run("Clients", testClientsBind)
+	t.Run("FederatedLogins", testFederatedLoginsBind)
 	t.Run("Groups", testGroupsBind)
 	t.Run("JWTKeys", testJWTKeysBind)
+	t.Run("LoginFailures", testLoginFailuresBind)
+	t.Run("PasswordHistories", testPasswordHistoriesBind)
 	t.Run("Passwords", testPasswordsBind)
+	t.Run("RecoveryCodes", testRecoveryCodesBind)
+	t.Run("RefreshTokens", testRefreshTokensBind)
+	t.Run("RevokedTokens", testRevokedTokensBind)
+	t.Run("TotpSecrets", testTotpSecretsBind)
+	t.Run("UserIdentities", testUserIdentitiesBind)
 	t.Run("Users", testUsersBind)
+	t.Run("WebauthnChallenges", testWebauthnChallengesBind)
+	t.Run("WebauthnCredentials", testWebauthnCredentialsBind)
 }
 
 func TestOne(t *testing.T) {
 	t.Run("Audiences", testAudiencesOne)
+	t.Run("AuthCodes", testAuthCodesOne)
+	t.Run("ClientGrants", testClientGrantsOne)
+	t.Run("Clients", testClientsOne)
+	t.Run("FederatedLogins", testFederatedLoginsOne)
 	t.Run("Groups", testGroupsOne)
 	t.Run("JWTKeys", testJWTKeysOne)
+	t.Run("LoginFailures", testLoginFailuresOne)
+	t.Run("PasswordHistories", testPasswordHistoriesOne)
 	t.Run("Passwords", testPasswordsOne)
+	t.Run("RecoveryCodes", testRecoveryCodesOne)
+	t.Run("RefreshTokens", testRefreshTokensOne)
+	t.Run("RevokedTokens", testRevokedTokensOne)
+	t.Run("TotpSecrets", testTotpSecretsOne)
+	t.Run("UserIdentities", testUserIdentitiesOne)
 	t.Run("Users", testUsersOne)
+	t.Run("WebauthnChallenges", testWebauthnChallengesOne)
+	t.Run("WebauthnCredentials", testWebauthnCredentialsOne)
 }
 
 func TestAll(t *testing.T) {
 	t.Run("Audiences", testAudiencesAll)
+	t.Run("AuthCodes", testAuthCodesAll)
+	t.Run("ClientGrants", testClientGrantsAll)
+	t.Run("Clients", testClientsAll)
+	t.Run("FederatedLogins", testFederatedLoginsAll)
 	t.Run("Groups", testGroupsAll)
 	t.Run("JWTKeys", testJWTKeysAll)
+	t.Run("LoginFailures", testLoginFailuresAll)
+	t.Run("PasswordHistories", testPasswordHistoriesAll)
 	t.Run("Passwords", testPasswordsAll)
+	t.Run("RecoveryCodes", testRecoveryCodesAll)
+	t.Run("RefreshTokens", testRefreshTokensAll)
+	t.Run("RevokedTokens", testRevokedTokensAll)
+	t.Run("TotpSecrets", testTotpSecretsAll)
+	t.Run("UserIdentities", testUserIdentitiesAll)
 	t.Run("Users", testUsersAll)
+	t.Run("WebauthnChallenges", testWebauthnChallengesAll)
+	t.Run("WebauthnCredentials", testWebauthnCredentialsAll)
 }
 
 func TestCount(t *testing.T) {
 	t.Run("Audiences", testAudiencesCount)
+	t.Run("AuthCodes", testAuthCodesCount)
+	t.Run("ClientGrants", testClientGrantsCount)
+	t.Run("Clients", testClientsCount)
+	t.Run("FederatedLogins", testFederatedLoginsCount)
 	t.Run("Groups", testGroupsCount)
 	t.Run("JWTKeys", testJWTKeysCount)
+	t.Run("LoginFailures", testLoginFailuresCount)
+	t.Run("PasswordHistories", testPasswordHistoriesCount)
 	t.Run("Passwords", testPasswordsCount)
+	t.Run("RecoveryCodes", testRecoveryCodesCount)
+	t.Run("RefreshTokens", testRefreshTokensCount)
+	t.Run("RevokedTokens", testRevokedTokensCount)
+	t.Run("TotpSecrets", testTotpSecretsCount)
+	t.Run("UserIdentities", testUserIdentitiesCount)
 	t.Run("Users", testUsersCount)
+	t.Run("WebauthnChallenges", testWebauthnChallengesCount)
+	t.Run("WebauthnCredentials", testWebauthnCredentialsCount)
 }
 
 func TestHooks(t *testing.T) {
 	t.Run("Audiences", testAudiencesHooks)
+	t.Run("AuthCodes", testAuthCodesHooks)
+	t.Run("ClientGrants", testClientGrantsHooks)
+	t.Run("Clients", testClientsHooks)
+	t.Run("FederatedLogins", testFederatedLoginsHooks)
 	t.Run("Groups", testGroupsHooks)
 	t.Run("JWTKeys", testJWTKeysHooks)
+	t.Run("LoginFailures", testLoginFailuresHooks)
+	t.Run("PasswordHistories", testPasswordHistoriesHooks)
 	t.Run("Passwords", testPasswordsHooks)
+	t.Run("RecoveryCodes", testRecoveryCodesHooks)
+	t.Run("RefreshTokens", testRefreshTokensHooks)
+	t.Run("RevokedTokens", testRevokedTokensHooks)
+	t.Run("TotpSecrets", testTotpSecretsHooks)
+	t.Run("UserIdentities", testUserIdentitiesHooks)
 	t.Run("Users", testUsersHooks)
+	t.Run("WebauthnChallenges", testWebauthnChallengesHooks)
+	t.Run("WebauthnCredentials", testWebauthnCredentialsHooks)
 }
 
 func TestInsert(t *testing.T) {
 	t.Run("Audiences", testAudiencesInsert)
 	t.Run("Audiences", testAudiencesInsertWhitelist)
+	t.Run("AuthCodes", testAuthCodesInsert)
+	t.Run("AuthCodes", testAuthCodesInsertWhitelist)
+	t.Run("ClientGrants", testClientGrantsInsert)
+	t.Run("ClientGrants", testClientGrantsInsertWhitelist)
+	t.Run("Clients", testClientsInsert)
+	t.Run("Clients", testClientsInsertWhitelist)
+	t.Run("FederatedLogins", testFederatedLoginsInsert)
+	t.Run("FederatedLogins", testFederatedLoginsInsertWhitelist)
 	t.Run("Groups", testGroupsInsert)
 	t.Run("Groups", testGroupsInsertWhitelist)
 	t.Run("JWTKeys", testJWTKeysInsert)
 	t.Run("JWTKeys", testJWTKeysInsertWhitelist)
+	t.Run("LoginFailures", testLoginFailuresInsert)
+	t.Run("LoginFailures", testLoginFailuresInsertWhitelist)
+	t.Run("PasswordHistories", testPasswordHistoriesInsert)
+	t.Run("PasswordHistories", testPasswordHistoriesInsertWhitelist)
 	t.Run("Passwords", testPasswordsInsert)
 	t.Run("Passwords", testPasswordsInsertWhitelist)
+	t.Run("RecoveryCodes", testRecoveryCodesInsert)
+	t.Run("RecoveryCodes", testRecoveryCodesInsertWhitelist)
+	t.Run("RefreshTokens", testRefreshTokensInsert)
+	t.Run("RefreshTokens", testRefreshTokensInsertWhitelist)
+	t.Run("RevokedTokens", testRevokedTokensInsert)
+	t.Run("RevokedTokens", testRevokedTokensInsertWhitelist)
+	t.Run("TotpSecrets", testTotpSecretsInsert)
+	t.Run("TotpSecrets", testTotpSecretsInsertWhitelist)
+	t.Run("UserIdentities", testUserIdentitiesInsert)
+	t.Run("UserIdentities", testUserIdentitiesInsertWhitelist)
 	t.Run("Users", testUsersInsert)
 	t.Run("Users", testUsersInsertWhitelist)
+	t.Run("WebauthnChallenges", testWebauthnChallengesInsert)
+	t.Run("WebauthnChallenges", testWebauthnChallengesInsertWhitelist)
+	t.Run("WebauthnCredentials", testWebauthnCredentialsInsert)
+	t.Run("WebauthnCredentials", testWebauthnCredentialsInsertWhitelist)
 }
 
 // TestToOne tests cannot be run in parallel
 // or deadlocks can occur.
 func TestToOne(t *testing.T) {
+	t.Run("AuthCodeToUserUsingUser", testAuthCodeToOneUserUsingUser)
+	t.Run("AuthCodeToClientUsingClient", testAuthCodeToOneClientUsingClient)
+	t.Run("ClientGrantToClientUsingClient", testClientGrantToOneClientUsingClient)
+	t.Run("PasswordHistoryToUserUsingUser", testPasswordHistoryToOneUserUsingUser)
 	t.Run("PasswordToUserUsingUser", testPasswordToOneUserUsingUser)
+	t.Run("RecoveryCodeToUserUsingUser", testRecoveryCodeToOneUserUsingUser)
+	t.Run("RefreshTokenToUserUsingUser", testRefreshTokenToOneUserUsingUser)
+	t.Run("TotpSecretToUserUsingUser", testTotpSecretToOneUserUsingUser)
+	t.Run("UserIdentityToUserUsingUser", testUserIdentityToOneUserUsingUser)
+	t.Run("WebauthnChallengeToUserUsingUser", testWebauthnChallengeToOneUserUsingUser)
+	t.Run("WebauthnCredentialToUserUsingUser", testWebauthnCredentialToOneUserUsingUser)
 }
 
 // TestOneToOne tests cannot be run in parallel
 // or deadlocks can occur.
 func TestOneToOne(t *testing.T) {
 	t.Run("UserToPasswordUsingPassword", testUserOneToOnePasswordUsingPassword)
+	t.Run("UserToTotpSecretUsingTotpSecret", testUserOneToOneTotpSecretUsingTotpSecret)
 }
 
 // TestToMany tests cannot be run in parallel
 // or deadlocks can occur.
 func TestToMany(t *testing.T) {
 	t.Run("AudienceToUsers", testAudienceToManyUsers)
+	t.Run("ClientToAuthCodes", testClientToManyAuthCodes)
+	t.Run("ClientToClientGrants", testClientToManyClientGrants)
 	t.Run("GroupToUsers", testGroupToManyUsers)
+	t.Run("UserToAuthCodes", testUserToManyAuthCodes)
+	t.Run("UserToPasswordHistories", testUserToManyPasswordHistories)
+	t.Run("UserToRecoveryCodes", testUserToManyRecoveryCodes)
+	t.Run("UserToRefreshTokens", testUserToManyRefreshTokens)
 	t.Run("UserToAudiences", testUserToManyAudiences)
 	t.Run("UserToGroups", testUserToManyGroups)
+	t.Run("UserToUserIdentities", testUserToManyUserIdentities)
+	t.Run("UserToWebauthnChallenges", testUserToManyWebauthnChallenges)
+	t.Run("UserToWebauthnCredentials", testUserToManyWebauthnCredentials)
 }
 
 // TestToOneSet tests cannot be run in parallel
 // or deadlocks can occur.
 func TestToOneSet(t *testing.T) {
+	t.Run("AuthCodeToUserUsingAuthCodes", testAuthCodeToOneSetOpUserUsingUser)
+	t.Run("AuthCodeToClientUsingAuthCodes", testAuthCodeToOneSetOpClientUsingClient)
+	t.Run("ClientGrantToClientUsingClientGrants", testClientGrantToOneSetOpClientUsingClient)
+	t.Run("PasswordHistoryToUserUsingPasswordHistories", testPasswordHistoryToOneSetOpUserUsingUser)
 	t.Run("PasswordToUserUsingPassword", testPasswordToOneSetOpUserUsingUser)
+	t.Run("RecoveryCodeToUserUsingRecoveryCodes", testRecoveryCodeToOneSetOpUserUsingUser)
+	t.Run("RefreshTokenToUserUsingRefreshTokens", testRefreshTokenToOneSetOpUserUsingUser)
+	t.Run("TotpSecretToUserUsingTotpSecret", testTotpSecretToOneSetOpUserUsingUser)
+	t.Run("UserIdentityToUserUsingUserIdentities", testUserIdentityToOneSetOpUserUsingUser)
+	t.Run("WebauthnChallengeToUserUsingWebauthnChallenges", testWebauthnChallengeToOneSetOpUserUsingUser)
+	t.Run("WebauthnCredentialToUserUsingWebauthnCredentials", testWebauthnCredentialToOneSetOpUserUsingUser)
 }
 
 // TestToOneRemove tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToOneRemove(t *testing.T) {}
+func TestToOneRemove(t *testing.T) {
+	t.Run("AuthCodeToClientUsingAuthCodes", testAuthCodeToOneRemoveOpClientUsingClient)
+	t.Run("WebauthnChallengeToUserUsingWebauthnChallenges", testWebauthnChallengeToOneRemoveOpUserUsingUser)
+}
 
 // TestOneToOneSet tests cannot be run in parallel
 // or deadlocks can occur.
 func TestOneToOneSet(t *testing.T) {
 	t.Run("UserToPasswordUsingPassword", testUserOneToOneSetOpPasswordUsingPassword)
+	t.Run("UserToTotpSecretUsingTotpSecret", testUserOneToOneSetOpTotpSecretUsingTotpSecret)
 }
 
 // TestOneToOneRemove tests cannot be run in parallel
@@ -157,65 +360,143 @@ func TestOneToOneRemove(t *testing.T) {}
 // or deadlocks can occur.
 func TestToManyAdd(t *testing.T) {
 	t.Run("AudienceToUsers", testAudienceToManyAddOpUsers)
+	t.Run("ClientToAuthCodes", testClientToManyAddOpAuthCodes)
+	t.Run("ClientToClientGrants", testClientToManyAddOpClientGrants)
 	t.Run("GroupToUsers", testGroupToManyAddOpUsers)
+	t.Run("UserToAuthCodes", testUserToManyAddOpAuthCodes)
+	t.Run("UserToPasswordHistories", testUserToManyAddOpPasswordHistories)
+	t.Run("UserToRecoveryCodes", testUserToManyAddOpRecoveryCodes)
+	t.Run("UserToRefreshTokens", testUserToManyAddOpRefreshTokens)
 	t.Run("UserToAudiences", testUserToManyAddOpAudiences)
 	t.Run("UserToGroups", testUserToManyAddOpGroups)
+	t.Run("UserToUserIdentities", testUserToManyAddOpUserIdentities)
+	t.Run("UserToWebauthnChallenges", testUserToManyAddOpWebauthnChallenges)
+	t.Run("UserToWebauthnCredentials", testUserToManyAddOpWebauthnCredentials)
 }
 
 // TestToManySet tests cannot be run in parallel
 // or deadlocks can occur.
 func TestToManySet(t *testing.T) {
 	t.Run("AudienceToUsers", testAudienceToManySetOpUsers)
+	t.Run("ClientToAuthCodes", testClientToManySetOpAuthCodes)
 	t.Run("GroupToUsers", testGroupToManySetOpUsers)
 	t.Run("UserToAudiences", testUserToManySetOpAudiences)
 	t.Run("UserToGroups", testUserToManySetOpGroups)
+	t.Run("UserToWebauthnChallenges", testUserToManySetOpWebauthnChallenges)
 }
 
 // TestToManyRemove tests cannot be run in parallel
 // or deadlocks can occur.
 func TestToManyRemove(t *testing.T) {
 	t.Run("AudienceToUsers", testAudienceToManyRemoveOpUsers)
+	t.Run("ClientToAuthCodes", testClientToManyRemoveOpAuthCodes)
 	t.Run("GroupToUsers", testGroupToManyRemoveOpUsers)
 	t.Run("UserToAudiences", testUserToManyRemoveOpAudiences)
 	t.Run("UserToGroups", testUserToManyRemoveOpGroups)
+	t.Run("UserToWebauthnChallenges", testUserToManyRemoveOpWebauthnChallenges)
 }
 
 func TestReload(t *testing.T) {
 	t.Run("Audiences", testAudiencesReload)
+	t.Run("AuthCodes", testAuthCodesReload)
+	t.Run("ClientGrants", testClientGrantsReload)
+	t.Run("Clients", testClientsReload)
+	t.Run("FederatedLogins", testFederatedLoginsReload)
 	t.Run("Groups", testGroupsReload)
 	t.Run("JWTKeys", testJWTKeysReload)
+	t.Run("LoginFailures", testLoginFailuresReload)
+	t.Run("PasswordHistories", testPasswordHistoriesReload)
 	t.Run("Passwords", testPasswordsReload)
+	t.Run("RecoveryCodes", testRecoveryCodesReload)
+	t.Run("RefreshTokens", testRefreshTokensReload)
+	t.Run("RevokedTokens", testRevokedTokensReload)
+	t.Run("TotpSecrets", testTotpSecretsReload)
+	t.Run("UserIdentities", testUserIdentitiesReload)
 	t.Run("Users", testUsersReload)
+	t.Run("WebauthnChallenges", testWebauthnChallengesReload)
+	t.Run("WebauthnCredentials", testWebauthnCredentialsReload)
 }
 
 func TestReloadAll(t *testing.T) {
 	t.Run("Audiences", testAudiencesReloadAll)
+	t.Run("AuthCodes", testAuthCodesReloadAll)
+	t.Run("ClientGrants", testClientGrantsReloadAll)
+	t.Run("Clients", testClientsReloadAll)
+	t.Run("FederatedLogins", testFederatedLoginsReloadAll)
 	t.Run("Groups", testGroupsReloadAll)
 	t.Run("JWTKeys", testJWTKeysReloadAll)
+	t.Run("LoginFailures", testLoginFailuresReloadAll)
+	t.Run("PasswordHistories", testPasswordHistoriesReloadAll)
 	t.Run("Passwords", testPasswordsReloadAll)
+	t.Run("RecoveryCodes", testRecoveryCodesReloadAll)
+	t.Run("RefreshTokens", testRefreshTokensReloadAll)
+	t.Run("RevokedTokens", testRevokedTokensReloadAll)
+	t.Run("TotpSecrets", testTotpSecretsReloadAll)
+	t.Run("UserIdentities", testUserIdentitiesReloadAll)
 	t.Run("Users", testUsersReloadAll)
+	t.Run("WebauthnChallenges", testWebauthnChallengesReloadAll)
+	t.Run("WebauthnCredentials", testWebauthnCredentialsReloadAll)
 }
 
 func TestSelect(t *testing.T) {
 	t.Run("Audiences", testAudiencesSelect)
+	t.Run("AuthCodes", testAuthCodesSelect)
+	t.Run("ClientGrants", testClientGrantsSelect)
+	t.Run("Clients", testClientsSelect)
+	t.Run("FederatedLogins", testFederatedLoginsSelect)
 	t.Run("Groups", testGroupsSelect)
 	t.Run("JWTKeys", testJWTKeysSelect)
+	t.Run("LoginFailures", testLoginFailuresSelect)
+	t.Run("PasswordHistories", testPasswordHistoriesSelect)
 	t.Run("Passwords", testPasswordsSelect)
+	t.Run("RecoveryCodes", testRecoveryCodesSelect)
+	t.Run("RefreshTokens", testRefreshTokensSelect)
+	t.Run("RevokedTokens", testRevokedTokensSelect)
+	t.Run("TotpSecrets", testTotpSecretsSelect)
+	t.Run("UserIdentities", testUserIdentitiesSelect)
 	t.Run("Users", testUsersSelect)
+	t.Run("WebauthnChallenges", testWebauthnChallengesSelect)
+	t.Run("WebauthnCredentials", testWebauthnCredentialsSelect)
 }
 
 func TestUpdate(t *testing.T) {
 	t.Run("Audiences", testAudiencesUpdate)
+	t.Run("AuthCodes", testAuthCodesUpdate)
+	t.Run("ClientGrants", testClientGrantsUpdate)
+	t.Run("Clients", testClientsUpdate)
+	t.Run("FederatedLogins", testFederatedLoginsUpdate)
 	t.Run("Groups", testGroupsUpdate)
 	t.Run("JWTKeys", testJWTKeysUpdate)
+	t.Run("LoginFailures", testLoginFailuresUpdate)
+	t.Run("PasswordHistories", testPasswordHistoriesUpdate)
 	t.Run("Passwords", testPasswordsUpdate)
+	t.Run("RecoveryCodes", testRecoveryCodesUpdate)
+	t.Run("RefreshTokens", testRefreshTokensUpdate)
+	t.Run("RevokedTokens", testRevokedTokensUpdate)
+	t.Run("TotpSecrets", testTotpSecretsUpdate)
+	t.Run("UserIdentities", testUserIdentitiesUpdate)
 	t.Run("Users", testUsersUpdate)
+	t.Run("WebauthnChallenges", testWebauthnChallengesUpdate)
+	t.Run("WebauthnCredentials", testWebauthnCredentialsUpdate)
 }
 
 func TestSliceUpdateAll(t *testing.T) {
 	t.Run("Audiences", testAudiencesSliceUpdateAll)
+	t.Run("AuthCodes", testAuthCodesSliceUpdateAll)
+	t.Run("ClientGrants", testClientGrantsSliceUpdateAll)
+	t.Run("Clients", testClientsSliceUpdateAll)
+	t.Run("FederatedLogins", testFederatedLoginsSliceUpdateAll)
 	t.Run("Groups", testGroupsSliceUpdateAll)
 	t.Run("JWTKeys", testJWTKeysSliceUpdateAll)
+	t.Run("LoginFailures", testLoginFailuresSliceUpdateAll)
+	t.Run("PasswordHistories", testPasswordHistoriesSliceUpdateAll)
 	t.Run("Passwords", testPasswordsSliceUpdateAll)
+	t.Run("RecoveryCodes", testRecoveryCodesSliceUpdateAll)
+	t.Run("RefreshTokens", testRefreshTokensSliceUpdateAll)
+	t.Run("RevokedTokens", testRevokedTokensSliceUpdateAll)
+	t.Run("TotpSecrets", testTotpSecretsSliceUpdateAll)
+	t.Run("UserIdentities", testUserIdentitiesSliceUpdateAll)
 	t.Run("Users", testUsersSliceUpdateAll)
+	t.Run("WebauthnChallenges", testWebauthnChallengesSliceUpdateAll)
+	t.Run("WebauthnCredentials", testWebauthnCredentialsSliceUpdateAll)
 }
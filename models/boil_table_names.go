@@ -4,19 +4,45 @@
 package models
 
 var TableNames = struct {
-	Audiences     string
-	Groups        string
-	JWTKeys       string
-	Passwords     string
-	UserAudiences string
-	UserGroups    string
-	Users         string
+	Audiences           string
+	AuthCodes           string
+	ClientGrants        string
+	Clients             string
+	FederatedLogins     string
+	Groups              string
+	JWTKeys             string
+	LoginFailures       string
+	PasswordHistory     string
+	Passwords           string
+	RecoveryCodes       string
+	RefreshTokens       string
+	RevokedTokens       string
+	TotpSecrets         string
+	UserAudiences       string
+	UserGroups          string
+	UserIdentities      string
+	Users               string
+	WebauthnChallenges  string
+	WebauthnCredentials string
 }{
-	Audiences:     "audiences",
-	Groups:        "groups",
-	JWTKeys:       "jwt_keys",
-	Passwords:     "passwords",
-	UserAudiences: "user_audiences",
-	UserGroups:    "user_groups",
-	Users:         "users",
+	Audiences:           "audiences",
+	AuthCodes:           "auth_codes",
+	ClientGrants:        "client_grants",
+	Clients:             "clients",
+	FederatedLogins:     "federated_logins",
+	Groups:              "groups",
+	JWTKeys:             "jwt_keys",
+	LoginFailures:       "login_failures",
+	PasswordHistory:     "password_history",
+	Passwords:           "passwords",
+	RecoveryCodes:       "recovery_codes",
+	RefreshTokens:       "refresh_tokens",
+	RevokedTokens:       "revoked_tokens",
+	TotpSecrets:         "totp_secrets",
+	UserAudiences:       "user_audiences",
+	UserGroups:          "user_groups",
+	UserIdentities:      "user_identities",
+	Users:               "users",
+	WebauthnChallenges:  "webauthn_challenges",
+	WebauthnCredentials: "webauthn_credentials",
 }
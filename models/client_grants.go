@@ -0,0 +1,1073 @@
+// Code generated by SQLBoiler 4.1.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// ClientGrant is an object representing the database table.
+type ClientGrant struct {
+	ID        int               `boil:"id" json:"id" toml:"id" yaml:"id"`
+	ClientID  int               `boil:"client_id" json:"client_id" toml:"client_id" yaml:"client_id"`
+	Family    string            `boil:"family" json:"family" toml:"family" yaml:"family"`
+	Scopes    types.StringArray `boil:"scopes" json:"scopes" toml:"scopes" yaml:"scopes"`
+	CreatedAt time.Time         `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	AuthTime  null.Time         `boil:"auth_time" json:"auth_time,omitempty" toml:"auth_time" yaml:"auth_time,omitempty"`
+
+	R *clientGrantR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L clientGrantL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var ClientGrantColumns = struct {
+	ID        string
+	ClientID  string
+	Family    string
+	Scopes    string
+	CreatedAt string
+	AuthTime  string
+}{
+	ID:        "id",
+	ClientID:  "client_id",
+	Family:    "family",
+	Scopes:    "scopes",
+	CreatedAt: "created_at",
+	AuthTime:  "auth_time",
+}
+
+// Generated where
+
+var ClientGrantWhere = struct {
+	ID        whereHelperint
+	ClientID  whereHelperint
+	Family    whereHelperstring
+	Scopes    whereHelpertypes_StringArray
+	CreatedAt whereHelpertime_Time
+	AuthTime  whereHelpernull_Time
+}{
+	ID:        whereHelperint{field: "\"auth\".\"client_grants\".\"id\""},
+	ClientID:  whereHelperint{field: "\"auth\".\"client_grants\".\"client_id\""},
+	Family:    whereHelperstring{field: "\"auth\".\"client_grants\".\"family\""},
+	Scopes:    whereHelpertypes_StringArray{field: "\"auth\".\"client_grants\".\"scopes\""},
+	CreatedAt: whereHelpertime_Time{field: "\"auth\".\"client_grants\".\"created_at\""},
+	AuthTime:  whereHelpernull_Time{field: "\"auth\".\"client_grants\".\"auth_time\""},
+}
+
+// ClientGrantRels is where relationship names are stored.
+var ClientGrantRels = struct {
+	Client string
+}{
+	Client: "Client",
+}
+
+// clientGrantR is where relationships are stored.
+type clientGrantR struct {
+	Client *Client `boil:"Client" json:"Client" toml:"Client" yaml:"Client"`
+}
+
+// NewStruct creates a new relationship struct
+func (*clientGrantR) NewStruct() *clientGrantR {
+	return &clientGrantR{}
+}
+
+// clientGrantL is where Load methods for each relationship are stored.
+type clientGrantL struct{}
+
+var (
+	clientGrantAllColumns            = []string{"id", "client_id", "family", "scopes", "created_at", "auth_time"}
+	clientGrantColumnsWithoutDefault = []string{"client_id", "family", "created_at", "auth_time"}
+	clientGrantColumnsWithDefault    = []string{"id", "scopes"}
+	clientGrantPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// ClientGrantSlice is an alias for a slice of pointers to ClientGrant.
+	// This should generally be used opposed to []ClientGrant.
+	ClientGrantSlice []*ClientGrant
+	// ClientGrantHook is the signature for custom ClientGrant hook methods
+	ClientGrantHook func(context.Context, boil.ContextExecutor, *ClientGrant) error
+
+	clientGrantQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	clientGrantType                 = reflect.TypeOf(&ClientGrant{})
+	clientGrantMapping              = queries.MakeStructMapping(clientGrantType)
+	clientGrantPrimaryKeyMapping, _ = queries.BindMapping(clientGrantType, clientGrantMapping, clientGrantPrimaryKeyColumns)
+	clientGrantInsertCacheMut       sync.RWMutex
+	clientGrantInsertCache          = make(map[string]insertCache)
+	clientGrantUpdateCacheMut       sync.RWMutex
+	clientGrantUpdateCache          = make(map[string]updateCache)
+	clientGrantUpsertCacheMut       sync.RWMutex
+	clientGrantUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var clientGrantBeforeInsertHooks []ClientGrantHook
+var clientGrantBeforeUpdateHooks []ClientGrantHook
+var clientGrantBeforeDeleteHooks []ClientGrantHook
+var clientGrantBeforeUpsertHooks []ClientGrantHook
+
+var clientGrantAfterInsertHooks []ClientGrantHook
+var clientGrantAfterSelectHooks []ClientGrantHook
+var clientGrantAfterUpdateHooks []ClientGrantHook
+var clientGrantAfterDeleteHooks []ClientGrantHook
+var clientGrantAfterUpsertHooks []ClientGrantHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *ClientGrant) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientGrantBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *ClientGrant) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientGrantBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *ClientGrant) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientGrantBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *ClientGrant) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientGrantBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *ClientGrant) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientGrantAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *ClientGrant) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientGrantAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *ClientGrant) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientGrantAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *ClientGrant) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientGrantAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *ClientGrant) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range clientGrantAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddClientGrantHook registers your hook function for all future operations.
+func AddClientGrantHook(hookPoint boil.HookPoint, clientGrantHook ClientGrantHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		clientGrantBeforeInsertHooks = append(clientGrantBeforeInsertHooks, clientGrantHook)
+	case boil.BeforeUpdateHook:
+		clientGrantBeforeUpdateHooks = append(clientGrantBeforeUpdateHooks, clientGrantHook)
+	case boil.BeforeDeleteHook:
+		clientGrantBeforeDeleteHooks = append(clientGrantBeforeDeleteHooks, clientGrantHook)
+	case boil.BeforeUpsertHook:
+		clientGrantBeforeUpsertHooks = append(clientGrantBeforeUpsertHooks, clientGrantHook)
+	case boil.AfterInsertHook:
+		clientGrantAfterInsertHooks = append(clientGrantAfterInsertHooks, clientGrantHook)
+	case boil.AfterSelectHook:
+		clientGrantAfterSelectHooks = append(clientGrantAfterSelectHooks, clientGrantHook)
+	case boil.AfterUpdateHook:
+		clientGrantAfterUpdateHooks = append(clientGrantAfterUpdateHooks, clientGrantHook)
+	case boil.AfterDeleteHook:
+		clientGrantAfterDeleteHooks = append(clientGrantAfterDeleteHooks, clientGrantHook)
+	case boil.AfterUpsertHook:
+		clientGrantAfterUpsertHooks = append(clientGrantAfterUpsertHooks, clientGrantHook)
+	}
+}
+
+// One returns a single clientGrant record from the query.
+func (q clientGrantQuery) One(ctx context.Context, exec boil.ContextExecutor) (*ClientGrant, error) {
+	o := &ClientGrant{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for client_grants")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all ClientGrant records from the query.
+func (q clientGrantQuery) All(ctx context.Context, exec boil.ContextExecutor) (ClientGrantSlice, error) {
+	var o []*ClientGrant
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to ClientGrant slice")
+	}
+
+	if len(clientGrantAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all ClientGrant records in the query.
+func (q clientGrantQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count client_grants rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q clientGrantQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if client_grants exists")
+	}
+
+	return count > 0, nil
+}
+
+// Client pointed to by the foreign key.
+func (o *ClientGrant) Client(mods ...qm.QueryMod) clientQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.ClientID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Clients(queryMods...)
+	queries.SetFrom(query.Query, "\"auth\".\"clients\"")
+
+	return query
+}
+
+// LoadClient allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (clientGrantL) LoadClient(ctx context.Context, e boil.ContextExecutor, singular bool, maybeClientGrant interface{}, mods queries.Applicator) error {
+	var slice []*ClientGrant
+	var object *ClientGrant
+
+	if singular {
+		object = maybeClientGrant.(*ClientGrant)
+	} else {
+		slice = *maybeClientGrant.(*[]*ClientGrant)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &clientGrantR{}
+		}
+		args = append(args, object.ClientID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &clientGrantR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ClientID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ClientID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`auth.clients`),
+		qm.WhereIn(`auth.clients.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Client")
+	}
+
+	var resultSlice []*Client
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Client")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for clients")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for clients")
+	}
+
+	if len(clientGrantAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Client = foreign
+		if foreign.R == nil {
+			foreign.R = &clientR{}
+		}
+		foreign.R.ClientGrants = append(foreign.R.ClientGrants, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ClientID == foreign.ID {
+				local.R.Client = foreign
+				if foreign.R == nil {
+					foreign.R = &clientR{}
+				}
+				foreign.R.ClientGrants = append(foreign.R.ClientGrants, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetClient of the clientGrant to the related item.
+// Sets o.R.Client to related.
+// Adds o to related.R.ClientGrants.
+func (o *ClientGrant) SetClient(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Client) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"auth\".\"client_grants\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"client_id"}),
+		strmangle.WhereClause("\"", "\"", 2, clientGrantPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.ClientID = related.ID
+	if o.R == nil {
+		o.R = &clientGrantR{
+			Client: related,
+		}
+	} else {
+		o.R.Client = related
+	}
+
+	if related.R == nil {
+		related.R = &clientR{
+			ClientGrants: ClientGrantSlice{o},
+		}
+	} else {
+		related.R.ClientGrants = append(related.R.ClientGrants, o)
+	}
+
+	return nil
+}
+
+// ClientGrants retrieves all the records using an executor.
+func ClientGrants(mods ...qm.QueryMod) clientGrantQuery {
+	mods = append(mods, qm.From("\"auth\".\"client_grants\""))
+	return clientGrantQuery{NewQuery(mods...)}
+}
+
+// FindClientGrant retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindClientGrant(ctx context.Context, exec boil.ContextExecutor, iD int, selectCols ...string) (*ClientGrant, error) {
+	clientGrantObj := &ClientGrant{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"auth\".\"client_grants\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, clientGrantObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from client_grants")
+	}
+
+	return clientGrantObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *ClientGrant) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no client_grants provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(clientGrantColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	clientGrantInsertCacheMut.RLock()
+	cache, cached := clientGrantInsertCache[key]
+	clientGrantInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			clientGrantAllColumns,
+			clientGrantColumnsWithDefault,
+			clientGrantColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(clientGrantType, clientGrantMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(clientGrantType, clientGrantMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"auth\".\"client_grants\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"auth\".\"client_grants\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into client_grants")
+	}
+
+	if !cached {
+		clientGrantInsertCacheMut.Lock()
+		clientGrantInsertCache[key] = cache
+		clientGrantInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the ClientGrant.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *ClientGrant) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	clientGrantUpdateCacheMut.RLock()
+	cache, cached := clientGrantUpdateCache[key]
+	clientGrantUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			clientGrantAllColumns,
+			clientGrantPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update client_grants, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"auth\".\"client_grants\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, clientGrantPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(clientGrantType, clientGrantMapping, append(wl, clientGrantPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update client_grants row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for client_grants")
+	}
+
+	if !cached {
+		clientGrantUpdateCacheMut.Lock()
+		clientGrantUpdateCache[key] = cache
+		clientGrantUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q clientGrantQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for client_grants")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for client_grants")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o ClientGrantSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), clientGrantPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"auth\".\"client_grants\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, clientGrantPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in clientGrant slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all clientGrant")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *ClientGrant) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no client_grants provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(clientGrantColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	clientGrantUpsertCacheMut.RLock()
+	cache, cached := clientGrantUpsertCache[key]
+	clientGrantUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			clientGrantAllColumns,
+			clientGrantColumnsWithDefault,
+			clientGrantColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			clientGrantAllColumns,
+			clientGrantPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert client_grants, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(clientGrantPrimaryKeyColumns))
+			copy(conflict, clientGrantPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"auth\".\"client_grants\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(clientGrantType, clientGrantMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(clientGrantType, clientGrantMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert client_grants")
+	}
+
+	if !cached {
+		clientGrantUpsertCacheMut.Lock()
+		clientGrantUpsertCache[key] = cache
+		clientGrantUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single ClientGrant record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *ClientGrant) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no ClientGrant provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), clientGrantPrimaryKeyMapping)
+	sql := "DELETE FROM \"auth\".\"client_grants\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from client_grants")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for client_grants")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q clientGrantQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no clientGrantQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from client_grants")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for client_grants")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o ClientGrantSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(clientGrantBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), clientGrantPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"auth\".\"client_grants\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, clientGrantPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from clientGrant slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for client_grants")
+	}
+
+	if len(clientGrantAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *ClientGrant) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindClientGrant(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *ClientGrantSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := ClientGrantSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), clientGrantPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"auth\".\"client_grants\".* FROM \"auth\".\"client_grants\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, clientGrantPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in ClientGrantSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// ClientGrantExists checks if the ClientGrant row exists.
+func ClientGrantExists(ctx context.Context, exec boil.ContextExecutor, iD int) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"auth\".\"client_grants\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if client_grants exists")
+	}
+
+	return exists, nil
+}
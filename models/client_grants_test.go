@@ -0,0 +1,841 @@
+// Code generated by SQLBoiler 4.1.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/randomize"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testClientGrants(t *testing.T) {
+	t.Parallel()
+
+	query := ClientGrants()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testClientGrantsDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ClientGrant{}
+	if err = randomize.Struct(seed, o, clientGrantDBTypes, true, clientGrantColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ClientGrant struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := ClientGrants().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testClientGrantsQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ClientGrant{}
+	if err = randomize.Struct(seed, o, clientGrantDBTypes, true, clientGrantColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ClientGrant struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := ClientGrants().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := ClientGrants().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testClientGrantsSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ClientGrant{}
+	if err = randomize.Struct(seed, o, clientGrantDBTypes, true, clientGrantColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ClientGrant struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := ClientGrantSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := ClientGrants().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testClientGrantsExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ClientGrant{}
+	if err = randomize.Struct(seed, o, clientGrantDBTypes, true, clientGrantColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ClientGrant struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := ClientGrantExists(ctx, tx, o.ID)
+	if err != nil {
+		t.Errorf("Unable to check if ClientGrant exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected ClientGrantExists to return true, but got false.")
+	}
+}
+
+func testClientGrantsFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ClientGrant{}
+	if err = randomize.Struct(seed, o, clientGrantDBTypes, true, clientGrantColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ClientGrant struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	clientGrantFound, err := FindClientGrant(ctx, tx, o.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if clientGrantFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testClientGrantsBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ClientGrant{}
+	if err = randomize.Struct(seed, o, clientGrantDBTypes, true, clientGrantColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ClientGrant struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = ClientGrants().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testClientGrantsOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ClientGrant{}
+	if err = randomize.Struct(seed, o, clientGrantDBTypes, true, clientGrantColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ClientGrant struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := ClientGrants().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testClientGrantsAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	clientGrantOne := &ClientGrant{}
+	clientGrantTwo := &ClientGrant{}
+	if err = randomize.Struct(seed, clientGrantOne, clientGrantDBTypes, false, clientGrantColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ClientGrant struct: %s", err)
+	}
+	if err = randomize.Struct(seed, clientGrantTwo, clientGrantDBTypes, false, clientGrantColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ClientGrant struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = clientGrantOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = clientGrantTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := ClientGrants().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testClientGrantsCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	clientGrantOne := &ClientGrant{}
+	clientGrantTwo := &ClientGrant{}
+	if err = randomize.Struct(seed, clientGrantOne, clientGrantDBTypes, false, clientGrantColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ClientGrant struct: %s", err)
+	}
+	if err = randomize.Struct(seed, clientGrantTwo, clientGrantDBTypes, false, clientGrantColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ClientGrant struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = clientGrantOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = clientGrantTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := ClientGrants().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func clientGrantBeforeInsertHook(ctx context.Context, e boil.ContextExecutor, o *ClientGrant) error {
+	*o = ClientGrant{}
+	return nil
+}
+
+func clientGrantAfterInsertHook(ctx context.Context, e boil.ContextExecutor, o *ClientGrant) error {
+	*o = ClientGrant{}
+	return nil
+}
+
+func clientGrantAfterSelectHook(ctx context.Context, e boil.ContextExecutor, o *ClientGrant) error {
+	*o = ClientGrant{}
+	return nil
+}
+
+func clientGrantBeforeUpdateHook(ctx context.Context, e boil.ContextExecutor, o *ClientGrant) error {
+	*o = ClientGrant{}
+	return nil
+}
+
+func clientGrantAfterUpdateHook(ctx context.Context, e boil.ContextExecutor, o *ClientGrant) error {
+	*o = ClientGrant{}
+	return nil
+}
+
+func clientGrantBeforeDeleteHook(ctx context.Context, e boil.ContextExecutor, o *ClientGrant) error {
+	*o = ClientGrant{}
+	return nil
+}
+
+func clientGrantAfterDeleteHook(ctx context.Context, e boil.ContextExecutor, o *ClientGrant) error {
+	*o = ClientGrant{}
+	return nil
+}
+
+func clientGrantBeforeUpsertHook(ctx context.Context, e boil.ContextExecutor, o *ClientGrant) error {
+	*o = ClientGrant{}
+	return nil
+}
+
+func clientGrantAfterUpsertHook(ctx context.Context, e boil.ContextExecutor, o *ClientGrant) error {
+	*o = ClientGrant{}
+	return nil
+}
+
+func testClientGrantsHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	ctx := context.Background()
+	empty := &ClientGrant{}
+	o := &ClientGrant{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, clientGrantDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize ClientGrant object: %s", err)
+	}
+
+	AddClientGrantHook(boil.BeforeInsertHook, clientGrantBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	clientGrantBeforeInsertHooks = []ClientGrantHook{}
+
+	AddClientGrantHook(boil.AfterInsertHook, clientGrantAfterInsertHook)
+	if err = o.doAfterInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	clientGrantAfterInsertHooks = []ClientGrantHook{}
+
+	AddClientGrantHook(boil.AfterSelectHook, clientGrantAfterSelectHook)
+	if err = o.doAfterSelectHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	clientGrantAfterSelectHooks = []ClientGrantHook{}
+
+	AddClientGrantHook(boil.BeforeUpdateHook, clientGrantBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	clientGrantBeforeUpdateHooks = []ClientGrantHook{}
+
+	AddClientGrantHook(boil.AfterUpdateHook, clientGrantAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	clientGrantAfterUpdateHooks = []ClientGrantHook{}
+
+	AddClientGrantHook(boil.BeforeDeleteHook, clientGrantBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	clientGrantBeforeDeleteHooks = []ClientGrantHook{}
+
+	AddClientGrantHook(boil.AfterDeleteHook, clientGrantAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	clientGrantAfterDeleteHooks = []ClientGrantHook{}
+
+	AddClientGrantHook(boil.BeforeUpsertHook, clientGrantBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	clientGrantBeforeUpsertHooks = []ClientGrantHook{}
+
+	AddClientGrantHook(boil.AfterUpsertHook, clientGrantAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	clientGrantAfterUpsertHooks = []ClientGrantHook{}
+}
+
+func testClientGrantsInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ClientGrant{}
+	if err = randomize.Struct(seed, o, clientGrantDBTypes, true, clientGrantColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ClientGrant struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := ClientGrants().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testClientGrantsInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ClientGrant{}
+	if err = randomize.Struct(seed, o, clientGrantDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize ClientGrant struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(clientGrantColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := ClientGrants().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testClientGrantToOneClientUsingClient(t *testing.T) {
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var local ClientGrant
+	var foreign Client
+
+	seed := randomize.NewSeed()
+	if err := randomize.Struct(seed, &local, clientGrantDBTypes, false, clientGrantColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ClientGrant struct: %s", err)
+	}
+	if err := randomize.Struct(seed, &foreign, clientDBTypes, false, clientColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Client struct: %s", err)
+	}
+
+	if err := foreign.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	local.ClientID = foreign.ID
+	if err := local.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := local.Client().One(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if check.ID != foreign.ID {
+		t.Errorf("want: %v, got %v", foreign.ID, check.ID)
+	}
+
+	slice := ClientGrantSlice{&local}
+	if err = local.L.LoadClient(ctx, tx, false, (*[]*ClientGrant)(&slice), nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.Client == nil {
+		t.Error("struct should have been eager loaded")
+	}
+
+	local.R.Client = nil
+	if err = local.L.LoadClient(ctx, tx, true, &local, nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.Client == nil {
+		t.Error("struct should have been eager loaded")
+	}
+}
+
+func testClientGrantToOneSetOpClientUsingClient(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a ClientGrant
+	var b, c Client
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, clientGrantDBTypes, false, strmangle.SetComplement(clientGrantPrimaryKeyColumns, clientGrantColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &b, clientDBTypes, false, strmangle.SetComplement(clientPrimaryKeyColumns, clientColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &c, clientDBTypes, false, strmangle.SetComplement(clientPrimaryKeyColumns, clientColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, x := range []*Client{&b, &c} {
+		err = a.SetClient(ctx, tx, i != 0, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if a.R.Client != x {
+			t.Error("relationship struct not set to correct value")
+		}
+
+		if x.R.ClientGrants[0] != &a {
+			t.Error("failed to append to foreign relationship struct")
+		}
+		if a.ClientID != x.ID {
+			t.Error("foreign key was wrong value", a.ClientID)
+		}
+
+		zero := reflect.Zero(reflect.TypeOf(a.ClientID))
+		reflect.Indirect(reflect.ValueOf(&a.ClientID)).Set(zero)
+
+		if err = a.Reload(ctx, tx); err != nil {
+			t.Fatal("failed to reload", err)
+		}
+
+		if a.ClientID != x.ID {
+			t.Error("foreign key was wrong value", a.ClientID, x.ID)
+		}
+	}
+}
+
+func testClientGrantsReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ClientGrant{}
+	if err = randomize.Struct(seed, o, clientGrantDBTypes, true, clientGrantColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ClientGrant struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testClientGrantsReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ClientGrant{}
+	if err = randomize.Struct(seed, o, clientGrantDBTypes, true, clientGrantColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ClientGrant struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := ClientGrantSlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testClientGrantsSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ClientGrant{}
+	if err = randomize.Struct(seed, o, clientGrantDBTypes, true, clientGrantColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ClientGrant struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := ClientGrants().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	clientGrantDBTypes = map[string]string{`ID`: `integer`, `ClientID`: `integer`, `Family`: `character varying`, `Scopes`: `ARRAYtext`, `CreatedAt`: `timestamp with time zone`, `AuthTime`: `timestamp with time zone`}
+	_                  = bytes.MinRead
+)
+
+func testClientGrantsUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(clientGrantPrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(clientGrantAllColumns) == len(clientGrantPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ClientGrant{}
+	if err = randomize.Struct(seed, o, clientGrantDBTypes, true, clientGrantColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ClientGrant struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := ClientGrants().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, clientGrantDBTypes, true, clientGrantPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize ClientGrant struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testClientGrantsSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(clientGrantAllColumns) == len(clientGrantPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ClientGrant{}
+	if err = randomize.Struct(seed, o, clientGrantDBTypes, true, clientGrantColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ClientGrant struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := ClientGrants().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, clientGrantDBTypes, true, clientGrantPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize ClientGrant struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(clientGrantAllColumns, clientGrantPrimaryKeyColumns) {
+		fields = clientGrantAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			clientGrantAllColumns,
+			clientGrantPrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := ClientGrantSlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testClientGrantsUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(clientGrantAllColumns) == len(clientGrantPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := ClientGrant{}
+	if err = randomize.Struct(seed, &o, clientGrantDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize ClientGrant struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert ClientGrant: %s", err)
+	}
+
+	count, err := ClientGrants().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, clientGrantDBTypes, false, clientGrantPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize ClientGrant struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert ClientGrant: %s", err)
+	}
+
+	count, err = ClientGrants().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
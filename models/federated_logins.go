@@ -0,0 +1,912 @@
+// Code generated by SQLBoiler 4.1.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// FederatedLogin is an object representing the database table.
+type FederatedLogin struct {
+	ID           int       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Provider     string    `boil:"provider" json:"provider" toml:"provider" yaml:"provider"`
+	StateHash    []byte    `boil:"state_hash" json:"state_hash" toml:"state_hash" yaml:"state_hash"`
+	Nonce        string    `boil:"nonce" json:"nonce" toml:"nonce" yaml:"nonce"`
+	CodeVerifier string    `boil:"code_verifier" json:"code_verifier" toml:"code_verifier" yaml:"code_verifier"`
+	RedirectURI  string    `boil:"redirect_uri" json:"redirect_uri" toml:"redirect_uri" yaml:"redirect_uri"`
+	ExpiresAt    time.Time `boil:"expires_at" json:"expires_at" toml:"expires_at" yaml:"expires_at"`
+	CreatedAt    time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+
+	R *federatedLoginR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L federatedLoginL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var FederatedLoginColumns = struct {
+	ID           string
+	Provider     string
+	StateHash    string
+	Nonce        string
+	CodeVerifier string
+	RedirectURI  string
+	ExpiresAt    string
+	CreatedAt    string
+}{
+	ID:           "id",
+	Provider:     "provider",
+	StateHash:    "state_hash",
+	Nonce:        "nonce",
+	CodeVerifier: "code_verifier",
+	RedirectURI:  "redirect_uri",
+	ExpiresAt:    "expires_at",
+	CreatedAt:    "created_at",
+}
+
+// Generated where
+
+var FederatedLoginWhere = struct {
+	ID           whereHelperint
+	Provider     whereHelperstring
+	StateHash    whereHelper__byte
+	Nonce        whereHelperstring
+	CodeVerifier whereHelperstring
+	RedirectURI  whereHelperstring
+	ExpiresAt    whereHelpertime_Time
+	CreatedAt    whereHelpertime_Time
+}{
+	ID:           whereHelperint{field: "\"auth\".\"federated_logins\".\"id\""},
+	Provider:     whereHelperstring{field: "\"auth\".\"federated_logins\".\"provider\""},
+	StateHash:    whereHelper__byte{field: "\"auth\".\"federated_logins\".\"state_hash\""},
+	Nonce:        whereHelperstring{field: "\"auth\".\"federated_logins\".\"nonce\""},
+	CodeVerifier: whereHelperstring{field: "\"auth\".\"federated_logins\".\"code_verifier\""},
+	RedirectURI:  whereHelperstring{field: "\"auth\".\"federated_logins\".\"redirect_uri\""},
+	ExpiresAt:    whereHelpertime_Time{field: "\"auth\".\"federated_logins\".\"expires_at\""},
+	CreatedAt:    whereHelpertime_Time{field: "\"auth\".\"federated_logins\".\"created_at\""},
+}
+
+// FederatedLoginRels is where relationship names are stored.
+var FederatedLoginRels = struct {
+}{}
+
+// federatedLoginR is where relationships are stored.
+type federatedLoginR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*federatedLoginR) NewStruct() *federatedLoginR {
+	return &federatedLoginR{}
+}
+
+// federatedLoginL is where Load methods for each relationship are stored.
+type federatedLoginL struct{}
+
+var (
+	federatedLoginAllColumns            = []string{"id", "provider", "state_hash", "nonce", "code_verifier", "redirect_uri", "expires_at", "created_at"}
+	federatedLoginColumnsWithoutDefault = []string{"provider", "state_hash", "nonce", "code_verifier", "redirect_uri", "expires_at", "created_at"}
+	federatedLoginColumnsWithDefault    = []string{"id"}
+	federatedLoginPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// FederatedLoginSlice is an alias for a slice of pointers to FederatedLogin.
+	// This should generally be used opposed to []FederatedLogin.
+	FederatedLoginSlice []*FederatedLogin
+	// FederatedLoginHook is the signature for custom FederatedLogin hook methods
+	FederatedLoginHook func(context.Context, boil.ContextExecutor, *FederatedLogin) error
+
+	federatedLoginQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	federatedLoginType                 = reflect.TypeOf(&FederatedLogin{})
+	federatedLoginMapping              = queries.MakeStructMapping(federatedLoginType)
+	federatedLoginPrimaryKeyMapping, _ = queries.BindMapping(federatedLoginType, federatedLoginMapping, federatedLoginPrimaryKeyColumns)
+	federatedLoginInsertCacheMut       sync.RWMutex
+	federatedLoginInsertCache          = make(map[string]insertCache)
+	federatedLoginUpdateCacheMut       sync.RWMutex
+	federatedLoginUpdateCache          = make(map[string]updateCache)
+	federatedLoginUpsertCacheMut       sync.RWMutex
+	federatedLoginUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var federatedLoginBeforeInsertHooks []FederatedLoginHook
+var federatedLoginBeforeUpdateHooks []FederatedLoginHook
+var federatedLoginBeforeDeleteHooks []FederatedLoginHook
+var federatedLoginBeforeUpsertHooks []FederatedLoginHook
+
+var federatedLoginAfterInsertHooks []FederatedLoginHook
+var federatedLoginAfterSelectHooks []FederatedLoginHook
+var federatedLoginAfterUpdateHooks []FederatedLoginHook
+var federatedLoginAfterDeleteHooks []FederatedLoginHook
+var federatedLoginAfterUpsertHooks []FederatedLoginHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *FederatedLogin) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range federatedLoginBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *FederatedLogin) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range federatedLoginBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *FederatedLogin) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range federatedLoginBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *FederatedLogin) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range federatedLoginBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *FederatedLogin) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range federatedLoginAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *FederatedLogin) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range federatedLoginAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *FederatedLogin) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range federatedLoginAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *FederatedLogin) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range federatedLoginAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *FederatedLogin) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range federatedLoginAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddFederatedLoginHook registers your hook function for all future operations.
+func AddFederatedLoginHook(hookPoint boil.HookPoint, federatedLoginHook FederatedLoginHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		federatedLoginBeforeInsertHooks = append(federatedLoginBeforeInsertHooks, federatedLoginHook)
+	case boil.BeforeUpdateHook:
+		federatedLoginBeforeUpdateHooks = append(federatedLoginBeforeUpdateHooks, federatedLoginHook)
+	case boil.BeforeDeleteHook:
+		federatedLoginBeforeDeleteHooks = append(federatedLoginBeforeDeleteHooks, federatedLoginHook)
+	case boil.BeforeUpsertHook:
+		federatedLoginBeforeUpsertHooks = append(federatedLoginBeforeUpsertHooks, federatedLoginHook)
+	case boil.AfterInsertHook:
+		federatedLoginAfterInsertHooks = append(federatedLoginAfterInsertHooks, federatedLoginHook)
+	case boil.AfterSelectHook:
+		federatedLoginAfterSelectHooks = append(federatedLoginAfterSelectHooks, federatedLoginHook)
+	case boil.AfterUpdateHook:
+		federatedLoginAfterUpdateHooks = append(federatedLoginAfterUpdateHooks, federatedLoginHook)
+	case boil.AfterDeleteHook:
+		federatedLoginAfterDeleteHooks = append(federatedLoginAfterDeleteHooks, federatedLoginHook)
+	case boil.AfterUpsertHook:
+		federatedLoginAfterUpsertHooks = append(federatedLoginAfterUpsertHooks, federatedLoginHook)
+	}
+}
+
+// One returns a single federatedLogin record from the query.
+func (q federatedLoginQuery) One(ctx context.Context, exec boil.ContextExecutor) (*FederatedLogin, error) {
+	o := &FederatedLogin{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for federated_logins")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all FederatedLogin records from the query.
+func (q federatedLoginQuery) All(ctx context.Context, exec boil.ContextExecutor) (FederatedLoginSlice, error) {
+	var o []*FederatedLogin
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to FederatedLogin slice")
+	}
+
+	if len(federatedLoginAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all FederatedLogin records in the query.
+func (q federatedLoginQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count federated_logins rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q federatedLoginQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if federated_logins exists")
+	}
+
+	return count > 0, nil
+}
+
+// FederatedLogins retrieves all the records using an executor.
+func FederatedLogins(mods ...qm.QueryMod) federatedLoginQuery {
+	mods = append(mods, qm.From("\"auth\".\"federated_logins\""))
+	return federatedLoginQuery{NewQuery(mods...)}
+}
+
+// FindFederatedLogin retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindFederatedLogin(ctx context.Context, exec boil.ContextExecutor, iD int, selectCols ...string) (*FederatedLogin, error) {
+	federatedLoginObj := &FederatedLogin{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"auth\".\"federated_logins\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, federatedLoginObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from federated_logins")
+	}
+
+	return federatedLoginObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *FederatedLogin) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no federated_logins provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(federatedLoginColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	federatedLoginInsertCacheMut.RLock()
+	cache, cached := federatedLoginInsertCache[key]
+	federatedLoginInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			federatedLoginAllColumns,
+			federatedLoginColumnsWithDefault,
+			federatedLoginColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(federatedLoginType, federatedLoginMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(federatedLoginType, federatedLoginMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"auth\".\"federated_logins\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"auth\".\"federated_logins\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into federated_logins")
+	}
+
+	if !cached {
+		federatedLoginInsertCacheMut.Lock()
+		federatedLoginInsertCache[key] = cache
+		federatedLoginInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the FederatedLogin.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *FederatedLogin) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	federatedLoginUpdateCacheMut.RLock()
+	cache, cached := federatedLoginUpdateCache[key]
+	federatedLoginUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			federatedLoginAllColumns,
+			federatedLoginPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update federated_logins, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"auth\".\"federated_logins\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, federatedLoginPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(federatedLoginType, federatedLoginMapping, append(wl, federatedLoginPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update federated_logins row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for federated_logins")
+	}
+
+	if !cached {
+		federatedLoginUpdateCacheMut.Lock()
+		federatedLoginUpdateCache[key] = cache
+		federatedLoginUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q federatedLoginQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for federated_logins")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for federated_logins")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o FederatedLoginSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), federatedLoginPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"auth\".\"federated_logins\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, federatedLoginPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in federatedLogin slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all federatedLogin")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *FederatedLogin) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no federated_logins provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(federatedLoginColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	federatedLoginUpsertCacheMut.RLock()
+	cache, cached := federatedLoginUpsertCache[key]
+	federatedLoginUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			federatedLoginAllColumns,
+			federatedLoginColumnsWithDefault,
+			federatedLoginColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			federatedLoginAllColumns,
+			federatedLoginPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert federated_logins, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(federatedLoginPrimaryKeyColumns))
+			copy(conflict, federatedLoginPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"auth\".\"federated_logins\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(federatedLoginType, federatedLoginMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(federatedLoginType, federatedLoginMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert federated_logins")
+	}
+
+	if !cached {
+		federatedLoginUpsertCacheMut.Lock()
+		federatedLoginUpsertCache[key] = cache
+		federatedLoginUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single FederatedLogin record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *FederatedLogin) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no FederatedLogin provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), federatedLoginPrimaryKeyMapping)
+	sql := "DELETE FROM \"auth\".\"federated_logins\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from federated_logins")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for federated_logins")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q federatedLoginQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no federatedLoginQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from federated_logins")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for federated_logins")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o FederatedLoginSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(federatedLoginBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), federatedLoginPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"auth\".\"federated_logins\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, federatedLoginPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from federatedLogin slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for federated_logins")
+	}
+
+	if len(federatedLoginAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *FederatedLogin) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindFederatedLogin(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *FederatedLoginSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := FederatedLoginSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), federatedLoginPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"auth\".\"federated_logins\".* FROM \"auth\".\"federated_logins\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, federatedLoginPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in FederatedLoginSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// FederatedLoginExists checks if the FederatedLogin row exists.
+func FederatedLoginExists(ctx context.Context, exec boil.ContextExecutor, iD int) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"auth\".\"federated_logins\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if federated_logins exists")
+	}
+
+	return exists, nil
+}
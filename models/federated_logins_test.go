@@ -0,0 +1,732 @@
+// Code generated by SQLBoiler 4.1.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/randomize"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testFederatedLogins(t *testing.T) {
+	t.Parallel()
+
+	query := FederatedLogins()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testFederatedLoginsDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &FederatedLogin{}
+	if err = randomize.Struct(seed, o, federatedLoginDBTypes, true, federatedLoginColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FederatedLogin struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := FederatedLogins().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testFederatedLoginsQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &FederatedLogin{}
+	if err = randomize.Struct(seed, o, federatedLoginDBTypes, true, federatedLoginColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FederatedLogin struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := FederatedLogins().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := FederatedLogins().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testFederatedLoginsSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &FederatedLogin{}
+	if err = randomize.Struct(seed, o, federatedLoginDBTypes, true, federatedLoginColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FederatedLogin struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := FederatedLoginSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := FederatedLogins().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testFederatedLoginsExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &FederatedLogin{}
+	if err = randomize.Struct(seed, o, federatedLoginDBTypes, true, federatedLoginColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FederatedLogin struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := FederatedLoginExists(ctx, tx, o.ID)
+	if err != nil {
+		t.Errorf("Unable to check if FederatedLogin exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected FederatedLoginExists to return true, but got false.")
+	}
+}
+
+func testFederatedLoginsFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &FederatedLogin{}
+	if err = randomize.Struct(seed, o, federatedLoginDBTypes, true, federatedLoginColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FederatedLogin struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	federatedLoginFound, err := FindFederatedLogin(ctx, tx, o.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if federatedLoginFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testFederatedLoginsBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &FederatedLogin{}
+	if err = randomize.Struct(seed, o, federatedLoginDBTypes, true, federatedLoginColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FederatedLogin struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = FederatedLogins().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testFederatedLoginsOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &FederatedLogin{}
+	if err = randomize.Struct(seed, o, federatedLoginDBTypes, true, federatedLoginColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FederatedLogin struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := FederatedLogins().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testFederatedLoginsAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	federatedLoginOne := &FederatedLogin{}
+	federatedLoginTwo := &FederatedLogin{}
+	if err = randomize.Struct(seed, federatedLoginOne, federatedLoginDBTypes, false, federatedLoginColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FederatedLogin struct: %s", err)
+	}
+	if err = randomize.Struct(seed, federatedLoginTwo, federatedLoginDBTypes, false, federatedLoginColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FederatedLogin struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = federatedLoginOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = federatedLoginTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := FederatedLogins().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testFederatedLoginsCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	federatedLoginOne := &FederatedLogin{}
+	federatedLoginTwo := &FederatedLogin{}
+	if err = randomize.Struct(seed, federatedLoginOne, federatedLoginDBTypes, false, federatedLoginColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FederatedLogin struct: %s", err)
+	}
+	if err = randomize.Struct(seed, federatedLoginTwo, federatedLoginDBTypes, false, federatedLoginColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FederatedLogin struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = federatedLoginOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = federatedLoginTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := FederatedLogins().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func federatedLoginBeforeInsertHook(ctx context.Context, e boil.ContextExecutor, o *FederatedLogin) error {
+	*o = FederatedLogin{}
+	return nil
+}
+
+func federatedLoginAfterInsertHook(ctx context.Context, e boil.ContextExecutor, o *FederatedLogin) error {
+	*o = FederatedLogin{}
+	return nil
+}
+
+func federatedLoginAfterSelectHook(ctx context.Context, e boil.ContextExecutor, o *FederatedLogin) error {
+	*o = FederatedLogin{}
+	return nil
+}
+
+func federatedLoginBeforeUpdateHook(ctx context.Context, e boil.ContextExecutor, o *FederatedLogin) error {
+	*o = FederatedLogin{}
+	return nil
+}
+
+func federatedLoginAfterUpdateHook(ctx context.Context, e boil.ContextExecutor, o *FederatedLogin) error {
+	*o = FederatedLogin{}
+	return nil
+}
+
+func federatedLoginBeforeDeleteHook(ctx context.Context, e boil.ContextExecutor, o *FederatedLogin) error {
+	*o = FederatedLogin{}
+	return nil
+}
+
+func federatedLoginAfterDeleteHook(ctx context.Context, e boil.ContextExecutor, o *FederatedLogin) error {
+	*o = FederatedLogin{}
+	return nil
+}
+
+func federatedLoginBeforeUpsertHook(ctx context.Context, e boil.ContextExecutor, o *FederatedLogin) error {
+	*o = FederatedLogin{}
+	return nil
+}
+
+func federatedLoginAfterUpsertHook(ctx context.Context, e boil.ContextExecutor, o *FederatedLogin) error {
+	*o = FederatedLogin{}
+	return nil
+}
+
+func testFederatedLoginsHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	ctx := context.Background()
+	empty := &FederatedLogin{}
+	o := &FederatedLogin{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, federatedLoginDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize FederatedLogin object: %s", err)
+	}
+
+	AddFederatedLoginHook(boil.BeforeInsertHook, federatedLoginBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	federatedLoginBeforeInsertHooks = []FederatedLoginHook{}
+
+	AddFederatedLoginHook(boil.AfterInsertHook, federatedLoginAfterInsertHook)
+	if err = o.doAfterInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	federatedLoginAfterInsertHooks = []FederatedLoginHook{}
+
+	AddFederatedLoginHook(boil.AfterSelectHook, federatedLoginAfterSelectHook)
+	if err = o.doAfterSelectHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	federatedLoginAfterSelectHooks = []FederatedLoginHook{}
+
+	AddFederatedLoginHook(boil.BeforeUpdateHook, federatedLoginBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	federatedLoginBeforeUpdateHooks = []FederatedLoginHook{}
+
+	AddFederatedLoginHook(boil.AfterUpdateHook, federatedLoginAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	federatedLoginAfterUpdateHooks = []FederatedLoginHook{}
+
+	AddFederatedLoginHook(boil.BeforeDeleteHook, federatedLoginBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	federatedLoginBeforeDeleteHooks = []FederatedLoginHook{}
+
+	AddFederatedLoginHook(boil.AfterDeleteHook, federatedLoginAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	federatedLoginAfterDeleteHooks = []FederatedLoginHook{}
+
+	AddFederatedLoginHook(boil.BeforeUpsertHook, federatedLoginBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	federatedLoginBeforeUpsertHooks = []FederatedLoginHook{}
+
+	AddFederatedLoginHook(boil.AfterUpsertHook, federatedLoginAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	federatedLoginAfterUpsertHooks = []FederatedLoginHook{}
+}
+
+func testFederatedLoginsInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &FederatedLogin{}
+	if err = randomize.Struct(seed, o, federatedLoginDBTypes, true, federatedLoginColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FederatedLogin struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := FederatedLogins().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testFederatedLoginsInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &FederatedLogin{}
+	if err = randomize.Struct(seed, o, federatedLoginDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize FederatedLogin struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(federatedLoginColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := FederatedLogins().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testFederatedLoginsReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &FederatedLogin{}
+	if err = randomize.Struct(seed, o, federatedLoginDBTypes, true, federatedLoginColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FederatedLogin struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testFederatedLoginsReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &FederatedLogin{}
+	if err = randomize.Struct(seed, o, federatedLoginDBTypes, true, federatedLoginColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FederatedLogin struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := FederatedLoginSlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testFederatedLoginsSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &FederatedLogin{}
+	if err = randomize.Struct(seed, o, federatedLoginDBTypes, true, federatedLoginColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FederatedLogin struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := FederatedLogins().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	federatedLoginDBTypes = map[string]string{`ID`: `integer`, `Provider`: `character varying`, `StateHash`: `bytea`, `Nonce`: `character varying`, `CodeVerifier`: `character varying`, `RedirectURI`: `text`, `ExpiresAt`: `timestamp with time zone`, `CreatedAt`: `timestamp with time zone`}
+	_                     = bytes.MinRead
+)
+
+func testFederatedLoginsUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(federatedLoginPrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(federatedLoginAllColumns) == len(federatedLoginPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &FederatedLogin{}
+	if err = randomize.Struct(seed, o, federatedLoginDBTypes, true, federatedLoginColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FederatedLogin struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := FederatedLogins().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, federatedLoginDBTypes, true, federatedLoginPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize FederatedLogin struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testFederatedLoginsSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(federatedLoginAllColumns) == len(federatedLoginPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &FederatedLogin{}
+	if err = randomize.Struct(seed, o, federatedLoginDBTypes, true, federatedLoginColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FederatedLogin struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := FederatedLogins().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, federatedLoginDBTypes, true, federatedLoginPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize FederatedLogin struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(federatedLoginAllColumns, federatedLoginPrimaryKeyColumns) {
+		fields = federatedLoginAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			federatedLoginAllColumns,
+			federatedLoginPrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := FederatedLoginSlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testFederatedLoginsUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(federatedLoginAllColumns) == len(federatedLoginPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := FederatedLogin{}
+	if err = randomize.Struct(seed, &o, federatedLoginDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize FederatedLogin struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert FederatedLogin: %s", err)
+	}
+
+	count, err := FederatedLogins().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, federatedLoginDBTypes, false, federatedLoginPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize FederatedLogin struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert FederatedLogin: %s", err)
+	}
+
+	count, err = FederatedLogins().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
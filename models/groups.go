@@ -448,7 +448,7 @@ func (groupL) LoadUsers(ctx context.Context, e boil.ContextExecutor, singular bo
 		one := new(User)
 		var localJoinCol int
 
-		err = results.Scan(&one.ID, &one.Email, &one.Name, &one.CreatedAt, &one.UpdatedAt, &localJoinCol)
+		err = results.Scan(&one.ID, &one.Email, &one.Name, &one.CreatedAt, &one.UpdatedAt, &one.VerifiedAt, &one.CredentialBackend, &localJoinCol)
 		if err != nil {
 			return errors.Wrap(err, "failed to scan eager loaded results for users")
 		}
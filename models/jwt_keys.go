@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -23,43 +24,49 @@ import (
 
 // JWTKey is an object representing the database table.
 type JWTKey struct {
-	ID        int       `boil:"id" json:"id" toml:"id" yaml:"id"`
-	PublicKey []byte    `boil:"public_key" json:"public_key" toml:"public_key" yaml:"public_key"`
-	CreatedAt time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	ID           int        `boil:"id" json:"id" toml:"id" yaml:"id"`
+	PublicKey    []byte     `boil:"public_key" json:"public_key" toml:"public_key" yaml:"public_key"`
+	CreatedAt    time.Time  `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	RetiredAt    null.Time  `boil:"retired_at" json:"retired_at,omitempty" toml:"retired_at" yaml:"retired_at,omitempty"`
+	ExpiresAt    null.Time  `boil:"expires_at" json:"expires_at,omitempty" toml:"expires_at" yaml:"expires_at,omitempty"`
+	EncryptedKey null.Bytes `boil:"encrypted_key" json:"encrypted_key,omitempty" toml:"encrypted_key" yaml:"encrypted_key,omitempty"`
 
 	R *jwtKeyR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L jwtKeyL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var JWTKeyColumns = struct {
-	ID        string
-	PublicKey string
-	CreatedAt string
+	ID           string
+	PublicKey    string
+	CreatedAt    string
+	RetiredAt    string
+	ExpiresAt    string
+	EncryptedKey string
 }{
-	ID:        "id",
-	PublicKey: "public_key",
-	CreatedAt: "created_at",
+	ID:           "id",
+	PublicKey:    "public_key",
+	CreatedAt:    "created_at",
+	RetiredAt:    "retired_at",
+	ExpiresAt:    "expires_at",
+	EncryptedKey: "encrypted_key",
 }
 
 // Generated where
 
-type whereHelper__byte struct{ field string }
-
-func (w whereHelper__byte) EQ(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelper__byte) NEQ(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelper__byte) LT(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelper__byte) LTE(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelper__byte) GT(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelper__byte) GTE(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-
 var JWTKeyWhere = struct {
-	ID        whereHelperint
-	PublicKey whereHelper__byte
-	CreatedAt whereHelpertime_Time
+	ID           whereHelperint
+	PublicKey    whereHelper__byte
+	CreatedAt    whereHelpertime_Time
+	RetiredAt    whereHelpernull_Time
+	ExpiresAt    whereHelpernull_Time
+	EncryptedKey whereHelpernull_Bytes
 }{
-	ID:        whereHelperint{field: "\"auth\".\"jwt_keys\".\"id\""},
-	PublicKey: whereHelper__byte{field: "\"auth\".\"jwt_keys\".\"public_key\""},
-	CreatedAt: whereHelpertime_Time{field: "\"auth\".\"jwt_keys\".\"created_at\""},
+	ID:           whereHelperint{field: "\"auth\".\"jwt_keys\".\"id\""},
+	PublicKey:    whereHelper__byte{field: "\"auth\".\"jwt_keys\".\"public_key\""},
+	CreatedAt:    whereHelpertime_Time{field: "\"auth\".\"jwt_keys\".\"created_at\""},
+	RetiredAt:    whereHelpernull_Time{field: "\"auth\".\"jwt_keys\".\"retired_at\""},
+	ExpiresAt:    whereHelpernull_Time{field: "\"auth\".\"jwt_keys\".\"expires_at\""},
+	EncryptedKey: whereHelpernull_Bytes{field: "\"auth\".\"jwt_keys\".\"encrypted_key\""},
 }
 
 // JWTKeyRels is where relationship names are stored.
@@ -79,8 +86,8 @@ func (*jwtKeyR) NewStruct() *jwtKeyR {
 type jwtKeyL struct{}
 
 var (
-	jwtKeyAllColumns            = []string{"id", "public_key", "created_at"}
-	jwtKeyColumnsWithoutDefault = []string{"public_key", "created_at"}
+	jwtKeyAllColumns            = []string{"id", "public_key", "created_at", "retired_at", "expires_at", "encrypted_key"}
+	jwtKeyColumnsWithoutDefault = []string{"public_key", "created_at", "retired_at", "expires_at", "encrypted_key"}
 	jwtKeyColumnsWithDefault    = []string{"id"}
 	jwtKeyPrimaryKeyColumns     = []string{"id"}
 )
@@ -568,7 +568,7 @@ func testJWTKeysSelect(t *testing.T) {
 }
 
 var (
-	jwtKeyDBTypes = map[string]string{`ID`: `integer`, `PublicKey`: `bytea`, `CreatedAt`: `timestamp with time zone`}
+	jwtKeyDBTypes = map[string]string{`ID`: `integer`, `PublicKey`: `bytea`, `CreatedAt`: `timestamp with time zone`, `RetiredAt`: `timestamp with time zone`, `ExpiresAt`: `timestamp with time zone`, `EncryptedKey`: `bytea`}
 	_             = bytes.MinRead
 )
 
@@ -0,0 +1,732 @@
+// Code generated by SQLBoiler 4.1.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/randomize"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testLoginFailures(t *testing.T) {
+	t.Parallel()
+
+	query := LoginFailures()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testLoginFailuresDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &LoginFailure{}
+	if err = randomize.Struct(seed, o, loginFailureDBTypes, true, loginFailureColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LoginFailure struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := LoginFailures().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testLoginFailuresQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &LoginFailure{}
+	if err = randomize.Struct(seed, o, loginFailureDBTypes, true, loginFailureColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LoginFailure struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := LoginFailures().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := LoginFailures().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testLoginFailuresSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &LoginFailure{}
+	if err = randomize.Struct(seed, o, loginFailureDBTypes, true, loginFailureColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LoginFailure struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := LoginFailureSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := LoginFailures().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testLoginFailuresExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &LoginFailure{}
+	if err = randomize.Struct(seed, o, loginFailureDBTypes, true, loginFailureColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LoginFailure struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := LoginFailureExists(ctx, tx, o.ID)
+	if err != nil {
+		t.Errorf("Unable to check if LoginFailure exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected LoginFailureExists to return true, but got false.")
+	}
+}
+
+func testLoginFailuresFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &LoginFailure{}
+	if err = randomize.Struct(seed, o, loginFailureDBTypes, true, loginFailureColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LoginFailure struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	loginFailureFound, err := FindLoginFailure(ctx, tx, o.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if loginFailureFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testLoginFailuresBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &LoginFailure{}
+	if err = randomize.Struct(seed, o, loginFailureDBTypes, true, loginFailureColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LoginFailure struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = LoginFailures().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testLoginFailuresOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &LoginFailure{}
+	if err = randomize.Struct(seed, o, loginFailureDBTypes, true, loginFailureColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LoginFailure struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := LoginFailures().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testLoginFailuresAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	loginFailureOne := &LoginFailure{}
+	loginFailureTwo := &LoginFailure{}
+	if err = randomize.Struct(seed, loginFailureOne, loginFailureDBTypes, false, loginFailureColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LoginFailure struct: %s", err)
+	}
+	if err = randomize.Struct(seed, loginFailureTwo, loginFailureDBTypes, false, loginFailureColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LoginFailure struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = loginFailureOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = loginFailureTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := LoginFailures().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testLoginFailuresCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	loginFailureOne := &LoginFailure{}
+	loginFailureTwo := &LoginFailure{}
+	if err = randomize.Struct(seed, loginFailureOne, loginFailureDBTypes, false, loginFailureColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LoginFailure struct: %s", err)
+	}
+	if err = randomize.Struct(seed, loginFailureTwo, loginFailureDBTypes, false, loginFailureColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LoginFailure struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = loginFailureOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = loginFailureTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := LoginFailures().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func loginFailureBeforeInsertHook(ctx context.Context, e boil.ContextExecutor, o *LoginFailure) error {
+	*o = LoginFailure{}
+	return nil
+}
+
+func loginFailureAfterInsertHook(ctx context.Context, e boil.ContextExecutor, o *LoginFailure) error {
+	*o = LoginFailure{}
+	return nil
+}
+
+func loginFailureAfterSelectHook(ctx context.Context, e boil.ContextExecutor, o *LoginFailure) error {
+	*o = LoginFailure{}
+	return nil
+}
+
+func loginFailureBeforeUpdateHook(ctx context.Context, e boil.ContextExecutor, o *LoginFailure) error {
+	*o = LoginFailure{}
+	return nil
+}
+
+func loginFailureAfterUpdateHook(ctx context.Context, e boil.ContextExecutor, o *LoginFailure) error {
+	*o = LoginFailure{}
+	return nil
+}
+
+func loginFailureBeforeDeleteHook(ctx context.Context, e boil.ContextExecutor, o *LoginFailure) error {
+	*o = LoginFailure{}
+	return nil
+}
+
+func loginFailureAfterDeleteHook(ctx context.Context, e boil.ContextExecutor, o *LoginFailure) error {
+	*o = LoginFailure{}
+	return nil
+}
+
+func loginFailureBeforeUpsertHook(ctx context.Context, e boil.ContextExecutor, o *LoginFailure) error {
+	*o = LoginFailure{}
+	return nil
+}
+
+func loginFailureAfterUpsertHook(ctx context.Context, e boil.ContextExecutor, o *LoginFailure) error {
+	*o = LoginFailure{}
+	return nil
+}
+
+func testLoginFailuresHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	ctx := context.Background()
+	empty := &LoginFailure{}
+	o := &LoginFailure{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, loginFailureDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize LoginFailure object: %s", err)
+	}
+
+	AddLoginFailureHook(boil.BeforeInsertHook, loginFailureBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	loginFailureBeforeInsertHooks = []LoginFailureHook{}
+
+	AddLoginFailureHook(boil.AfterInsertHook, loginFailureAfterInsertHook)
+	if err = o.doAfterInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	loginFailureAfterInsertHooks = []LoginFailureHook{}
+
+	AddLoginFailureHook(boil.AfterSelectHook, loginFailureAfterSelectHook)
+	if err = o.doAfterSelectHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	loginFailureAfterSelectHooks = []LoginFailureHook{}
+
+	AddLoginFailureHook(boil.BeforeUpdateHook, loginFailureBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	loginFailureBeforeUpdateHooks = []LoginFailureHook{}
+
+	AddLoginFailureHook(boil.AfterUpdateHook, loginFailureAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	loginFailureAfterUpdateHooks = []LoginFailureHook{}
+
+	AddLoginFailureHook(boil.BeforeDeleteHook, loginFailureBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	loginFailureBeforeDeleteHooks = []LoginFailureHook{}
+
+	AddLoginFailureHook(boil.AfterDeleteHook, loginFailureAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	loginFailureAfterDeleteHooks = []LoginFailureHook{}
+
+	AddLoginFailureHook(boil.BeforeUpsertHook, loginFailureBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	loginFailureBeforeUpsertHooks = []LoginFailureHook{}
+
+	AddLoginFailureHook(boil.AfterUpsertHook, loginFailureAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	loginFailureAfterUpsertHooks = []LoginFailureHook{}
+}
+
+func testLoginFailuresInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &LoginFailure{}
+	if err = randomize.Struct(seed, o, loginFailureDBTypes, true, loginFailureColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LoginFailure struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := LoginFailures().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testLoginFailuresInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &LoginFailure{}
+	if err = randomize.Struct(seed, o, loginFailureDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize LoginFailure struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(loginFailureColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := LoginFailures().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testLoginFailuresReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &LoginFailure{}
+	if err = randomize.Struct(seed, o, loginFailureDBTypes, true, loginFailureColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LoginFailure struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testLoginFailuresReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &LoginFailure{}
+	if err = randomize.Struct(seed, o, loginFailureDBTypes, true, loginFailureColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LoginFailure struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := LoginFailureSlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testLoginFailuresSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &LoginFailure{}
+	if err = randomize.Struct(seed, o, loginFailureDBTypes, true, loginFailureColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LoginFailure struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := LoginFailures().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	loginFailureDBTypes = map[string]string{`ID`: `integer`, `Scope`: `character varying`, `Subject`: `character varying`, `Failures`: `integer`, `LockedUntil`: `timestamp with time zone`, `LastFailureAt`: `timestamp with time zone`, `CreatedAt`: `timestamp with time zone`}
+	_                   = bytes.MinRead
+)
+
+func testLoginFailuresUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(loginFailurePrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(loginFailureAllColumns) == len(loginFailurePrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &LoginFailure{}
+	if err = randomize.Struct(seed, o, loginFailureDBTypes, true, loginFailureColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LoginFailure struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := LoginFailures().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, loginFailureDBTypes, true, loginFailurePrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize LoginFailure struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testLoginFailuresSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(loginFailureAllColumns) == len(loginFailurePrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &LoginFailure{}
+	if err = randomize.Struct(seed, o, loginFailureDBTypes, true, loginFailureColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LoginFailure struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := LoginFailures().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, loginFailureDBTypes, true, loginFailurePrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize LoginFailure struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(loginFailureAllColumns, loginFailurePrimaryKeyColumns) {
+		fields = loginFailureAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			loginFailureAllColumns,
+			loginFailurePrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := LoginFailureSlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testLoginFailuresUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(loginFailureAllColumns) == len(loginFailurePrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := LoginFailure{}
+	if err = randomize.Struct(seed, &o, loginFailureDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize LoginFailure struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert LoginFailure: %s", err)
+	}
+
+	count, err := LoginFailures().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, loginFailureDBTypes, false, loginFailurePrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize LoginFailure struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert LoginFailure: %s", err)
+	}
+
+	count, err = LoginFailures().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
@@ -0,0 +1,841 @@
+// Code generated by SQLBoiler 4.1.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/randomize"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testPasswordHistories(t *testing.T) {
+	t.Parallel()
+
+	query := PasswordHistories()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testPasswordHistoriesDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &PasswordHistory{}
+	if err = randomize.Struct(seed, o, passwordHistoryDBTypes, true, passwordHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PasswordHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := PasswordHistories().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testPasswordHistoriesQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &PasswordHistory{}
+	if err = randomize.Struct(seed, o, passwordHistoryDBTypes, true, passwordHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PasswordHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := PasswordHistories().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := PasswordHistories().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testPasswordHistoriesSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &PasswordHistory{}
+	if err = randomize.Struct(seed, o, passwordHistoryDBTypes, true, passwordHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PasswordHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := PasswordHistorySlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := PasswordHistories().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testPasswordHistoriesExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &PasswordHistory{}
+	if err = randomize.Struct(seed, o, passwordHistoryDBTypes, true, passwordHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PasswordHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := PasswordHistoryExists(ctx, tx, o.ID)
+	if err != nil {
+		t.Errorf("Unable to check if PasswordHistory exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected PasswordHistoryExists to return true, but got false.")
+	}
+}
+
+func testPasswordHistoriesFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &PasswordHistory{}
+	if err = randomize.Struct(seed, o, passwordHistoryDBTypes, true, passwordHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PasswordHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	passwordHistoryFound, err := FindPasswordHistory(ctx, tx, o.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if passwordHistoryFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testPasswordHistoriesBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &PasswordHistory{}
+	if err = randomize.Struct(seed, o, passwordHistoryDBTypes, true, passwordHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PasswordHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = PasswordHistories().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testPasswordHistoriesOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &PasswordHistory{}
+	if err = randomize.Struct(seed, o, passwordHistoryDBTypes, true, passwordHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PasswordHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := PasswordHistories().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testPasswordHistoriesAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	passwordHistoryOne := &PasswordHistory{}
+	passwordHistoryTwo := &PasswordHistory{}
+	if err = randomize.Struct(seed, passwordHistoryOne, passwordHistoryDBTypes, false, passwordHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PasswordHistory struct: %s", err)
+	}
+	if err = randomize.Struct(seed, passwordHistoryTwo, passwordHistoryDBTypes, false, passwordHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PasswordHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = passwordHistoryOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = passwordHistoryTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := PasswordHistories().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testPasswordHistoriesCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	passwordHistoryOne := &PasswordHistory{}
+	passwordHistoryTwo := &PasswordHistory{}
+	if err = randomize.Struct(seed, passwordHistoryOne, passwordHistoryDBTypes, false, passwordHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PasswordHistory struct: %s", err)
+	}
+	if err = randomize.Struct(seed, passwordHistoryTwo, passwordHistoryDBTypes, false, passwordHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PasswordHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = passwordHistoryOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = passwordHistoryTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := PasswordHistories().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func passwordHistoryBeforeInsertHook(ctx context.Context, e boil.ContextExecutor, o *PasswordHistory) error {
+	*o = PasswordHistory{}
+	return nil
+}
+
+func passwordHistoryAfterInsertHook(ctx context.Context, e boil.ContextExecutor, o *PasswordHistory) error {
+	*o = PasswordHistory{}
+	return nil
+}
+
+func passwordHistoryAfterSelectHook(ctx context.Context, e boil.ContextExecutor, o *PasswordHistory) error {
+	*o = PasswordHistory{}
+	return nil
+}
+
+func passwordHistoryBeforeUpdateHook(ctx context.Context, e boil.ContextExecutor, o *PasswordHistory) error {
+	*o = PasswordHistory{}
+	return nil
+}
+
+func passwordHistoryAfterUpdateHook(ctx context.Context, e boil.ContextExecutor, o *PasswordHistory) error {
+	*o = PasswordHistory{}
+	return nil
+}
+
+func passwordHistoryBeforeDeleteHook(ctx context.Context, e boil.ContextExecutor, o *PasswordHistory) error {
+	*o = PasswordHistory{}
+	return nil
+}
+
+func passwordHistoryAfterDeleteHook(ctx context.Context, e boil.ContextExecutor, o *PasswordHistory) error {
+	*o = PasswordHistory{}
+	return nil
+}
+
+func passwordHistoryBeforeUpsertHook(ctx context.Context, e boil.ContextExecutor, o *PasswordHistory) error {
+	*o = PasswordHistory{}
+	return nil
+}
+
+func passwordHistoryAfterUpsertHook(ctx context.Context, e boil.ContextExecutor, o *PasswordHistory) error {
+	*o = PasswordHistory{}
+	return nil
+}
+
+func testPasswordHistoriesHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	ctx := context.Background()
+	empty := &PasswordHistory{}
+	o := &PasswordHistory{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, passwordHistoryDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize PasswordHistory object: %s", err)
+	}
+
+	AddPasswordHistoryHook(boil.BeforeInsertHook, passwordHistoryBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	passwordHistoryBeforeInsertHooks = []PasswordHistoryHook{}
+
+	AddPasswordHistoryHook(boil.AfterInsertHook, passwordHistoryAfterInsertHook)
+	if err = o.doAfterInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	passwordHistoryAfterInsertHooks = []PasswordHistoryHook{}
+
+	AddPasswordHistoryHook(boil.AfterSelectHook, passwordHistoryAfterSelectHook)
+	if err = o.doAfterSelectHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	passwordHistoryAfterSelectHooks = []PasswordHistoryHook{}
+
+	AddPasswordHistoryHook(boil.BeforeUpdateHook, passwordHistoryBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	passwordHistoryBeforeUpdateHooks = []PasswordHistoryHook{}
+
+	AddPasswordHistoryHook(boil.AfterUpdateHook, passwordHistoryAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	passwordHistoryAfterUpdateHooks = []PasswordHistoryHook{}
+
+	AddPasswordHistoryHook(boil.BeforeDeleteHook, passwordHistoryBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	passwordHistoryBeforeDeleteHooks = []PasswordHistoryHook{}
+
+	AddPasswordHistoryHook(boil.AfterDeleteHook, passwordHistoryAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	passwordHistoryAfterDeleteHooks = []PasswordHistoryHook{}
+
+	AddPasswordHistoryHook(boil.BeforeUpsertHook, passwordHistoryBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	passwordHistoryBeforeUpsertHooks = []PasswordHistoryHook{}
+
+	AddPasswordHistoryHook(boil.AfterUpsertHook, passwordHistoryAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	passwordHistoryAfterUpsertHooks = []PasswordHistoryHook{}
+}
+
+func testPasswordHistoriesInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &PasswordHistory{}
+	if err = randomize.Struct(seed, o, passwordHistoryDBTypes, true, passwordHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PasswordHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := PasswordHistories().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testPasswordHistoriesInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &PasswordHistory{}
+	if err = randomize.Struct(seed, o, passwordHistoryDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize PasswordHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(passwordHistoryColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := PasswordHistories().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testPasswordHistoryToOneUserUsingUser(t *testing.T) {
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var local PasswordHistory
+	var foreign User
+
+	seed := randomize.NewSeed()
+	if err := randomize.Struct(seed, &local, passwordHistoryDBTypes, false, passwordHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PasswordHistory struct: %s", err)
+	}
+	if err := randomize.Struct(seed, &foreign, userDBTypes, false, userColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize User struct: %s", err)
+	}
+
+	if err := foreign.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	local.UserID = foreign.ID
+	if err := local.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := local.User().One(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if check.ID != foreign.ID {
+		t.Errorf("want: %v, got %v", foreign.ID, check.ID)
+	}
+
+	slice := PasswordHistorySlice{&local}
+	if err = local.L.LoadUser(ctx, tx, false, (*[]*PasswordHistory)(&slice), nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.User == nil {
+		t.Error("struct should have been eager loaded")
+	}
+
+	local.R.User = nil
+	if err = local.L.LoadUser(ctx, tx, true, &local, nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.User == nil {
+		t.Error("struct should have been eager loaded")
+	}
+}
+
+func testPasswordHistoryToOneSetOpUserUsingUser(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a PasswordHistory
+	var b, c User
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, passwordHistoryDBTypes, false, strmangle.SetComplement(passwordHistoryPrimaryKeyColumns, passwordHistoryColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &b, userDBTypes, false, strmangle.SetComplement(userPrimaryKeyColumns, userColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &c, userDBTypes, false, strmangle.SetComplement(userPrimaryKeyColumns, userColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, x := range []*User{&b, &c} {
+		err = a.SetUser(ctx, tx, i != 0, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if a.R.User != x {
+			t.Error("relationship struct not set to correct value")
+		}
+
+		if x.R.PasswordHistories[0] != &a {
+			t.Error("failed to append to foreign relationship struct")
+		}
+		if a.UserID != x.ID {
+			t.Error("foreign key was wrong value", a.UserID)
+		}
+
+		zero := reflect.Zero(reflect.TypeOf(a.UserID))
+		reflect.Indirect(reflect.ValueOf(&a.UserID)).Set(zero)
+
+		if err = a.Reload(ctx, tx); err != nil {
+			t.Fatal("failed to reload", err)
+		}
+
+		if a.UserID != x.ID {
+			t.Error("foreign key was wrong value", a.UserID, x.ID)
+		}
+	}
+}
+
+func testPasswordHistoriesReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &PasswordHistory{}
+	if err = randomize.Struct(seed, o, passwordHistoryDBTypes, true, passwordHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PasswordHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testPasswordHistoriesReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &PasswordHistory{}
+	if err = randomize.Struct(seed, o, passwordHistoryDBTypes, true, passwordHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PasswordHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := PasswordHistorySlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testPasswordHistoriesSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &PasswordHistory{}
+	if err = randomize.Struct(seed, o, passwordHistoryDBTypes, true, passwordHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PasswordHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := PasswordHistories().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	passwordHistoryDBTypes = map[string]string{`ID`: `integer`, `UserID`: `integer`, `Hash`: `character varying`, `CreatedAt`: `timestamp with time zone`}
+	_                      = bytes.MinRead
+)
+
+func testPasswordHistoriesUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(passwordHistoryPrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(passwordHistoryAllColumns) == len(passwordHistoryPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &PasswordHistory{}
+	if err = randomize.Struct(seed, o, passwordHistoryDBTypes, true, passwordHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PasswordHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := PasswordHistories().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, passwordHistoryDBTypes, true, passwordHistoryPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize PasswordHistory struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testPasswordHistoriesSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(passwordHistoryAllColumns) == len(passwordHistoryPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &PasswordHistory{}
+	if err = randomize.Struct(seed, o, passwordHistoryDBTypes, true, passwordHistoryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize PasswordHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := PasswordHistories().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, passwordHistoryDBTypes, true, passwordHistoryPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize PasswordHistory struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(passwordHistoryAllColumns, passwordHistoryPrimaryKeyColumns) {
+		fields = passwordHistoryAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			passwordHistoryAllColumns,
+			passwordHistoryPrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := PasswordHistorySlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testPasswordHistoriesUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(passwordHistoryAllColumns) == len(passwordHistoryPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := PasswordHistory{}
+	if err = randomize.Struct(seed, &o, passwordHistoryDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize PasswordHistory struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert PasswordHistory: %s", err)
+	}
+
+	count, err := PasswordHistories().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, passwordHistoryDBTypes, false, passwordHistoryPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize PasswordHistory struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert PasswordHistory: %s", err)
+	}
+
+	count, err = PasswordHistories().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
@@ -27,8 +27,7 @@ type Password struct {
 	UserID    int       `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
 	CreatedAt time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
 	UpdatedAt time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
-	Salt      []byte    `boil:"salt" json:"salt" toml:"salt" yaml:"salt"`
-	Hash      []byte    `boil:"hash" json:"hash" toml:"hash" yaml:"hash"`
+	Hash      string    `boil:"hash" json:"hash" toml:"hash" yaml:"hash"`
 
 	R *passwordR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L passwordL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -39,14 +38,12 @@ var PasswordColumns = struct {
 	UserID    string
 	CreatedAt string
 	UpdatedAt string
-	Salt      string
 	Hash      string
 }{
 	ID:        "id",
 	UserID:    "user_id",
 	CreatedAt: "created_at",
 	UpdatedAt: "updated_at",
-	Salt:      "salt",
 	Hash:      "hash",
 }
 
@@ -57,15 +54,13 @@ var PasswordWhere = struct {
 	UserID    whereHelperint
 	CreatedAt whereHelpertime_Time
 	UpdatedAt whereHelpertime_Time
-	Salt      whereHelper__byte
-	Hash      whereHelper__byte
+	Hash      whereHelperstring
 }{
 	ID:        whereHelperint{field: "\"auth\".\"passwords\".\"id\""},
 	UserID:    whereHelperint{field: "\"auth\".\"passwords\".\"user_id\""},
 	CreatedAt: whereHelpertime_Time{field: "\"auth\".\"passwords\".\"created_at\""},
 	UpdatedAt: whereHelpertime_Time{field: "\"auth\".\"passwords\".\"updated_at\""},
-	Salt:      whereHelper__byte{field: "\"auth\".\"passwords\".\"salt\""},
-	Hash:      whereHelper__byte{field: "\"auth\".\"passwords\".\"hash\""},
+	Hash:      whereHelperstring{field: "\"auth\".\"passwords\".\"hash\""},
 }
 
 // PasswordRels is where relationship names are stored.
@@ -89,8 +84,8 @@ func (*passwordR) NewStruct() *passwordR {
 type passwordL struct{}
 
 var (
-	passwordAllColumns            = []string{"id", "user_id", "created_at", "updated_at", "salt", "hash"}
-	passwordColumnsWithoutDefault = []string{"user_id", "created_at", "updated_at", "salt", "hash"}
+	passwordAllColumns            = []string{"id", "user_id", "created_at", "updated_at", "hash"}
+	passwordColumnsWithoutDefault = []string{"user_id", "created_at", "updated_at", "hash"}
 	passwordColumnsWithDefault    = []string{"id"}
 	passwordPrimaryKeyColumns     = []string{"id"}
 )
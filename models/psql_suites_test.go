@@ -8,11 +8,37 @@ import "testing"
 func TestUpsert(t *testing.T) {
 	t.Run("Audiences", testAudiencesUpsert)
 
+	t.Run("AuthCodes", testAuthCodesUpsert)
+
+	t.Run("ClientGrants", testClientGrantsUpsert)
+
+	t.Run("Clients", testClientsUpsert)
+
+	t.Run("FederatedLogins", testFederatedLoginsUpsert)
+
 	t.Run("Groups", testGroupsUpsert)
 
 	t.Run("JWTKeys", testJWTKeysUpsert)
 
+	t.Run("LoginFailures", testLoginFailuresUpsert)
+
+	t.Run("PasswordHistories", testPasswordHistoriesUpsert)
+
 	t.Run("Passwords", testPasswordsUpsert)
 
+	t.Run("RecoveryCodes", testRecoveryCodesUpsert)
+
+	t.Run("RefreshTokens", testRefreshTokensUpsert)
+
+	t.Run("RevokedTokens", testRevokedTokensUpsert)
+
+	t.Run("TotpSecrets", testTotpSecretsUpsert)
+
+	t.Run("UserIdentities", testUserIdentitiesUpsert)
+
 	t.Run("Users", testUsersUpsert)
+
+	t.Run("WebauthnChallenges", testWebauthnChallengesUpsert)
+
+	t.Run("WebauthnCredentials", testWebauthnCredentialsUpsert)
 }
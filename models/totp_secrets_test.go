@@ -0,0 +1,841 @@
+// Code generated by SQLBoiler 4.1.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/randomize"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testTotpSecrets(t *testing.T) {
+	t.Parallel()
+
+	query := TotpSecrets()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testTotpSecretsDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &TotpSecret{}
+	if err = randomize.Struct(seed, o, totpSecretDBTypes, true, totpSecretColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize TotpSecret struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := TotpSecrets().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testTotpSecretsQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &TotpSecret{}
+	if err = randomize.Struct(seed, o, totpSecretDBTypes, true, totpSecretColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize TotpSecret struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := TotpSecrets().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := TotpSecrets().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testTotpSecretsSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &TotpSecret{}
+	if err = randomize.Struct(seed, o, totpSecretDBTypes, true, totpSecretColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize TotpSecret struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := TotpSecretSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := TotpSecrets().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testTotpSecretsExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &TotpSecret{}
+	if err = randomize.Struct(seed, o, totpSecretDBTypes, true, totpSecretColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize TotpSecret struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := TotpSecretExists(ctx, tx, o.ID)
+	if err != nil {
+		t.Errorf("Unable to check if TotpSecret exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected TotpSecretExists to return true, but got false.")
+	}
+}
+
+func testTotpSecretsFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &TotpSecret{}
+	if err = randomize.Struct(seed, o, totpSecretDBTypes, true, totpSecretColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize TotpSecret struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	totpSecretFound, err := FindTotpSecret(ctx, tx, o.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if totpSecretFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testTotpSecretsBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &TotpSecret{}
+	if err = randomize.Struct(seed, o, totpSecretDBTypes, true, totpSecretColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize TotpSecret struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = TotpSecrets().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testTotpSecretsOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &TotpSecret{}
+	if err = randomize.Struct(seed, o, totpSecretDBTypes, true, totpSecretColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize TotpSecret struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := TotpSecrets().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testTotpSecretsAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	totpSecretOne := &TotpSecret{}
+	totpSecretTwo := &TotpSecret{}
+	if err = randomize.Struct(seed, totpSecretOne, totpSecretDBTypes, false, totpSecretColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize TotpSecret struct: %s", err)
+	}
+	if err = randomize.Struct(seed, totpSecretTwo, totpSecretDBTypes, false, totpSecretColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize TotpSecret struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = totpSecretOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = totpSecretTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := TotpSecrets().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testTotpSecretsCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	totpSecretOne := &TotpSecret{}
+	totpSecretTwo := &TotpSecret{}
+	if err = randomize.Struct(seed, totpSecretOne, totpSecretDBTypes, false, totpSecretColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize TotpSecret struct: %s", err)
+	}
+	if err = randomize.Struct(seed, totpSecretTwo, totpSecretDBTypes, false, totpSecretColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize TotpSecret struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = totpSecretOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = totpSecretTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := TotpSecrets().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func totpSecretBeforeInsertHook(ctx context.Context, e boil.ContextExecutor, o *TotpSecret) error {
+	*o = TotpSecret{}
+	return nil
+}
+
+func totpSecretAfterInsertHook(ctx context.Context, e boil.ContextExecutor, o *TotpSecret) error {
+	*o = TotpSecret{}
+	return nil
+}
+
+func totpSecretAfterSelectHook(ctx context.Context, e boil.ContextExecutor, o *TotpSecret) error {
+	*o = TotpSecret{}
+	return nil
+}
+
+func totpSecretBeforeUpdateHook(ctx context.Context, e boil.ContextExecutor, o *TotpSecret) error {
+	*o = TotpSecret{}
+	return nil
+}
+
+func totpSecretAfterUpdateHook(ctx context.Context, e boil.ContextExecutor, o *TotpSecret) error {
+	*o = TotpSecret{}
+	return nil
+}
+
+func totpSecretBeforeDeleteHook(ctx context.Context, e boil.ContextExecutor, o *TotpSecret) error {
+	*o = TotpSecret{}
+	return nil
+}
+
+func totpSecretAfterDeleteHook(ctx context.Context, e boil.ContextExecutor, o *TotpSecret) error {
+	*o = TotpSecret{}
+	return nil
+}
+
+func totpSecretBeforeUpsertHook(ctx context.Context, e boil.ContextExecutor, o *TotpSecret) error {
+	*o = TotpSecret{}
+	return nil
+}
+
+func totpSecretAfterUpsertHook(ctx context.Context, e boil.ContextExecutor, o *TotpSecret) error {
+	*o = TotpSecret{}
+	return nil
+}
+
+func testTotpSecretsHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	ctx := context.Background()
+	empty := &TotpSecret{}
+	o := &TotpSecret{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, totpSecretDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize TotpSecret object: %s", err)
+	}
+
+	AddTotpSecretHook(boil.BeforeInsertHook, totpSecretBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	totpSecretBeforeInsertHooks = []TotpSecretHook{}
+
+	AddTotpSecretHook(boil.AfterInsertHook, totpSecretAfterInsertHook)
+	if err = o.doAfterInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	totpSecretAfterInsertHooks = []TotpSecretHook{}
+
+	AddTotpSecretHook(boil.AfterSelectHook, totpSecretAfterSelectHook)
+	if err = o.doAfterSelectHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	totpSecretAfterSelectHooks = []TotpSecretHook{}
+
+	AddTotpSecretHook(boil.BeforeUpdateHook, totpSecretBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	totpSecretBeforeUpdateHooks = []TotpSecretHook{}
+
+	AddTotpSecretHook(boil.AfterUpdateHook, totpSecretAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	totpSecretAfterUpdateHooks = []TotpSecretHook{}
+
+	AddTotpSecretHook(boil.BeforeDeleteHook, totpSecretBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	totpSecretBeforeDeleteHooks = []TotpSecretHook{}
+
+	AddTotpSecretHook(boil.AfterDeleteHook, totpSecretAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	totpSecretAfterDeleteHooks = []TotpSecretHook{}
+
+	AddTotpSecretHook(boil.BeforeUpsertHook, totpSecretBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	totpSecretBeforeUpsertHooks = []TotpSecretHook{}
+
+	AddTotpSecretHook(boil.AfterUpsertHook, totpSecretAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	totpSecretAfterUpsertHooks = []TotpSecretHook{}
+}
+
+func testTotpSecretsInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &TotpSecret{}
+	if err = randomize.Struct(seed, o, totpSecretDBTypes, true, totpSecretColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize TotpSecret struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := TotpSecrets().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testTotpSecretsInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &TotpSecret{}
+	if err = randomize.Struct(seed, o, totpSecretDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize TotpSecret struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(totpSecretColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := TotpSecrets().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testTotpSecretToOneUserUsingUser(t *testing.T) {
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var local TotpSecret
+	var foreign User
+
+	seed := randomize.NewSeed()
+	if err := randomize.Struct(seed, &local, totpSecretDBTypes, false, totpSecretColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize TotpSecret struct: %s", err)
+	}
+	if err := randomize.Struct(seed, &foreign, userDBTypes, false, userColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize User struct: %s", err)
+	}
+
+	if err := foreign.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	local.UserID = foreign.ID
+	if err := local.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := local.User().One(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if check.ID != foreign.ID {
+		t.Errorf("want: %v, got %v", foreign.ID, check.ID)
+	}
+
+	slice := TotpSecretSlice{&local}
+	if err = local.L.LoadUser(ctx, tx, false, (*[]*TotpSecret)(&slice), nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.User == nil {
+		t.Error("struct should have been eager loaded")
+	}
+
+	local.R.User = nil
+	if err = local.L.LoadUser(ctx, tx, true, &local, nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.User == nil {
+		t.Error("struct should have been eager loaded")
+	}
+}
+
+func testTotpSecretToOneSetOpUserUsingUser(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a TotpSecret
+	var b, c User
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, totpSecretDBTypes, false, strmangle.SetComplement(totpSecretPrimaryKeyColumns, totpSecretColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &b, userDBTypes, false, strmangle.SetComplement(userPrimaryKeyColumns, userColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &c, userDBTypes, false, strmangle.SetComplement(userPrimaryKeyColumns, userColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, x := range []*User{&b, &c} {
+		err = a.SetUser(ctx, tx, i != 0, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if a.R.User != x {
+			t.Error("relationship struct not set to correct value")
+		}
+
+		if x.R.TotpSecret != &a {
+			t.Error("failed to append to foreign relationship struct")
+		}
+		if a.UserID != x.ID {
+			t.Error("foreign key was wrong value", a.UserID)
+		}
+
+		zero := reflect.Zero(reflect.TypeOf(a.UserID))
+		reflect.Indirect(reflect.ValueOf(&a.UserID)).Set(zero)
+
+		if err = a.Reload(ctx, tx); err != nil {
+			t.Fatal("failed to reload", err)
+		}
+
+		if a.UserID != x.ID {
+			t.Error("foreign key was wrong value", a.UserID, x.ID)
+		}
+	}
+}
+
+func testTotpSecretsReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &TotpSecret{}
+	if err = randomize.Struct(seed, o, totpSecretDBTypes, true, totpSecretColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize TotpSecret struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testTotpSecretsReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &TotpSecret{}
+	if err = randomize.Struct(seed, o, totpSecretDBTypes, true, totpSecretColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize TotpSecret struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := TotpSecretSlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testTotpSecretsSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &TotpSecret{}
+	if err = randomize.Struct(seed, o, totpSecretDBTypes, true, totpSecretColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize TotpSecret struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := TotpSecrets().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	totpSecretDBTypes = map[string]string{`ID`: `integer`, `UserID`: `integer`, `EncryptedSecret`: `bytea`, `LastCounter`: `bigint`, `ConfirmedAt`: `timestamp with time zone`, `CreatedAt`: `timestamp with time zone`, `UpdatedAt`: `timestamp with time zone`}
+	_                 = bytes.MinRead
+)
+
+func testTotpSecretsUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(totpSecretPrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(totpSecretAllColumns) == len(totpSecretPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &TotpSecret{}
+	if err = randomize.Struct(seed, o, totpSecretDBTypes, true, totpSecretColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize TotpSecret struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := TotpSecrets().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, totpSecretDBTypes, true, totpSecretPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize TotpSecret struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testTotpSecretsSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(totpSecretAllColumns) == len(totpSecretPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &TotpSecret{}
+	if err = randomize.Struct(seed, o, totpSecretDBTypes, true, totpSecretColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize TotpSecret struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := TotpSecrets().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, totpSecretDBTypes, true, totpSecretPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize TotpSecret struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(totpSecretAllColumns, totpSecretPrimaryKeyColumns) {
+		fields = totpSecretAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			totpSecretAllColumns,
+			totpSecretPrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := TotpSecretSlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testTotpSecretsUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(totpSecretAllColumns) == len(totpSecretPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := TotpSecret{}
+	if err = randomize.Struct(seed, &o, totpSecretDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize TotpSecret struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert TotpSecret: %s", err)
+	}
+
+	count, err := TotpSecrets().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, totpSecretDBTypes, false, totpSecretPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize TotpSecret struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert TotpSecret: %s", err)
+	}
+
+	count, err = TotpSecrets().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
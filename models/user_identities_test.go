@@ -0,0 +1,841 @@
+// Code generated by SQLBoiler 4.1.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/randomize"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testUserIdentities(t *testing.T) {
+	t.Parallel()
+
+	query := UserIdentities()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testUserIdentitiesDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserIdentity{}
+	if err = randomize.Struct(seed, o, userIdentityDBTypes, true, userIdentityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserIdentity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := UserIdentities().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testUserIdentitiesQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserIdentity{}
+	if err = randomize.Struct(seed, o, userIdentityDBTypes, true, userIdentityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserIdentity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := UserIdentities().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := UserIdentities().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testUserIdentitiesSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserIdentity{}
+	if err = randomize.Struct(seed, o, userIdentityDBTypes, true, userIdentityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserIdentity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := UserIdentitySlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := UserIdentities().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testUserIdentitiesExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserIdentity{}
+	if err = randomize.Struct(seed, o, userIdentityDBTypes, true, userIdentityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserIdentity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := UserIdentityExists(ctx, tx, o.ID)
+	if err != nil {
+		t.Errorf("Unable to check if UserIdentity exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected UserIdentityExists to return true, but got false.")
+	}
+}
+
+func testUserIdentitiesFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserIdentity{}
+	if err = randomize.Struct(seed, o, userIdentityDBTypes, true, userIdentityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserIdentity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	userIdentityFound, err := FindUserIdentity(ctx, tx, o.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if userIdentityFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testUserIdentitiesBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserIdentity{}
+	if err = randomize.Struct(seed, o, userIdentityDBTypes, true, userIdentityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserIdentity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = UserIdentities().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testUserIdentitiesOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserIdentity{}
+	if err = randomize.Struct(seed, o, userIdentityDBTypes, true, userIdentityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserIdentity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := UserIdentities().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testUserIdentitiesAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	userIdentityOne := &UserIdentity{}
+	userIdentityTwo := &UserIdentity{}
+	if err = randomize.Struct(seed, userIdentityOne, userIdentityDBTypes, false, userIdentityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserIdentity struct: %s", err)
+	}
+	if err = randomize.Struct(seed, userIdentityTwo, userIdentityDBTypes, false, userIdentityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserIdentity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = userIdentityOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = userIdentityTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := UserIdentities().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testUserIdentitiesCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	userIdentityOne := &UserIdentity{}
+	userIdentityTwo := &UserIdentity{}
+	if err = randomize.Struct(seed, userIdentityOne, userIdentityDBTypes, false, userIdentityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserIdentity struct: %s", err)
+	}
+	if err = randomize.Struct(seed, userIdentityTwo, userIdentityDBTypes, false, userIdentityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserIdentity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = userIdentityOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = userIdentityTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := UserIdentities().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func userIdentityBeforeInsertHook(ctx context.Context, e boil.ContextExecutor, o *UserIdentity) error {
+	*o = UserIdentity{}
+	return nil
+}
+
+func userIdentityAfterInsertHook(ctx context.Context, e boil.ContextExecutor, o *UserIdentity) error {
+	*o = UserIdentity{}
+	return nil
+}
+
+func userIdentityAfterSelectHook(ctx context.Context, e boil.ContextExecutor, o *UserIdentity) error {
+	*o = UserIdentity{}
+	return nil
+}
+
+func userIdentityBeforeUpdateHook(ctx context.Context, e boil.ContextExecutor, o *UserIdentity) error {
+	*o = UserIdentity{}
+	return nil
+}
+
+func userIdentityAfterUpdateHook(ctx context.Context, e boil.ContextExecutor, o *UserIdentity) error {
+	*o = UserIdentity{}
+	return nil
+}
+
+func userIdentityBeforeDeleteHook(ctx context.Context, e boil.ContextExecutor, o *UserIdentity) error {
+	*o = UserIdentity{}
+	return nil
+}
+
+func userIdentityAfterDeleteHook(ctx context.Context, e boil.ContextExecutor, o *UserIdentity) error {
+	*o = UserIdentity{}
+	return nil
+}
+
+func userIdentityBeforeUpsertHook(ctx context.Context, e boil.ContextExecutor, o *UserIdentity) error {
+	*o = UserIdentity{}
+	return nil
+}
+
+func userIdentityAfterUpsertHook(ctx context.Context, e boil.ContextExecutor, o *UserIdentity) error {
+	*o = UserIdentity{}
+	return nil
+}
+
+func testUserIdentitiesHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	ctx := context.Background()
+	empty := &UserIdentity{}
+	o := &UserIdentity{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, userIdentityDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize UserIdentity object: %s", err)
+	}
+
+	AddUserIdentityHook(boil.BeforeInsertHook, userIdentityBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	userIdentityBeforeInsertHooks = []UserIdentityHook{}
+
+	AddUserIdentityHook(boil.AfterInsertHook, userIdentityAfterInsertHook)
+	if err = o.doAfterInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	userIdentityAfterInsertHooks = []UserIdentityHook{}
+
+	AddUserIdentityHook(boil.AfterSelectHook, userIdentityAfterSelectHook)
+	if err = o.doAfterSelectHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	userIdentityAfterSelectHooks = []UserIdentityHook{}
+
+	AddUserIdentityHook(boil.BeforeUpdateHook, userIdentityBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	userIdentityBeforeUpdateHooks = []UserIdentityHook{}
+
+	AddUserIdentityHook(boil.AfterUpdateHook, userIdentityAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	userIdentityAfterUpdateHooks = []UserIdentityHook{}
+
+	AddUserIdentityHook(boil.BeforeDeleteHook, userIdentityBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	userIdentityBeforeDeleteHooks = []UserIdentityHook{}
+
+	AddUserIdentityHook(boil.AfterDeleteHook, userIdentityAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	userIdentityAfterDeleteHooks = []UserIdentityHook{}
+
+	AddUserIdentityHook(boil.BeforeUpsertHook, userIdentityBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	userIdentityBeforeUpsertHooks = []UserIdentityHook{}
+
+	AddUserIdentityHook(boil.AfterUpsertHook, userIdentityAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	userIdentityAfterUpsertHooks = []UserIdentityHook{}
+}
+
+func testUserIdentitiesInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserIdentity{}
+	if err = randomize.Struct(seed, o, userIdentityDBTypes, true, userIdentityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserIdentity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := UserIdentities().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testUserIdentitiesInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserIdentity{}
+	if err = randomize.Struct(seed, o, userIdentityDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize UserIdentity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(userIdentityColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := UserIdentities().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testUserIdentityToOneUserUsingUser(t *testing.T) {
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var local UserIdentity
+	var foreign User
+
+	seed := randomize.NewSeed()
+	if err := randomize.Struct(seed, &local, userIdentityDBTypes, false, userIdentityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserIdentity struct: %s", err)
+	}
+	if err := randomize.Struct(seed, &foreign, userDBTypes, false, userColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize User struct: %s", err)
+	}
+
+	if err := foreign.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	local.UserID = foreign.ID
+	if err := local.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := local.User().One(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if check.ID != foreign.ID {
+		t.Errorf("want: %v, got %v", foreign.ID, check.ID)
+	}
+
+	slice := UserIdentitySlice{&local}
+	if err = local.L.LoadUser(ctx, tx, false, (*[]*UserIdentity)(&slice), nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.User == nil {
+		t.Error("struct should have been eager loaded")
+	}
+
+	local.R.User = nil
+	if err = local.L.LoadUser(ctx, tx, true, &local, nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.User == nil {
+		t.Error("struct should have been eager loaded")
+	}
+}
+
+func testUserIdentityToOneSetOpUserUsingUser(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a UserIdentity
+	var b, c User
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, userIdentityDBTypes, false, strmangle.SetComplement(userIdentityPrimaryKeyColumns, userIdentityColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &b, userDBTypes, false, strmangle.SetComplement(userPrimaryKeyColumns, userColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &c, userDBTypes, false, strmangle.SetComplement(userPrimaryKeyColumns, userColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, x := range []*User{&b, &c} {
+		err = a.SetUser(ctx, tx, i != 0, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if a.R.User != x {
+			t.Error("relationship struct not set to correct value")
+		}
+
+		if x.R.UserIdentities[0] != &a {
+			t.Error("failed to append to foreign relationship struct")
+		}
+		if a.UserID != x.ID {
+			t.Error("foreign key was wrong value", a.UserID)
+		}
+
+		zero := reflect.Zero(reflect.TypeOf(a.UserID))
+		reflect.Indirect(reflect.ValueOf(&a.UserID)).Set(zero)
+
+		if err = a.Reload(ctx, tx); err != nil {
+			t.Fatal("failed to reload", err)
+		}
+
+		if a.UserID != x.ID {
+			t.Error("foreign key was wrong value", a.UserID, x.ID)
+		}
+	}
+}
+
+func testUserIdentitiesReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserIdentity{}
+	if err = randomize.Struct(seed, o, userIdentityDBTypes, true, userIdentityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserIdentity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testUserIdentitiesReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserIdentity{}
+	if err = randomize.Struct(seed, o, userIdentityDBTypes, true, userIdentityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserIdentity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := UserIdentitySlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testUserIdentitiesSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserIdentity{}
+	if err = randomize.Struct(seed, o, userIdentityDBTypes, true, userIdentityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserIdentity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := UserIdentities().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	userIdentityDBTypes = map[string]string{`ID`: `integer`, `UserID`: `integer`, `Issuer`: `character varying`, `Subject`: `character varying`, `CreatedAt`: `timestamp with time zone`, `UpdatedAt`: `timestamp with time zone`}
+	_                   = bytes.MinRead
+)
+
+func testUserIdentitiesUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(userIdentityPrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(userIdentityAllColumns) == len(userIdentityPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserIdentity{}
+	if err = randomize.Struct(seed, o, userIdentityDBTypes, true, userIdentityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserIdentity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := UserIdentities().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, userIdentityDBTypes, true, userIdentityPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize UserIdentity struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testUserIdentitiesSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(userIdentityAllColumns) == len(userIdentityPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &UserIdentity{}
+	if err = randomize.Struct(seed, o, userIdentityDBTypes, true, userIdentityColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize UserIdentity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := UserIdentities().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, userIdentityDBTypes, true, userIdentityPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize UserIdentity struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(userIdentityAllColumns, userIdentityPrimaryKeyColumns) {
+		fields = userIdentityAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			userIdentityAllColumns,
+			userIdentityPrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := UserIdentitySlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testUserIdentitiesUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(userIdentityAllColumns) == len(userIdentityPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := UserIdentity{}
+	if err = randomize.Struct(seed, &o, userIdentityDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize UserIdentity struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert UserIdentity: %s", err)
+	}
+
+	count, err := UserIdentities().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, userIdentityDBTypes, false, userIdentityPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize UserIdentity struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert UserIdentity: %s", err)
+	}
+
+	count, err = UserIdentities().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
@@ -1,12 +1,16 @@
 package verify_test
 
 import (
+	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
 	"log"
+	"time"
 
+	auth "github.com/moapis/authenticator"
 	"github.com/moapis/authenticator/verify"
+	"google.golang.org/grpc"
 )
 
 func ExampleVerificationErr() {
@@ -28,3 +32,37 @@ func ExampleParseJWTHeader() {
 	fmt.Printf("Key ID: %d", kid)
 	// Output: Key ID: 10
 }
+
+func ExampleVerificator_UnaryServerInterceptor() {
+	cc, err := grpc.Dial("127.0.0.1:8765", grpc.WithInsecure())
+	if err != nil {
+		log.Fatal(err)
+	}
+	v := &verify.Verificator{
+		Client:    auth.NewAuthenticatorClient(cc),
+		Audiences: []string{"myService"},
+	}
+
+	gs := grpc.NewServer(
+		grpc.UnaryInterceptor(v.UnaryServerInterceptor(verify.Policies{
+			"/myService.MyService/Status": {Public: true},
+			"/myService.MyService/Delete": {Groups: []string{"admin"}},
+		})),
+	)
+	_ = gs // Register services and serve
+
+	// In the handlers:
+	// id, ok := verify.UserID(ctx)
+}
+
+func ExampleVerificator_PollKeys() {
+	v := &verify.Verificator{
+		Audiences: []string{"myService"},
+		Offline:   true,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	src := verify.JWKSURL(nil, "https://auth.example.com/.well-known/jwks.json")
+	go v.PollKeys(ctx, time.Hour, src, func(err error) { log.Println(err) })
+}
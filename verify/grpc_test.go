@@ -7,6 +7,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/golang/protobuf/ptypes/empty"
 	auth "github.com/moapis/authenticator"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -39,6 +40,31 @@ func (*testAuthenticatorServer) GetPubKey(ctx context.Context, req *auth.KeyID)
 	}
 }
 
+const testRevokedJTI = "revoked"
+
+func (*testAuthenticatorServer) ListRevoked(ctx context.Context, _ *empty.Empty) (*auth.RevokedTokens, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	return &auth.RevokedTokens{
+		Tokens: []*auth.RevokedToken{
+			{Jti: testRevokedJTI, Expires: 1000},
+		},
+	}, nil
+}
+
+func (*testAuthenticatorServer) ListPubKeys(ctx context.Context, _ *empty.Empty) (*auth.PublicKeys, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	return &auth.PublicKeys{
+		Keys: []*auth.PublicKey{
+			{Kid: 22, Key: testKeyReponse},
+			{Kid: 33, Key: testKeyReponse},
+		},
+	}, nil
+}
+
 var testVerificator *Verificator
 
 const testAddr = "127.0.0.1:10000"
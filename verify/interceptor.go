@@ -0,0 +1,184 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package verify
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/pascaldekloe/jwt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// AuthorizationKey is the metadata key of the bearer token.
+	AuthorizationKey = "authorization"
+	bearerPrefix     = "bearer "
+
+	// Claims set by the Authenticator server
+	userIDClaim = "user_id"
+	groupsClaim = "groups"
+)
+
+const (
+	errMissingToken = "Missing bearer token"
+	errInvalidToken = "Invalid bearer token"
+	errUnavailable  = "Token verification unavailable"
+	errAudience     = "Required audience not found"
+	errGroup        = "Not member of any required group"
+	errPredicate    = "Claim predicate failed"
+)
+
+// Policy sets the requirements on the token for a gRPC method.
+type Policy struct {
+	// Public methods don't require a token.
+	// A passed token is ignored.
+	Public bool
+	// Audiences of which at least 1 needs to be in the token.
+	// Nil accepts all.
+	Audiences []string
+	// Groups of which at least 1 needs to be in the "groups" claim of the token.
+	// Nil accepts all.
+	Groups []string
+	// Options for additional validation of the token.
+	Options []Option
+}
+
+// Policies maps full gRPC method names, such as "/package.Service/Method", to their Policy.
+// Methods without a Policy require a valid token.
+type Policies map[string]Policy
+
+// BearerToken returns the token from the "authorization" metadata,
+// passed as "Bearer <jwt>".
+// An empty string is returned when there is none.
+func BearerToken(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, v := range md.Get(AuthorizationKey) {
+		if len(v) > len(bearerPrefix) && strings.EqualFold(v[:len(bearerPrefix)], bearerPrefix) {
+			return strings.TrimSpace(v[len(bearerPrefix):])
+		}
+	}
+	return ""
+}
+
+// authorize verifies the bearer token against the policy for method,
+// and returns ctx with the claims.
+// Errors are of grpc/status.
+func (v *Verificator) authorize(ctx context.Context, policies Policies, method string) (context.Context, error) {
+	p := policies[method]
+	if p.Public {
+		return ctx, nil
+	}
+
+	token := BearerToken(ctx)
+	if token == "" {
+		return nil, status.Error(codes.Unauthenticated, errMissingToken)
+	}
+	claims, err := v.Token(ctx, token, p.Options...)
+	if err != nil {
+		var re *RetrieveError
+		switch {
+		case errors.As(err, &re):
+			return nil, status.Error(codes.Unavailable, errUnavailable)
+		case errors.Is(err, ErrGroup):
+			return nil, status.Error(codes.PermissionDenied, errGroup)
+		case errors.Is(err, ErrPredicate):
+			return nil, status.Error(codes.PermissionDenied, errPredicate)
+		}
+		return nil, status.Error(codes.Unauthenticated, errInvalidToken)
+	}
+
+	if p.Audiences != nil && !HasAnyEntry(p.Audiences, claims.Audiences) {
+		return nil, status.Error(codes.PermissionDenied, errAudience)
+	}
+	if p.Groups != nil && !HasAnyEntry(p.Groups, groups(claims)) {
+		return nil, status.Error(codes.PermissionDenied, errGroup)
+	}
+	return NewContext(ctx, claims), nil
+}
+
+// UnaryServerInterceptor returns a gRPC interceptor which verifies the bearer token
+// of each call against the Policy of the method.
+// The claims of the token are passed to the handler in the context,
+// see ClaimsFromContext.
+func (v *Verificator) UnaryServerInterceptor(policies Policies) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := v.authorize(ctx, policies, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// serverStream overrides the context of a grpc.ServerStream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context { return s.ctx }
+
+// StreamServerInterceptor returns a gRPC interceptor which verifies the bearer token
+// of each stream against the Policy of the method.
+// The claims of the token are passed to the handler in the stream's context,
+// see ClaimsFromContext.
+func (v *Verificator) StreamServerInterceptor(policies Policies) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := v.authorize(ss.Context(), policies, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ss, ctx})
+	}
+}
+
+type claimsKey struct{}
+
+// NewContext returns a new Context that carries claims.
+func NewContext(ctx context.Context, claims *jwt.Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ClaimsFromContext returns the claims set by the server interceptors.
+func ClaimsFromContext(ctx context.Context) (*jwt.Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*jwt.Claims)
+	return claims, ok
+}
+
+// UserID returns the "user_id" claim of the token in ctx.
+func UserID(ctx context.Context) (int, bool) {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return 0, false
+	}
+	// Numbers are decoded from JSON as float64.
+	id, ok := claims.Set[userIDClaim].(float64)
+	return int(id), ok
+}
+
+// Groups returns the "groups" claim of the token in ctx.
+func Groups(ctx context.Context) []string {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return groups(claims)
+}
+
+func groups(claims *jwt.Claims) []string {
+	is, _ := claims.Set[groupsClaim].([]interface{})
+	gs := make([]string, 0, len(is))
+	for _, i := range is {
+		if s, ok := i.(string); ok {
+			gs = append(gs, s)
+		}
+	}
+	return gs
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package verify
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pascaldekloe/jwt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestBearerToken(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"No metadata", context.Background(), ""},
+		{"Bearer", metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer foo")), "foo"},
+		{"Lower case", metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "bearer foo")), "foo"},
+		{"Other scheme", metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Basic foo")), ""},
+		{"Empty token", metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer ")), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BearerToken(tt.ctx); got != tt.want {
+				t.Errorf("BearerToken() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// bearerCtx returns a context with a bearer token,
+// signed with key ID kid and containing audiences and set.
+func bearerCtx(t *testing.T, kid string, audiences []string, set map[string]interface{}) context.Context {
+	claims := &jwt.Claims{
+		KeyID: kid,
+		Registered: jwt.Registered{
+			Subject:   "foo@bar.com",
+			Expires:   jwt.NewNumericTime(time.Now().Add(time.Minute)),
+			Audiences: audiences,
+		},
+		Set: set,
+	}
+	tkn, err := claims.EdDSASign([]byte(testPrivKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+string(tkn)))
+}
+
+var testPolicies = Policies{
+	"/test.Service/Public":   {Public: true},
+	"/test.Service/Audience": {Audiences: []string{"special"}},
+	"/test.Service/Group":    {Groups: []string{"admin"}},
+	"/test.Service/Options": {Options: []Option{
+		WithPredicate("user", func(c *jwt.Claims) bool { _, ok := c.Set["user_id"]; return ok }),
+	}},
+}
+
+func TestVerificator_UnaryServerInterceptor(t *testing.T) {
+	set := map[string]interface{}{
+		"user_id": 12,
+		"groups":  []string{"user", "admin"},
+	}
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		want   codes.Code
+	}{
+		{"Public", context.Background(), "/test.Service/Public", codes.OK},
+		{"Missing token", context.Background(), "/test.Service/Other", codes.Unauthenticated},
+		{"Valid token", bearerCtx(t, "10", []string{"tester"}, set), "/test.Service/Other", codes.OK},
+		{"Invalid token", bearerCtx(t, "10", []string{"foobar"}, set), "/test.Service/Other", codes.Unauthenticated},
+		{"Unknown key", bearerCtx(t, "44", []string{"tester"}, set), "/test.Service/Other", codes.Unauthenticated},
+		{"Retrieval error", bearerCtx(t, "0", []string{"tester"}, set), "/test.Service/Other", codes.Unavailable},
+		{"Audience", bearerCtx(t, "10", []string{"tester", "special"}, set), "/test.Service/Audience", codes.OK},
+		{"Missing audience", bearerCtx(t, "10", []string{"tester"}, set), "/test.Service/Audience", codes.PermissionDenied},
+		{"Group", bearerCtx(t, "10", []string{"tester"}, set), "/test.Service/Group", codes.OK},
+		{"Missing group", bearerCtx(t, "10", []string{"tester"}, map[string]interface{}{"groups": []string{"user"}}), "/test.Service/Group", codes.PermissionDenied},
+		{"No groups", bearerCtx(t, "10", []string{"tester"}, nil), "/test.Service/Group", codes.PermissionDenied},
+		{"Options", bearerCtx(t, "10", []string{"tester"}, set), "/test.Service/Options", codes.OK},
+		{"Failed options", bearerCtx(t, "10", []string{"tester"}, nil), "/test.Service/Options", codes.PermissionDenied},
+	}
+	interceptor := testVerificator.UnaryServerInterceptor(testPolicies)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var called bool
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				if _, ok := ClaimsFromContext(ctx); !ok && tt.method != "/test.Service/Public" {
+					t.Errorf("UnaryServerInterceptor() handler without claims")
+				}
+				return req, nil
+			}
+
+			got, err := interceptor(tt.ctx, "req", &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if c := status.Code(err); c != tt.want {
+				t.Fatalf("UnaryServerInterceptor() error = %v, want %v", err, tt.want)
+			}
+			if called != (tt.want == codes.OK) {
+				t.Errorf("UnaryServerInterceptor() handler called = %v", called)
+			}
+			if err == nil && got != "req" {
+				t.Errorf("UnaryServerInterceptor() = %v, want %v", got, "req")
+			}
+		})
+	}
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context { return s.ctx }
+
+func TestVerificator_StreamServerInterceptor(t *testing.T) {
+	set := map[string]interface{}{
+		"user_id": 12,
+		"groups":  []string{"user", "admin"},
+	}
+	interceptor := testVerificator.StreamServerInterceptor(testPolicies)
+	info := &grpc.StreamServerInfo{FullMethod: "/test.Service/Group"}
+
+	var (
+		gotID     int
+		gotGroups []string
+	)
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		gotID, _ = UserID(ss.Context())
+		gotGroups = Groups(ss.Context())
+		return nil
+	}
+
+	if err := interceptor(nil, &testServerStream{ctx: bearerCtx(t, "10", []string{"tester"}, set)}, info, handler); err != nil {
+		t.Fatal(err)
+	}
+	if gotID != 12 || !reflect.DeepEqual(gotGroups, []string{"user", "admin"}) {
+		t.Errorf("StreamServerInterceptor() claims = %v, %v, want %v, %v", gotID, gotGroups, 12, []string{"user", "admin"})
+	}
+
+	err := interceptor(nil, &testServerStream{ctx: context.Background()}, info, handler)
+	if c := status.Code(err); c != codes.Unauthenticated {
+		t.Errorf("StreamServerInterceptor() error = %v, want %v", err, codes.Unauthenticated)
+	}
+}
+
+func TestUserID(t *testing.T) {
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		want   int
+		wantOK bool
+	}{
+		{"No claims", context.Background(), 0, false},
+		{"No user ID", NewContext(context.Background(), &jwt.Claims{}), 0, false},
+		{"User ID", NewContext(context.Background(), &jwt.Claims{Set: map[string]interface{}{"user_id": float64(12)}}), 12, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := UserID(tt.ctx)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("UserID() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestGroups(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want []string
+	}{
+		{"No claims", context.Background(), nil},
+		{"No groups", NewContext(context.Background(), &jwt.Claims{}), []string{}},
+		{"Groups", NewContext(context.Background(), &jwt.Claims{Set: map[string]interface{}{"groups": []interface{}{"foo", 1, "bar"}}}), []string{"foo", "bar"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Groups(tt.ctx); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Groups() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package verify
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+)
+
+// DefaultMissTTL is used when Verificator.MissTTL is zero.
+const DefaultMissTTL = time.Minute
+
+// ErrKeyNotFound is wrapped by the VerificationErr for unknown key IDs.
+var ErrKeyNotFound = errors.New("Key not found")
+
+// KeySource returns public keys by their ID,
+// for loading into a Verificator with SyncKeys or PollKeys.
+type KeySource func(ctx context.Context) (map[int32][]byte, error)
+
+const (
+	errKeys = "Key loading"
+
+	// maxJWKSSize limits the size of a JWKS document.
+	maxJWKSSize = 1 << 20
+)
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Kid string `json:"kid"`
+}
+
+// parseJWKS returns the Ed25519 keys from a JSON Web Key Set.
+// Keys of other types are ignored.
+func parseJWKS(r io.Reader) (map[int32][]byte, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(io.LimitReader(r, maxJWKSSize)).Decode(&set); err != nil {
+		return nil, &VerificationErr{errKeys, err}
+	}
+
+	keys := make(map[int32][]byte, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "OKP" || k.Crv != "Ed25519" {
+			continue
+		}
+		kid, err := strconv.ParseInt(k.Kid, 10, 32)
+		if err != nil {
+			return nil, &VerificationErr{errKeys, err}
+		}
+		key, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, &VerificationErr{errKeys, err}
+		}
+		if len(key) != ed25519.PublicKeySize {
+			return nil, &VerificationErr{errKeys, fmt.Errorf("Key ID %d: invalid key size %d", kid, len(key))}
+		}
+		keys[int32(kid)] = key
+	}
+	return keys, nil
+}
+
+// JWKSURL returns a KeySource which retrieves a JSON Web Key Set over HTTP,
+// such as from the "/.well-known/jwks.json" path of the Authenticator server.
+// http.DefaultClient is used when client is nil.
+func JWKSURL(client *http.Client, url string) KeySource {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return func(ctx context.Context) (map[int32][]byte, error) {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			return nil, &VerificationErr{errKeys, err}
+		}
+		resp, err := client.Do(req.WithContext(ctx))
+		if err != nil {
+			return nil, &RetrieveError{VerificationErr{errKeys, err}}
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, &RetrieveError{VerificationErr{errKeys, fmt.Errorf("%s: %s", url, resp.Status)}}
+		}
+		return parseJWKS(resp.Body)
+	}
+}
+
+// JWKSFile returns a KeySource which reads a JSON Web Key Set from a file.
+func JWKSFile(name string) KeySource {
+	return func(context.Context) (map[int32][]byte, error) {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, &VerificationErr{errKeys, err}
+		}
+		defer f.Close()
+		return parseJWKS(f)
+	}
+}
+
+// ListPubKeys retrieves all valid public keys through the Client,
+// in a single call. It can be used as a KeySource.
+func (v *Verificator) ListPubKeys(ctx context.Context) (map[int32][]byte, error) {
+	list, err := v.Client.ListPubKeys(ctx, &empty.Empty{})
+	if err != nil {
+		return nil, &RetrieveError{VerificationErr{errKeys, err}}
+	}
+	keys := make(map[int32][]byte, len(list.GetKeys()))
+	for _, k := range list.GetKeys() {
+		keys[k.GetKid()] = k.GetKey()
+	}
+	return keys, nil
+}
+
+// SyncKeys replaces the cache with the keys from src.
+// Cached keys which are not in src are evicted,
+// as the server no longer publishes them for verification.
+// On error, the cache is left untouched.
+func (v *Verificator) SyncKeys(ctx context.Context, src KeySource) error {
+	keys, err := src(ctx)
+	if err != nil {
+		return err
+	}
+
+	cache := make(map[int32][]byte, len(keys))
+	for kid, key := range keys {
+		cache[kid] = key
+	}
+
+	v.mtx.Lock()
+	v.keys = cache
+	for kid := range keys {
+		delete(v.misses, kid)
+	}
+	v.mtx.Unlock()
+	return nil
+}
+
+// PollKeys calls SyncKeys every interval, until ctx is done.
+// Errors are passed to onErr, if not nil.
+// Polling continues after errors, using the cached keys.
+func (v *Verificator) PollKeys(ctx context.Context, interval time.Duration, src KeySource, onErr func(error)) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		if err := v.SyncKeys(ctx, src); err != nil && onErr != nil {
+			onErr(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// flight is a running retrieval of a key,
+// which is shared by concurrent lookups of the same key ID.
+type flight struct {
+	done chan struct{}
+	key  []byte
+	err  error
+}
+
+func (v *Verificator) missTTL() time.Duration {
+	if v.MissTTL > 0 {
+		return v.MissTTL
+	}
+	return DefaultMissTTL
+}
+
+func notFound(kid int32) error {
+	return &VerificationErr{errRetrieval, fmt.Errorf("Key ID %v: %w", kid, ErrKeyNotFound)}
+}
+
+// lookup retrieves a key which is not in the cache.
+// Only one retrieval per key ID runs at a time.
+// Key IDs which were not found are not retrieved again during MissTTL.
+func (v *Verificator) lookup(ctx context.Context, kid int32) ([]byte, error) {
+	if v.Offline {
+		return nil, notFound(kid)
+	}
+
+	v.mtx.Lock()
+	if exp, ok := v.misses[kid]; ok {
+		if time.Now().Before(exp) {
+			v.mtx.Unlock()
+			return nil, notFound(kid)
+		}
+		delete(v.misses, kid)
+	}
+	if f, ok := v.flights[kid]; ok {
+		v.mtx.Unlock()
+		select {
+		case <-f.done:
+			return f.key, f.err
+		case <-ctx.Done():
+			return nil, &RetrieveError{VerificationErr{errRetrieval, ctx.Err()}}
+		}
+	}
+	f := &flight{done: make(chan struct{})}
+	if v.flights == nil {
+		v.flights = make(map[int32]*flight)
+	}
+	v.flights[kid] = f
+	v.mtx.Unlock()
+
+	f.key, f.err = v.retrieve(ctx, kid)
+
+	v.mtx.Lock()
+	delete(v.flights, kid)
+	if errors.Is(f.err, ErrKeyNotFound) {
+		if v.misses == nil {
+			v.misses = make(map[int32]time.Time)
+		}
+		v.pruneMisses()
+		v.misses[kid] = time.Now().Add(v.missTTL())
+	}
+	v.mtx.Unlock()
+	close(f.done)
+
+	return f.key, f.err
+}
+
+// maxMisses is the size of the negative cache,
+// from which expired entries are pruned.
+const maxMisses = 1024
+
+// pruneMisses deletes expired entries from the negative cache,
+// when it is full. The lock needs to be held.
+func (v *Verificator) pruneMisses() {
+	if len(v.misses) < maxMisses {
+		return
+	}
+	now := time.Now()
+	for kid, exp := range v.misses {
+		if !now.Before(exp) {
+			delete(v.misses, kid)
+		}
+	}
+}
@@ -0,0 +1,341 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package verify
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	auth "github.com/moapis/authenticator"
+	"google.golang.org/grpc"
+)
+
+func testJWKS(kid string, key []byte) string {
+	return fmt.Sprintf(`{"keys":[
+		{"kty":"OKP","crv":"Ed25519","x":%q,"kid":%q,"use":"sig","alg":"EdDSA"},
+		{"kty":"EC","crv":"P-256","x":"foo","y":"bar","kid":"ec"}
+	]}`, base64.RawURLEncoding.EncodeToString(key), kid)
+}
+
+func Test_parseJWKS(t *testing.T) {
+	tests := []struct {
+		name    string
+		jwks    string
+		want    map[int32][]byte
+		wantErr bool
+	}{
+		{"Valid", testJWKS("10", []byte(testPubKey)), map[int32][]byte{10: []byte(testPubKey)}, false},
+		{"Empty set", `{"keys":[]}`, map[int32][]byte{}, false},
+		{"Corrupt", "???", nil, true},
+		{"Invalid kid", testJWKS("foo", []byte(testPubKey)), nil, true},
+		{"Invalid x", `{"keys":[{"kty":"OKP","crv":"Ed25519","x":"!","kid":"10"}]}`, nil, true},
+		{"Key size", testJWKS("10", []byte("foo")), nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseJWKS(strings.NewReader(tt.jwks))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseJWKS() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseJWKS() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJWKSURL(t *testing.T) {
+	hs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/jwks.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(testJWKS("10", []byte(testPubKey))))
+	}))
+	defer hs.Close()
+
+	ectx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		url     string
+		want    map[int32][]byte
+		wantErr bool
+	}{
+		{"Success", context.Background(), hs.URL + "/.well-known/jwks.json", map[int32][]byte{10: []byte(testPubKey)}, false},
+		{"Not found", context.Background(), hs.URL + "/foo", nil, true},
+		{"Canceled context", ectx, hs.URL + "/.well-known/jwks.json", nil, true},
+		{"Invalid URL", context.Background(), "://", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := JWKSURL(nil, tt.url)(tt.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("JWKSURL() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("JWKSURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJWKSFile(t *testing.T) {
+	f, err := ioutil.TempFile("", "jwks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	if _, err = f.WriteString(testJWKS("10", []byte(testPubKey))); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	got, err := JWKSFile(f.Name())(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[int32][]byte{10: []byte(testPubKey)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("JWKSFile() = %v, want %v", got, want)
+	}
+
+	if _, err = JWKSFile("foo/bar")(context.Background()); err == nil {
+		t.Errorf("JWKSFile() error = %v, want error", err)
+	}
+}
+
+func TestVerificator_ListPubKeys(t *testing.T) {
+	ectx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	v := &Verificator{Client: testVerificator.Client}
+	if _, err := v.ListPubKeys(ectx); err == nil {
+		t.Errorf("Verificator.ListPubKeys() error = %v, want error", err)
+	}
+
+	got, err := v.ListPubKeys(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[int32][]byte{22: testKeyReponse, 33: testKeyReponse}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Verificator.ListPubKeys() = %v, want %v", got, want)
+	}
+}
+
+func TestVerificator_SyncKeys(t *testing.T) {
+	srcErr := errors.New("foo")
+
+	tests := []struct {
+		name    string
+		src     KeySource
+		want    map[int32][]byte
+		wantErr bool
+	}{
+		{
+			"Source error",
+			func(context.Context) (map[int32][]byte, error) { return nil, srcErr },
+			map[int32][]byte{10: []byte("foo")},
+			true,
+		},
+		{
+			"Replace",
+			func(context.Context) (map[int32][]byte, error) {
+				return map[int32][]byte{10: []byte("bar"), 11: []byte("baz")}, nil
+			},
+			map[int32][]byte{10: []byte("bar"), 11: []byte("baz")},
+			false,
+		},
+		{
+			"Evict",
+			func(context.Context) (map[int32][]byte, error) {
+				return map[int32][]byte{11: []byte("baz")}, nil
+			},
+			map[int32][]byte{11: []byte("baz")},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &Verificator{
+				keys:   map[int32][]byte{10: []byte("foo")},
+				misses: map[int32]time.Time{11: time.Now().Add(time.Hour)},
+			}
+			if err := v.SyncKeys(context.Background(), tt.src); (err != nil) != tt.wantErr {
+				t.Errorf("Verificator.SyncKeys() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(v.keys, tt.want) {
+				t.Errorf("Verificator.SyncKeys() keys = %v, want %v", v.keys, tt.want)
+			}
+			if _, missed := v.misses[11]; missed == !tt.wantErr {
+				t.Errorf("Verificator.SyncKeys() misses = %v", v.misses)
+			}
+		})
+	}
+}
+
+func TestVerificator_PollKeys(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	v := &Verificator{Client: testVerificator.Client}
+	var errs []error
+	v.PollKeys(ctx, 10*time.Millisecond, v.ListPubKeys, func(err error) { errs = append(errs, err) })
+
+	if _, ok := v.get(22); !ok {
+		t.Errorf("Verificator.PollKeys() did not load key %d", 22)
+	}
+	// The last poll may have raced with the context deadline
+	if len(errs) > 1 {
+		t.Errorf("Verificator.PollKeys() errors = %v", errs)
+	}
+}
+
+// countingClient counts GetPubKey calls, which wait for release when not nil.
+type countingClient struct {
+	auth.AuthenticatorClient
+	calls   int32
+	release chan struct{}
+}
+
+func (c *countingClient) GetPubKey(ctx context.Context, in *auth.KeyID, opts ...grpc.CallOption) (*auth.PublicKey, error) {
+	atomic.AddInt32(&c.calls, 1)
+	if c.release != nil {
+		<-c.release
+	}
+	return c.AuthenticatorClient.GetPubKey(ctx, in, opts...)
+}
+
+func TestVerificator_lookup(t *testing.T) {
+	t.Run("Offline", func(t *testing.T) {
+		c := &countingClient{AuthenticatorClient: testVerificator.Client}
+		v := &Verificator{Client: c, Offline: true}
+
+		if _, err := v.getOrRetrieve(context.Background(), 22); !errors.Is(err, ErrKeyNotFound) {
+			t.Errorf("Verificator.getOrRetrieve() error = %v, want %v", err, ErrKeyNotFound)
+		}
+		if c.calls != 0 {
+			t.Errorf("Verificator.getOrRetrieve() calls = %d, want %d", c.calls, 0)
+		}
+	})
+
+	t.Run("Negative cache", func(t *testing.T) {
+		c := &countingClient{AuthenticatorClient: testVerificator.Client}
+		v := &Verificator{Client: c, MissTTL: 50 * time.Millisecond}
+
+		for i := 0; i < 3; i++ {
+			if _, err := v.getOrRetrieve(context.Background(), 99); !errors.Is(err, ErrKeyNotFound) {
+				t.Errorf("Verificator.getOrRetrieve() error = %v, want %v", err, ErrKeyNotFound)
+			}
+		}
+		if c.calls != 1 {
+			t.Errorf("Verificator.getOrRetrieve() calls = %d, want %d", c.calls, 1)
+		}
+
+		time.Sleep(60 * time.Millisecond)
+		v.getOrRetrieve(context.Background(), 99)
+		if c.calls != 2 {
+			t.Errorf("Verificator.getOrRetrieve() after MissTTL calls = %d, want %d", c.calls, 2)
+		}
+	})
+
+	t.Run("Retrieval errors are not cached", func(t *testing.T) {
+		c := &countingClient{AuthenticatorClient: testVerificator.Client}
+		v := &Verificator{Client: c}
+
+		v.getOrRetrieve(context.Background(), 0)
+		v.getOrRetrieve(context.Background(), 0)
+		if c.calls != 2 {
+			t.Errorf("Verificator.getOrRetrieve() calls = %d, want %d", c.calls, 2)
+		}
+	})
+
+	t.Run("Single flight", func(t *testing.T) {
+		c := &countingClient{AuthenticatorClient: testVerificator.Client, release: make(chan struct{})}
+		v := &Verificator{Client: c}
+
+		const n = 10
+		var (
+			wg   sync.WaitGroup
+			errs = make(chan error, n)
+		)
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				key, err := v.getOrRetrieve(context.Background(), 22)
+				if err == nil && !reflect.DeepEqual(key, testKeyReponse) {
+					err = fmt.Errorf("key = %v, want %v", key, testKeyReponse)
+				}
+				errs <- err
+			}()
+		}
+		// Wait for the lookups to join the flight.
+		time.Sleep(20 * time.Millisecond)
+		close(c.release)
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			if err != nil {
+				t.Error(err)
+			}
+		}
+		if c.calls != 1 {
+			t.Errorf("Verificator.getOrRetrieve() calls = %d, want %d", c.calls, 1)
+		}
+	})
+
+	t.Run("Canceled follower", func(t *testing.T) {
+		c := &countingClient{AuthenticatorClient: testVerificator.Client, release: make(chan struct{})}
+		v := &Verificator{Client: c}
+
+		done := make(chan struct{})
+		go func() {
+			v.getOrRetrieve(context.Background(), 33)
+			close(done)
+		}()
+		time.Sleep(10 * time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		var re *RetrieveError
+		if _, err := v.getOrRetrieve(ctx, 33); !errors.As(err, &re) {
+			t.Errorf("Verificator.getOrRetrieve() error = %v, want %T", err, re)
+		}
+		close(c.release)
+		<-done
+	})
+}
+
+func TestVerificator_pruneMisses(t *testing.T) {
+	v := &Verificator{misses: make(map[int32]time.Time, maxMisses)}
+	for i := int32(0); i < maxMisses; i++ {
+		exp := time.Now().Add(time.Hour)
+		if i%2 == 0 {
+			exp = time.Now().Add(-time.Hour)
+		}
+		v.misses[i] = exp
+	}
+	v.pruneMisses()
+	if len(v.misses) != maxMisses/2 {
+		t.Errorf("Verificator.pruneMisses() = %d entries, want %d", len(v.misses), maxMisses/2)
+	}
+}
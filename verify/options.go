@@ -0,0 +1,127 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package verify
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pascaldekloe/jwt"
+)
+
+// Errors wrapped by the VerificationErr from Token, for each failed claim check.
+// Use errors.Is to tell them apart.
+var (
+	ErrExpired     = errors.New("Token expired")
+	ErrNotYetValid = errors.New("Token not yet valid")
+	ErrTooOld      = errors.New("Token exceeds maximum age")
+	ErrIssuer      = errors.New("Wrong issuer")
+	ErrAudience    = errors.New("Required audience not found")
+	ErrGroup       = errors.New("Not member of any required group")
+	ErrPredicate   = errors.New("Claim predicate failed")
+	ErrPurpose     = errors.New("Single-purpose token")
+)
+
+const errInvalid = "Token invalid"
+
+// Option adds a check to the validation of token claims.
+// Options can be set for all tokens in Verificator.Options,
+// or passed to a single call of Token.
+type Option func(*validation)
+
+type predicate struct {
+	name string
+	fn   func(*jwt.Claims) bool
+}
+
+type validation struct {
+	issuer     string
+	leeway     time.Duration
+	maxAge     time.Duration
+	audiences  []string
+	groups     []string
+	predicates []predicate
+}
+
+// WithIssuer requires the "iss" claim to be iss.
+func WithIssuer(iss string) Option {
+	return func(v *validation) { v.issuer = iss }
+}
+
+// WithLeeway allows for clock skew between the Authenticator server and the verifier,
+// when checking the "exp", "nbf" and "iat" claims.
+func WithLeeway(d time.Duration) Option {
+	return func(v *validation) { v.leeway = d }
+}
+
+// WithMaxAge rejects tokens which were issued longer than d ago,
+// according to the "iat" claim. Tokens without "iat" are rejected.
+func WithMaxAge(d time.Duration) Option {
+	return func(v *validation) { v.maxAge = d }
+}
+
+// WithAudiences requires at least one of audiences in the "aud" claim.
+// It applies in addition to Verificator.Audiences.
+func WithAudiences(audiences ...string) Option {
+	return func(v *validation) { v.audiences = append(v.audiences, audiences...) }
+}
+
+// WithGroups requires at least one of groups in the "groups" claim.
+func WithGroups(groups ...string) Option {
+	return func(v *validation) { v.groups = append(v.groups, groups...) }
+}
+
+// WithPredicate requires fn to return true for the claims.
+// The name is used in the error when it returns false.
+func WithPredicate(name string, fn func(*jwt.Claims) bool) Option {
+	return func(v *validation) { v.predicates = append(v.predicates, predicate{name, fn}) }
+}
+
+func newValidation(opts ...[]Option) *validation {
+	v := new(validation)
+	for _, set := range opts {
+		for _, o := range set {
+			o(v)
+		}
+	}
+	return v
+}
+
+// check the claims at now.
+// Errors are VerificationErr, wrapping one of the claim check errors.
+func (v *validation) check(claims *jwt.Claims, now time.Time) error {
+	if claims.Expires != nil && !now.Before(claims.Expires.Time().Add(v.leeway)) {
+		return &VerificationErr{errInvalid, fmt.Errorf("%w: Expires %v", ErrExpired, claims.Expires.Time())}
+	}
+	if claims.NotBefore != nil && now.Add(v.leeway).Before(claims.NotBefore.Time()) {
+		return &VerificationErr{errInvalid, fmt.Errorf("%w: NotBefore %v", ErrNotYetValid, claims.NotBefore.Time())}
+	}
+	if v.maxAge > 0 {
+		if claims.Issued == nil {
+			return &VerificationErr{errInvalid, fmt.Errorf("%w: Issued missing", ErrTooOld)}
+		}
+		if now.Sub(claims.Issued.Time()) > v.maxAge+v.leeway {
+			return &VerificationErr{errInvalid, fmt.Errorf("%w: Issued %v", ErrTooOld, claims.Issued.Time())}
+		}
+	}
+	if v.issuer != "" && claims.Issuer != v.issuer {
+		return &VerificationErr{errInvalid, fmt.Errorf("%w: Accepted: %s; Claimed: %s", ErrIssuer, v.issuer, claims.Issuer)}
+	}
+	if v.audiences != nil && !HasAnyEntry(v.audiences, claims.Audiences) {
+		return &VerificationErr{errInvalid, fmt.Errorf("%w: Accepted: %v; Claimed: %v", ErrAudience, v.audiences, claims.Audiences)}
+	}
+	if v.groups != nil {
+		if gs := groups(claims); !HasAnyEntry(v.groups, gs) {
+			return &VerificationErr{errInvalid, fmt.Errorf("%w: Required: %v; Claimed: %v", ErrGroup, v.groups, gs)}
+		}
+	}
+	for _, p := range v.predicates {
+		if !p.fn(claims) {
+			return &VerificationErr{errInvalid, fmt.Errorf("%w: %s", ErrPredicate, p.name)}
+		}
+	}
+	return nil
+}
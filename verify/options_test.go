@@ -0,0 +1,100 @@
+// Copyright (c) 2020, Mohlmann Solutions SRL. All rights reserved.
+// Use of this source code is governed by a License that can be found in the LICENSE file.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package verify
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pascaldekloe/jwt"
+)
+
+func Test_validation_check(t *testing.T) {
+	now := time.Now()
+	at := func(d time.Duration) *jwt.NumericTime { return jwt.NewNumericTime(now.Add(d)) }
+
+	isAdmin := func(c *jwt.Claims) bool { return c.Subject == "admin" }
+
+	tests := []struct {
+		name    string
+		claims  jwt.Claims
+		opts    []Option
+		wantErr error
+	}{
+		{"No options", jwt.Claims{}, nil, nil},
+		{"Expired", jwt.Claims{Registered: jwt.Registered{Expires: at(-time.Second)}}, nil, ErrExpired},
+		{"Expired within leeway", jwt.Claims{Registered: jwt.Registered{Expires: at(-time.Second)}}, []Option{WithLeeway(time.Minute)}, nil},
+		{"Not yet valid", jwt.Claims{Registered: jwt.Registered{NotBefore: at(time.Second)}}, nil, ErrNotYetValid},
+		{"Not yet valid within leeway", jwt.Claims{Registered: jwt.Registered{NotBefore: at(time.Second)}}, []Option{WithLeeway(time.Minute)}, nil},
+		{"Max age", jwt.Claims{Registered: jwt.Registered{Issued: at(-time.Second)}}, []Option{WithMaxAge(time.Minute)}, nil},
+		{"Too old", jwt.Claims{Registered: jwt.Registered{Issued: at(-time.Hour)}}, []Option{WithMaxAge(time.Minute)}, ErrTooOld},
+		{"Missing issued", jwt.Claims{}, []Option{WithMaxAge(time.Minute)}, ErrTooOld},
+		{"Issuer", jwt.Claims{Registered: jwt.Registered{Issuer: "localhost"}}, []Option{WithIssuer("localhost")}, nil},
+		{"Wrong issuer", jwt.Claims{Registered: jwt.Registered{Issuer: "example.com"}}, []Option{WithIssuer("localhost")}, ErrIssuer},
+		{"Audience", jwt.Claims{Registered: jwt.Registered{Audiences: []string{"foo", "bar"}}}, []Option{WithAudiences("bar")}, nil},
+		{"Wrong audience", jwt.Claims{Registered: jwt.Registered{Audiences: []string{"foo"}}}, []Option{WithAudiences("bar")}, ErrAudience},
+		{"Group", jwt.Claims{Set: map[string]interface{}{"groups": []interface{}{"user", "admin"}}}, []Option{WithGroups("admin")}, nil},
+		{"Missing group", jwt.Claims{Set: map[string]interface{}{"groups": []interface{}{"user"}}}, []Option{WithGroups("admin")}, ErrGroup},
+		{"No groups", jwt.Claims{}, []Option{WithGroups("admin")}, ErrGroup},
+		{"Predicate", jwt.Claims{Registered: jwt.Registered{Subject: "admin"}}, []Option{WithPredicate("admin", isAdmin)}, nil},
+		{"Failed predicate", jwt.Claims{Registered: jwt.Registered{Subject: "user"}}, []Option{WithPredicate("admin", isAdmin)}, ErrPredicate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newValidation(tt.opts).check(&tt.claims, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("validation.check() error = %v, want %v", err, tt.wantErr)
+			}
+			var verErr *VerificationErr
+			if err != nil && !errors.As(err, &verErr) {
+				t.Errorf("validation.check() error = %T, want %T", err, verErr)
+			}
+		})
+	}
+}
+
+func TestVerificator_Token_options(t *testing.T) {
+	claims := &jwt.Claims{
+		KeyID: "10",
+		Registered: jwt.Registered{
+			Issuer:    "localhost",
+			Expires:   jwt.NewNumericTime(time.Now().Add(time.Minute)),
+			Audiences: []string{"tester"},
+		},
+	}
+	token, err := claims.EdDSASign([]byte(testPrivKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims.Set = map[string]interface{}{PurposeClaim: "mfa"}
+	mfaToken, err := claims.EdDSASign([]byte(testPrivKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		v       *Verificator
+		token   []byte
+		opts    []Option
+		wantErr error
+	}{
+		{"Verificator options", &Verificator{Options: []Option{WithIssuer("localhost")}}, token, nil, nil},
+		{"Verificator options error", &Verificator{Options: []Option{WithIssuer("example.com")}}, token, nil, ErrIssuer},
+		{"Call options error", &Verificator{}, token, []Option{WithMaxAge(time.Minute)}, ErrTooOld},
+		{"Verificator audiences", &Verificator{Audiences: []string{"foobar"}}, token, nil, ErrAudience},
+		{"Single-purpose token", &Verificator{}, mfaToken, nil, ErrPurpose},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.v.keys = map[int32][]byte{10: []byte(testPubKey)}
+			if _, err := tt.v.Token(context.Background(), string(tt.token), tt.opts...); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verificator.Token() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
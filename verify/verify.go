@@ -5,6 +5,11 @@
 /*
 Package verify provides middleware for GRPc servers which need
 to verify JSON Web Tokens generated by this Authenticator service.
+
+The server interceptors verify the bearer token of each call,
+passed in the "authorization" metadata as "Bearer <jwt>",
+against the Policy of the method.
+The claims are available to the handlers through ClaimsFromContext, UserID and Groups.
 */
 package verify
 
@@ -12,14 +17,18 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/golang/protobuf/ptypes/empty"
 	auth "github.com/moapis/authenticator"
 	"github.com/pascaldekloe/jwt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // VerificationErr type for errors generated by this package.
@@ -61,13 +70,26 @@ func ParseJWTHeader(token string) (int, error) {
 // Verificator holds public keys, which are used to verify tokens.
 // Public keys that are not found in the local cache, are retrieved
 // through an gRPC call from an Authenticator server.
+// The cache can be preloaded with SyncKeys or PollKeys.
 type Verificator struct {
 	Client auth.AuthenticatorClient
 	// Audiences that are accepted.
 	// Nil accepts all.
 	Audiences []string
-	keys      map[int32][]byte
-	mtx       sync.RWMutex
+	// Offline disables the retrieval of unknown keys through the Client.
+	// Only keys loaded with SyncKeys or PollKeys are used.
+	Offline bool
+	// MissTTL is the duration for which unknown key IDs are not retrieved again.
+	// DefaultMissTTL is used when zero.
+	MissTTL time.Duration
+	// Options for the validation of all tokens.
+	Options []Option
+
+	keys    map[int32][]byte
+	revoked map[string]time.Time // Token IDs and their expiry
+	misses  map[int32]time.Time  // Unknown key IDs and their expiry
+	flights map[int32]*flight    // Running key retrievals
+	mtx     sync.RWMutex
 }
 
 // Get key from cache
@@ -96,16 +118,19 @@ type RetrieveError struct {
 const errRetrieval = "Key retrieval"
 
 // Retrieve a key over gRPC Client.
-// After succesful retrieval, the key is Set to the cache
+// After succesful retrieval, the key is Set to the cache.
+// A NotFound status or empty key results in an error wrapping ErrKeyNotFound.
 func (v *Verificator) retrieve(ctx context.Context, kid int32) ([]byte, error) {
 	pkl, err := v.Client.GetPubKey(ctx, &auth.KeyID{Kid: kid})
+	if status.Code(err) == codes.NotFound {
+		return nil, notFound(kid)
+	}
 	if err != nil {
 		return nil, &RetrieveError{VerificationErr{errRetrieval, err}}
 	}
 	key := pkl.GetKey()
 	if key == nil {
-		err = fmt.Errorf("Key ID %v not found", kid)
-		return nil, &VerificationErr{errRetrieval, err}
+		return nil, notFound(kid)
 	}
 	v.set(kid, key)
 	return key, nil
@@ -115,13 +140,15 @@ func (v *Verificator) getOrRetrieve(ctx context.Context, kid int32) ([]byte, err
 	if key, ok := v.get(kid); ok {
 		return key, nil
 	}
-	return v.retrieve(ctx, kid)
+	return v.lookup(ctx, kid)
 }
 
 // Token verifies the passed JSON web token and checks validity (like expiry).
 // If the key is not in the cache, it will be fetched through the client before checking.
+// The claims are checked against the Verificator Options, followed by opts.
 // Typical errors can by of grpc/status or Verfication errors.
-func (v *Verificator) Token(ctx context.Context, token string) (*jwt.Claims, error) {
+// Failed claim checks wrap one of the claim check errors, such as ErrExpired.
+func (v *Verificator) Token(ctx context.Context, token string, opts ...Option) (*jwt.Claims, error) {
 	kid, err := ParseJWTHeader(token)
 	if err != nil {
 		return nil, err
@@ -134,24 +161,83 @@ func (v *Verificator) Token(ctx context.Context, token string) (*jwt.Claims, err
 	if err != nil {
 		return nil, &VerificationErr{"EdDSACheck", err}
 	}
-	if !claims.Valid(time.Now()) {
-		return nil, &VerificationErr{"Token invalid", fmt.Errorf(
-			"Issued: %v; NotBefore %v; Expires %v",
-			claims.Issued.Time(),
-			claims.NotBefore.Time(),
-			claims.Expires.Time(),
-		)}
+	if purpose, ok := claims.Set[PurposeClaim]; ok {
+		return nil, &VerificationErr{errInvalid, fmt.Errorf("%w: %v", ErrPurpose, purpose)}
 	}
 	if v.Audiences != nil && !HasAnyEntry(v.Audiences, claims.Audiences) {
-		return nil, &VerificationErr{"Required audience not found", fmt.Errorf(
-			"Accepted: %v; Claimed: %v",
+		return nil, &VerificationErr{errInvalid, fmt.Errorf(
+			"%w: Accepted: %v; Claimed: %v",
+			ErrAudience,
 			v.Audiences,
 			claims.Audiences,
 		)}
 	}
+	if err = newValidation(v.Options, opts).check(claims, time.Now()); err != nil {
+		return nil, err
+	}
+	if claims.ID != "" && v.isRevoked(claims.ID) {
+		return nil, &VerificationErr{claims.ID, ErrRevoked}
+	}
 	return claims, nil
 }
 
+// PurposeClaim is set on tokens for a single purpose,
+// such as the pending second factor of a login or a password reset.
+// Such tokens don't authenticate a user and are always rejected by Token.
+const PurposeClaim = "purpose"
+
+// ErrRevoked is wrapped by the VerificationErr for tokens in the local revocation list.
+var ErrRevoked = errors.New("Token revoked")
+
+func (v *Verificator) isRevoked(jti string) bool {
+	v.mtx.RLock()
+	_, ok := v.revoked[jti]
+	v.mtx.RUnlock()
+	return ok
+}
+
+const errRevocations = "Revocation list retrieval"
+
+// SyncRevoked retrieves the revoked tokens from the Authenticator server,
+// replacing the local revocation list.
+// Token checks the local revocation list only,
+// so SyncRevoked needs to be called periodically. See PollRevoked.
+func (v *Verificator) SyncRevoked(ctx context.Context) error {
+	list, err := v.Client.ListRevoked(ctx, &empty.Empty{})
+	if err != nil {
+		return &RetrieveError{VerificationErr{errRevocations, err}}
+	}
+
+	revoked := make(map[string]time.Time, len(list.GetTokens()))
+	for _, t := range list.GetTokens() {
+		revoked[t.GetJti()] = time.Unix(t.GetExpires(), 0)
+	}
+
+	v.mtx.Lock()
+	v.revoked = revoked
+	v.mtx.Unlock()
+	return nil
+}
+
+// PollRevoked calls SyncRevoked every interval, until ctx is done.
+// Errors are passed to onErr, if not nil.
+// Polling continues after errors, using the last known revocation list.
+func (v *Verificator) PollRevoked(ctx context.Context, interval time.Duration, onErr func(error)) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		if err := v.SyncRevoked(ctx); err != nil && onErr != nil {
+			onErr(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
 // HasAnyEntry is a utility function, which compares slice A and B.
 // It returns true if one or more entries is present in both A and B or when both are nil.
 func HasAnyEntry(a, b []string) bool {
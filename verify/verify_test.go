@@ -18,7 +18,11 @@ import (
 	"testing"
 	"time"
 
+	auth "github.com/moapis/authenticator"
 	"github.com/pascaldekloe/jwt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -229,6 +233,27 @@ func TestVerificator_retrieve(t *testing.T) {
 	}
 }
 
+// notFoundClient responds to GetPubKey with a NotFound status.
+type notFoundClient struct {
+	auth.AuthenticatorClient
+}
+
+func (notFoundClient) GetPubKey(ctx context.Context, in *auth.KeyID, opts ...grpc.CallOption) (*auth.PublicKey, error) {
+	return nil, status.Error(codes.NotFound, "Key not found")
+}
+
+func TestVerificator_retrieve_notFound(t *testing.T) {
+	v := &Verificator{Client: notFoundClient{}}
+	_, err := v.retrieve(context.Background(), 33)
+	if !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Verificator.retrieve() error = %v, want %v", err, ErrKeyNotFound)
+	}
+	var rErr *RetrieveError
+	if errors.As(err, &rErr) {
+		t.Errorf("Verificator.retrieve() error = %T, want %T", err, &VerificationErr{})
+	}
+}
+
 func TestVerificator_getOrRetrieve(t *testing.T) {
 	type args struct {
 		ctx context.Context
@@ -323,6 +348,22 @@ func TestVerificator_Token(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	rvClaims := &jwt.Claims{
+		KeyID: "10",
+		Registered: jwt.Registered{
+			Expires:   jwt.NewNumericTime(time.Now().Add(time.Minute)),
+			Audiences: []string{"tester"},
+			ID:        testRevokedJTI,
+		},
+	}
+	revoked, err := rvClaims.EdDSASign([]byte(testPrivKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = testVerificator.SyncRevoked(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
 	type args struct {
 		ctx   context.Context
 		token string
@@ -369,6 +410,12 @@ func TestVerificator_Token(t *testing.T) {
 			nil,
 			true,
 		},
+		{
+			"Revoked token",
+			args{context.Background(), string(revoked)},
+			nil,
+			true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -385,6 +432,61 @@ func TestVerificator_Token(t *testing.T) {
 	}
 }
 
+func TestVerificator_SyncRevoked(t *testing.T) {
+	ectx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		want    map[string]time.Time
+		wantErr bool
+	}{
+		{
+			"Retrieve error",
+			ectx,
+			nil,
+			true,
+		},
+		{
+			"Success",
+			context.Background(),
+			map[string]time.Time{testRevokedJTI: time.Unix(1000, 0)},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &Verificator{Client: testVerificator.Client}
+			err := v.SyncRevoked(tt.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Verificator.SyncRevoked() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(v.revoked, tt.want) {
+				t.Errorf("Verificator.SyncRevoked() = %v, want %v", v.revoked, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerificator_PollRevoked(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	v := &Verificator{Client: testVerificator.Client}
+	var errs []error
+	v.PollRevoked(ctx, 10*time.Millisecond, func(err error) { errs = append(errs, err) })
+
+	if !v.isRevoked(testRevokedJTI) {
+		t.Errorf("Verificator.PollRevoked() did not sync %q", testRevokedJTI)
+	}
+	// The last poll may have raced with the context deadline
+	if len(errs) > 1 {
+		t.Errorf("Verificator.PollRevoked() errors = %v", errs)
+	}
+}
+
 func TestVerificationErr_Unwrap(t *testing.T) {
 	tests := []struct {
 		name string